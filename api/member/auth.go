@@ -4,14 +4,14 @@ import (
 	"database/sql"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
 	"github.com/ianidi/exchange-server/internal/db"
-	"github.com/ianidi/exchange-server/internal/models"
-	"github.com/sethvargo/go-password/password"
+	"github.com/ianidi/exchange-server/internal/jwt"
+	"github.com/ianidi/exchange-server/internal/verify"
+	"github.com/spf13/cast"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -22,7 +22,8 @@ import (
 // @Accept  json
 // @Produce  json
 // @ID Member-Email-Update
-// @Param   Email	query		string		true		"Новый email адрес"
+// @Param   Email		query		string		true		"Новый email адрес"
+// @Param   MFACode	query		string		false		"TOTP or recovery code (MFA enabled)"
 // @Success 200 {object} Success
 // @Failure 400 {object} Error
 // @Router /profile/email/update [post]
@@ -36,7 +37,8 @@ func EmailUpdate(c *gin.Context) {
 	}
 
 	var query struct {
-		Email string `json:"Email" binding:"required"`
+		Email   string `json:"Email" binding:"required"`
+		MFACode string `json:"MFACode"`
 	}
 
 	if err := c.ShouldBindJSON(&query); err != nil {
@@ -76,55 +78,26 @@ func EmailUpdate(c *gin.Context) {
 		return
 	}
 
-	//Предотвратить запрос новых кодов подтверждения чаще, чем раз в 1 минуту
-	if err := db.Get(&count, "SELECT count(*) FROM Verify WHERE MemberID=$1 AND Type=$2 AND Created>$3", sender.MemberID, "email_verification", time.Now().Unix()-60); err != nil {
-		if err != sql.ErrNoRows {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
-			return
-		}
-	}
-
-	if count > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status": false,
-			"error":  "TOO_OFTEN_EMAIL_UPDATE_REQUESTS",
-		})
-		return
-	}
-
-	//Сгенерировать код подтверждения
-	Code, err := password.Generate(6, 6, 0, true, false)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status": false,
-			"error":  err.Error(),
-		})
+	//Step-up verification of members with MFA enabled
+	if err := verify.StepUp(verify.PURPOSE_EMAIL_CHANGE, sender, query.MFACode); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
 		return
 	}
 
-	//Зашифровать код подтверждения в bcrypt
-	CodeHash, err := bcrypt.GenerateFromPassword([]byte(Code), 12)
-
+	//Отправить код подтверждения на новый email, новые коды запрашиваются не чаще, чем раз в 1 минуту
+	Timeout, err := verify.Send(verify.Request{
+		Purpose:  verify.PURPOSE_EMAIL_CHANGE,
+		Channel:  verify.CHANNEL_EMAIL,
+		MemberID: sender.MemberID,
+		Email:    query.Email,
+		IP:       c.ClientIP(),
+	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status": false,
-			"error":  err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "timeout": Timeout})
 		return
 	}
 
-	tx := db.MustBegin()
-	tx.MustExec("UPDATE Verify SET Status=$1 WHERE MemberID=$2 AND Type=$3 AND Status=$4", "cancelled", sender.MemberID, "email_verification", "pending")
-	tx.MustExec("INSERT INTO Verify (MemberID, Code, Type, Email, Status, Created) VALUES ($1, $2, $3, $4, $5, $6)", sender.MemberID, CodeHash, "email_verification", query.Email, "pending", time.Now().Unix())
-	tx.Commit()
-
-	//Отправить код подтверждения на новый email
-	//mail.SendMail(query.Email, "Email change request", "Please use code "+Code+" to update your email")
-
-	c.JSON(http.StatusOK, gin.H{"status": true})
+	c.JSON(http.StatusOK, gin.H{"status": true, "timeout": Timeout})
 }
 
 // EmailVerify подтверждение обновления email пользователя с помощью кода подтверждения
@@ -165,53 +138,37 @@ func EmailVerify(c *gin.Context) {
 		return
 	}
 
-	var verify models.Verify
-
-	//Найти запросы на подтверждение от пользователя и проверить, что они не истекли по времени (сутки)
-	if err := db.Get(&verify, "SELECT * FROM Verify WHERE MemberID=$1 AND Action=$2 AND Method=$3 AND Status=$4 AND Created>$5", sender.MemberID, "email", "signup", "pending", time.Now().Unix()-86400); err != nil {
-		if err == sql.ErrNoRows {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": false,
-				"error":  "NO_VERIFY_RECORD",
-			})
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
-		}
+	request, err := verify.Check(verify.PURPOSE_EMAIL_CHANGE, verify.CHANNEL_EMAIL, sender.MemberID, query.Code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
 		return
 	}
 
-	//Проверка кода
-	if err := bcrypt.CompareHashAndPassword([]byte(verify.CodeHash), []byte(query.Code)); err != nil {
-
-		var status = "pending"
-		var error = "WRONG_CODE"
-
-		// Если число попыток ввода кода превышено
-		if verify.Attempts == 2 {
-			status = "fail"
-			error = "TOO_MANY_ATTEMPTS"
-		}
-
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status": false,
-			"error":  error,
-		})
+	var count int
 
-		tx := db.MustBegin()
-		tx.MustExec("UPDATE Verify SET Attempts=Attempts+$1, Status=$2 WHERE VerifyID=$3", 1, status, verify.VerifyID)
-		tx.Commit()
+	//Email мог быть занят после запроса кода
+	if err := db.Get(&count, "SELECT count(*) FROM Member WHERE Email=$1", request.Email); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
 
+	if count > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "EMAIL_ALREADY_IN_USE"})
 		return
 	}
 
 	//Проверка пройдена, сменить email пользователя
 	tx := db.MustBegin()
-	tx.MustExec("UPDATE Member SET Email=$1 WHERE MemberID=$2", verify.Email, sender.MemberID)
-	tx.MustExec("UPDATE Verify SET Status=$1 WHERE VerifyID=$2", "success", verify.VerifyID)
-	tx.Commit()
+	tx.MustExec("UPDATE Member SET Email=$1 WHERE MemberID=$2", request.Email, sender.MemberID)
+	if err := verify.Complete(tx, request.VerifyID); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"status": true})
 }
@@ -225,6 +182,7 @@ func EmailVerify(c *gin.Context) {
 // @ID Member-Profile-Password
 // @Param   PasswordCurrent	query		string		true		"Current password"
 // @Param   PasswordNew			query		string		true		"New password"
+// @Param   MFACode					query		string		false		"TOTP or recovery code (MFA enabled)"
 // @Success 200 {object} Success
 // @Failure 400 {object} Error
 // @Router /profile/password/update [post]
@@ -240,6 +198,7 @@ func ProfilePassword(c *gin.Context) {
 	var query struct {
 		PasswordCurrent string `json:"PasswordCurrent" binding:"required"`
 		PasswordNew     string `json:"PasswordNew" binding:"required"`
+		MFACode         string `json:"MFACode"`
 	}
 
 	if err := c.ShouldBindJSON(&query); err != nil {
@@ -273,6 +232,12 @@ func ProfilePassword(c *gin.Context) {
 		return
 	}
 
+	//Step-up verification of members with MFA enabled
+	if err := verify.StepUp(verify.PURPOSE_PASSWORD, sender, query.MFACode); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
 	PasswordNewHash, err := bcrypt.GenerateFromPassword([]byte(query.PasswordNew), 12)
 
 	if err != nil {
@@ -287,5 +252,11 @@ func ProfilePassword(c *gin.Context) {
 	tx.MustExec("UPDATE Member SET PasswordHash=$1 WHERE MemberID=$2", PasswordNewHash, sender.MemberID)
 	tx.Commit()
 
+	//Password was changed, sign out other devices
+	if err := jwt.Service.RevokeSessions(cast.ToString(sender.MemberID), c.GetString(jwt.SessionKey)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"status": true})
 }
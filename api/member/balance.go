@@ -1,10 +1,19 @@
 package member
 
 import (
+	"database/sql"
+	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/account"
 	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/payment"
+	"github.com/ianidi/exchange-server/internal/transfer"
+	"github.com/ianidi/exchange-server/internal/verify"
+	"github.com/ianidi/exchange-server/internal/withdrawal"
 	"github.com/shopspring/decimal"
 )
 
@@ -15,12 +24,12 @@ import (
 // @Accept  json
 // @Produce  json
 // @ID Member-Balance-Deposit
+// @Param   Amount			query		number		true		"Amount in account currency"
+// @Param   Provider		query		string		true		"Payment method (bank, card)"
 // @Success 200 {object} Success
 // @Failure 400 {object} Error
-// @Router /balance/deposit [get]
+// @Router /balance/deposit [post]
 func BalanceDeposit(c *gin.Context) {
-	db := db.GetDB()
-
 	sender, err := QueryMember(c)
 	if err != nil {
 		c.Abort()
@@ -28,7 +37,8 @@ func BalanceDeposit(c *gin.Context) {
 	}
 
 	var query struct {
-		Amount float64 `json:"Amount" binding:"required"`
+		Amount   float64 `json:"Amount" binding:"required"`
+		Provider string  `json:"Provider" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&query); err != nil {
@@ -41,19 +51,93 @@ func BalanceDeposit(c *gin.Context) {
 		return
 	}
 
-	Amount := sender.USD.Decimal.Add(decimal.NewFromFloat(query.Amount))
+	deposit, instructions, err := payment.Start(sender.MemberID, query.Provider, decimal.NewFromFloat(query.Amount))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": gin.H{
+			"Deposit":      deposit,
+			"Instructions": instructions,
+		},
+	})
+}
+
+// BalanceDepositGet
+// @Summary
+// @Description BalanceDepositGet
+// @Tags Member
+// @Accept  json
+// @Produce  json
+// @ID Member-Balance-Deposit-Get
+// @Success 200 {object} models.Deposit
+// @Failure 400 {object} Error
+// @Router /balance/deposit [get]
+func BalanceDepositGet(c *gin.Context) {
+	db := db.GetDB()
 
-	if Amount.GreaterThanOrEqual(decimal.NewFromInt(50000)) {
-		Amount = decimal.NewFromInt(50000)
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
 	}
 
-	tx := db.MustBegin()
-	tx.MustExec("INSERT INTO Deposit (MemberID, Amount) VALUES ($1, $2)", sender.MemberID, query.Amount)
-	tx.Commit()
+	var result []*models.Deposit
+
+	if err := db.Select(&result, "SELECT * FROM Deposit WHERE MemberID=$1 ORDER BY DepositID DESC", sender.MemberID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
 
 	c.JSON(200, gin.H{
-		"status":  true,
-		"balance": Amount,
+		"status": true,
+		"result": result,
+	})
+}
+
+// PaymentWebhook
+// @Summary
+// @Description PaymentWebhook
+// @Tags Member
+// @Accept  json
+// @Produce  json
+// @ID Payment-Webhook
+// @Param   provider		path		string		true		"Payment provider (bank, card, mock)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /payment/webhook/{provider} [post]
+func PaymentWebhook(c *gin.Context) {
+	provider, err := payment.GetProvider(c.Param("provider"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	event, err := provider.Parse(c.Request.Header, body)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	if err := payment.Handle(c.Param("provider"), event, body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
 	})
 }
 
@@ -64,12 +148,12 @@ func BalanceDeposit(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @ID Member-Balance-Withdraw
+// @Param   Amount			query		number		true		"Amount in account currency"
+// @Param   MFACode			query		string		false		"TOTP or recovery code (MFA enabled)"
 // @Success 200 {object} Success
 // @Failure 400 {object} Error
-// @Router /balance/withdraw [get]
+// @Router /balance/withdraw [post]
 func BalanceWithdraw(c *gin.Context) {
-	db := db.GetDB()
-
 	sender, err := QueryMember(c)
 	if err != nil {
 		c.Abort()
@@ -77,7 +161,8 @@ func BalanceWithdraw(c *gin.Context) {
 	}
 
 	var query struct {
-		Amount float64 `json:"Amount" binding:"required"`
+		Amount  float64 `json:"Amount" binding:"required"`
+		MFACode string  `json:"MFACode"`
 	}
 
 	if err := c.ShouldBindJSON(&query); err != nil {
@@ -90,19 +175,151 @@ func BalanceWithdraw(c *gin.Context) {
 		return
 	}
 
-	//TODO: select all withdrawal requests and count total sum. it should be bigger or equal to balance, esle throw INSUFFICIENT
+	//Step-up verification of members with MFA enabled
+	if err := verify.StepUp(verify.PURPOSE_WITHDRAWAL, sender, query.MFACode); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
 
-	if decimal.NewFromFloat(query.Amount).GreaterThan(sender.USD.Decimal) {
-		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "WITHDRAW_INSUFFICIENT"})
+	//Amount is held right away, so pending withdrawals can't exceed the balance
+	result, err := withdrawal.Request(sender.MemberID, decimal.NewFromFloat(query.Amount))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
 		return
 	}
 
-	tx := db.MustBegin()
-	tx.MustExec("INSERT INTO Withdrawal (MemberID, Amount) VALUES ($1, $2)", sender.MemberID, query.Amount)
-	tx.Commit()
+	balance, err := account.QueryBalance(sender.MemberID, result.Currency)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
 
 	c.JSON(200, gin.H{
 		"status":  true,
-		"balance": sender.USD.Decimal.Sub(decimal.NewFromFloat(query.Amount)),
+		"result":  result,
+		"balance": balance,
+	})
+}
+
+// BalanceWithdrawGet
+// @Summary
+// @Description BalanceWithdrawGet
+// @Tags Member
+// @Accept  json
+// @Produce  json
+// @ID Member-Balance-Withdraw-Get
+// @Success 200 {object} models.Withdrawal
+// @Failure 400 {object} Error
+// @Router /balance/withdraw [get]
+func BalanceWithdrawGet(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	var result []*models.Withdrawal
+
+	if err := db.Select(&result, "SELECT * FROM Withdrawal WHERE MemberID=$1 ORDER BY WithdrawalID DESC", sender.MemberID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// BalanceTransfer
+// @Summary
+// @Description BalanceTransfer
+// @Tags Member
+// @Accept  json
+// @Produce  json
+// @ID Member-Balance-Transfer
+// @Param   Amount					query		number		true		"Amount in Currency"
+// @Param   Currency				query		string		true		"Currency to transfer from"
+// @Param   ToCurrency			query		string		false		"Member currency to convert to"
+// @Param   RecipientEmail	query		string		false		"Email of member to transfer to"
+// @Param   MFACode				query		string		false		"TOTP or recovery code (MFA enabled, transfer to another member)"
+// @Success 200 {object} models.Transfer
+// @Failure 400 {object} Error
+// @Router /balance/transfer [post]
+func BalanceTransfer(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	var query struct {
+		Amount         float64 `json:"Amount" binding:"required"`
+		Currency       string  `json:"Currency" binding:"required"`
+		ToCurrency     string  `json:"ToCurrency"`
+		RecipientEmail string  `json:"RecipientEmail"`
+		MFACode        string  `json:"MFACode"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "REQUIRED", "type": "validation"})
+		return
+	}
+
+	if query.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_AMOUNT"})
+		return
+	}
+
+	//Either conversion between own currencies or transfer to another member
+	if (query.ToCurrency == "") == (query.RecipientEmail == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_TRANSFER", "type": "validation"})
+		return
+	}
+
+	amount := decimal.NewFromFloat(query.Amount)
+	currency := strings.ToUpper(query.Currency)
+
+	var result models.Transfer
+
+	if query.ToCurrency != "" {
+		result, err = transfer.Convert(sender.MemberID, currency, strings.ToUpper(query.ToCurrency), amount)
+	} else {
+		//Money leaves member balance as with withdrawal
+		if err := verify.StepUp(verify.PURPOSE_WITHDRAWAL, sender, query.MFACode); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+			return
+		}
+
+		var RecipientID int64
+
+		if err := db.Get(&RecipientID, "SELECT MemberID FROM Member WHERE Email=$1", strings.ToLower(query.RecipientEmail)); err != nil {
+			if err == sql.ErrNoRows {
+				//Same error as of a recipient that can't receive transfers, so members can't be enumerated
+				c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "TRANSFER_RECIPIENT_INVALID"})
+			} else {
+				c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+			}
+			return
+		}
+
+		result, err = transfer.Send(sender.MemberID, RecipientID, currency, amount)
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
 	})
 }
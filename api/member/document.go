@@ -0,0 +1,64 @@
+package member
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+// DocumentGet
+// @Summary
+// @Description DocumentGet
+// @Tags Member
+// @Accept  json
+// @Produce  json
+// @ID Member-Document-Get
+// @Param   Type				query		string		false		"Document type (statement, confirmation)"
+// @Param   TradeID			query		int				false		"Trade ID of confirmations"
+// @Param   Offset			query		int				false		"Offset"
+// @Param   Limit				query		int				false		"Limit"
+// @Success 200 {object} models.Document
+// @Failure 400 {object} Error
+// @Router /document [get]
+func DocumentGet(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	var query struct {
+		Type    string `form:"type"`
+		TradeID int64  `form:"tradeid"`
+		Offset  int    `form:"offset"`
+		Limit   int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	result := []models.Document{}
+
+	if err := db.Select(&result, "SELECT * FROM Document WHERE MemberID=$1 AND ($2='' OR Type=$2) AND ($3=0 OR TradeID=$3) ORDER BY DocumentID DESC OFFSET $4 LIMIT $5", sender.MemberID, query.Type, query.TradeID, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
@@ -0,0 +1,201 @@
+package member
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/mfa"
+)
+
+// MFAGet
+// @Summary
+// @Description MFAGet
+// @Tags Member, MFA
+// @Accept  json
+// @Produce  json
+// @ID Member-MFA-Get
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /profile/mfa [get]
+func MFAGet(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	required, err := mfa.RequiredForRole(sender.Role)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	RecoveryLeft, err := mfa.QueryRecoveryLeft(sender.MemberID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": gin.H{
+			"Enabled":      sender.MFAEnabled,
+			"Required":     required,
+			"RecoveryLeft": RecoveryLeft,
+		},
+	})
+}
+
+// MFAEnroll
+// @Summary
+// @Description MFAEnroll - create TOTP secret and provisioning URI for QR code
+// @Tags Member, MFA
+// @Accept  json
+// @Produce  json
+// @ID Member-MFA-Enroll
+// @Success 200 {object} mfa.Enrollment
+// @Failure 400 {object} Error
+// @Router /profile/mfa/enroll [post]
+func MFAEnroll(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	result, err := mfa.Enroll(sender.MemberID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// MFAActivate
+// @Summary
+// @Description MFAActivate - enable MFA with the first code of authenticator app, returns recovery codes
+// @Tags Member, MFA
+// @Accept  json
+// @Produce  json
+// @ID Member-MFA-Activate
+// @Param   Code	query		string		true		"TOTP code"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /profile/mfa/activate [post]
+func MFAActivate(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	var query struct {
+		Code string `json:"Code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "REQUIRED", "type": "validation"})
+		return
+	}
+
+	codes, err := mfa.Activate(sender.MemberID, query.Code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": codes,
+	})
+}
+
+// MFADisable
+// @Summary
+// @Description MFADisable
+// @Tags Member, MFA
+// @Accept  json
+// @Produce  json
+// @ID Member-MFA-Disable
+// @Param   Code	query		string		true		"TOTP or recovery code"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /profile/mfa/disable [post]
+func MFADisable(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	var query struct {
+		Code string `json:"Code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "REQUIRED", "type": "validation"})
+		return
+	}
+
+	//Operators can't turn off MFA required by settings
+	required, err := mfa.RequiredForRole(sender.Role)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	if required {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "MFA_REQUIRED"})
+		return
+	}
+
+	if err := mfa.Disable(sender.MemberID, query.Code); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true})
+}
+
+// MFARecovery
+// @Summary
+// @Description MFARecovery - replace recovery codes
+// @Tags Member, MFA
+// @Accept  json
+// @Produce  json
+// @ID Member-MFA-Recovery
+// @Param   Code	query		string		true		"TOTP or recovery code"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /profile/mfa/recovery [post]
+func MFARecovery(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	var query struct {
+		Code string `json:"Code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "REQUIRED", "type": "validation"})
+		return
+	}
+
+	codes, err := mfa.Recovery(sender.MemberID, query.Code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": codes,
+	})
+}
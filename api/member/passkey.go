@@ -0,0 +1,159 @@
+package member
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/verify"
+	"github.com/ianidi/exchange-server/internal/webauthn"
+)
+
+// PasskeyGet
+// @Summary
+// @Description PasskeyGet
+// @Tags Member, Passkey
+// @Accept  json
+// @Produce  json
+// @ID Member-Passkey-Get
+// @Success 200 {object} models.WebAuthnCredential
+// @Failure 400 {object} Error
+// @Router /profile/passkey [get]
+func PasskeyGet(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	result, err := webauthn.QueryCredentials(sender.MemberID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// PasskeyRegisterBegin
+// @Summary
+// @Description PasskeyRegisterBegin - options of navigator.credentials.create()
+// @Tags Member, Passkey
+// @Accept  json
+// @Produce  json
+// @ID Member-Passkey-Register-Begin
+// @Param   MFACode	query		string		false		"TOTP or recovery code (MFA enabled)"
+// @Success 200 {object} webauthn.CreationOptions
+// @Failure 400 {object} Error
+// @Router /profile/passkey/register/begin [post]
+func PasskeyRegisterBegin(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	var query struct {
+		MFACode string `json:"MFACode"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "REQUIRED", "type": "validation"})
+		return
+	}
+
+	//Passkey signs member in, so adding one is verified like password change
+	if err := verify.StepUp(verify.PURPOSE_PASSKEY, sender, query.MFACode); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	result, err := webauthn.RegistrationOptions(sender)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// PasskeyRegister
+// @Summary
+// @Description PasskeyRegister - store passkey of navigator.credentials.create() response
+// @Tags Member, Passkey
+// @Accept  json
+// @Produce  json
+// @ID Member-Passkey-Register
+// @Param   Name				query		string								false		"Passkey name"
+// @Param   Credential	query		webauthn.Credential		true		"PublicKeyCredential"
+// @Success 200 {object} models.WebAuthnCredential
+// @Failure 400 {object} Error
+// @Router /profile/passkey/register [post]
+func PasskeyRegister(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	var query struct {
+		Name       string              `json:"Name" binding:"max=100"`
+		Credential webauthn.Credential `json:"Credential"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "REQUIRED", "type": "validation"})
+		return
+	}
+
+	result, err := webauthn.FinishRegistration(sender.MemberID, query.Name, query.Credential)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// PasskeyDelete
+// @Summary
+// @Description PasskeyDelete
+// @Tags Member, Passkey
+// @Accept  json
+// @Produce  json
+// @ID Member-Passkey-Delete
+// @Param   WebAuthnCredentialID	query		int		true		"Passkey ID"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /profile/passkey/delete [post]
+func PasskeyDelete(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		c.Abort()
+		return
+	}
+
+	var query struct {
+		WebAuthnCredentialID int64 `json:"WebAuthnCredentialID" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "REQUIRED", "type": "validation"})
+		return
+	}
+
+	if err := webauthn.Delete(sender.MemberID, query.WebAuthnCredentialID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/fx"
 	"github.com/ianidi/exchange-server/internal/models"
 	"github.com/ianidi/exchange-server/internal/trade"
 	"github.com/shopspring/decimal"
@@ -128,7 +129,11 @@ func TradeNew(c *gin.Context) {
 	}
 
 	//Query current asset market rate
-	order.MarketRate.Decimal = order.DetermineMarketRate()
+	order.MarketRate.Decimal, err = order.DetermineMarketRate()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
 
 	//Determine current asset rate
 	order.RateEntry.Decimal = order.DetermineRateEntry()
@@ -192,6 +197,20 @@ func TradeNew(c *gin.Context) {
 		order.ForexAmount.Decimal = order.TotalReal.Decimal.Div(order.RateEntry.Decimal)
 	}
 
+	//Member account currency the order is settled in
+	order.AccountCurrency, err = order.DetermineAccountCurrency()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	//Live conversion rate of order margin into member account currency
+	order.MarginRate.Decimal, err = fx.Rate(order.DetermineMarginCurrency(), order.AccountCurrency)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
 	//Get member account balance before order was placed
 	order.BalanceEntry.Decimal, err = order.QueryCurrentBalance()
 	if err != nil {
@@ -200,7 +219,7 @@ func TradeNew(c *gin.Context) {
 	}
 
 	//Сheck that user has enough funds (leverage applied) on balance to buy this qty of assets
-	if order.Total.Decimal.GreaterThan(order.BalanceEntry.Decimal) {
+	if order.DetermineMargin().GreaterThan(order.BalanceEntry.Decimal) {
 		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "TRADE_INSUFFICIENT_WALLET"})
 		return
 	}
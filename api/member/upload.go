@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ianidi/exchange-server/graph/methods/constants"
+	"github.com/ianidi/exchange-server/internal/config"
 	"github.com/ianidi/exchange-server/internal/db"
 	"github.com/ianidi/exchange-server/internal/s3"
 	"github.com/minio/minio-go/v6"
@@ -31,14 +32,11 @@ type File struct {
 }
 
 func CloudUpload(filename string) bool {
-	endpoint := "hb.bizmrg.com"
-	accessKeyID := "pKQkDFULfrBEqRP9wXUXFT"
-	secretAccessKey := "6s5AH9FmAptZTeGqzuyNMs2nvsUtc9BwfKcVdh7Q1UPY"
+	settings := config.Get().S3
 	useSSL := true
-	bucketName := "exchange"
 
 	// Initialize minio client object.
-	minioClient, err := minio.New(endpoint, accessKeyID, secretAccessKey, useSSL)
+	minioClient, err := minio.New(settings.Endpoint, settings.Key, settings.Secret, useSSL)
 	if err != nil {
 		return false
 	}
@@ -48,7 +46,7 @@ func CloudUpload(filename string) bool {
 	contentType := "image/jpeg"
 
 	// Upload the zip file with FPutObject
-	_, err = minioClient.FPutObject(bucketName, filename, filePath, minio.PutObjectOptions{ContentType: contentType})
+	_, err = minioClient.FPutObject(settings.UploadBucket, filename, filePath, minio.PutObjectOptions{ContentType: contentType})
 	if err != nil {
 		return false
 	}
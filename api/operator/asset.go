@@ -2,13 +2,59 @@ package operator
 
 import (
 	"database/sql"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/graph/model"
+	"github.com/ianidi/exchange-server/internal/audit"
 	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/job"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/outbox"
+	"github.com/ianidi/exchange-server/internal/spread"
+	"github.com/ianidi/exchange-server/internal/trade"
 	"github.com/shopspring/decimal"
 )
 
+const (
+	//Max time a manual rate can be pinned for (seconds)
+	MAX_OVERRIDE_DURATION = 86400
+	BADTICK_CANCEL        = "cancel"
+	BADTICK_REPRICE       = "reprice"
+)
+
+//AssetHaltRequest - body of /operator/asset/halt
+type AssetHaltRequest struct {
+	AssetID int64  `json:"AssetID" binding:"required"`
+	Reason  string `json:"Reason" binding:"required"`
+}
+
+//AssetResumeRequest - body of /operator/asset/resume and /operator/asset/override/clear
+type AssetResumeRequest struct {
+	AssetID int64  `json:"AssetID" binding:"required"`
+	Reason  string `json:"Reason"`
+}
+
+//AssetOverrideRequest - body of /operator/asset/override, Duration in seconds
+type AssetOverrideRequest struct {
+	AssetID  int64   `json:"AssetID" binding:"required"`
+	Rate     float64 `json:"Rate" binding:"required"`
+	Duration int64   `json:"Duration" binding:"required"`
+	Reason   string  `json:"Reason" binding:"required"`
+}
+
+//AssetBadTickRequest - body of /operator/asset/badtick, From and To are UNIX timestamps of the window, Rate is used by reprice
+type AssetBadTickRequest struct {
+	AssetID int64   `json:"AssetID" binding:"required"`
+	From    int64   `json:"From" binding:"required"`
+	To      int64   `json:"To" binding:"required"`
+	Action  string  `json:"Action" binding:"required"`
+	Rate    float64 `json:"Rate"`
+	Reason  string  `json:"Reason" binding:"required"`
+}
+
 // AssetGetByID
 // @Summary
 // @Description AssetGetByID
@@ -115,6 +161,7 @@ func AssetGet(c *gin.Context) {
 // @Produce  json
 // @ID Operator-Asset-Update-By-ID
 // @Param   AssetID					query		int				true		"ID"
+// @Param   Reason					query		string		false		"Reason of the change, kept in audit log"
 // @Success 200 {object} Success
 // @Failure 400 {object} Error
 // @Router /operator/asset/update [post]
@@ -122,17 +169,21 @@ func AssetUpdateByID(c *gin.Context) {
 	db := db.GetDB()
 
 	var query struct {
-		AssetID         int     `json:"AssetID" binding:"required"`
-		Title           string  `json:"Title" binding:"required"`
-		Description     string  `json:"Description" binding:"required"`
-		BuySpread       float64 `json:"BuySpread" binding:"required"`
-		SellSpread      float64 `json:"SellSpread" binding:"required"`
-		DecimalScale    int     `json:"DecimalScale" binding:"required"`
-		Priority        int     `json:"Priority" binding:"required"`
-		Sentiment       int     `json:"Sentiment" binding:"required"`
-		SentimentType   string  `json:"SentimentType" binding:"required"`
-		LeverageAllowed int     `json:"LeverageAllowed"`
-		Tradable        bool    `json:"Tradable"`
+		AssetID             int     `json:"AssetID" binding:"required"`
+		Title               string  `json:"Title" binding:"required"`
+		Description         string  `json:"Description" binding:"required"`
+		BuySpread           float64 `json:"BuySpread" binding:"required"`
+		SellSpread          float64 `json:"SellSpread" binding:"required"`
+		DecimalScale        int     `json:"DecimalScale" binding:"required"`
+		Priority            int     `json:"Priority" binding:"required"`
+		Sentiment           int     `json:"Sentiment" binding:"required"`
+		SentimentType       string  `json:"SentimentType" binding:"required"`
+		LeverageAllowed     int     `json:"LeverageAllowed"`
+		Tradable            bool    `json:"Tradable"`
+		VolatilityWindow    int64   `json:"VolatilityWindow"`
+		VolatilityFactor    float64 `json:"VolatilityFactor"`
+		MaxSpreadMultiplier float64 `json:"MaxSpreadMultiplier"`
+		Reason              string  `json:"Reason"`
 	}
 
 	if err := c.ShouldBindJSON(&query); err != nil {
@@ -140,7 +191,12 @@ func AssetUpdateByID(c *gin.Context) {
 		return
 	}
 
-	var asset Asset
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var asset models.Asset
 
 	if err := db.Get(&asset, "SELECT * FROM Asset WHERE AssetID=$1", query.AssetID); err != nil {
 		if err == sql.ErrNoRows {
@@ -157,26 +213,527 @@ func AssetUpdateByID(c *gin.Context) {
 		return
 	}
 
-	var rateBuy decimal.Decimal
-	var rateSell decimal.Decimal
+	if query.VolatilityWindow < 0 || query.VolatilityFactor < 0 || query.MaxSpreadMultiplier < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_SPREAD_POLICY"})
+		return
+	}
+
+	var policy = spread.Policy{
+		Asset:     asset,
+		Timestamp: time.Now().Unix(),
+	}
+
+	policy.Asset.BuySpread.Decimal = decimal.NewFromFloat(query.BuySpread)
+	policy.Asset.SellSpread.Decimal = decimal.NewFromFloat(query.SellSpread)
+	policy.Asset.VolatilityWindow = query.VolatilityWindow
+	policy.Asset.VolatilityFactor.Decimal = decimal.NewFromFloat(query.VolatilityFactor)
+	policy.Asset.MaxSpreadMultiplier.Decimal = decimal.NewFromFloat(query.MaxSpreadMultiplier)
+
+	//New asset spread policy must be valid, rates with it are set by the next tick (or kept by manual rate override)
+	if _, err := policy.Determine(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Asset SET Title=$1, Description=$2, BuySpread=$3, SellSpread=$4, DecimalScale=$5, Priority=$6, Sentiment=$7, SentimentType=$8, Tradable=$9, LeverageAllowed=$10, VolatilityWindow=$11, VolatilityFactor=$12, MaxSpreadMultiplier=$13 WHERE AssetID=$14", query.Title, query.Description, query.BuySpread, query.SellSpread, query.DecimalScale, query.Priority, query.Sentiment, query.SentimentType, query.Tradable, query.LeverageAllowed, query.VolatilityWindow, query.VolatilityFactor, query.MaxSpreadMultiplier, query.AssetID)
+	//Trading and pricing settings are audited, descriptive fields are not
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "asset.update",
+		Entity:   "asset",
+		EntityID: int64(query.AssetID),
+		Reason:   query.Reason,
+		Data: gin.H{
+			"Tradable":            query.Tradable,
+			"BuySpread":           policy.Asset.BuySpread.Decimal.String(),
+			"SellSpread":          policy.Asset.SellSpread.Decimal.String(),
+			"VolatilityWindow":    query.VolatilityWindow,
+			"VolatilityFactor":    policy.Asset.VolatilityFactor.Decimal.String(),
+			"MaxSpreadMultiplier": policy.Asset.MaxSpreadMultiplier.Decimal.String(),
+			"LeverageAllowed":     query.LeverageAllowed,
+			"Previous": gin.H{
+				"Tradable":            asset.Tradable,
+				"BuySpread":           asset.BuySpread.Decimal.String(),
+				"SellSpread":          asset.SellSpread.Decimal.String(),
+				"VolatilityWindow":    asset.VolatilityWindow,
+				"VolatilityFactor":    asset.VolatilityFactor.Decimal.String(),
+				"MaxSpreadMultiplier": asset.MaxSpreadMultiplier.Decimal.String(),
+				"LeverageAllowed":     asset.LeverageAllowed.Decimal.String(),
+			},
+		},
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// AssetHalt
+// @Summary
+// @Description AssetHalt
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Asset-Halt
+// @Param   request					body		AssetHaltRequest		true		"Asset and reason"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/asset/halt [post]
+func AssetHalt(c *gin.Context) {
+	db := db.GetDB()
+
+	var query AssetHaltRequest
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var asset Asset
+
+	if err := db.Get(&asset, "SELECT * FROM Asset WHERE AssetID=$1", query.AssetID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_ASSET_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	if asset.Halted {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "ASSET_ALREADY_HALTED"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Asset SET Halted=$1, HaltReason=$2 WHERE AssetID=$3", true, query.Reason, query.AssetID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "asset.halt",
+		Entity:   "asset",
+		EntityID: query.AssetID,
+		Reason:   query.Reason,
+	}.Record(tx)
+	outbox.Info(model.Info{
+		Event:  "asset",
+		ID:     int(query.AssetID),
+		Value:  "halt",
+		Reason: query.Reason,
+	}).Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// AssetResume
+// @Summary
+// @Description AssetResume
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Asset-Resume
+// @Param   request					body		AssetResumeRequest		true		"Asset and optional reason"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/asset/resume [post]
+func AssetResume(c *gin.Context) {
+	db := db.GetDB()
+
+	var query AssetResumeRequest
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var asset Asset
+
+	if err := db.Get(&asset, "SELECT * FROM Asset WHERE AssetID=$1", query.AssetID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_ASSET_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	if !asset.Halted {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "ASSET_NOT_HALTED"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Asset SET Halted=$1, HaltReason=$2 WHERE AssetID=$3", false, "", query.AssetID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "asset.resume",
+		Entity:   "asset",
+		EntityID: query.AssetID,
+		Reason:   query.Reason,
+	}.Record(tx)
+	outbox.Info(model.Info{
+		Event:  "asset",
+		ID:     int(query.AssetID),
+		Value:  "resume",
+		Reason: query.Reason,
+	}).Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// AssetOverride
+// @Summary
+// @Description AssetOverride
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Asset-Override
+// @Param   request					body		AssetOverrideRequest		true		"Manual rate and how long it is pinned (seconds)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/asset/override [post]
+func AssetOverride(c *gin.Context) {
+	db := db.GetDB()
+
+	var query AssetOverrideRequest
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Rate <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_RATE"})
+		return
+	}
+
+	if query.Duration <= 0 || query.Duration > MAX_OVERRIDE_DURATION {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_DURATION"})
+		return
+	}
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var asset Asset
+
+	if err := db.Get(&asset, "SELECT * FROM Asset WHERE AssetID=$1", query.AssetID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_ASSET_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	rate := decimal.NewFromFloat(query.Rate)
+	until := time.Now().Unix() + query.Duration
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Asset SET ManualRate=$1, ManualRateUntil=$2 WHERE AssetID=$3", rate, until, query.AssetID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "asset.override",
+		Entity:   "asset",
+		EntityID: query.AssetID,
+		Reason:   query.Reason,
+		Data: gin.H{
+			"Rate":         rate.String(),
+			"Until":        until,
+			"PreviousRate": asset.Rate.Decimal.String(),
+		},
+	}.Record(tx)
+	//ws notify
+	outbox.Info(model.Info{
+		Event:  "asset",
+		ID:     int(query.AssetID),
+		Value:  "override",
+		Rate:   rate.String(),
+		Reason: query.Reason,
+	}).Record(tx)
+	tx.Commit()
+
+	//Apply the pinned rate right away instead of waiting for the next feed update.
+	//The override is in place either way, a failed update is applied by the next tick
+	if err := (job.Rate{Asset: models.Asset{Ticker: asset.Ticker}, RateString: rate.String()}).Update(); err != nil {
+		log.Println("AssetOverride rate update error", asset.Ticker, err)
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// AssetOverrideClear
+// @Summary
+// @Description AssetOverrideClear
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Asset-Override-Clear
+// @Param   request					body		AssetResumeRequest		true		"Asset and optional reason"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/asset/override/clear [post]
+func AssetOverrideClear(c *gin.Context) {
+	db := db.GetDB()
+
+	var query AssetResumeRequest
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
 
-	var BuySpread = decimal.NewFromFloat(query.BuySpread)
-	var SellSpread = decimal.NewFromFloat(query.SellSpread)
+	var asset Asset
 
-	//Forex spread has different formula (counted in pips)
-	if asset.MarketID == 3 {
-		rateBuy = asset.Rate.Decimal.Add(BuySpread)
-		rateSell = asset.Rate.Decimal.Sub(SellSpread)
-	} else {
-		rateBuy = asset.Rate.Decimal.Add(asset.Rate.Decimal.Mul(BuySpread.Div(decimal.NewFromInt(100))))
-		rateSell = asset.Rate.Decimal.Sub(asset.Rate.Decimal.Mul(SellSpread.Div(decimal.NewFromInt(100))))
+	if err := db.Get(&asset, "SELECT * FROM Asset WHERE AssetID=$1", query.AssetID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_ASSET_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	if asset.ManualRateUntil < time.Now().Unix() {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "NO_ACTIVE_OVERRIDE"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Asset SET ManualRate=$1, ManualRateUntil=$2 WHERE AssetID=$3", 0, 0, query.AssetID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "asset.override.clear",
+		Entity:   "asset",
+		EntityID: query.AssetID,
+		Reason:   query.Reason,
+	}.Record(tx)
+	outbox.Info(model.Info{
+		Event:  "asset",
+		ID:     int(query.AssetID),
+		Value:  "override_clear",
+		Reason: query.Reason,
+	}).Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// AssetBadTick
+// @Summary
+// @Description AssetBadTick
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Asset-Bad-Tick
+// @Param   request					body		AssetBadTickRequest		true		"Bad tick window, action (cancel/reprice) and corrected rate (reprice only)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/asset/badtick [post]
+func AssetBadTick(c *gin.Context) {
+	db := db.GetDB()
+
+	var query AssetBadTickRequest
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Action != BADTICK_CANCEL && query.Action != BADTICK_REPRICE {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_ACTION"})
+		return
+	}
+
+	if query.From >= query.To {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_WINDOW"})
+		return
+	}
+
+	if query.Action == BADTICK_REPRICE && query.Rate <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_RATE"})
+		return
+	}
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var asset models.Asset
+
+	if err := db.Get(&asset, "SELECT * FROM Asset WHERE AssetID=$1", query.AssetID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_ASSET_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	var orders []trade.Order
+
+	//Orders executed during the bad tick window
+	if err := db.Select(&orders, "SELECT * FROM Trade WHERE AssetID=$1 AND Timestamp>=$2 AND Timestamp<=$3 AND Status<>$4 ORDER BY TradeID ASC", query.AssetID, query.From, query.To, trade.STATUS_CANCELLED); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	rate := decimal.NewFromFloat(query.Rate)
+
+	affected := []int64{}
+	skipped := []int64{}
+
+	for _, orderRow := range orders {
+		var err error
+
+		order := orderRow
+		order.Asset = asset
+
+		if query.Action == BADTICK_REPRICE {
+			order.Member, err = order.QueryMember()
+			if err != nil {
+				skipped = append(skipped, order.TradeID)
+				continue
+			}
+		}
+
+		//Order change and its audit record are committed together
+		tx := db.MustBegin()
+
+		if query.Action == BADTICK_CANCEL {
+			err = order.CancelBadTick(tx)
+		} else {
+			order, err = order.Reprice(tx, rate)
+		}
+
+		if err != nil {
+			tx.Rollback()
+			skipped = append(skipped, order.TradeID)
+			continue
+		}
+
+		audit.Entry{
+			MemberID: sender.MemberID,
+			Action:   "trade." + query.Action,
+			Entity:   "trade",
+			EntityID: order.TradeID,
+			Reason:   query.Reason,
+			Data: gin.H{
+				"AssetID": query.AssetID,
+				"From":    query.From,
+				"To":      query.To,
+				"Rate":    rate.String(),
+			},
+		}.Record(tx)
+		outbox.Info(model.Info{
+			MemberID: int(order.MemberID),
+			Event:    "trade",
+			ID:       int(order.TradeID),
+			Value:    query.Action,
+			Reason:   query.Reason,
+		}).Record(tx)
+
+		if err := tx.Commit(); err != nil {
+			skipped = append(skipped, order.TradeID)
+			continue
+		}
+
+		affected = append(affected, order.TradeID)
+
+		//Recalculate profit of repriced order with corrected entry rate
+		if query.Action == BADTICK_REPRICE {
+			order.CalculateProfit()
+		}
 	}
 
 	tx := db.MustBegin()
-	tx.MustExec("UPDATE Asset SET Title=$1, Description=$2, BuySpread=$3, SellSpread=$4, DecimalScale=$5, Priority=$6, Sentiment=$7, SentimentType=$8, Tradable=$9, RateBuy=$10, RateSell=$11, LeverageAllowed=$12 WHERE AssetID=$13", query.Title, query.Description, query.BuySpread, query.SellSpread, query.DecimalScale, query.Priority, query.Sentiment, query.SentimentType, query.Tradable, rateBuy, rateSell, query.LeverageAllowed, query.AssetID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "asset.badtick",
+		Entity:   "asset",
+		EntityID: query.AssetID,
+		Reason:   query.Reason,
+		Data: gin.H{
+			"Action":   query.Action,
+			"From":     query.From,
+			"To":       query.To,
+			"Rate":     rate.String(),
+			"Affected": affected,
+			"Skipped":  skipped,
+		},
+	}.Record(tx)
+	outbox.Info(model.Info{
+		Event:  "asset",
+		ID:     int(query.AssetID),
+		Value:  "badtick",
+		Reason: query.Reason,
+	}).Record(tx)
 	tx.Commit()
 
 	c.JSON(200, gin.H{
 		"status": true,
+		"result": gin.H{
+			"Affected": affected,
+			"Skipped":  skipped,
+		},
 	})
 }
@@ -0,0 +1,58 @@
+package operator
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+// AuditGet
+// @Summary
+// @Description AuditGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Audit-Get
+// @Param   Entity			query		string	false		"Entity"
+// @Param   EntityID		query		int			false		"Entity ID"
+// @Param   Offset			query		int			false		"Offset"
+// @Param   Limit				query		int			false		"Limit"
+// @Success 200 {object} models.Audit
+// @Failure 400 {object} Error
+// @Router /operator/audit [get]
+func AuditGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		Entity   string `form:"entity"`
+		EntityID int64  `form:"entityid"`
+		Offset   int    `form:"offset"`
+		Limit    int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	var audit []*models.Audit
+
+	if err := db.Select(&audit, "SELECT * FROM Audit WHERE ($1='' OR Entity=$1) AND ($2=0 OR EntityID=$2) ORDER BY AuditID DESC OFFSET $3 LIMIT $4", query.Entity, query.EntityID, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": audit,
+	})
+}
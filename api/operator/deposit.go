@@ -5,7 +5,11 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
 	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/payment"
+	"github.com/shopspring/decimal"
 )
 
 // DepositGet
@@ -79,15 +83,24 @@ func DepositGet(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @ID Operator-Deposit-Update
+// @Param   DepositID		query		int				true		"ID of a pending or confirmed deposit"
+// @Param   Action			query		bool			false		"true - credit member balance, false - fail deposit"
+// @Param   Reason			query		string		false		"Reason"
 // @Success 200 {object} Success
 // @Failure 400 {object} Error
 // @Router /operator/deposit/update [post]
 func DepositUpdate(c *gin.Context) {
 	db := db.GetDB()
 
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
 	var query struct {
-		DepositID int  `json:"DepositID" binding:"required"`
-		Action    bool `json:"Action"`
+		DepositID int    `json:"DepositID" binding:"required"`
+		Action    bool   `json:"Action"`
+		Reason    string `json:"Reason"`
 	}
 
 	if err := c.ShouldBindJSON(&query); err != nil {
@@ -95,9 +108,12 @@ func DepositUpdate(c *gin.Context) {
 		return
 	}
 
-	var deposit Deposit
+	tx := db.MustBegin()
+
+	var deposit models.Deposit
 
-	if err := db.Get(&deposit, "SELECT * FROM Deposit WHERE DepositID=$1 AND Status=$2", query.DepositID, "pending"); err != nil {
+	if err := tx.Get(&deposit, "SELECT * FROM Deposit WHERE DepositID=$1 AND Status IN ($2, $3) FOR UPDATE", query.DepositID, payment.STATUS_PENDING, payment.STATUS_CONFIRMED); err != nil {
+		tx.Rollback()
 		if err == sql.ErrNoRows {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"status": false,
@@ -112,18 +128,168 @@ func DepositUpdate(c *gin.Context) {
 		return
 	}
 
-	var status = "declined"
+	action := "deposit.credit"
 
-	if query.Action == true {
-		status = "complete"
+	if query.Action {
+		err = payment.Credit(tx, deposit)
+	} else {
+		action = "deposit.fail"
+		err = payment.Fail(tx, deposit, query.Reason)
 	}
 
-	tx := db.MustBegin()
-	tx.MustExec("UPDATE Deposit SET Status=$1 WHERE DepositID=$2", status, query.DepositID)
-	if status == "complete" {
-		tx.MustExec("UPDATE Member SET USD=$1 WHERE MemberID=$2", deposit.Amount.Decimal, deposit.MemberID)
-		tx.MustExec("INSERT INTO History (MemberID, Type, Status, Currency, Profit, ProfitAbs) VALUES ($1, $2, $3, $4, $5, $6)", deposit.MemberID, "balance", "deposit", "USD", deposit.Amount.Decimal, deposit.Amount.Decimal.Abs())
+	if err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   action,
+		Entity:   "deposit",
+		EntityID: deposit.DepositID,
+		Reason:   query.Reason,
+		Data: gin.H{
+			"Amount":   deposit.Amount.Decimal.String(),
+			"Currency": deposit.Currency,
+			"Status":   deposit.Status,
+		},
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// DepositMock
+// @Summary
+// @Description DepositMock
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Deposit-Mock
+// @Param   DepositID		query		int				true		"ID of a pending mock deposit"
+// @Param   Status			query		string		true		"Status reported by the mock processor (confirmed, failed)"
+// @Param   Amount			query		number		false		"Amount reported by the mock processor (default - deposit amount)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/deposit/mock [post]
+func DepositMock(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		DepositID int64   `json:"DepositID" binding:"required"`
+		Status    string  `json:"Status" binding:"required"`
+		Amount    float64 `json:"Amount"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	//Mock processor is registered only when enabled in config
+	provider, err := payment.GetProvider(payment.PROVIDER_MOCK)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	mock, ok := provider.(payment.Mock)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_PROVIDER"})
+		return
+	}
+
+	var deposit models.Deposit
+
+	if err := db.Get(&deposit, "SELECT * FROM Deposit WHERE DepositID=$1 AND Provider=$2", query.DepositID, payment.PROVIDER_MOCK); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_DEPOSIT_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	amount := deposit.Amount.Decimal
+	if query.Amount > 0 {
+		amount = decimal.NewFromFloat(query.Amount)
+	}
+
+	if err := mock.Complete(deposit, query.Status, amount); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// DepositSettingsUpdate
+// @Summary
+// @Description DepositSettingsUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Deposit-Settings-Update
+// @Param   DepositBankDetailsID			query		int				true		"Bank account members transfer deposits to"
+// @Param   DepositAutoCreditLimit		query		number		true		"Confirmed deposits above the limit are credited by operator"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/deposit/settings/update [post]
+func DepositSettingsUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
 	}
+
+	var query struct {
+		DepositBankDetailsID   int64   `json:"DepositBankDetailsID" binding:"required"`
+		DepositAutoCreditLimit float64 `json:"DepositAutoCreditLimit" binding:"min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	var BankDetailsID int64
+
+	if err := db.Get(&BankDetailsID, "SELECT BankDetailsID FROM BankDetails WHERE BankDetailsID=$1", query.DepositBankDetailsID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_BANK_DETAILS_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Settings SET DepositBankDetailsID=$1, DepositAutoCreditLimit=$2 WHERE SettingsID=$3", query.DepositBankDetailsID, decimal.NewFromFloat(query.DepositAutoCreditLimit), 1)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "deposit.settings.update",
+		Entity:   "settings",
+		EntityID: 1,
+		Data:     query,
+	}.Record(tx)
 	tx.Commit()
 
 	c.JSON(200, gin.H{
@@ -0,0 +1,139 @@
+package operator
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/document"
+)
+
+// DocumentGet
+// @Summary
+// @Description DocumentGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Document-Get
+// @Param   MemberID		query		int				false		"Member ID"
+// @Param   Type				query		string		false		"Document type (statement, confirmation)"
+// @Param   Offset			query		int				false		"Offset"
+// @Param   Limit				query		int				false		"Limit"
+// @Success 200 {object} Document
+// @Failure 400 {object} Error
+// @Router /operator/document [get]
+func DocumentGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		MemberID int64  `form:"memberid"`
+		Type     string `form:"type"`
+		Offset   int    `form:"offset"`
+		Limit    int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	result := []Document{}
+
+	if err := db.Select(&result, "SELECT Document.*, Member.Email AS MemberEmail FROM Document INNER JOIN Member ON Member.MemberID=Document.MemberID WHERE ($1=0 OR Document.MemberID=$1) AND ($2='' OR Document.Type=$2) ORDER BY Document.DocumentID DESC OFFSET $3 LIMIT $4", query.MemberID, query.Type, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// DocumentStatement
+// @Summary
+// @Description DocumentStatement
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Document-Statement
+// @Param   MemberID		query		int				true		"Member ID"
+// @Param   Period			query		string		true		"Statement month (2020-09)"
+// @Param   Email				query		bool			false		"Email statement to member"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/document/statement [post]
+func DocumentStatement(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		MemberID int64  `json:"MemberID" binding:"required"`
+		Period   string `json:"Period" binding:"required"`
+		Email    bool   `json:"Email"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if _, _, err := document.ParsePeriod(query.Period); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	var count int
+
+	if err := db.Get(&count, "SELECT count(*) FROM Member WHERE MemberID=$1", query.MemberID); err != nil || count == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "NO_MEMBER_RECORD"})
+		return
+	}
+
+	tx := db.MustBegin()
+
+	DocumentID, created, err := document.Create(tx, query.MemberID, document.TYPE_STATEMENT, query.Period, 0, query.Email)
+	if err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	//Statement of the period exists, it is rendered again with current data
+	if !created {
+		if err := tx.Get(&DocumentID, "SELECT DocumentID FROM Document WHERE MemberID=$1 AND Type=$2 AND Period=$3 AND TradeID=$4", query.MemberID, document.TYPE_STATEMENT, query.Period, 0); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+			return
+		}
+
+		if err := document.Regenerate(tx, DocumentID); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+			return
+		}
+	}
+
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "document.statement",
+		Entity:   "document",
+		EntityID: DocumentID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status":   true,
+		"RecordID": DocumentID,
+	})
+}
@@ -0,0 +1,205 @@
+package operator
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/job"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+// FeedStaleGet
+// @Summary
+// @Description FeedStaleGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Feed-Stale-Get
+// @Success 200 {object} job.StaleAsset
+// @Failure 400 {object} Error
+// @Router /operator/feed/stale [get]
+func FeedStaleGet(c *gin.Context) {
+	now := time.Now()
+
+	asset, err := job.QueryStaleAssets(now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	market, err := job.QueryStaleMarkets(now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": asset,
+		"market": market,
+	})
+}
+
+// FeedMarketGet
+// @Summary
+// @Description FeedMarketGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Feed-Market-Get
+// @Success 200 {object} models.Market
+// @Failure 400 {object} Error
+// @Router /operator/feed/market [get]
+func FeedMarketGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var market []*models.Market
+
+	if err := db.Select(&market, "SELECT * FROM Market ORDER BY MarketID ASC"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": market,
+	})
+}
+
+// FeedMarketUpdate
+// @Summary
+// @Description FeedMarketUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Feed-Market-Update
+// @Param   MarketID				query		int				true		"ID"
+// @Param   Weekdays				query		string		false		"Comma separated trading weekdays (0 - sunday), empty - every day"
+// @Param   StartMinute			query		int				false		"Session start (minutes since midnight)"
+// @Param   EndMinute				query		int				false		"Session end (minutes since midnight), equal to start - all day"
+// @Param   StaleIntervals	query		int				false		"Alert after this many update intervals without rates (0 - disabled)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/feed/market/update [post]
+func FeedMarketUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		MarketID       int64  `json:"MarketID" binding:"required"`
+		Weekdays       string `json:"Weekdays"`
+		StartMinute    int    `json:"StartMinute"`
+		EndMinute      int    `json:"EndMinute"`
+		StaleIntervals int64  `json:"StaleIntervals"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.StartMinute < 0 || query.StartMinute >= 1440 || query.EndMinute < 0 || query.EndMinute >= 1440 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_SESSION_TIME"})
+		return
+	}
+
+	if query.StaleIntervals < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_STALE_INTERVALS"})
+		return
+	}
+
+	var market models.Market
+
+	if err := db.Get(&market, "SELECT * FROM Market WHERE MarketID=$1", query.MarketID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_MARKET_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Market SET Weekdays=$1, StartMinute=$2, EndMinute=$3, StaleIntervals=$4 WHERE MarketID=$5", query.Weekdays, query.StartMinute, query.EndMinute, query.StaleIntervals, query.MarketID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "feed.market.update",
+		Entity:   "market",
+		EntityID: query.MarketID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// FeedAlertUpdate
+// @Summary
+// @Description FeedAlertUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Feed-Alert-Update
+// @Param   StaleAlertEmail			query		string		false		"Email notified when a market goes stale (empty - disabled)"
+// @Param   StaleAlertWebhook		query		string		false		"Webhook URL notified when a market goes stale (empty - disabled)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/feed/alert/update [post]
+func FeedAlertUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		StaleAlertEmail   string `json:"StaleAlertEmail" binding:"omitempty,email"`
+		StaleAlertWebhook string `json:"StaleAlertWebhook" binding:"omitempty,url"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Settings SET StaleAlertEmail=$1, StaleAlertWebhook=$2 WHERE SettingsID=$3", query.StaleAlertEmail, query.StaleAlertWebhook, 1)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "feed.alert.update",
+		Entity:   "settings",
+		EntityID: 1,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
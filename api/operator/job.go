@@ -0,0 +1,159 @@
+package operator
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/job"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+// JobGet
+// @Summary
+// @Description JobGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Job-Get
+// @Success 200 {object} models.Job
+// @Failure 400 {object} Error
+// @Router /operator/job [get]
+func JobGet(c *gin.Context) {
+	result, err := job.QueryJobs()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// JobUpdate
+// @Summary
+// @Description JobUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Job-Update
+// @Param   JobID				query		int				true		"ID"
+// @Param   Interval		query		int				true		"Run interval (seconds)"
+// @Param   Active			query		bool			false		"Active"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/job/update [post]
+func JobUpdate(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		JobID    int64 `json:"JobID" binding:"required"`
+		Interval int64 `json:"Interval" binding:"required"`
+		Active   bool  `json:"Active"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if err := job.UpdateJob(sender.MemberID, query.JobID, query.Interval, query.Active); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// JobTrigger
+// @Summary
+// @Description JobTrigger
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Job-Trigger
+// @Param   JobID				query		int				true		"ID"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/job/trigger [post]
+func JobTrigger(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		JobID int64 `json:"JobID" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if err := job.TriggerJob(sender.MemberID, query.JobID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// JobRunGet
+// @Summary
+// @Description JobRunGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Job-Run-Get
+// @Param   Name				query		string	false		"Scheduler job name"
+// @Param   Offset			query		int			false		"Offset"
+// @Param   Limit				query		int			false		"Limit"
+// @Success 200 {object} models.JobRun
+// @Failure 400 {object} Error
+// @Router /operator/job/run [get]
+func JobRunGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		Name   string `form:"name"`
+		Offset int    `form:"offset"`
+		Limit  int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	var run []*models.JobRun
+
+	if err := db.Select(&run, "SELECT * FROM JobRun WHERE ($1='' OR Name=$1) ORDER BY JobRunID DESC OFFSET $2 LIMIT $3", query.Name, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": run,
+	})
+}
@@ -0,0 +1,174 @@
+package operator
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/ledger"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+//LedgerEntry - journal entry with its postings
+type LedgerEntry struct {
+	models.LedgerEntry
+	Postings []*LedgerPosting
+}
+
+//LedgerPosting - posting with account details
+type LedgerPosting struct {
+	models.LedgerPosting
+	AccountType string
+	MemberID    int64
+}
+
+// LedgerAccountGet
+// @Summary
+// @Description LedgerAccountGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Ledger-Account-Get
+// @Param   Type				query		string	false		"Type (member, margin, house, fees, transit, opening)"
+// @Param   MemberID		query		int			false		"MemberID"
+// @Param   Offset			query		int			false		"Offset"
+// @Param   Limit				query		int			false		"Limit"
+// @Success 200 {object} models.LedgerAccount
+// @Failure 400 {object} Error
+// @Router /operator/ledger/account [get]
+func LedgerAccountGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		Type     string `form:"type"`
+		MemberID int64  `form:"memberid"`
+		Offset   int    `form:"offset"`
+		Limit    int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	var result []*models.LedgerAccount
+
+	if err := db.Select(&result, "SELECT * FROM LedgerAccount WHERE ($1='' OR Type=$1) AND ($2=0 OR MemberID=$2) ORDER BY LedgerAccountID ASC OFFSET $3 LIMIT $4", query.Type, query.MemberID, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// LedgerEntryGet
+// @Summary
+// @Description LedgerEntryGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Ledger-Entry-Get
+// @Param   Entity			query		string	false		"Entity (trade, deposit, withdrawal)"
+// @Param   EntityID		query		int			false		"EntityID"
+// @Param   MemberID		query		int			false		"Entries posted to accounts of the member"
+// @Param   Offset			query		int			false		"Offset"
+// @Param   Limit				query		int			false		"Limit"
+// @Success 200 {object} LedgerEntry
+// @Failure 400 {object} Error
+// @Router /operator/ledger/entry [get]
+func LedgerEntryGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		Entity   string `form:"entity"`
+		EntityID int64  `form:"entityid"`
+		MemberID int64  `form:"memberid"`
+		Offset   int    `form:"offset"`
+		Limit    int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	var entries []*models.LedgerEntry
+
+	if err := db.Select(&entries, "SELECT * FROM LedgerEntry WHERE ($1='' OR Entity=$1) AND ($2=0 OR EntityID=$2) AND ($3=0 OR LedgerEntryID IN (SELECT LedgerPosting.LedgerEntryID FROM LedgerPosting INNER JOIN LedgerAccount ON LedgerAccount.LedgerAccountID=LedgerPosting.LedgerAccountID WHERE LedgerAccount.MemberID=$3)) ORDER BY LedgerEntryID DESC OFFSET $4 LIMIT $5", query.Entity, query.EntityID, query.MemberID, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	result := []*LedgerEntry{}
+
+	for _, entry := range entries {
+		var postings []*LedgerPosting
+
+		if err := db.Select(&postings, "SELECT LedgerPosting.*, LedgerAccount.Type AS AccountType, LedgerAccount.MemberID FROM LedgerPosting INNER JOIN LedgerAccount ON LedgerAccount.LedgerAccountID=LedgerPosting.LedgerAccountID WHERE LedgerPosting.LedgerEntryID=$1 ORDER BY LedgerPosting.LedgerPostingID ASC", entry.LedgerEntryID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+			return
+		}
+
+		result = append(result, &LedgerEntry{
+			LedgerEntry: *entry,
+			Postings:    postings,
+		})
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// LedgerReconcile
+// @Summary
+// @Description LedgerReconcile
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Ledger-Reconcile
+// @Success 200 {object} ledger.Mismatch
+// @Failure 400 {object} Error
+// @Router /operator/ledger/reconcile [get]
+func LedgerReconcile(c *gin.Context) {
+	mismatch, err := ledger.QueryMismatch()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	trialBalance, err := ledger.QueryTrialBalance()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": gin.H{
+			"Mismatch":     mismatch,
+			"TrialBalance": trialBalance,
+		},
+	})
+}
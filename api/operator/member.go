@@ -10,6 +10,9 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
 	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/jwt"
+	"github.com/ianidi/exchange-server/internal/rbac"
+	"github.com/spf13/cast"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -342,6 +345,13 @@ func MemberCreate(c *gin.Context) {
 		}
 	}
 
+	//New operators get permissions of the default operator role
+	if query.Role == 2 && RecordID != 0 {
+		tx := db.MustBegin()
+		rbac.Assign(tx, int64(RecordID), rbac.ROLE_OPERATOR)
+		tx.Commit()
+	}
+
 	c.JSON(200, gin.H{
 		"status":   true,
 		"RecordID": RecordID,
@@ -547,6 +557,12 @@ func MemberUpdateByID(c *gin.Context) {
 		tx := db.MustBegin()
 		tx.MustExec("UPDATE Member SET PasswordHash=$1 WHERE MemberID=$2", PasswordHash, query.MemberID)
 		tx.Commit()
+
+		//Password was changed by operator, sign out all member devices
+		if err := jwt.Service.RD.DeleteSessions(cast.ToString(query.MemberID)); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+			return
+		}
 	}
 
 	tx := db.MustBegin()
@@ -0,0 +1,59 @@
+package operator
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+)
+
+// MFASettingsUpdate
+// @Summary
+// @Description MFASettingsUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-MFA-Settings-Update
+// @Param   MFARequiredOperator		query		bool		false		"Require two-factor authentication of operators and admins"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/mfa/settings/update [post]
+func MFASettingsUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		MFARequiredOperator bool `json:"MFARequiredOperator"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	//Operator requiring MFA has to be enrolled, otherwise they lose access
+	if query.MFARequiredOperator && !sender.MFAEnabled {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "MFA_ENROLLMENT_REQUIRED"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Settings SET MFARequiredOperator=$1 WHERE SettingsID=$2", query.MFARequiredOperator, 1)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "mfa.settings.update",
+		Entity:   "settings",
+		EntityID: 1,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
@@ -0,0 +1,157 @@
+package operator
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+//ReconciliationRun - reconciliation run summary
+type ReconciliationRun struct {
+	Run           int64
+	Discrepancies int64
+	Members       int64 //Number of members with discrepancies
+}
+
+// ReconciliationGet
+// @Summary
+// @Description ReconciliationGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Reconciliation-Get
+// @Param   Run					query		int			false		"Run (UNIX timestamp)"
+// @Param   Type				query		string	false		"Type (ledger, balance, legacy, margin, wallet, trade.history, trade.ledger, deposit, withdrawal)"
+// @Param   MemberID		query		int			false		"MemberID"
+// @Param   Offset			query		int			false		"Offset"
+// @Param   Limit				query		int			false		"Limit"
+// @Success 200 {object} models.Reconciliation
+// @Failure 400 {object} Error
+// @Router /operator/reconciliation [get]
+func ReconciliationGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		Run      int64  `form:"run"`
+		Type     string `form:"type"`
+		MemberID int64  `form:"memberid"`
+		Offset   int    `form:"offset"`
+		Limit    int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	var result []*models.Reconciliation
+
+	if err := db.Select(&result, "SELECT * FROM Reconciliation WHERE ($1=0 OR Run=$1) AND ($2='' OR Type=$2) AND ($3=0 OR MemberID=$3) ORDER BY ReconciliationID DESC OFFSET $4 LIMIT $5", query.Run, query.Type, query.MemberID, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// ReconciliationRunGet
+// @Summary
+// @Description ReconciliationRunGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Reconciliation-Run-Get
+// @Param   Offset			query		int			false		"Offset"
+// @Param   Limit				query		int			false		"Limit"
+// @Success 200 {object} ReconciliationRun
+// @Failure 400 {object} Error
+// @Router /operator/reconciliation/run [get]
+func ReconciliationRunGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		Offset int `form:"offset"`
+		Limit  int `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	var result []*ReconciliationRun
+
+	if err := db.Select(&result, "SELECT Run, COUNT(*) AS Discrepancies, COUNT(DISTINCT MemberID) AS Members FROM Reconciliation GROUP BY Run ORDER BY Run DESC OFFSET $1 LIMIT $2", query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// ReconciliationEmailUpdate
+// @Summary
+// @Description ReconciliationEmailUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Reconciliation-Email-Update
+// @Param   ReconciliationEmail		query		string		false		"Email receiving reconciliation summary (empty - disabled)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/reconciliation/email/update [post]
+func ReconciliationEmailUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		ReconciliationEmail string `json:"ReconciliationEmail" binding:"omitempty,email"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Settings SET ReconciliationEmail=$1 WHERE SettingsID=$2", query.ReconciliationEmail, 1)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "reconciliation.email.update",
+		Entity:   "settings",
+		EntityID: 1,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
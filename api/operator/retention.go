@@ -0,0 +1,120 @@
+package operator
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+// RetentionPolicyGet
+// @Summary
+// @Description RetentionPolicyGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Retention-Get
+// @Success 200 {object} models.RetentionPolicy
+// @Failure 400 {object} Error
+// @Router /operator/retention [get]
+func RetentionPolicyGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var policy []*models.RetentionPolicy
+
+	if err := db.Select(&policy, "SELECT * FROM RetentionPolicy ORDER BY RetentionPolicyID ASC"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": policy,
+	})
+}
+
+// RetentionPolicyUpdate
+// @Summary
+// @Description RetentionPolicyUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Retention-Update
+// @Param   RetentionPolicyID		query		int				true		"ID"
+// @Param   RawPeriod						query		int				true		"Keep full resolution rows for this period (seconds)"
+// @Param   DownsampleInterval	query		int				false		"Downsample older rows to one row per interval (seconds, 0 - disabled)"
+// @Param   MaxPeriod						query		int				false		"Delete rows older than this period (seconds, 0 - keep forever)"
+// @Param   Active							query		bool			false		"Active"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/retention/update [post]
+func RetentionPolicyUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		RetentionPolicyID  int64 `json:"RetentionPolicyID" binding:"required"`
+		RawPeriod          int64 `json:"RawPeriod"`
+		DownsampleInterval int64 `json:"DownsampleInterval"`
+		MaxPeriod          int64 `json:"MaxPeriod"`
+		Active             bool  `json:"Active"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.RawPeriod < 0 || query.DownsampleInterval < 0 || query.MaxPeriod < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_RETENTION_PERIOD"})
+		return
+	}
+
+	//Raw rows must expire before the table is pruned, otherwise downsampling never applies
+	if query.MaxPeriod > 0 && query.MaxPeriod <= query.RawPeriod {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_RETENTION_PERIOD"})
+		return
+	}
+
+	var policy models.RetentionPolicy
+
+	if err := db.Get(&policy, "SELECT * FROM RetentionPolicy WHERE RetentionPolicyID=$1", query.RetentionPolicyID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_RETENTION_POLICY_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE RetentionPolicy SET RawPeriod=$1, DownsampleInterval=$2, MaxPeriod=$3, Active=$4 WHERE RetentionPolicyID=$5", query.RawPeriod, query.DownsampleInterval, query.MaxPeriod, query.Active, query.RetentionPolicyID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "retention.update",
+		Entity:   "retention_policy",
+		EntityID: query.RetentionPolicyID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
@@ -0,0 +1,442 @@
+package operator
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/rbac"
+)
+
+// RoleGet
+// @Summary
+// @Description RoleGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Role-Get
+// @Success 200 {object} rbac.Role
+// @Failure 400 {object} Error
+// @Router /operator/role [get]
+func RoleGet(c *gin.Context) {
+	roles, err := rbac.QueryRoles()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": roles,
+	})
+}
+
+// PermissionGet
+// @Summary
+// @Description PermissionGet - all permissions and permissions of sender
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Permission-Get
+// @Success 200 {object} rbac.Permission
+// @Failure 400 {object} Error
+// @Router /operator/role/permission [get]
+func PermissionGet(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	granted, err := rbac.QueryPermissions(sender.MemberID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status":  true,
+		"result":  rbac.Permissions,
+		"granted": granted,
+	})
+}
+
+// RoleCreate
+// @Summary
+// @Description RoleCreate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Role-Create
+// @Param   Title				query		string		true		"Title"
+// @Param   Description	query		string		false		"Description"
+// @Param   Permissions	query		[]string	false		"Permissions, e.g. trades.read"
+// @Success 200 {object} rbac.Role
+// @Failure 400 {object} Error
+// @Router /operator/role/create [post]
+func RoleCreate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		Title       string   `json:"Title" binding:"required,max=50"`
+		Description string   `json:"Description" binding:"max=200"`
+		Permissions []string `json:"Permissions"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	query.Title = strings.TrimSpace(query.Title)
+
+	if err := validatePermissions(sender.MemberID, query.Permissions); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	var count int
+
+	if err := db.Get(&count, "SELECT count(*) FROM Role WHERE Title=$1", query.Title); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	if count > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "ROLE_EXISTS"})
+		return
+	}
+
+	var RoleID int64
+
+	tx := db.MustBegin()
+	if err := tx.Get(&RoleID, "INSERT INTO Role (Title, Description, Created) VALUES ($1, $2, $3) RETURNING RoleID", query.Title, query.Description, time.Now().Unix()); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+	rbac.SetPermissions(tx, RoleID, query.Permissions)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "role.create",
+		Entity:   "role",
+		EntityID: RoleID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	role, err := rbac.QueryRole(RoleID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": role,
+	})
+}
+
+// RoleUpdate
+// @Summary
+// @Description RoleUpdate - permissions are replaced
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Role-Update
+// @Param   RoleID			query		int				true		"Role ID"
+// @Param   Title				query		string		true		"Title"
+// @Param   Description	query		string		false		"Description"
+// @Param   Permissions	query		[]string	false		"Permissions, e.g. trades.read"
+// @Success 200 {object} rbac.Role
+// @Failure 400 {object} Error
+// @Router /operator/role/update [post]
+func RoleUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		RoleID      int64    `json:"RoleID" binding:"required"`
+		Title       string   `json:"Title" binding:"required,max=50"`
+		Description string   `json:"Description" binding:"max=200"`
+		Permissions []string `json:"Permissions"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	query.Title = strings.TrimSpace(query.Title)
+
+	role, err := rbac.QueryRole(query.RoleID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	//Permissions being removed are checked as well, operator can't edit a role above their own
+	if err := validatePermissions(sender.MemberID, append(query.Permissions, role.Permissions...)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	var count int
+
+	if err := db.Get(&count, "SELECT count(*) FROM Role WHERE Title=$1 AND RoleID!=$2", query.Title, query.RoleID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	if count > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "ROLE_EXISTS"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Role SET Title=$1, Description=$2 WHERE RoleID=$3", query.Title, query.Description, query.RoleID)
+	rbac.SetPermissions(tx, query.RoleID, query.Permissions)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "role.update",
+		Entity:   "role",
+		EntityID: query.RoleID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	role, err = rbac.QueryRole(query.RoleID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": role,
+	})
+}
+
+// RoleDelete
+// @Summary
+// @Description RoleDelete - role is removed from members
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Role-Delete
+// @Param   RoleID	query		int		true		"Role ID"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/role/delete [delete]
+func RoleDelete(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		RoleID int64 `json:"RoleID" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	role, err := rbac.QueryRole(query.RoleID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	if err := validatePermissions(sender.MemberID, role.Permissions); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("DELETE FROM Role WHERE RoleID=$1", query.RoleID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "role.delete",
+		Entity:   "role",
+		EntityID: query.RoleID,
+		Data:     role,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// MemberRoleGet
+// @Summary
+// @Description MemberRoleGet - roles and resulting permissions of member
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Role-Member-Get
+// @Param   memberid	query		int		true		"Member ID"
+// @Success 200 {object} models.Role
+// @Failure 400 {object} Error
+// @Router /operator/role/member [get]
+func MemberRoleGet(c *gin.Context) {
+	var query struct {
+		MemberID int64 `form:"memberid" binding:"required"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	roles, err := rbac.QueryMemberRoles(query.MemberID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	permissions, err := rbac.QueryPermissions(query.MemberID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status":      true,
+		"result":      roles,
+		"permissions": permissions,
+	})
+}
+
+// MemberRoleUpdate
+// @Summary
+// @Description MemberRoleUpdate - roles of member are replaced
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Role-Member-Update
+// @Param   MemberID	query		int			true		"Member ID"
+// @Param   RoleIDs		query		[]int		false		"Role IDs"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/role/member/update [post]
+func MemberRoleUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		MemberID int64   `json:"MemberID" binding:"required"`
+		RoleIDs  []int64 `json:"RoleIDs"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	var member Member
+
+	if err := db.Get(&member, "SELECT * FROM Member WHERE MemberID=$1 AND Role<=$2", query.MemberID, sender.Role); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "NO_MEMBER_RECORD"})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		}
+		return
+	}
+
+	if member.MemberID == sender.MemberID {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "ACCESS_DENIED"})
+		return
+	}
+
+	current, err := rbac.QueryMemberRoles(query.MemberID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	//Sender must have permissions of every role being assigned or removed
+	RoleIDs := query.RoleIDs
+	for _, role := range current {
+		RoleIDs = append(RoleIDs, role.RoleID)
+	}
+
+	var permissions []string
+
+	for _, RoleID := range RoleIDs {
+		role, err := rbac.QueryRole(RoleID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+			return
+		}
+		permissions = append(permissions, role.Permissions...)
+	}
+
+	if err := validatePermissions(sender.MemberID, permissions); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	tx := db.MustBegin()
+	rbac.SetMemberRoles(tx, query.MemberID, query.RoleIDs)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "member.role.update",
+		Entity:   "member",
+		EntityID: query.MemberID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+//validatePermissions checks that permissions are known and sender has them, so roles can't be used to escalate privileges
+func validatePermissions(MemberID int64, permissions []string) error {
+	for _, permission := range permissions {
+		if !rbac.Valid(permission) {
+			return errors.New("INVALID_PERMISSION")
+		}
+	}
+
+	granted, err := rbac.QueryPermissions(MemberID)
+	if err != nil {
+		return err
+	}
+
+	if !rbac.Covers(granted, permissions) {
+		return errors.New("ACCESS_DENIED")
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package operator
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/lockout"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+// SecurityEventGet
+// @Summary
+// @Description SecurityEventGet - failed sign ins, lockouts and unlocks
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Security-Event-Get
+// @Param   MemberID		query		int			false		"Member ID"
+// @Param   Email				query		string	false		"Email"
+// @Param   IP					query		string	false		"IP address"
+// @Param   Type				query		string	false		"Type (login.failed, account.locked, account.unlocked)"
+// @Param   Offset			query		int			false		"Offset"
+// @Param   Limit				query		int			false		"Limit"
+// @Success 200 {object} models.SecurityEvent
+// @Failure 400 {object} Error
+// @Router /operator/security/event [get]
+func SecurityEventGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		MemberID int64  `form:"memberid"`
+		Email    string `form:"email"`
+		IP       string `form:"ip"`
+		Type     string `form:"type"`
+		Offset   int    `form:"offset"`
+		Limit    int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	var events []*models.SecurityEvent
+
+	if err := db.Select(&events, "SELECT * FROM SecurityEvent WHERE ($1=0 OR MemberID=$1) AND ($2='' OR Email=$2) AND ($3='' OR IP=$3) AND ($4='' OR Type=$4) ORDER BY SecurityEventID DESC OFFSET $5 LIMIT $6", query.MemberID, strings.ToLower(query.Email), query.IP, query.Type, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": events,
+	})
+}
+
+// LockoutGet
+// @Summary
+// @Description LockoutGet - failed sign ins and lockout of account
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Security-Lockout-Get
+// @Param   Email	query		string	true		"Email"
+// @Success 200 {object} lockout.Status
+// @Failure 400 {object} Error
+// @Router /operator/security/lockout [get]
+func LockoutGet(c *gin.Context) {
+	var query struct {
+		Email string `form:"email" binding:"required"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	status, failures, err := lockout.QueryStatus(strings.ToLower(query.Email))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status":   true,
+		"result":   status,
+		"failures": failures,
+	})
+}
+
+// LockoutUnlock
+// @Summary
+// @Description LockoutUnlock - unlock account locked after failed sign ins
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Security-Unlock
+// @Param   Email		query		string	true		"Email"
+// @Param   Reason	query		string	false		"Reason"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/security/unlock [post]
+func LockoutUnlock(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		Email  string `json:"Email" binding:"required"`
+		Reason string `json:"Reason"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	query.Email = strings.ToLower(query.Email)
+
+	if err := lockout.UnlockAccount(query.Email, c.ClientIP(), c.Request.UserAgent()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	var MemberID int64
+
+	db.Get(&MemberID, "SELECT MemberID FROM Member WHERE Email=$1", query.Email)
+
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "security.unlock",
+		Entity:   "member",
+		EntityID: MemberID,
+		Reason:   query.Reason,
+		Data:     query,
+	}.Save()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
@@ -0,0 +1,526 @@
+package operator
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+// SpreadSessionGet
+// @Summary
+// @Description SpreadSessionGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Spread-Session-Get
+// @Success 200 {object} models.SpreadSession
+// @Failure 400 {object} Error
+// @Router /operator/spread/session [get]
+func SpreadSessionGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var session []*models.SpreadSession
+
+	if err := db.Select(&session, "SELECT * FROM SpreadSession ORDER BY SpreadSessionID ASC"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": session,
+	})
+}
+
+// SpreadSessionAdd
+// @Summary
+// @Description SpreadSessionAdd
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Spread-Session-Add
+// @Param   Title						query		string		true		"Title"
+// @Param   MarketID				query		int				false		"MarketID (0 - all markets)"
+// @Param   AssetID					query		int				false		"AssetID (0 - all assets)"
+// @Param   Weekdays				query		string		false		"Comma separated weekdays (0 - sunday, UTC)"
+// @Param   StartMinute			query		int				true		"Session start (minutes since midnight UTC)"
+// @Param   EndMinute				query		int				true		"Session end (minutes since midnight UTC)"
+// @Param   Multiplier			query		number		true		"Spread multiplier"
+// @Param   Active					query		bool			false		"Active"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/spread/session/add [post]
+func SpreadSessionAdd(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		Title       string  `json:"Title" binding:"required"`
+		MarketID    int64   `json:"MarketID"`
+		AssetID     int64   `json:"AssetID"`
+		Weekdays    string  `json:"Weekdays"`
+		StartMinute int     `json:"StartMinute"`
+		EndMinute   int     `json:"EndMinute"`
+		Multiplier  float64 `json:"Multiplier" binding:"required"`
+		Active      bool    `json:"Active"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.StartMinute < 0 || query.StartMinute >= 1440 || query.EndMinute < 0 || query.EndMinute >= 1440 || query.StartMinute == query.EndMinute {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_SESSION_TIME"})
+		return
+	}
+
+	if query.Multiplier <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_MULTIPLIER"})
+		return
+	}
+
+	var RecordID int64
+
+	tx := db.MustBegin()
+	if err := tx.Get(&RecordID, "INSERT INTO SpreadSession (Title, MarketID, AssetID, Weekdays, StartMinute, EndMinute, Multiplier, Active) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING SpreadSessionID", query.Title, query.MarketID, query.AssetID, query.Weekdays, query.StartMinute, query.EndMinute, query.Multiplier, query.Active); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "spread.session.add",
+		Entity:   "spread_session",
+		EntityID: RecordID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status":   true,
+		"RecordID": RecordID,
+	})
+}
+
+// SpreadSessionUpdate
+// @Summary
+// @Description SpreadSessionUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Spread-Session-Update
+// @Param   SpreadSessionID	query		int				true		"ID"
+// @Param   Title						query		string		true		"Title"
+// @Param   MarketID				query		int				false		"MarketID (0 - all markets)"
+// @Param   AssetID					query		int				false		"AssetID (0 - all assets)"
+// @Param   Weekdays				query		string		false		"Comma separated weekdays (0 - sunday, UTC)"
+// @Param   StartMinute			query		int				true		"Session start (minutes since midnight UTC)"
+// @Param   EndMinute				query		int				true		"Session end (minutes since midnight UTC)"
+// @Param   Multiplier			query		number		true		"Spread multiplier"
+// @Param   Active					query		bool			false		"Active"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/spread/session/update [post]
+func SpreadSessionUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		SpreadSessionID int64   `json:"SpreadSessionID" binding:"required"`
+		Title           string  `json:"Title" binding:"required"`
+		MarketID        int64   `json:"MarketID"`
+		AssetID         int64   `json:"AssetID"`
+		Weekdays        string  `json:"Weekdays"`
+		StartMinute     int     `json:"StartMinute"`
+		EndMinute       int     `json:"EndMinute"`
+		Multiplier      float64 `json:"Multiplier" binding:"required"`
+		Active          bool    `json:"Active"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.StartMinute < 0 || query.StartMinute >= 1440 || query.EndMinute < 0 || query.EndMinute >= 1440 || query.StartMinute == query.EndMinute {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_SESSION_TIME"})
+		return
+	}
+
+	if query.Multiplier <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_MULTIPLIER"})
+		return
+	}
+
+	var session models.SpreadSession
+
+	if err := db.Get(&session, "SELECT * FROM SpreadSession WHERE SpreadSessionID=$1", query.SpreadSessionID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_SPREAD_SESSION_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE SpreadSession SET Title=$1, MarketID=$2, AssetID=$3, Weekdays=$4, StartMinute=$5, EndMinute=$6, Multiplier=$7, Active=$8 WHERE SpreadSessionID=$9", query.Title, query.MarketID, query.AssetID, query.Weekdays, query.StartMinute, query.EndMinute, query.Multiplier, query.Active, query.SpreadSessionID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "spread.session.update",
+		Entity:   "spread_session",
+		EntityID: query.SpreadSessionID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// SpreadSessionDelete
+// @Summary
+// @Description SpreadSessionDelete
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Spread-Session-Delete
+// @Param   SpreadSessionID	query		int				true		"ID"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/spread/session/delete [delete]
+func SpreadSessionDelete(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		SpreadSessionID int64 `json:"SpreadSessionID" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("DELETE FROM SpreadSession WHERE SpreadSessionID=$1", query.SpreadSessionID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "spread.session.delete",
+		Entity:   "spread_session",
+		EntityID: query.SpreadSessionID,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// MemberGroupGet
+// @Summary
+// @Description MemberGroupGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Member-Group-Get
+// @Success 200 {object} models.MemberGroup
+// @Failure 400 {object} Error
+// @Router /operator/spread/group [get]
+func MemberGroupGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var group []*models.MemberGroup
+
+	if err := db.Select(&group, "SELECT * FROM MemberGroup ORDER BY MemberGroupID ASC"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": group,
+	})
+}
+
+// MemberGroupAdd
+// @Summary
+// @Description MemberGroupAdd
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Member-Group-Add
+// @Param   Title						query		string		true		"Title"
+// @Param   Markup					query		number		false		"Spread markup (% of effective spread)"
+// @Param   WithdrawDailyLimit			query		number		false		"Daily withdrawal limit (0 - default limit)"
+// @Param   WithdrawMonthlyLimit		query		number		false		"Monthly withdrawal limit (0 - default limit)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/spread/group/add [post]
+func MemberGroupAdd(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		Title                string  `json:"Title" binding:"required"`
+		Markup               float64 `json:"Markup"`
+		WithdrawDailyLimit   float64 `json:"WithdrawDailyLimit" binding:"min=0"`
+		WithdrawMonthlyLimit float64 `json:"WithdrawMonthlyLimit" binding:"min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	//Markup can't narrow spread below zero
+	if query.Markup <= -100 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_MARKUP"})
+		return
+	}
+
+	var RecordID int64
+
+	tx := db.MustBegin()
+	if err := tx.Get(&RecordID, "INSERT INTO MemberGroup (Title, Markup, WithdrawDailyLimit, WithdrawMonthlyLimit) VALUES ($1, $2, $3, $4) RETURNING MemberGroupID", query.Title, query.Markup, decimal.NewFromFloat(query.WithdrawDailyLimit), decimal.NewFromFloat(query.WithdrawMonthlyLimit)); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "member_group.add",
+		Entity:   "member_group",
+		EntityID: RecordID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status":   true,
+		"RecordID": RecordID,
+	})
+}
+
+// MemberGroupUpdate
+// @Summary
+// @Description MemberGroupUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Member-Group-Update
+// @Param   MemberGroupID		query		int				true		"ID"
+// @Param   Title						query		string		true		"Title"
+// @Param   Markup					query		number		false		"Spread markup (% of effective spread)"
+// @Param   WithdrawDailyLimit			query		number		false		"Daily withdrawal limit (0 - default limit)"
+// @Param   WithdrawMonthlyLimit		query		number		false		"Monthly withdrawal limit (0 - default limit)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/spread/group/update [post]
+func MemberGroupUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		MemberGroupID        int64   `json:"MemberGroupID" binding:"required"`
+		Title                string  `json:"Title" binding:"required"`
+		Markup               float64 `json:"Markup"`
+		WithdrawDailyLimit   float64 `json:"WithdrawDailyLimit" binding:"min=0"`
+		WithdrawMonthlyLimit float64 `json:"WithdrawMonthlyLimit" binding:"min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Markup <= -100 {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "INVALID_MARKUP"})
+		return
+	}
+
+	var group models.MemberGroup
+
+	if err := db.Get(&group, "SELECT * FROM MemberGroup WHERE MemberGroupID=$1", query.MemberGroupID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_MEMBER_GROUP_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE MemberGroup SET Title=$1, Markup=$2, WithdrawDailyLimit=$3, WithdrawMonthlyLimit=$4 WHERE MemberGroupID=$5", query.Title, query.Markup, decimal.NewFromFloat(query.WithdrawDailyLimit), decimal.NewFromFloat(query.WithdrawMonthlyLimit), query.MemberGroupID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "member_group.update",
+		Entity:   "member_group",
+		EntityID: query.MemberGroupID,
+		Data: gin.H{
+			"Title":                        query.Title,
+			"Markup":                       query.Markup,
+			"PreviousMarkup":               group.Markup.Decimal.String(),
+			"WithdrawDailyLimit":           query.WithdrawDailyLimit,
+			"WithdrawMonthlyLimit":         query.WithdrawMonthlyLimit,
+			"PreviousWithdrawDailyLimit":   group.WithdrawDailyLimit.Decimal.String(),
+			"PreviousWithdrawMonthlyLimit": group.WithdrawMonthlyLimit.Decimal.String(),
+		},
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// MemberGroupAssign
+// @Summary
+// @Description MemberGroupAssign
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Member-Group-Assign
+// @Param   MemberID				query		int				true		"Member ID"
+// @Param   MemberGroupID		query		int				false		"Member group ID (0 - no group)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/member/group [post]
+func MemberGroupAssign(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		MemberID      int64 `json:"MemberID" binding:"required"`
+		MemberGroupID int64 `json:"MemberGroupID"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.MemberGroupID != 0 {
+		var count int
+
+		if err := db.Get(&count, "SELECT count(*) FROM MemberGroup WHERE MemberGroupID=$1", query.MemberGroupID); err != nil || count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "NO_MEMBER_GROUP_RECORD"})
+			return
+		}
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Member SET MemberGroupID=$1 WHERE MemberID=$2", query.MemberGroupID, query.MemberID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "member.group",
+		Entity:   "member",
+		EntityID: query.MemberID,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// TickGet
+// @Summary
+// @Description TickGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Tick-Get
+// @Param   AssetID			query		int		true		"Asset ID"
+// @Param   From				query		int		true		"From (UNIX timestamp)"
+// @Param   To					query		int		true		"To (UNIX timestamp)"
+// @Param   Offset			query		int		false		"Offset"
+// @Param   Limit				query		int		false		"Limit"
+// @Success 200 {object} models.Tick
+// @Failure 400 {object} Error
+// @Router /operator/tick [get]
+func TickGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		AssetID int64 `form:"assetid" binding:"required"`
+		From    int64 `form:"from" binding:"required"`
+		To      int64 `form:"to" binding:"required"`
+		Offset  int   `form:"offset"`
+		Limit   int   `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	var tick []*models.Tick
+
+	if err := db.Select(&tick, "SELECT * FROM Tick WHERE AssetID=$1 AND Timestamp>=$2 AND Timestamp<=$3 ORDER BY Timestamp ASC OFFSET $4 LIMIT $5", query.AssetID, query.From, query.To, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": tick,
+	})
+}
@@ -0,0 +1,157 @@
+package operator
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/task"
+)
+
+// TaskGet
+// @Summary
+// @Description TaskGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Task-Get
+// @Param   Status			query		string	false		"Status (pending, running, done, dead)"
+// @Param   Type				query		string	false		"Type (email, sms, s3, pdf)"
+// @Param   Offset			query		int			false		"Offset"
+// @Param   Limit				query		int			false		"Limit"
+// @Success 200 {object} models.Task
+// @Failure 400 {object} Error
+// @Router /operator/task [get]
+func TaskGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		Status string `form:"status"`
+		Type   string `form:"type"`
+		Offset int    `form:"offset"`
+		Limit  int    `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	var result []*models.Task
+
+	if err := db.Select(&result, "SELECT * FROM Task WHERE ($1='' OR Status=$1) AND ($2='' OR Type=$2) ORDER BY TaskID DESC OFFSET $3 LIMIT $4", query.Status, query.Type, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": false,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	//Payload holds one-time codes and account links, it is left out of the listing
+	for _, item := range result {
+		item.Payload = ""
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// TaskGetByID
+// @Summary
+// @Description TaskGetByID dead task with payload without secrets
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Task-Get-By-ID
+// @Param   id	path		int		true		"Task ID"
+// @Success 200 {object} models.Task
+// @Failure 400 {object} Error
+// @Router /operator/task/{id} [get]
+func TaskGetByID(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		TaskID int64 `uri:"id" binding:"required"`
+	}
+
+	if err := c.ShouldBindUri(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	var result models.Task
+
+	if err := db.Get(&result, "SELECT * FROM Task WHERE TaskID=$1 AND Status=$2", query.TaskID, task.STATUS_DEAD); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": "NO_RECORD"})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		}
+		return
+	}
+
+	payload, err := task.Redact(result)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	result.Payload = payload
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// TaskRetry
+// @Summary
+// @Description TaskRetry
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Task-Retry
+// @Param   TaskID			query		int				true		"ID of a dead task"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/task/retry [post]
+func TaskRetry(c *gin.Context) {
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		TaskID int64 `json:"TaskID" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if err := task.Retry(query.TaskID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "task.retry",
+		Entity:   "task",
+		EntityID: query.TaskID,
+	}.Save()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
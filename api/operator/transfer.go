@@ -0,0 +1,170 @@
+package operator
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+// TransferGet
+// @Summary
+// @Description TransferGet
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Transfer-Get
+// @Param   MemberID		query		int		false		"Sender or recipient member ID"
+// @Param   Offset			query		int		false		"Offset"
+// @Param   Limit				query		int		false		"Limit"
+// @Success 200 {object} models.Transfer
+// @Failure 400 {object} Error
+// @Router /operator/transfer [get]
+func TransferGet(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		MemberID int64 `form:"memberid"`
+		Offset   int   `form:"offset"`
+		Limit    int   `form:"limit"`
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 1000
+	}
+
+	result := []models.Transfer{}
+
+	if err := db.Select(&result, "SELECT * FROM Transfer WHERE ($1=0 OR MemberID=$1 OR RecipientID=$1) ORDER BY TransferID DESC OFFSET $2 LIMIT $3", query.MemberID, query.Offset, query.Limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": result,
+	})
+}
+
+// MemberTransferUpdate
+// @Summary
+// @Description MemberTransferUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Member-Transfer-Update
+// @Param   MemberID								query		int				true		"Member ID"
+// @Param   TransferSendAllowed			query		bool			false		"Member can transfer funds to other members"
+// @Param   TransferReceiveAllowed	query		bool			false		"Member can receive funds from other members"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/member/transfer [post]
+func MemberTransferUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		MemberID               int64 `json:"MemberID" binding:"required"`
+		TransferSendAllowed    bool  `json:"TransferSendAllowed"`
+		TransferReceiveAllowed bool  `json:"TransferReceiveAllowed"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	var member models.Member
+
+	if err := db.Get(&member, "SELECT * FROM Member WHERE MemberID=$1", query.MemberID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  "NO_MEMBER_RECORD",
+			})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+		}
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Member SET TransferSendAllowed=$1, TransferReceiveAllowed=$2 WHERE MemberID=$3", query.TransferSendAllowed, query.TransferReceiveAllowed, query.MemberID)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "member.transfer",
+		Entity:   "member",
+		EntityID: query.MemberID,
+		Data: gin.H{
+			"TransferSendAllowed":            query.TransferSendAllowed,
+			"TransferReceiveAllowed":         query.TransferReceiveAllowed,
+			"PreviousTransferSendAllowed":    member.TransferSendAllowed,
+			"PreviousTransferReceiveAllowed": member.TransferReceiveAllowed,
+		},
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
+
+// TransferSettingsUpdate
+// @Summary
+// @Description TransferSettingsUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Transfer-Settings-Update
+// @Param   ConversionFee		query		number		false		"Fee of conversion between member currencies (% of converted amount)"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/transfer/settings/update [post]
+func TransferSettingsUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		ConversionFee float64 `json:"ConversionFee" binding:"min=0,max=100"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Settings SET ConversionFee=$1 WHERE SettingsID=$2", decimal.NewFromFloat(query.ConversionFee), 1)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "transfer.settings.update",
+		Entity:   "settings",
+		EntityID: 1,
+		Data:     query,
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+	})
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/ianidi/exchange-server/internal/db"
 	"github.com/ianidi/exchange-server/internal/jwt"
+	"github.com/ianidi/exchange-server/internal/mfa"
+	"github.com/ianidi/exchange-server/internal/rbac"
+	"github.com/spf13/cast"
 )
 
 //Middleware to check member permission
@@ -26,6 +29,42 @@ func Middleware() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+
+		//Operators sign in with MFA once it is required in settings
+		required, err := mfa.RequiredForRole(int64(sender.Role))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		if required && !sender.MFAEnabled {
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": false,
+				"error":  "MFA_ENROLLMENT_REQUIRED",
+			})
+			c.Abort()
+			return
+		}
+	}
+}
+
+//Permission middleware checks that sender has the permission of the route
+func Permission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		MemberID := c.MustGet(jwt.MemberKey).(string)
+
+		if err := rbac.Check(cast.ToInt64(MemberID), permission); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"status": false,
+				"error":  err.Error(),
+			})
+			c.Abort()
+			return
+		}
 	}
 }
 
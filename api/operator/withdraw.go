@@ -5,7 +5,11 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/audit"
 	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/withdrawal"
+	"github.com/shopspring/decimal"
 )
 
 // WithdrawGet
@@ -79,15 +83,24 @@ func WithdrawGet(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @ID Operator-Withdraw-Update
+// @Param   WithdrawalID		query		int				true		"ID of a pending or approved withdrawal"
+// @Param   Action					query		bool			false		"true - approve (withdrawals above approval threshold are completed by second operator), false - decline"
+// @Param   Reason					query		string		false		"Reason"
 // @Success 200 {object} Success
 // @Failure 400 {object} Error
 // @Router /operator/withdraw/update [post]
 func WithdrawUpdate(c *gin.Context) {
 	db := db.GetDB()
 
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
 	var query struct {
-		WithdrawalID int  `json:"WithdrawalID" binding:"required"`
-		Action       bool `json:"Action"`
+		WithdrawalID int    `json:"WithdrawalID" binding:"required"`
+		Action       bool   `json:"Action"`
+		Reason       string `json:"Reason"`
 	}
 
 	if err := c.ShouldBindJSON(&query); err != nil {
@@ -95,9 +108,12 @@ func WithdrawUpdate(c *gin.Context) {
 		return
 	}
 
-	var withdrawal Withdrawal
+	tx := db.MustBegin()
+
+	var record models.Withdrawal
 
-	if err := db.Get(&withdrawal, "SELECT * FROM Withdrawal WHERE WithdrawalID=$1 AND Status=$2", query.WithdrawalID, "pending"); err != nil {
+	if err := tx.Get(&record, "SELECT * FROM Withdrawal WHERE WithdrawalID=$1 AND Status IN ($2, $3) FOR UPDATE", query.WithdrawalID, withdrawal.STATUS_PENDING, withdrawal.STATUS_APPROVED); err != nil {
+		tx.Rollback()
 		if err == sql.ErrNoRows {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"status": false,
@@ -112,18 +128,82 @@ func WithdrawUpdate(c *gin.Context) {
 		return
 	}
 
-	var status = "declined"
+	previous := record.Status
 
-	if query.Action == true {
-		status = "complete"
+	if query.Action {
+		record, err = withdrawal.Approve(tx, record, sender.MemberID)
+	} else {
+		record, err = withdrawal.Decline(tx, record, sender.MemberID, query.Reason)
 	}
 
-	tx := db.MustBegin()
-	tx.MustExec("UPDATE Withdrawal SET Status=$1 WHERE WithdrawalID=$2", status, query.WithdrawalID)
-	if status == "complete" {
-		tx.MustExec("UPDATE Member SET USD=USD-$1 WHERE MemberID=$2", withdrawal.Amount.Decimal, withdrawal.MemberID)
-		tx.MustExec("INSERT INTO History (MemberID, Type, Status, Currency, Profit, ProfitAbs, ProfitNegative) VALUES ($1, $2, $3, $4, $5, $6, $7)", withdrawal.MemberID, "balance", "withdrawal", "USD", withdrawal.Amount.Decimal, withdrawal.Amount.Decimal.Abs(), true)
+	if err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error()})
+		return
 	}
+
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "withdrawal." + record.Status,
+		Entity:   "withdrawal",
+		EntityID: record.WithdrawalID,
+		Reason:   query.Reason,
+		Data: gin.H{
+			"Amount":         record.Amount.Decimal.String(),
+			"Currency":       record.Currency,
+			"Status":         record.Status,
+			"PreviousStatus": previous,
+		},
+	}.Record(tx)
+	tx.Commit()
+
+	c.JSON(200, gin.H{
+		"status": true,
+		"result": record.Status,
+	})
+}
+
+// WithdrawSettingsUpdate
+// @Summary
+// @Description WithdrawSettingsUpdate
+// @Tags Operator
+// @Accept  json
+// @Produce  json
+// @ID Operator-Withdraw-Settings-Update
+// @Param   WithdrawDailyLimit					query		number		false		"Default daily withdrawal limit (0 - unlimited)"
+// @Param   WithdrawMonthlyLimit				query		number		false		"Default monthly withdrawal limit (0 - unlimited)"
+// @Param   WithdrawApprovalThreshold		query		number		false		"Withdrawals above the amount are approved by two operators"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /operator/withdraw/settings/update [post]
+func WithdrawSettingsUpdate(c *gin.Context) {
+	db := db.GetDB()
+
+	sender, err := QueryMember(c)
+	if err != nil {
+		return
+	}
+
+	var query struct {
+		WithdrawDailyLimit        float64 `json:"WithdrawDailyLimit" binding:"min=0"`
+		WithdrawMonthlyLimit      float64 `json:"WithdrawMonthlyLimit" binding:"min=0"`
+		WithdrawApprovalThreshold float64 `json:"WithdrawApprovalThreshold" binding:"min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": false, "error": err.Error(), "type": "validation"})
+		return
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Settings SET WithdrawDailyLimit=$1, WithdrawMonthlyLimit=$2, WithdrawApprovalThreshold=$3 WHERE SettingsID=$4", decimal.NewFromFloat(query.WithdrawDailyLimit), decimal.NewFromFloat(query.WithdrawMonthlyLimit), decimal.NewFromFloat(query.WithdrawApprovalThreshold), 1)
+	audit.Entry{
+		MemberID: sender.MemberID,
+		Action:   "withdrawal.settings.update",
+		Entity:   "settings",
+		EntityID: 1,
+		Data:     query,
+	}.Record(tx)
 	tx.Commit()
 
 	c.JSON(200, gin.H{
@@ -41,6 +41,7 @@ require (
 	github.com/swaggo/swag v1.6.7
 	github.com/thecodingmachine/gotenberg-go-client/v7 v7.1.0
 	github.com/twinj/uuid v1.0.0
+	github.com/ugorji/go/codec v1.1.7
 	github.com/vektah/gqlparser/v2 v2.0.1
 	golang.org/x/crypto v0.0.0-20200728195943-123391ffb6de
 	gopkg.in/h2non/bimg.v1 v1.1.2
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"strconv"
 	"sync"
@@ -42,6 +43,7 @@ type ResolverRoot interface {
 }
 
 type DirectiveRoot struct {
+	HasPermission func(ctx context.Context, obj interface{}, next graphql.Resolver, permission string) (res interface{}, err error)
 }
 
 type ComplexityRoot struct {
@@ -189,8 +191,10 @@ type ComplexityRoot struct {
 		Rate          func(childComplexity int) int
 		RateBuy       func(childComplexity int) int
 		RateSell      func(childComplexity int) int
+		Reason        func(childComplexity int) int
 		Sentiment     func(childComplexity int) int
 		SentimentType func(childComplexity int) int
+		Seq           func(childComplexity int) int
 		Value         func(childComplexity int) int
 	}
 
@@ -233,6 +237,19 @@ type ComplexityRoot struct {
 		TimestampPaid    func(childComplexity int) int
 	}
 
+	Job struct {
+		Active       func(childComplexity int) int
+		Interval     func(childComplexity int) int
+		JobID        func(childComplexity int) int
+		LastDuration func(childComplexity int) int
+		LastError    func(childComplexity int) int
+		LastRun      func(childComplexity int) int
+		LastStatus   func(childComplexity int) int
+		MarketID     func(childComplexity int) int
+		Timestamp    func(childComplexity int) int
+		Title        func(childComplexity int) int
+	}
+
 	Lead struct {
 		Address1         func(childComplexity int) int
 		Address2         func(childComplexity int) int
@@ -379,12 +396,18 @@ type ComplexityRoot struct {
 		OfferPhoneVerify                         func(childComplexity int, input model.OfferPhoneVerifyRequest) int
 		OfferPhoneVerifyResend                   func(childComplexity int, input model.RecordRequest) int
 		OfferSign                                func(childComplexity int, input model.OfferSignRequest) int
+		OperatorJobTrigger                       func(childComplexity int, input model.RecordRequest) int
+		OperatorJobUpdate                        func(childComplexity int, input model.OperatorJobUpdateRequest) int
 		PhoneVerify                              func(childComplexity int, input model.PhoneVerifyRequest) int
 		RemoveDeal                               func(childComplexity int, input model.RecordRequest) int
 		Reset                                    func(childComplexity int, input model.ResetRequest) int
 		ResetComplete                            func(childComplexity int, input model.ResetCompleteRequest) int
 		SignIn                                   func(childComplexity int, input model.SignInRequest) int
+		SignInMfa                                func(childComplexity int, input model.SignInMFARequest) int
+		SignInPasskey                            func(childComplexity int, input model.SignInPasskeyRequest) int
+		SignInPasskeyBegin                       func(childComplexity int, input model.SignInPasskeyBeginRequest) int
 		SignUp                                   func(childComplexity int, input model.SignUpRequest) int
+		Unlock                                   func(childComplexity int, input model.UnlockRequest) int
 		ValidateField                            func(childComplexity int, input model.ValidateFieldRequest) int
 		Verify                                   func(childComplexity int, input model.VerifyRequest) int
 		VerifyResend                             func(childComplexity int, input model.VerifyResendRequest) int
@@ -428,6 +451,7 @@ type ComplexityRoot struct {
 		CurrencyList                   func(childComplexity int) int
 		DealByOfferID                  func(childComplexity int, input model.RecordRequest) int
 		DealList                       func(childComplexity int) int
+		InfoSince                      func(childComplexity int, input model.InfoSinceRequest) int
 		InterestByDealID               func(childComplexity int, input *model.RecordRequest) int
 		InterestByOfferID              func(childComplexity int, input *model.RecordRequest) int
 		InterestListByOfferID          func(childComplexity int, input *model.RecordRequest) int
@@ -484,6 +508,7 @@ type ComplexityRoot struct {
 		Offer                          func(childComplexity int, input model.RecordRequest) int
 		OfferByInvoiceID               func(childComplexity int, input model.RecordRequest) int
 		OfferList                      func(childComplexity int) int
+		OperatorJobList                func(childComplexity int) int
 		TXList                         func(childComplexity int) int
 	}
 
@@ -492,10 +517,20 @@ type ComplexityRoot struct {
 		Status  func(childComplexity int) int
 	}
 
-	SignInResponse struct {
-		Message func(childComplexity int) int
+	SignInPasskeyBeginResponse struct {
+		Options func(childComplexity int) int
 		Status  func(childComplexity int) int
-		Token   func(childComplexity int) int
+	}
+
+	SignInResponse struct {
+		Captcha    func(childComplexity int) int
+		Locked     func(childComplexity int) int
+		MFAMethods func(childComplexity int) int
+		MFAToken   func(childComplexity int) int
+		Message    func(childComplexity int) int
+		RetryAfter func(childComplexity int) int
+		Status     func(childComplexity int) int
+		Token      func(childComplexity int) int
 	}
 
 	Subscription struct {
@@ -512,7 +547,9 @@ type ComplexityRoot struct {
 		Status            func(childComplexity int) int
 		TimestampComplete func(childComplexity int) int
 		TimestampCreated  func(childComplexity int) int
+		TransferID        func(childComplexity int) int
 		Txid              func(childComplexity int) int
+		Type              func(childComplexity int) int
 	}
 
 	Upload struct {
@@ -536,9 +573,11 @@ type ComplexityRoot struct {
 	}
 
 	VerifyResponse struct {
-		Message func(childComplexity int) int
-		Status  func(childComplexity int) int
-		Token   func(childComplexity int) int
+		MFAMethods func(childComplexity int) int
+		MFAToken   func(childComplexity int) int
+		Message    func(childComplexity int) int
+		Status     func(childComplexity int) int
+		Token      func(childComplexity int) int
 	}
 }
 
@@ -600,6 +639,10 @@ type MutationResolver interface {
 	RemoveDeal(ctx context.Context, input model.RecordRequest) (*model.Result, error)
 	InvoiceSendToEmail(ctx context.Context, input model.InvoiceSendToEmailRequest) (*model.Result, error)
 	SignIn(ctx context.Context, input model.SignInRequest) (*model.SignInResponse, error)
+	SignInMfa(ctx context.Context, input model.SignInMFARequest) (*model.SignInResponse, error)
+	SignInPasskeyBegin(ctx context.Context, input model.SignInPasskeyBeginRequest) (*model.SignInPasskeyBeginResponse, error)
+	SignInPasskey(ctx context.Context, input model.SignInPasskeyRequest) (*model.SignInResponse, error)
+	Unlock(ctx context.Context, input model.UnlockRequest) (*model.Result, error)
 	SignUp(ctx context.Context, input model.SignUpRequest) (*model.CreationResponse, error)
 	Reset(ctx context.Context, input model.ResetRequest) (*model.Result, error)
 	ResetComplete(ctx context.Context, input model.ResetCompleteRequest) (*model.VerifyResponse, error)
@@ -608,6 +651,8 @@ type MutationResolver interface {
 	VerifyResend(ctx context.Context, input model.VerifyResendRequest) (*model.VerifyResendResponse, error)
 	OfferPhoneVerify(ctx context.Context, input model.OfferPhoneVerifyRequest) (*model.Result, error)
 	OfferPhoneVerifyResend(ctx context.Context, input model.RecordRequest) (*model.PhoneVerifyResponse, error)
+	OperatorJobUpdate(ctx context.Context, input model.OperatorJobUpdateRequest) (*model.Result, error)
+	OperatorJobTrigger(ctx context.Context, input model.RecordRequest) (*model.Result, error)
 }
 type QueryResolver interface {
 	Invest(ctx context.Context, input model.RecordRequest) (*model.Invest, error)
@@ -676,6 +721,8 @@ type QueryResolver interface {
 	ManagerSearchManager(ctx context.Context, input *model.SearchRequest) ([]*model.ManagerSearch, error)
 	Member(ctx context.Context) (*model.Member, error)
 	Alert(ctx context.Context) ([]*model.Alert, error)
+	InfoSince(ctx context.Context, input model.InfoSinceRequest) ([]*model.Info, error)
+	OperatorJobList(ctx context.Context) ([]*model.Job, error)
 }
 type SubscriptionResolver interface {
 	NewInfo(ctx context.Context) (<-chan *model.Info, error)
@@ -1424,6 +1471,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Info.RateSell(childComplexity), true
 
+	case "Info.Reason":
+		if e.complexity.Info.Reason == nil {
+			break
+		}
+
+		return e.complexity.Info.Reason(childComplexity), true
+
 	case "Info.Sentiment":
 		if e.complexity.Info.Sentiment == nil {
 			break
@@ -1438,6 +1492,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Info.SentimentType(childComplexity), true
 
+	case "Info.Seq":
+		if e.complexity.Info.Seq == nil {
+			break
+		}
+
+		return e.complexity.Info.Seq(childComplexity), true
+
 	case "Info.Value":
 		if e.complexity.Info.Value == nil {
 			break
@@ -1655,6 +1716,76 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Invoice.TimestampPaid(childComplexity), true
 
+	case "Job.Active":
+		if e.complexity.Job.Active == nil {
+			break
+		}
+
+		return e.complexity.Job.Active(childComplexity), true
+
+	case "Job.Interval":
+		if e.complexity.Job.Interval == nil {
+			break
+		}
+
+		return e.complexity.Job.Interval(childComplexity), true
+
+	case "Job.JobID":
+		if e.complexity.Job.JobID == nil {
+			break
+		}
+
+		return e.complexity.Job.JobID(childComplexity), true
+
+	case "Job.LastDuration":
+		if e.complexity.Job.LastDuration == nil {
+			break
+		}
+
+		return e.complexity.Job.LastDuration(childComplexity), true
+
+	case "Job.LastError":
+		if e.complexity.Job.LastError == nil {
+			break
+		}
+
+		return e.complexity.Job.LastError(childComplexity), true
+
+	case "Job.LastRun":
+		if e.complexity.Job.LastRun == nil {
+			break
+		}
+
+		return e.complexity.Job.LastRun(childComplexity), true
+
+	case "Job.LastStatus":
+		if e.complexity.Job.LastStatus == nil {
+			break
+		}
+
+		return e.complexity.Job.LastStatus(childComplexity), true
+
+	case "Job.MarketID":
+		if e.complexity.Job.MarketID == nil {
+			break
+		}
+
+		return e.complexity.Job.MarketID(childComplexity), true
+
+	case "Job.Timestamp":
+		if e.complexity.Job.Timestamp == nil {
+			break
+		}
+
+		return e.complexity.Job.Timestamp(childComplexity), true
+
+	case "Job.Title":
+		if e.complexity.Job.Title == nil {
+			break
+		}
+
+		return e.complexity.Job.Title(childComplexity), true
+
 	case "Lead.Address1":
 		if e.complexity.Lead.Address1 == nil {
 			break
@@ -2845,6 +2976,30 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.OfferSign(childComplexity, args["input"].(model.OfferSignRequest)), true
 
+	case "Mutation.OperatorJobTrigger":
+		if e.complexity.Mutation.OperatorJobTrigger == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_OperatorJobTrigger_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.OperatorJobTrigger(childComplexity, args["input"].(model.RecordRequest)), true
+
+	case "Mutation.OperatorJobUpdate":
+		if e.complexity.Mutation.OperatorJobUpdate == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_OperatorJobUpdate_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.OperatorJobUpdate(childComplexity, args["input"].(model.OperatorJobUpdateRequest)), true
+
 	case "Mutation.PhoneVerify":
 		if e.complexity.Mutation.PhoneVerify == nil {
 			break
@@ -2905,6 +3060,42 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.SignIn(childComplexity, args["input"].(model.SignInRequest)), true
 
+	case "Mutation.SignInMFA":
+		if e.complexity.Mutation.SignInMfa == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_SignInMFA_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SignInMfa(childComplexity, args["input"].(model.SignInMFARequest)), true
+
+	case "Mutation.SignInPasskey":
+		if e.complexity.Mutation.SignInPasskey == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_SignInPasskey_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SignInPasskey(childComplexity, args["input"].(model.SignInPasskeyRequest)), true
+
+	case "Mutation.SignInPasskeyBegin":
+		if e.complexity.Mutation.SignInPasskeyBegin == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_SignInPasskeyBegin_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SignInPasskeyBegin(childComplexity, args["input"].(model.SignInPasskeyBeginRequest)), true
+
 	case "Mutation.SignUp":
 		if e.complexity.Mutation.SignUp == nil {
 			break
@@ -2917,6 +3108,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.SignUp(childComplexity, args["input"].(model.SignUpRequest)), true
 
+	case "Mutation.Unlock":
+		if e.complexity.Mutation.Unlock == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_Unlock_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.Unlock(childComplexity, args["input"].(model.UnlockRequest)), true
+
 	case "Mutation.ValidateField":
 		if e.complexity.Mutation.ValidateField == nil {
 			break
@@ -3143,6 +3346,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.DealList(childComplexity), true
 
+	case "Query.InfoSince":
+		if e.complexity.Query.InfoSince == nil {
+			break
+		}
+
+		args, err := ec.field_Query_InfoSince_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.InfoSince(childComplexity, args["input"].(model.InfoSinceRequest)), true
+
 	case "Query.InterestByDealID":
 		if e.complexity.Query.InterestByDealID == nil {
 			break
@@ -3805,6 +4020,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.OfferList(childComplexity), true
 
+	case "Query.OperatorJobList":
+		if e.complexity.Query.OperatorJobList == nil {
+			break
+		}
+
+		return e.complexity.Query.OperatorJobList(childComplexity), true
+
 	case "Query.TXList":
 		if e.complexity.Query.TXList == nil {
 			break
@@ -3826,6 +4048,48 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Result.Status(childComplexity), true
 
+	case "SignInPasskeyBeginResponse.Options":
+		if e.complexity.SignInPasskeyBeginResponse.Options == nil {
+			break
+		}
+
+		return e.complexity.SignInPasskeyBeginResponse.Options(childComplexity), true
+
+	case "SignInPasskeyBeginResponse.Status":
+		if e.complexity.SignInPasskeyBeginResponse.Status == nil {
+			break
+		}
+
+		return e.complexity.SignInPasskeyBeginResponse.Status(childComplexity), true
+
+	case "SignInResponse.Captcha":
+		if e.complexity.SignInResponse.Captcha == nil {
+			break
+		}
+
+		return e.complexity.SignInResponse.Captcha(childComplexity), true
+
+	case "SignInResponse.Locked":
+		if e.complexity.SignInResponse.Locked == nil {
+			break
+		}
+
+		return e.complexity.SignInResponse.Locked(childComplexity), true
+
+	case "SignInResponse.MFAMethods":
+		if e.complexity.SignInResponse.MFAMethods == nil {
+			break
+		}
+
+		return e.complexity.SignInResponse.MFAMethods(childComplexity), true
+
+	case "SignInResponse.MFAToken":
+		if e.complexity.SignInResponse.MFAToken == nil {
+			break
+		}
+
+		return e.complexity.SignInResponse.MFAToken(childComplexity), true
+
 	case "SignInResponse.Message":
 		if e.complexity.SignInResponse.Message == nil {
 			break
@@ -3833,6 +4097,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SignInResponse.Message(childComplexity), true
 
+	case "SignInResponse.RetryAfter":
+		if e.complexity.SignInResponse.RetryAfter == nil {
+			break
+		}
+
+		return e.complexity.SignInResponse.RetryAfter(childComplexity), true
+
 	case "SignInResponse.Status":
 		if e.complexity.SignInResponse.Status == nil {
 			break
@@ -3917,6 +4188,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Tx.TimestampCreated(childComplexity), true
 
+	case "TX.TransferID":
+		if e.complexity.Tx.TransferID == nil {
+			break
+		}
+
+		return e.complexity.Tx.TransferID(childComplexity), true
+
 	case "TX.TXID":
 		if e.complexity.Tx.Txid == nil {
 			break
@@ -3924,6 +4202,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Tx.Txid(childComplexity), true
 
+	case "TX.Type":
+		if e.complexity.Tx.Type == nil {
+			break
+		}
+
+		return e.complexity.Tx.Type(childComplexity), true
+
 	case "Upload.Category":
 		if e.complexity.Upload.Category == nil {
 			break
@@ -4001,6 +4286,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.VerifyResendResponse.Timeout(childComplexity), true
 
+	case "VerifyResponse.MFAMethods":
+		if e.complexity.VerifyResponse.MFAMethods == nil {
+			break
+		}
+
+		return e.complexity.VerifyResponse.MFAMethods(childComplexity), true
+
+	case "VerifyResponse.MFAToken":
+		if e.complexity.VerifyResponse.MFAToken == nil {
+			break
+		}
+
+		return e.complexity.VerifyResponse.MFAToken(childComplexity), true
+
 	case "VerifyResponse.Message":
 		if e.complexity.VerifyResponse.Message == nil {
 			break
@@ -4159,6 +4458,13 @@ type Info {
   Change: String!
   Sentiment: Int!
   SentimentType: String!
+  Reason: String!
+  Seq: Int!
+}
+
+input InfoSinceRequest {
+  Seq: Int!
+  SharedSeq: Int!
 }
 
 type Subscription {
@@ -4183,6 +4489,8 @@ type VerifyResponse {
   Status: Boolean!
   Message: String
   Token: String
+  MFAToken: String
+  MFAMethods: [String!]
 }
 
 type VerifyResendResponse {
@@ -4195,6 +4503,11 @@ type SignInResponse {
   Status: Boolean!
   Message: String
   Token: String
+  MFAToken: String
+  MFAMethods: [String!]
+  Captcha: Boolean
+  Locked: Boolean
+  RetryAfter: Int
 }
 
 input RecordRequest {
@@ -4255,6 +4568,30 @@ input SignUpRequest {
 input SignInRequest {
   Email: String!
   Password: String!
+  Captcha: String
+}
+
+input UnlockRequest {
+  Token: String!
+}
+
+input SignInMFARequest {
+  Token: String!
+  Code: String!
+}
+
+input SignInPasskeyBeginRequest {
+  Token: String!
+}
+
+type SignInPasskeyBeginResponse {
+  Status: Boolean!
+  Options: String!
+}
+
+input SignInPasskeyRequest {
+  Token: String!
+  Credential: String!
 }
 
 input ResetRequest {
@@ -4570,6 +4907,8 @@ type TX {
   DateComplete: String
   TimestampCreated: Int
   TimestampComplete: Int
+  Type: String
+  TransferID: Int
 }
 
 input ManagerCreateBankDetailsRequest {
@@ -4609,6 +4948,7 @@ input MemberPhoneUpdateRequest {
 
 input MemberEmailUpdateRequest {
   Email: String!
+  MFACode: String
 }
 
 input ManagerCreateInvestRequest {
@@ -4892,12 +5232,33 @@ input ManagerDuplicateRequest {
   RecordID: Int!
 }
 
+type Job {
+  JobID: Int!
+  MarketID: Int!
+  Title: String!
+  Interval: Int!
+  Active: Boolean!
+  Timestamp: Int!
+  LastRun: Int!
+  LastStatus: String!
+  LastError: String!
+  LastDuration: Int!
+}
+
+input OperatorJobUpdateRequest {
+  JobID: Int!
+  Interval: Int!
+  Active: Boolean!
+}
+
+directive @hasPermission(permission: String!) on FIELD_DEFINITION
+
 type Query {
   Invest(input: RecordRequest!): Invest!
   InvestByOfferID(input: RecordRequest): Invest!
 
   InterestListByOfferID(input: RecordRequest): [Interest!]!
-  ManagerDealByContractID(input: ManagerDealByContractIDRequest): Deal!
+  ManagerDealByContractID(input: ManagerDealByContractIDRequest): Deal! @hasPermission(permission: "offers.read")
   Offer(input: RecordRequest!): Offer!
   ContractByOfferID(input: RecordRequest!): Contract!
   DealByOfferID(input: RecordRequest!): Deal!
@@ -4912,25 +5273,25 @@ type Query {
 
   OfferByInvoiceID(input: RecordRequest!): Invest!
 
-  ManagerBankDetails(input: RecordRequest!): BankDetails!
-  ManagerBankDetailsByOfferID(input: RecordRequest): BankDetails!
-  ManagerInvest(input: RecordRequest!): Invest!
-  ManagerMediaByInvestID(input: ManagerMediaByInvestIDRequest!): [Media!]!
-  ManagerInvestByOfferID(input: RecordRequest): Invest!
-  ManagerOffer(input: RecordRequest!): ManagerOffer!
-  ManagerDeal(input: RecordRequest!): Deal!
-  ManagerInvoiceByDealID(input: RecordRequest): Invoice!
-  ManagerContract(input: RecordRequest!): Contract!
-  ManagerCategory(input: RecordRequest!): Category!
-  ManagerCurrency(input: RecordRequest!): Currency!
-  ManagerMember(input: RecordRequest!): Member!
-  ManagerMemberByOfferID(input: RecordRequest!): Member!
-  ManagerManager(input: RecordRequest!): Member!
-  ManagerLead(input: RecordRequest!): Lead!
-  ManagerComment(input: RecordRequest!): Comment!
-  ManagerChecklist(input: RecordRequest!): Checklist!
-  ManagerAppointment(input: RecordRequest!): Appointment!
-  ManagerCampaign(input: RecordRequest!): Campaign!
+  ManagerBankDetails(input: RecordRequest!): BankDetails! @hasPermission(permission: "offers.read")
+  ManagerBankDetailsByOfferID(input: RecordRequest): BankDetails! @hasPermission(permission: "offers.read")
+  ManagerInvest(input: RecordRequest!): Invest! @hasPermission(permission: "offers.read")
+  ManagerMediaByInvestID(input: ManagerMediaByInvestIDRequest!): [Media!]! @hasPermission(permission: "offers.read")
+  ManagerInvestByOfferID(input: RecordRequest): Invest! @hasPermission(permission: "offers.read")
+  ManagerOffer(input: RecordRequest!): ManagerOffer! @hasPermission(permission: "offers.read")
+  ManagerDeal(input: RecordRequest!): Deal! @hasPermission(permission: "offers.read")
+  ManagerInvoiceByDealID(input: RecordRequest): Invoice! @hasPermission(permission: "offers.read")
+  ManagerContract(input: RecordRequest!): Contract! @hasPermission(permission: "offers.read")
+  ManagerCategory(input: RecordRequest!): Category! @hasPermission(permission: "offers.read")
+  ManagerCurrency(input: RecordRequest!): Currency! @hasPermission(permission: "offers.read")
+  ManagerMember(input: RecordRequest!): Member! @hasPermission(permission: "members.read")
+  ManagerMemberByOfferID(input: RecordRequest!): Member! @hasPermission(permission: "members.read")
+  ManagerManager(input: RecordRequest!): Member! @hasPermission(permission: "managers.read")
+  ManagerLead(input: RecordRequest!): Lead! @hasPermission(permission: "leads.read")
+  ManagerComment(input: RecordRequest!): Comment! @hasPermission(permission: "leads.read")
+  ManagerChecklist(input: RecordRequest!): Checklist! @hasPermission(permission: "leads.read")
+  ManagerAppointment(input: RecordRequest!): Appointment! @hasPermission(permission: "leads.read")
+  ManagerCampaign(input: RecordRequest!): Campaign! @hasPermission(permission: "leads.read")
 
   OfferList: [Invest!]!
   DealList: [Deal!]!
@@ -4940,36 +5301,39 @@ type Query {
   BalanceList: [Balance!]!
   TXList: [TX!]!
 
-  ManagerBankDetailsList(input: ListRequest): [BankDetails!]!
-  ManagerInvestList(input: ListRequest): [Invest!]!
-  ManagerOfferList(input: ListRequest): [ManagerOffer!]!
-  ManagerDealList(input: ListRequest): [Deal!]!
-  ManagerDealListByOfferID(input: RecordRequest!): [Deal!]!
-  ManagerContractList(input: ListRequest): [Contract!]!
-  ManagerCategoryList(input: ListRequest): [Category!]!
-  ManagerCurrencyList(input: ListRequest): [Currency!]!
-  ManagerLeadList(input: ListRequest): [Lead!]!
-  ManagerCommentList(input: ListRequest): [Comment!]!
-  ManagerCommentListByLeadID(input: RecordRequest): [Comment!]!
-  ManagerChecklistList(input: ListRequest): [Checklist!]!
-  ManagerAppointmentList(input: ListRequest): [Appointment!]!
-  ManagerAppointmentListByLeadID(input: RecordRequest): [Appointment!]!
-  ManagerCampaignList(input: ListRequest): [Campaign!]!
-  ManagerManagerList(input: ListRequest): [Member!]!
-
-  ManagerContractListByOfferID(input: RecordRequest): [Contract!]!
-  ManagerInterestListByOfferID(input: RecordRequest): [Interest!]!
-
-  ManagerSearchMember(input: SearchRequest): [Member!]!
-  ManagerSearchMemberNoManager(input: SearchRequest): [Member!]!
-  ManagerSearchInvest(input: SearchRequest): [Invest!]!
-  ManagerSearchContract(input: SearchRequest): [Contract!]!
-  ManagerSearchCurrency(input: SearchRequest): [Currency!]!
-  ManagerSearchBankDetails(input: SearchRequest): [BankDetails!]!
-  ManagerSearchManager(input: SearchRequest): [ManagerSearch!]!
+  ManagerBankDetailsList(input: ListRequest): [BankDetails!]! @hasPermission(permission: "offers.read")
+  ManagerInvestList(input: ListRequest): [Invest!]! @hasPermission(permission: "offers.read")
+  ManagerOfferList(input: ListRequest): [ManagerOffer!]! @hasPermission(permission: "offers.read")
+  ManagerDealList(input: ListRequest): [Deal!]! @hasPermission(permission: "offers.read")
+  ManagerDealListByOfferID(input: RecordRequest!): [Deal!]! @hasPermission(permission: "offers.read")
+  ManagerContractList(input: ListRequest): [Contract!]! @hasPermission(permission: "offers.read")
+  ManagerCategoryList(input: ListRequest): [Category!]! @hasPermission(permission: "offers.read")
+  ManagerCurrencyList(input: ListRequest): [Currency!]! @hasPermission(permission: "offers.read")
+  ManagerLeadList(input: ListRequest): [Lead!]! @hasPermission(permission: "leads.read")
+  ManagerCommentList(input: ListRequest): [Comment!]! @hasPermission(permission: "leads.read")
+  ManagerCommentListByLeadID(input: RecordRequest): [Comment!]! @hasPermission(permission: "leads.read")
+  ManagerChecklistList(input: ListRequest): [Checklist!]! @hasPermission(permission: "leads.read")
+  ManagerAppointmentList(input: ListRequest): [Appointment!]! @hasPermission(permission: "leads.read")
+  ManagerAppointmentListByLeadID(input: RecordRequest): [Appointment!]! @hasPermission(permission: "leads.read")
+  ManagerCampaignList(input: ListRequest): [Campaign!]! @hasPermission(permission: "leads.read")
+  ManagerManagerList(input: ListRequest): [Member!]! @hasPermission(permission: "managers.read")
+
+  ManagerContractListByOfferID(input: RecordRequest): [Contract!]! @hasPermission(permission: "offers.read")
+  ManagerInterestListByOfferID(input: RecordRequest): [Interest!]! @hasPermission(permission: "offers.read")
+
+  ManagerSearchMember(input: SearchRequest): [Member!]! @hasPermission(permission: "members.read")
+  ManagerSearchMemberNoManager(input: SearchRequest): [Member!]! @hasPermission(permission: "members.read")
+  ManagerSearchInvest(input: SearchRequest): [Invest!]! @hasPermission(permission: "offers.read")
+  ManagerSearchContract(input: SearchRequest): [Contract!]! @hasPermission(permission: "offers.read")
+  ManagerSearchCurrency(input: SearchRequest): [Currency!]! @hasPermission(permission: "offers.read")
+  ManagerSearchBankDetails(input: SearchRequest): [BankDetails!]! @hasPermission(permission: "offers.read")
+  ManagerSearchManager(input: SearchRequest): [ManagerSearch!]! @hasPermission(permission: "managers.read")
 
   Member: Member!
   alert: [Alert!]!
+  InfoSince(input: InfoSinceRequest!): [Info!]!
+
+  OperatorJobList: [Job!]! @hasPermission(permission: "jobs.read")
 }
 
 type Mutation {
@@ -4977,60 +5341,60 @@ type Mutation {
   MemberPhoneUpdate(input: MemberPhoneUpdateRequest!): Result!
   MemberEmailUpdate(input: MemberEmailUpdateRequest!): Result!
 
-  ManagerCreateBankDetails(input: ManagerCreateBankDetailsRequest!): CreationResponse!
-  ManagerCreateInvest(input: ManagerCreateInvestRequest!): CreationResponse!
-  ManagerCreateOffer(input: ManagerCreateOfferRequest!): CreationResponse!
-  ManagerCreateInterest(input: ManagerCreateInterestRequest!): CreationResponse!
-  ManagerCreateCategory(input: ManagerCreateCategoryRequest!): CreationResponse!
-  ManagerCreateContract(input: ManagerCreateContractRequest!): CreationResponse!
-  ManagerCreateCurrency(input: ManagerCreateCurrencyRequest!): CreationResponse!
-
-  ManagerCreateLead(input: ManagerCreateLeadRequest!): CreationResponse!
-  ManagerCreateComment(input: ManagerCreateCommentRequest!): CreationResponse!
-  ManagerCreateChecklist(input: ManagerCreateChecklistRequest!): CreationResponse!
-  ManagerCreateAppointment(input: ManagerCreateAppointmentRequest!): CreationResponse!
-  ManagerCreateCampaign(input: ManagerCreateCampaignRequest!): CreationResponse!
-
-  ManagerAssignMemberToOffer(input: ManagerAssignMemberToOfferRequest!): Result!
-  ManagerAssignInvestToOffer(input: ManagerAssignInvestToOfferRequest!): Result!
-  ManagerDuplicateAndAssignContractToOffer(input: ManagerDuplicateAndAssignContractToOfferRequest!): Result!
-  ManagerAssignBankDetailsToOffer(input: ManagerAssignBankDetailsToOfferRequest!): Result!
-  ManagerAssignLeadToManager(input: ManagerAssignLeadToManagerRequest!): Result!
-
-  ManagerDuplicateInvest(input: ManagerDuplicateRequest!): CreationResponse!
-  ManagerDuplicateOffer(input: ManagerDuplicateRequest!): CreationResponse!
-  ManagerDuplicateContract(input: ManagerDuplicateRequest!): CreationResponse!
-
-  ManagerDeactivateOffer(input: RecordRequest!): Result!
-  ManagerActivateOffer(input: RecordRequest!): Result!
-  ManagerCancelOffer(input: RecordRequest!): Result!
-
-  ManagerEditBankDetails(input: ManagerEditBankDetailsRequest!): Result!
-  ManagerEditInvest(input: ManagerEditInvestRequest!): Result!
-  ManagerEditOffer(input: ManagerEditOfferRequest!): Result!
-  ManagerEditCategory(input: ManagerEditCategoryRequest!): Result!
-  ManagerEditContract(input: ManagerEditContractRequest!): Result!
-  ManagerEditCurrency(input: ManagerEditCurrencyRequest!): Result!
-  ManagerEditInvoice(input: ManagerEditInvoiceRequest!): Result!
-  ManagerEditLead(input: ManagerEditLeadRequest!): Result!
-  ManagerEditComment(input: ManagerEditCommentRequest!): Result!
-  ManagerEditChecklist(input: ManagerEditChecklistRequest!): Result!
-  ManagerEditAppointment(input: ManagerEditAppointmentRequest!): Result!
-  ManagerEditCampaign(input: ManagerEditCampaignRequest!): Result!
-  ManagerEditMedia(input: ManagerEditMediaRequest!): Result!
-
-  ManagerRemoveLead(input: RecordRequest!): Result!
-  ManagerRemoveComment(input: RecordRequest!): Result!
-  ManagerRemoveChecklist(input: RecordRequest!): Result!
-  ManagerRemoveAppointment(input: RecordRequest!): Result!
-  ManagerRemoveCampaign(input: RecordRequest!): Result!
-  ManagerRemoveInterest(input: RecordRequest!): Result!
-  ManagerRemoveManager(input: RecordRequest!): Result!
-  ManagerRemoveMedia(input: RecordRequest!): Result!
-
-  ManagerDragMedia(input: DragRequest!): Result!
-
-  ManagerAssignManager(input: ManagerAssignManagerRequest!): Result!
+  ManagerCreateBankDetails(input: ManagerCreateBankDetailsRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+  ManagerCreateInvest(input: ManagerCreateInvestRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+  ManagerCreateOffer(input: ManagerCreateOfferRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+  ManagerCreateInterest(input: ManagerCreateInterestRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+  ManagerCreateCategory(input: ManagerCreateCategoryRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+  ManagerCreateContract(input: ManagerCreateContractRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+  ManagerCreateCurrency(input: ManagerCreateCurrencyRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+
+  ManagerCreateLead(input: ManagerCreateLeadRequest!): CreationResponse! @hasPermission(permission: "leads.update")
+  ManagerCreateComment(input: ManagerCreateCommentRequest!): CreationResponse! @hasPermission(permission: "leads.update")
+  ManagerCreateChecklist(input: ManagerCreateChecklistRequest!): CreationResponse! @hasPermission(permission: "leads.update")
+  ManagerCreateAppointment(input: ManagerCreateAppointmentRequest!): CreationResponse! @hasPermission(permission: "leads.update")
+  ManagerCreateCampaign(input: ManagerCreateCampaignRequest!): CreationResponse! @hasPermission(permission: "leads.update")
+
+  ManagerAssignMemberToOffer(input: ManagerAssignMemberToOfferRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerAssignInvestToOffer(input: ManagerAssignInvestToOfferRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerDuplicateAndAssignContractToOffer(input: ManagerDuplicateAndAssignContractToOfferRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerAssignBankDetailsToOffer(input: ManagerAssignBankDetailsToOfferRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerAssignLeadToManager(input: ManagerAssignLeadToManagerRequest!): Result! @hasPermission(permission: "leads.assign")
+
+  ManagerDuplicateInvest(input: ManagerDuplicateRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+  ManagerDuplicateOffer(input: ManagerDuplicateRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+  ManagerDuplicateContract(input: ManagerDuplicateRequest!): CreationResponse! @hasPermission(permission: "offers.update")
+
+  ManagerDeactivateOffer(input: RecordRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerActivateOffer(input: RecordRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerCancelOffer(input: RecordRequest!): Result! @hasPermission(permission: "offers.update")
+
+  ManagerEditBankDetails(input: ManagerEditBankDetailsRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerEditInvest(input: ManagerEditInvestRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerEditOffer(input: ManagerEditOfferRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerEditCategory(input: ManagerEditCategoryRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerEditContract(input: ManagerEditContractRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerEditCurrency(input: ManagerEditCurrencyRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerEditInvoice(input: ManagerEditInvoiceRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerEditLead(input: ManagerEditLeadRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerEditComment(input: ManagerEditCommentRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerEditChecklist(input: ManagerEditChecklistRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerEditAppointment(input: ManagerEditAppointmentRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerEditCampaign(input: ManagerEditCampaignRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerEditMedia(input: ManagerEditMediaRequest!): Result! @hasPermission(permission: "offers.update")
+
+  ManagerRemoveLead(input: RecordRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerRemoveComment(input: RecordRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerRemoveChecklist(input: RecordRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerRemoveAppointment(input: RecordRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerRemoveCampaign(input: RecordRequest!): Result! @hasPermission(permission: "leads.update")
+  ManagerRemoveInterest(input: RecordRequest!): Result! @hasPermission(permission: "offers.update")
+  ManagerRemoveManager(input: RecordRequest!): Result! @hasPermission(permission: "managers.update")
+  ManagerRemoveMedia(input: RecordRequest!): Result! @hasPermission(permission: "offers.update")
+
+  ManagerDragMedia(input: DragRequest!): Result! @hasPermission(permission: "offers.update")
+
+  ManagerAssignManager(input: ManagerAssignManagerRequest!): Result! @hasPermission(permission: "managers.update")
 
   ValidateField(input: ValidateFieldRequest!): ValudationStatus!
 
@@ -5044,6 +5408,10 @@ type Mutation {
   InvoiceSendToEmail(input: InvoiceSendToEmailRequest!): Result!
 
   SignIn(input: SignInRequest!): SignInResponse!
+  SignInMFA(input: SignInMFARequest!): SignInResponse!
+  SignInPasskeyBegin(input: SignInPasskeyBeginRequest!): SignInPasskeyBeginResponse!
+  SignInPasskey(input: SignInPasskeyRequest!): SignInResponse!
+  Unlock(input: UnlockRequest!): Result!
   SignUp(input: SignUpRequest!): CreationResponse!
   Reset(input: ResetRequest!): Result!
   ResetComplete(input: ResetCompleteRequest!): VerifyResponse!
@@ -5053,6 +5421,9 @@ type Mutation {
 
   OfferPhoneVerify(input: OfferPhoneVerifyRequest!): Result!
   OfferPhoneVerifyResend(input: RecordRequest!): PhoneVerifyResponse!
+
+  OperatorJobUpdate(input: OperatorJobUpdateRequest!): Result! @hasPermission(permission: "jobs.update")
+  OperatorJobTrigger(input: RecordRequest!): Result! @hasPermission(permission: "jobs.update")
 }
 
 ###
@@ -5068,6 +5439,20 @@ var parsedSchema = gqlparser.MustLoadSchema(sources...)
 
 // region    ***************************** args.gotpl *****************************
 
+func (ec *executionContext) dir_hasPermission_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["permission"]; ok {
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["permission"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_CancelDeal_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -5852,6 +6237,34 @@ func (ec *executionContext) field_Mutation_OfferSign_args(ctx context.Context, r
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_OperatorJobTrigger_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.RecordRequest
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalNRecordRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐRecordRequest(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_OperatorJobUpdate_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.OperatorJobUpdateRequest
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalNOperatorJobUpdateRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐOperatorJobUpdateRequest(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_PhoneVerify_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -5908,12 +6321,12 @@ func (ec *executionContext) field_Mutation_Reset_args(ctx context.Context, rawAr
 	return args, nil
 }
 
-func (ec *executionContext) field_Mutation_SignIn_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_SignInMFA_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.SignInRequest
+	var arg0 model.SignInMFARequest
 	if tmp, ok := rawArgs["input"]; ok {
-		arg0, err = ec.unmarshalNSignInRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInRequest(ctx, tmp)
+		arg0, err = ec.unmarshalNSignInMFARequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInMFARequest(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -5922,12 +6335,12 @@ func (ec *executionContext) field_Mutation_SignIn_args(ctx context.Context, rawA
 	return args, nil
 }
 
-func (ec *executionContext) field_Mutation_SignUp_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_SignInPasskeyBegin_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.SignUpRequest
+	var arg0 model.SignInPasskeyBeginRequest
 	if tmp, ok := rawArgs["input"]; ok {
-		arg0, err = ec.unmarshalNSignUpRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignUpRequest(ctx, tmp)
+		arg0, err = ec.unmarshalNSignInPasskeyBeginRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInPasskeyBeginRequest(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -5936,12 +6349,12 @@ func (ec *executionContext) field_Mutation_SignUp_args(ctx context.Context, rawA
 	return args, nil
 }
 
-func (ec *executionContext) field_Mutation_ValidateField_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_SignInPasskey_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.ValidateFieldRequest
+	var arg0 model.SignInPasskeyRequest
 	if tmp, ok := rawArgs["input"]; ok {
-		arg0, err = ec.unmarshalNValidateFieldRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐValidateFieldRequest(ctx, tmp)
+		arg0, err = ec.unmarshalNSignInPasskeyRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInPasskeyRequest(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -5950,12 +6363,12 @@ func (ec *executionContext) field_Mutation_ValidateField_args(ctx context.Contex
 	return args, nil
 }
 
-func (ec *executionContext) field_Mutation_VerifyResend_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_SignIn_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.VerifyResendRequest
+	var arg0 model.SignInRequest
 	if tmp, ok := rawArgs["input"]; ok {
-		arg0, err = ec.unmarshalNVerifyResendRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyResendRequest(ctx, tmp)
+		arg0, err = ec.unmarshalNSignInRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInRequest(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -5964,12 +6377,12 @@ func (ec *executionContext) field_Mutation_VerifyResend_args(ctx context.Context
 	return args, nil
 }
 
-func (ec *executionContext) field_Mutation_Verify_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_SignUp_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.VerifyRequest
+	var arg0 model.SignUpRequest
 	if tmp, ok := rawArgs["input"]; ok {
-		arg0, err = ec.unmarshalNVerifyRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyRequest(ctx, tmp)
+		arg0, err = ec.unmarshalNSignUpRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignUpRequest(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -5978,12 +6391,12 @@ func (ec *executionContext) field_Mutation_Verify_args(ctx context.Context, rawA
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_BankDetailsByInvoiceID_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_Unlock_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 *model.RecordRequest
+	var arg0 model.UnlockRequest
 	if tmp, ok := rawArgs["input"]; ok {
-		arg0, err = ec.unmarshalORecordRequest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐRecordRequest(ctx, tmp)
+		arg0, err = ec.unmarshalNUnlockRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐUnlockRequest(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -5992,12 +6405,12 @@ func (ec *executionContext) field_Query_BankDetailsByInvoiceID_args(ctx context.
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_ContractByOfferID_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_ValidateField_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.RecordRequest
+	var arg0 model.ValidateFieldRequest
 	if tmp, ok := rawArgs["input"]; ok {
-		arg0, err = ec.unmarshalNRecordRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐRecordRequest(ctx, tmp)
+		arg0, err = ec.unmarshalNValidateFieldRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐValidateFieldRequest(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -6006,12 +6419,12 @@ func (ec *executionContext) field_Query_ContractByOfferID_args(ctx context.Conte
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_DealByOfferID_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_VerifyResend_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.RecordRequest
+	var arg0 model.VerifyResendRequest
 	if tmp, ok := rawArgs["input"]; ok {
-		arg0, err = ec.unmarshalNRecordRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐRecordRequest(ctx, tmp)
+		arg0, err = ec.unmarshalNVerifyResendRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyResendRequest(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -6020,7 +6433,77 @@ func (ec *executionContext) field_Query_DealByOfferID_args(ctx context.Context,
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_InterestByDealID_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_Verify_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.VerifyRequest
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalNVerifyRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyRequest(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_BankDetailsByInvoiceID_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.RecordRequest
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalORecordRequest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐRecordRequest(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_ContractByOfferID_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.RecordRequest
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalNRecordRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐRecordRequest(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_DealByOfferID_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.RecordRequest
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalNRecordRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐRecordRequest(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_InfoSince_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 model.InfoSinceRequest
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalNInfoSinceRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInfoSinceRequest(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_InterestByDealID_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 *model.RecordRequest
@@ -10230,6 +10713,74 @@ func (ec *executionContext) _Info_SentimentType(ctx context.Context, field graph
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _Info_Reason(ctx context.Context, field graphql.CollectedField, obj *model.Info) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Info",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Reason, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Info_Seq(ctx context.Context, field graphql.CollectedField, obj *model.Info) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Info",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Seq, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _Interest_InterestID(ctx context.Context, field graphql.CollectedField, obj *model.Interest) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -11169,7 +11720,7 @@ func (ec *executionContext) _Invoice_TimestampPaid(ctx context.Context, field gr
 	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_LeadID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_JobID(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11177,7 +11728,7 @@ func (ec *executionContext) _Lead_LeadID(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11186,7 +11737,7 @@ func (ec *executionContext) _Lead_LeadID(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LeadID, nil
+		return obj.JobID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11203,7 +11754,7 @@ func (ec *executionContext) _Lead_LeadID(ctx context.Context, field graphql.Coll
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_ManagerID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_MarketID(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11211,7 +11762,7 @@ func (ec *executionContext) _Lead_ManagerID(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11220,21 +11771,24 @@ func (ec *executionContext) _Lead_ManagerID(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ManagerID, nil
+		return obj.MarketID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_Title(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11242,7 +11796,7 @@ func (ec *executionContext) _Lead_MemberID(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11251,21 +11805,24 @@ func (ec *executionContext) _Lead_MemberID(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MemberID, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_CampaignID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_Interval(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11273,7 +11830,7 @@ func (ec *executionContext) _Lead_CampaignID(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11282,21 +11839,24 @@ func (ec *executionContext) _Lead_CampaignID(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CampaignID, nil
+		return obj.Interval, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_CurrencyID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_Active(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11304,7 +11864,7 @@ func (ec *executionContext) _Lead_CurrencyID(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11313,21 +11873,24 @@ func (ec *executionContext) _Lead_CurrencyID(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CurrencyID, nil
+		return obj.Active, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Email(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_Timestamp(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11335,7 +11898,7 @@ func (ec *executionContext) _Lead_Email(ctx context.Context, field graphql.Colle
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11344,21 +11907,24 @@ func (ec *executionContext) _Lead_Email(ctx context.Context, field graphql.Colle
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Email, nil
+		return obj.Timestamp, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Phone(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_LastRun(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11366,7 +11932,7 @@ func (ec *executionContext) _Lead_Phone(ctx context.Context, field graphql.Colle
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11375,21 +11941,24 @@ func (ec *executionContext) _Lead_Phone(ctx context.Context, field graphql.Colle
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Phone, nil
+		return obj.LastRun, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_IP(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_LastStatus(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11397,7 +11966,7 @@ func (ec *executionContext) _Lead_IP(ctx context.Context, field graphql.Collecte
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11406,21 +11975,24 @@ func (ec *executionContext) _Lead_IP(ctx context.Context, field graphql.Collecte
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.IP, nil
+		return obj.LastStatus, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_FirstName(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_LastError(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11428,7 +12000,7 @@ func (ec *executionContext) _Lead_FirstName(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11437,21 +12009,24 @@ func (ec *executionContext) _Lead_FirstName(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.FirstName, nil
+		return obj.LastError, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_LastName(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Job_LastDuration(ctx context.Context, field graphql.CollectedField, obj *model.Job) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11459,7 +12034,7 @@ func (ec *executionContext) _Lead_LastName(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Lead",
+		Object:   "Job",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11468,21 +12043,24 @@ func (ec *executionContext) _Lead_LastName(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LastName, nil
+		return obj.LastDuration, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Gender(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_LeadID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11499,21 +12077,24 @@ func (ec *executionContext) _Lead_Gender(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Gender, nil
+		return obj.LeadID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_FamilyStatus(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_ManagerID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11530,7 +12111,7 @@ func (ec *executionContext) _Lead_FamilyStatus(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.FamilyStatus, nil
+		return obj.ManagerID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11539,12 +12120,12 @@ func (ec *executionContext) _Lead_FamilyStatus(ctx context.Context, field graphq
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_MaidenName(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11561,7 +12142,7 @@ func (ec *executionContext) _Lead_MaidenName(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MaidenName, nil
+		return obj.MemberID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11570,12 +12151,12 @@ func (ec *executionContext) _Lead_MaidenName(ctx context.Context, field graphql.
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Citizenship(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_CampaignID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11592,7 +12173,7 @@ func (ec *executionContext) _Lead_Citizenship(ctx context.Context, field graphql
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Citizenship, nil
+		return obj.CampaignID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11601,12 +12182,12 @@ func (ec *executionContext) _Lead_Citizenship(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Country(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_CurrencyID(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11623,7 +12204,7 @@ func (ec *executionContext) _Lead_Country(ctx context.Context, field graphql.Col
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Country, nil
+		return obj.CurrencyID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11632,12 +12213,12 @@ func (ec *executionContext) _Lead_Country(ctx context.Context, field graphql.Col
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_City(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Email(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11654,7 +12235,7 @@ func (ec *executionContext) _Lead_City(ctx context.Context, field graphql.Collec
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.City, nil
+		return obj.Email, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11668,7 +12249,7 @@ func (ec *executionContext) _Lead_City(ctx context.Context, field graphql.Collec
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Zip(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Phone(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11685,7 +12266,7 @@ func (ec *executionContext) _Lead_Zip(ctx context.Context, field graphql.Collect
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Zip, nil
+		return obj.Phone, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11699,7 +12280,7 @@ func (ec *executionContext) _Lead_Zip(ctx context.Context, field graphql.Collect
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Address1(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_IP(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11716,7 +12297,7 @@ func (ec *executionContext) _Lead_Address1(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Address1, nil
+		return obj.IP, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11730,7 +12311,7 @@ func (ec *executionContext) _Lead_Address1(ctx context.Context, field graphql.Co
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Address2(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_FirstName(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11747,7 +12328,7 @@ func (ec *executionContext) _Lead_Address2(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Address2, nil
+		return obj.FirstName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11761,7 +12342,7 @@ func (ec *executionContext) _Lead_Address2(ctx context.Context, field graphql.Co
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_StreetNumber(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_LastName(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11778,7 +12359,7 @@ func (ec *executionContext) _Lead_StreetNumber(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StreetNumber, nil
+		return obj.LastName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11792,7 +12373,7 @@ func (ec *executionContext) _Lead_StreetNumber(ctx context.Context, field graphq
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_StreetName(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Gender(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11809,7 +12390,7 @@ func (ec *executionContext) _Lead_StreetName(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StreetName, nil
+		return obj.Gender, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11823,7 +12404,7 @@ func (ec *executionContext) _Lead_StreetName(ctx context.Context, field graphql.
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Birthday(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_FamilyStatus(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11840,7 +12421,7 @@ func (ec *executionContext) _Lead_Birthday(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Birthday, nil
+		return obj.FamilyStatus, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11854,7 +12435,7 @@ func (ec *executionContext) _Lead_Birthday(ctx context.Context, field graphql.Co
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_Status(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_MaidenName(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11871,7 +12452,7 @@ func (ec *executionContext) _Lead_Status(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.MaidenName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11885,7 +12466,7 @@ func (ec *executionContext) _Lead_Status(ctx context.Context, field graphql.Coll
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_DateCreated(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Citizenship(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11902,7 +12483,7 @@ func (ec *executionContext) _Lead_DateCreated(ctx context.Context, field graphql
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DateCreated, nil
+		return obj.Citizenship, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11916,7 +12497,7 @@ func (ec *executionContext) _Lead_DateCreated(ctx context.Context, field graphql
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Lead_TimestampCreated(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Country(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11933,7 +12514,7 @@ func (ec *executionContext) _Lead_TimestampCreated(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TimestampCreated, nil
+		return obj.Country, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11942,12 +12523,12 @@ func (ec *executionContext) _Lead_TimestampCreated(ctx context.Context, field gr
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManagerOffer_OfferID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_City(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11955,7 +12536,7 @@ func (ec *executionContext) _ManagerOffer_OfferID(ctx context.Context, field gra
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "ManagerOffer",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11964,24 +12545,21 @@ func (ec *executionContext) _ManagerOffer_OfferID(ctx context.Context, field gra
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.OfferID, nil
+		return obj.City, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManagerOffer_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Zip(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -11989,7 +12567,7 @@ func (ec *executionContext) _ManagerOffer_MemberID(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "ManagerOffer",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -11998,7 +12576,7 @@ func (ec *executionContext) _ManagerOffer_MemberID(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MemberID, nil
+		return obj.Zip, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12007,12 +12585,12 @@ func (ec *executionContext) _ManagerOffer_MemberID(ctx context.Context, field gr
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManagerOffer_InvestID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Address1(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12020,7 +12598,7 @@ func (ec *executionContext) _ManagerOffer_InvestID(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "ManagerOffer",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12029,7 +12607,7 @@ func (ec *executionContext) _ManagerOffer_InvestID(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.InvestID, nil
+		return obj.Address1, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12038,12 +12616,12 @@ func (ec *executionContext) _ManagerOffer_InvestID(ctx context.Context, field gr
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManagerOffer_CurrencyID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Address2(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12051,7 +12629,7 @@ func (ec *executionContext) _ManagerOffer_CurrencyID(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "ManagerOffer",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12060,7 +12638,7 @@ func (ec *executionContext) _ManagerOffer_CurrencyID(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CurrencyID, nil
+		return obj.Address2, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12069,12 +12647,12 @@ func (ec *executionContext) _ManagerOffer_CurrencyID(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManagerOffer_BankDetailsID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_StreetNumber(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12082,7 +12660,7 @@ func (ec *executionContext) _ManagerOffer_BankDetailsID(ctx context.Context, fie
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "ManagerOffer",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12091,7 +12669,7 @@ func (ec *executionContext) _ManagerOffer_BankDetailsID(ctx context.Context, fie
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.BankDetailsID, nil
+		return obj.StreetNumber, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12100,12 +12678,12 @@ func (ec *executionContext) _ManagerOffer_BankDetailsID(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManagerOffer_Title(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_StreetName(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12113,7 +12691,7 @@ func (ec *executionContext) _ManagerOffer_Title(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "ManagerOffer",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12122,7 +12700,7 @@ func (ec *executionContext) _ManagerOffer_Title(ctx context.Context, field graph
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return obj.StreetName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12136,7 +12714,7 @@ func (ec *executionContext) _ManagerOffer_Title(ctx context.Context, field graph
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManagerOffer_Status(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Birthday(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12144,7 +12722,7 @@ func (ec *executionContext) _ManagerOffer_Status(ctx context.Context, field grap
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "ManagerOffer",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12153,7 +12731,7 @@ func (ec *executionContext) _ManagerOffer_Status(ctx context.Context, field grap
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.Birthday, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12167,7 +12745,7 @@ func (ec *executionContext) _ManagerOffer_Status(ctx context.Context, field grap
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManagerSearch_ManagerID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerSearch) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_Status(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12175,7 +12753,7 @@ func (ec *executionContext) _ManagerSearch_ManagerID(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "ManagerSearch",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12184,24 +12762,21 @@ func (ec *executionContext) _ManagerSearch_ManagerID(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ManagerID, nil
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ManagerSearch_Title(ctx context.Context, field graphql.CollectedField, obj *model.ManagerSearch) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_DateCreated(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12209,7 +12784,7 @@ func (ec *executionContext) _ManagerSearch_Title(ctx context.Context, field grap
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "ManagerSearch",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12218,7 +12793,7 @@ func (ec *executionContext) _ManagerSearch_Title(ctx context.Context, field grap
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return obj.DateCreated, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12232,7 +12807,7 @@ func (ec *executionContext) _ManagerSearch_Title(ctx context.Context, field grap
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Media_MediaID(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
+func (ec *executionContext) _Lead_TimestampCreated(ctx context.Context, field graphql.CollectedField, obj *model.Lead) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12240,7 +12815,7 @@ func (ec *executionContext) _Media_MediaID(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Media",
+		Object:   "Lead",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12249,24 +12824,21 @@ func (ec *executionContext) _Media_MediaID(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MediaID, nil
+		return obj.TimestampCreated, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Media_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
+func (ec *executionContext) _ManagerOffer_OfferID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12274,7 +12846,7 @@ func (ec *executionContext) _Media_MemberID(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Media",
+		Object:   "ManagerOffer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12283,21 +12855,24 @@ func (ec *executionContext) _Media_MemberID(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MemberID, nil
+		return obj.OfferID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Media_InvestID(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
+func (ec *executionContext) _ManagerOffer_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12305,7 +12880,7 @@ func (ec *executionContext) _Media_InvestID(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Media",
+		Object:   "ManagerOffer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12314,7 +12889,7 @@ func (ec *executionContext) _Media_InvestID(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.InvestID, nil
+		return obj.MemberID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12328,7 +12903,7 @@ func (ec *executionContext) _Media_InvestID(ctx context.Context, field graphql.C
 	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Media_URL(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
+func (ec *executionContext) _ManagerOffer_InvestID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12336,7 +12911,7 @@ func (ec *executionContext) _Media_URL(ctx context.Context, field graphql.Collec
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Media",
+		Object:   "ManagerOffer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12345,7 +12920,7 @@ func (ec *executionContext) _Media_URL(ctx context.Context, field graphql.Collec
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.URL, nil
+		return obj.InvestID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12354,12 +12929,12 @@ func (ec *executionContext) _Media_URL(ctx context.Context, field graphql.Collec
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Media_Title(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
+func (ec *executionContext) _ManagerOffer_CurrencyID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12367,7 +12942,7 @@ func (ec *executionContext) _Media_Title(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Media",
+		Object:   "ManagerOffer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12376,7 +12951,7 @@ func (ec *executionContext) _Media_Title(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return obj.CurrencyID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12385,12 +12960,12 @@ func (ec *executionContext) _Media_Title(ctx context.Context, field graphql.Coll
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Media_Position(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
+func (ec *executionContext) _ManagerOffer_BankDetailsID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12398,7 +12973,7 @@ func (ec *executionContext) _Media_Position(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Media",
+		Object:   "ManagerOffer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12407,7 +12982,7 @@ func (ec *executionContext) _Media_Position(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Position, nil
+		return obj.BankDetailsID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12421,7 +12996,7 @@ func (ec *executionContext) _Media_Position(ctx context.Context, field graphql.C
 	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Media_Filename(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
+func (ec *executionContext) _ManagerOffer_Title(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12429,7 +13004,7 @@ func (ec *executionContext) _Media_Filename(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Media",
+		Object:   "ManagerOffer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12438,7 +13013,7 @@ func (ec *executionContext) _Media_Filename(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Filename, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12452,7 +13027,7 @@ func (ec *executionContext) _Media_Filename(ctx context.Context, field graphql.C
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Media_Category(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
+func (ec *executionContext) _ManagerOffer_Status(ctx context.Context, field graphql.CollectedField, obj *model.ManagerOffer) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12460,7 +13035,7 @@ func (ec *executionContext) _Media_Category(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Media",
+		Object:   "ManagerOffer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12469,7 +13044,7 @@ func (ec *executionContext) _Media_Category(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Category, nil
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12483,7 +13058,7 @@ func (ec *executionContext) _Media_Category(ctx context.Context, field graphql.C
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Media_Created(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
+func (ec *executionContext) _ManagerSearch_ManagerID(ctx context.Context, field graphql.CollectedField, obj *model.ManagerSearch) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12491,7 +13066,7 @@ func (ec *executionContext) _Media_Created(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Media",
+		Object:   "ManagerSearch",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12500,21 +13075,24 @@ func (ec *executionContext) _Media_Created(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Created, nil
+		return obj.ManagerID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _ManagerSearch_Title(ctx context.Context, field graphql.CollectedField, obj *model.ManagerSearch) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12522,7 +13100,7 @@ func (ec *executionContext) _Member_MemberID(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "ManagerSearch",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12531,24 +13109,21 @@ func (ec *executionContext) _Member_MemberID(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MemberID, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Email(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Media_MediaID(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12556,7 +13131,7 @@ func (ec *executionContext) _Member_Email(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "Media",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12565,21 +13140,24 @@ func (ec *executionContext) _Member_Email(ctx context.Context, field graphql.Col
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Email, nil
+		return obj.MediaID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_ManagerID(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Media_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12587,7 +13165,7 @@ func (ec *executionContext) _Member_ManagerID(ctx context.Context, field graphql
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "Media",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12596,7 +13174,7 @@ func (ec *executionContext) _Member_ManagerID(ctx context.Context, field graphql
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ManagerID, nil
+		return obj.MemberID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12610,7 +13188,7 @@ func (ec *executionContext) _Member_ManagerID(ctx context.Context, field graphql
 	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_IP(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Media_InvestID(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12618,7 +13196,7 @@ func (ec *executionContext) _Member_IP(ctx context.Context, field graphql.Collec
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "Media",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12627,7 +13205,7 @@ func (ec *executionContext) _Member_IP(ctx context.Context, field graphql.Collec
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.IP, nil
+		return obj.InvestID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12636,12 +13214,12 @@ func (ec *executionContext) _Member_IP(ctx context.Context, field graphql.Collec
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_FirstName(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Media_URL(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12649,7 +13227,7 @@ func (ec *executionContext) _Member_FirstName(ctx context.Context, field graphql
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "Media",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12658,7 +13236,7 @@ func (ec *executionContext) _Member_FirstName(ctx context.Context, field graphql
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.FirstName, nil
+		return obj.URL, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12672,7 +13250,7 @@ func (ec *executionContext) _Member_FirstName(ctx context.Context, field graphql
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_LastName(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Media_Title(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12680,7 +13258,7 @@ func (ec *executionContext) _Member_LastName(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "Media",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12689,7 +13267,7 @@ func (ec *executionContext) _Member_LastName(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LastName, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12703,7 +13281,7 @@ func (ec *executionContext) _Member_LastName(ctx context.Context, field graphql.
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Gender(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Media_Position(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12711,7 +13289,7 @@ func (ec *executionContext) _Member_Gender(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "Media",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12720,7 +13298,7 @@ func (ec *executionContext) _Member_Gender(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Gender, nil
+		return obj.Position, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12729,12 +13307,12 @@ func (ec *executionContext) _Member_Gender(ctx context.Context, field graphql.Co
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_FamilyStatus(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Media_Filename(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12742,7 +13320,7 @@ func (ec *executionContext) _Member_FamilyStatus(ctx context.Context, field grap
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "Media",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12751,7 +13329,7 @@ func (ec *executionContext) _Member_FamilyStatus(ctx context.Context, field grap
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.FamilyStatus, nil
+		return obj.Filename, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12765,7 +13343,7 @@ func (ec *executionContext) _Member_FamilyStatus(ctx context.Context, field grap
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_MaidenName(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Media_Category(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12773,7 +13351,7 @@ func (ec *executionContext) _Member_MaidenName(ctx context.Context, field graphq
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "Media",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12782,7 +13360,7 @@ func (ec *executionContext) _Member_MaidenName(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MaidenName, nil
+		return obj.Category, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12796,7 +13374,7 @@ func (ec *executionContext) _Member_MaidenName(ctx context.Context, field graphq
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Citizenship(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Media_Created(ctx context.Context, field graphql.CollectedField, obj *model.Media) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12804,7 +13382,7 @@ func (ec *executionContext) _Member_Citizenship(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Member",
+		Object:   "Media",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -12813,7 +13391,7 @@ func (ec *executionContext) _Member_Citizenship(ctx context.Context, field graph
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Citizenship, nil
+		return obj.Created, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12822,12 +13400,12 @@ func (ec *executionContext) _Member_Citizenship(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Country(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12844,21 +13422,24 @@ func (ec *executionContext) _Member_Country(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Country, nil
+		return obj.MemberID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_City(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Email(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12875,7 +13456,7 @@ func (ec *executionContext) _Member_City(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.City, nil
+		return obj.Email, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12889,7 +13470,7 @@ func (ec *executionContext) _Member_City(ctx context.Context, field graphql.Coll
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Zip(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_ManagerID(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12906,7 +13487,7 @@ func (ec *executionContext) _Member_Zip(ctx context.Context, field graphql.Colle
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Zip, nil
+		return obj.ManagerID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12915,12 +13496,12 @@ func (ec *executionContext) _Member_Zip(ctx context.Context, field graphql.Colle
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Address1(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_IP(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12937,7 +13518,7 @@ func (ec *executionContext) _Member_Address1(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Address1, nil
+		return obj.IP, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12951,7 +13532,7 @@ func (ec *executionContext) _Member_Address1(ctx context.Context, field graphql.
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Address2(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_FirstName(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12968,7 +13549,7 @@ func (ec *executionContext) _Member_Address2(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Address2, nil
+		return obj.FirstName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -12982,7 +13563,7 @@ func (ec *executionContext) _Member_Address2(ctx context.Context, field graphql.
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_StreetNumber(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_LastName(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -12999,7 +13580,7 @@ func (ec *executionContext) _Member_StreetNumber(ctx context.Context, field grap
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StreetNumber, nil
+		return obj.LastName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13013,7 +13594,7 @@ func (ec *executionContext) _Member_StreetNumber(ctx context.Context, field grap
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_StreetName(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Gender(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13030,7 +13611,7 @@ func (ec *executionContext) _Member_StreetName(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StreetName, nil
+		return obj.Gender, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13044,7 +13625,7 @@ func (ec *executionContext) _Member_StreetName(ctx context.Context, field graphq
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Image(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_FamilyStatus(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13061,7 +13642,7 @@ func (ec *executionContext) _Member_Image(ctx context.Context, field graphql.Col
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Image, nil
+		return obj.FamilyStatus, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13075,7 +13656,7 @@ func (ec *executionContext) _Member_Image(ctx context.Context, field graphql.Col
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Birthday(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_MaidenName(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13092,7 +13673,7 @@ func (ec *executionContext) _Member_Birthday(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Birthday, nil
+		return obj.MaidenName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13106,7 +13687,7 @@ func (ec *executionContext) _Member_Birthday(ctx context.Context, field graphql.
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_EmailNotifications(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Citizenship(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13123,7 +13704,7 @@ func (ec *executionContext) _Member_EmailNotifications(ctx context.Context, fiel
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EmailNotifications, nil
+		return obj.Citizenship, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13132,12 +13713,12 @@ func (ec *executionContext) _Member_EmailNotifications(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*bool)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Phone(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Country(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13154,7 +13735,7 @@ func (ec *executionContext) _Member_Phone(ctx context.Context, field graphql.Col
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Phone, nil
+		return obj.Country, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13168,7 +13749,7 @@ func (ec *executionContext) _Member_Phone(ctx context.Context, field graphql.Col
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Created(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_City(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13185,7 +13766,7 @@ func (ec *executionContext) _Member_Created(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Created, nil
+		return obj.City, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13194,12 +13775,12 @@ func (ec *executionContext) _Member_Created(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Role(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Zip(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13216,7 +13797,7 @@ func (ec *executionContext) _Member_Role(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Role, nil
+		return obj.Zip, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13225,12 +13806,12 @@ func (ec *executionContext) _Member_Role(ctx context.Context, field graphql.Coll
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_CurrencyID(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Address1(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13247,7 +13828,7 @@ func (ec *executionContext) _Member_CurrencyID(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CurrencyID, nil
+		return obj.Address1, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13256,12 +13837,12 @@ func (ec *executionContext) _Member_CurrencyID(ctx context.Context, field graphq
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_USD(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Address2(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13278,7 +13859,7 @@ func (ec *executionContext) _Member_USD(ctx context.Context, field graphql.Colle
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Usd, nil
+		return obj.Address2, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13292,7 +13873,7 @@ func (ec *executionContext) _Member_USD(ctx context.Context, field graphql.Colle
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_EUR(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_StreetNumber(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13309,7 +13890,7 @@ func (ec *executionContext) _Member_EUR(ctx context.Context, field graphql.Colle
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Eur, nil
+		return obj.StreetNumber, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13323,7 +13904,7 @@ func (ec *executionContext) _Member_EUR(ctx context.Context, field graphql.Colle
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_LeverageAllowed(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_StreetName(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13340,7 +13921,7 @@ func (ec *executionContext) _Member_LeverageAllowed(ctx context.Context, field g
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LeverageAllowed, nil
+		return obj.StreetName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13354,7 +13935,7 @@ func (ec *executionContext) _Member_LeverageAllowed(ctx context.Context, field g
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_StopLossAllowed(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Image(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13371,7 +13952,7 @@ func (ec *executionContext) _Member_StopLossAllowed(ctx context.Context, field g
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StopLossAllowed, nil
+		return obj.Image, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13385,7 +13966,7 @@ func (ec *executionContext) _Member_StopLossAllowed(ctx context.Context, field g
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_TakeProfitAllowed(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Birthday(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13402,7 +13983,7 @@ func (ec *executionContext) _Member_TakeProfitAllowed(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TakeProfitAllowed, nil
+		return obj.Birthday, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13416,7 +13997,7 @@ func (ec *executionContext) _Member_TakeProfitAllowed(ctx context.Context, field
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_Status(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_EmailNotifications(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13433,7 +14014,7 @@ func (ec *executionContext) _Member_Status(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.EmailNotifications, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13442,12 +14023,12 @@ func (ec *executionContext) _Member_Status(ctx context.Context, field graphql.Co
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*bool)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Member_ManagerRole(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Phone(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13464,7 +14045,7 @@ func (ec *executionContext) _Member_ManagerRole(ctx context.Context, field graph
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ManagerRole, nil
+		return obj.Phone, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13478,7 +14059,7 @@ func (ec *executionContext) _Member_ManagerRole(ctx context.Context, field graph
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_MemberPersonalUpdate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Created(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13486,40 +14067,30 @@ func (ec *executionContext) _Mutation_MemberPersonalUpdate(ctx context.Context,
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_MemberPersonalUpdate_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().MemberPersonalUpdate(rctx, args["input"].(model.MemberPersonalUpdateRequest))
+		return obj.Created, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_MemberPhoneUpdate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Role(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13527,40 +14098,30 @@ func (ec *executionContext) _Mutation_MemberPhoneUpdate(ctx context.Context, fie
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_MemberPhoneUpdate_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().MemberPhoneUpdate(rctx, args["input"].(model.MemberPhoneUpdateRequest))
+		return obj.Role, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_MemberEmailUpdate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_CurrencyID(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13568,40 +14129,30 @@ func (ec *executionContext) _Mutation_MemberEmailUpdate(ctx context.Context, fie
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_MemberEmailUpdate_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().MemberEmailUpdate(rctx, args["input"].(model.MemberEmailUpdateRequest))
+		return obj.CurrencyID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateBankDetails(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_USD(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13609,40 +14160,30 @@ func (ec *executionContext) _Mutation_ManagerCreateBankDetails(ctx context.Conte
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateBankDetails_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateBankDetails(rctx, args["input"].(model.ManagerCreateBankDetailsRequest))
+		return obj.Usd, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_EUR(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13650,40 +14191,30 @@ func (ec *executionContext) _Mutation_ManagerCreateInvest(ctx context.Context, f
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateInvest_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateInvest(rctx, args["input"].(model.ManagerCreateInvestRequest))
+		return obj.Eur, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_LeverageAllowed(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13691,40 +14222,30 @@ func (ec *executionContext) _Mutation_ManagerCreateOffer(ctx context.Context, fi
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateOffer_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateOffer(rctx, args["input"].(model.ManagerCreateOfferRequest))
+		return obj.LeverageAllowed, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateInterest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_StopLossAllowed(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13732,40 +14253,30 @@ func (ec *executionContext) _Mutation_ManagerCreateInterest(ctx context.Context,
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateInterest_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateInterest(rctx, args["input"].(model.ManagerCreateInterestRequest))
+		return obj.StopLossAllowed, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateCategory(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_TakeProfitAllowed(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13773,40 +14284,30 @@ func (ec *executionContext) _Mutation_ManagerCreateCategory(ctx context.Context,
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateCategory_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateCategory(rctx, args["input"].(model.ManagerCreateCategoryRequest))
+		return obj.TakeProfitAllowed, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_Status(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13814,40 +14315,30 @@ func (ec *executionContext) _Mutation_ManagerCreateContract(ctx context.Context,
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateContract_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateContract(rctx, args["input"].(model.ManagerCreateContractRequest))
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateCurrency(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Member_ManagerRole(ctx context.Context, field graphql.CollectedField, obj *model.Member) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13855,40 +14346,30 @@ func (ec *executionContext) _Mutation_ManagerCreateCurrency(ctx context.Context,
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Mutation",
+		Object:   "Member",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateCurrency_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateCurrency(rctx, args["input"].(model.ManagerCreateCurrencyRequest))
+		return obj.ManagerRole, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateLead(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_MemberPersonalUpdate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13904,7 +14385,7 @@ func (ec *executionContext) _Mutation_ManagerCreateLead(ctx context.Context, fie
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateLead_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_MemberPersonalUpdate_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -13912,7 +14393,7 @@ func (ec *executionContext) _Mutation_ManagerCreateLead(ctx context.Context, fie
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateLead(rctx, args["input"].(model.ManagerCreateLeadRequest))
+		return ec.resolvers.Mutation().MemberPersonalUpdate(rctx, args["input"].(model.MemberPersonalUpdateRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13924,12 +14405,12 @@ func (ec *executionContext) _Mutation_ManagerCreateLead(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_MemberPhoneUpdate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13945,7 +14426,7 @@ func (ec *executionContext) _Mutation_ManagerCreateComment(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateComment_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_MemberPhoneUpdate_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -13953,7 +14434,7 @@ func (ec *executionContext) _Mutation_ManagerCreateComment(ctx context.Context,
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateComment(rctx, args["input"].(model.ManagerCreateCommentRequest))
+		return ec.resolvers.Mutation().MemberPhoneUpdate(rctx, args["input"].(model.MemberPhoneUpdateRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -13965,12 +14446,12 @@ func (ec *executionContext) _Mutation_ManagerCreateComment(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateChecklist(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_MemberEmailUpdate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -13986,7 +14467,7 @@ func (ec *executionContext) _Mutation_ManagerCreateChecklist(ctx context.Context
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateChecklist_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_MemberEmailUpdate_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -13994,7 +14475,7 @@ func (ec *executionContext) _Mutation_ManagerCreateChecklist(ctx context.Context
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateChecklist(rctx, args["input"].(model.ManagerCreateChecklistRequest))
+		return ec.resolvers.Mutation().MemberEmailUpdate(rctx, args["input"].(model.MemberEmailUpdateRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14006,12 +14487,12 @@ func (ec *executionContext) _Mutation_ManagerCreateChecklist(ctx context.Context
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateAppointment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateBankDetails(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14027,15 +14508,39 @@ func (ec *executionContext) _Mutation_ManagerCreateAppointment(ctx context.Conte
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateAppointment_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateBankDetails_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateAppointment(rctx, args["input"].(model.ManagerCreateAppointmentRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateBankDetails(rctx, args["input"].(model.ManagerCreateBankDetailsRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14052,7 +14557,7 @@ func (ec *executionContext) _Mutation_ManagerCreateAppointment(ctx context.Conte
 	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCreateCampaign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14068,15 +14573,39 @@ func (ec *executionContext) _Mutation_ManagerCreateCampaign(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCreateCampaign_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateInvest_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCreateCampaign(rctx, args["input"].(model.ManagerCreateCampaignRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateInvest(rctx, args["input"].(model.ManagerCreateInvestRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14093,7 +14622,7 @@ func (ec *executionContext) _Mutation_ManagerCreateCampaign(ctx context.Context,
 	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerAssignMemberToOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14109,15 +14638,39 @@ func (ec *executionContext) _Mutation_ManagerAssignMemberToOffer(ctx context.Con
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerAssignMemberToOffer_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerAssignMemberToOffer(rctx, args["input"].(model.ManagerAssignMemberToOfferRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateOffer(rctx, args["input"].(model.ManagerCreateOfferRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14129,12 +14682,12 @@ func (ec *executionContext) _Mutation_ManagerAssignMemberToOffer(ctx context.Con
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerAssignInvestToOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateInterest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14150,15 +14703,39 @@ func (ec *executionContext) _Mutation_ManagerAssignInvestToOffer(ctx context.Con
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerAssignInvestToOffer_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateInterest_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerAssignInvestToOffer(rctx, args["input"].(model.ManagerAssignInvestToOfferRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateInterest(rctx, args["input"].(model.ManagerCreateInterestRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14170,12 +14747,12 @@ func (ec *executionContext) _Mutation_ManagerAssignInvestToOffer(ctx context.Con
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerDuplicateAndAssignContractToOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateCategory(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14191,15 +14768,39 @@ func (ec *executionContext) _Mutation_ManagerDuplicateAndAssignContractToOffer(c
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerDuplicateAndAssignContractToOffer_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateCategory_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerDuplicateAndAssignContractToOffer(rctx, args["input"].(model.ManagerDuplicateAndAssignContractToOfferRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateCategory(rctx, args["input"].(model.ManagerCreateCategoryRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14211,12 +14812,12 @@ func (ec *executionContext) _Mutation_ManagerDuplicateAndAssignContractToOffer(c
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerAssignBankDetailsToOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14232,32 +14833,56 @@ func (ec *executionContext) _Mutation_ManagerAssignBankDetailsToOffer(ctx contex
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerAssignBankDetailsToOffer_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateContract_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerAssignBankDetailsToOffer(rctx, args["input"].(model.ManagerAssignBankDetailsToOfferRequest))
-	})
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	if resTmp == nil {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateContract(rctx, args["input"].(model.ManagerCreateContractRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
 		if !graphql.HasFieldError(ctx, fc) {
 			ec.Errorf(ctx, "must not be null")
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerAssignLeadToManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateCurrency(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14273,15 +14898,39 @@ func (ec *executionContext) _Mutation_ManagerAssignLeadToManager(ctx context.Con
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerAssignLeadToManager_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateCurrency_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerAssignLeadToManager(rctx, args["input"].(model.ManagerAssignLeadToManagerRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateCurrency(rctx, args["input"].(model.ManagerCreateCurrencyRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14293,12 +14942,12 @@ func (ec *executionContext) _Mutation_ManagerAssignLeadToManager(ctx context.Con
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerDuplicateInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateLead(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14314,15 +14963,39 @@ func (ec *executionContext) _Mutation_ManagerDuplicateInvest(ctx context.Context
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerDuplicateInvest_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateLead_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerDuplicateInvest(rctx, args["input"].(model.ManagerDuplicateRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateLead(rctx, args["input"].(model.ManagerCreateLeadRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14339,7 +15012,7 @@ func (ec *executionContext) _Mutation_ManagerDuplicateInvest(ctx context.Context
 	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerDuplicateOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14355,15 +15028,39 @@ func (ec *executionContext) _Mutation_ManagerDuplicateOffer(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerDuplicateOffer_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateComment_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerDuplicateOffer(rctx, args["input"].(model.ManagerDuplicateRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateComment(rctx, args["input"].(model.ManagerCreateCommentRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14380,7 +15077,7 @@ func (ec *executionContext) _Mutation_ManagerDuplicateOffer(ctx context.Context,
 	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerDuplicateContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateChecklist(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14396,15 +15093,39 @@ func (ec *executionContext) _Mutation_ManagerDuplicateContract(ctx context.Conte
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerDuplicateContract_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateChecklist_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerDuplicateContract(rctx, args["input"].(model.ManagerDuplicateRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateChecklist(rctx, args["input"].(model.ManagerCreateChecklistRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14421,7 +15142,7 @@ func (ec *executionContext) _Mutation_ManagerDuplicateContract(ctx context.Conte
 	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerDeactivateOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateAppointment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14437,15 +15158,39 @@ func (ec *executionContext) _Mutation_ManagerDeactivateOffer(ctx context.Context
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerDeactivateOffer_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateAppointment_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerDeactivateOffer(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateAppointment(rctx, args["input"].(model.ManagerCreateAppointmentRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14457,12 +15202,12 @@ func (ec *executionContext) _Mutation_ManagerDeactivateOffer(ctx context.Context
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerActivateOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCreateCampaign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14478,15 +15223,39 @@ func (ec *executionContext) _Mutation_ManagerActivateOffer(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerActivateOffer_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCreateCampaign_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerActivateOffer(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCreateCampaign(rctx, args["input"].(model.ManagerCreateCampaignRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14498,12 +15267,12 @@ func (ec *executionContext) _Mutation_ManagerActivateOffer(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerCancelOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerAssignMemberToOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14519,15 +15288,39 @@ func (ec *executionContext) _Mutation_ManagerCancelOffer(ctx context.Context, fi
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerCancelOffer_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerAssignMemberToOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerCancelOffer(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerAssignMemberToOffer(rctx, args["input"].(model.ManagerAssignMemberToOfferRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14544,7 +15337,7 @@ func (ec *executionContext) _Mutation_ManagerCancelOffer(ctx context.Context, fi
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditBankDetails(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerAssignInvestToOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14560,15 +15353,39 @@ func (ec *executionContext) _Mutation_ManagerEditBankDetails(ctx context.Context
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditBankDetails_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerAssignInvestToOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditBankDetails(rctx, args["input"].(model.ManagerEditBankDetailsRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerAssignInvestToOffer(rctx, args["input"].(model.ManagerAssignInvestToOfferRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14585,7 +15402,7 @@ func (ec *executionContext) _Mutation_ManagerEditBankDetails(ctx context.Context
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerDuplicateAndAssignContractToOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14601,15 +15418,39 @@ func (ec *executionContext) _Mutation_ManagerEditInvest(ctx context.Context, fie
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditInvest_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerDuplicateAndAssignContractToOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditInvest(rctx, args["input"].(model.ManagerEditInvestRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerDuplicateAndAssignContractToOffer(rctx, args["input"].(model.ManagerDuplicateAndAssignContractToOfferRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14626,7 +15467,7 @@ func (ec *executionContext) _Mutation_ManagerEditInvest(ctx context.Context, fie
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerAssignBankDetailsToOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14642,15 +15483,39 @@ func (ec *executionContext) _Mutation_ManagerEditOffer(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditOffer_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerAssignBankDetailsToOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditOffer(rctx, args["input"].(model.ManagerEditOfferRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerAssignBankDetailsToOffer(rctx, args["input"].(model.ManagerAssignBankDetailsToOfferRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14667,7 +15532,7 @@ func (ec *executionContext) _Mutation_ManagerEditOffer(ctx context.Context, fiel
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditCategory(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerAssignLeadToManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14683,15 +15548,39 @@ func (ec *executionContext) _Mutation_ManagerEditCategory(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditCategory_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerAssignLeadToManager_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditCategory(rctx, args["input"].(model.ManagerEditCategoryRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerAssignLeadToManager(rctx, args["input"].(model.ManagerAssignLeadToManagerRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.assign")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14708,7 +15597,7 @@ func (ec *executionContext) _Mutation_ManagerEditCategory(ctx context.Context, f
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerDuplicateInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14724,15 +15613,39 @@ func (ec *executionContext) _Mutation_ManagerEditContract(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditContract_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerDuplicateInvest_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditContract(rctx, args["input"].(model.ManagerEditContractRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerDuplicateInvest(rctx, args["input"].(model.ManagerDuplicateRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14744,12 +15657,12 @@ func (ec *executionContext) _Mutation_ManagerEditContract(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditCurrency(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerDuplicateOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14765,15 +15678,39 @@ func (ec *executionContext) _Mutation_ManagerEditCurrency(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditCurrency_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerDuplicateOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditCurrency(rctx, args["input"].(model.ManagerEditCurrencyRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerDuplicateOffer(rctx, args["input"].(model.ManagerDuplicateRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14785,12 +15722,12 @@ func (ec *executionContext) _Mutation_ManagerEditCurrency(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditInvoice(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerDuplicateContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14806,17 +15743,41 @@ func (ec *executionContext) _Mutation_ManagerEditInvoice(ctx context.Context, fi
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditInvoice_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerDuplicateContract_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditInvoice(rctx, args["input"].(model.ManagerEditInvoiceRequest))
-	})
-	if err != nil {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerDuplicateContract(rctx, args["input"].(model.ManagerDuplicateRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.CreationResponse); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.CreationResponse`, tmp)
+	})
+	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
@@ -14826,12 +15787,12 @@ func (ec *executionContext) _Mutation_ManagerEditInvoice(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Result)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditLead(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerDeactivateOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14847,15 +15808,39 @@ func (ec *executionContext) _Mutation_ManagerEditLead(ctx context.Context, field
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditLead_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerDeactivateOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditLead(rctx, args["input"].(model.ManagerEditLeadRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerDeactivateOffer(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14872,7 +15857,7 @@ func (ec *executionContext) _Mutation_ManagerEditLead(ctx context.Context, field
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerActivateOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14888,15 +15873,39 @@ func (ec *executionContext) _Mutation_ManagerEditComment(ctx context.Context, fi
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditComment_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerActivateOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditComment(rctx, args["input"].(model.ManagerEditCommentRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerActivateOffer(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14913,7 +15922,7 @@ func (ec *executionContext) _Mutation_ManagerEditComment(ctx context.Context, fi
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditChecklist(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerCancelOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14929,15 +15938,39 @@ func (ec *executionContext) _Mutation_ManagerEditChecklist(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditChecklist_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerCancelOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditChecklist(rctx, args["input"].(model.ManagerEditChecklistRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerCancelOffer(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14954,7 +15987,7 @@ func (ec *executionContext) _Mutation_ManagerEditChecklist(ctx context.Context,
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditAppointment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditBankDetails(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -14970,15 +16003,39 @@ func (ec *executionContext) _Mutation_ManagerEditAppointment(ctx context.Context
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditAppointment_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditBankDetails_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditAppointment(rctx, args["input"].(model.ManagerEditAppointmentRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditBankDetails(rctx, args["input"].(model.ManagerEditBankDetailsRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -14995,7 +16052,7 @@ func (ec *executionContext) _Mutation_ManagerEditAppointment(ctx context.Context
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditCampaign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15011,15 +16068,39 @@ func (ec *executionContext) _Mutation_ManagerEditCampaign(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditCampaign_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditInvest_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditCampaign(rctx, args["input"].(model.ManagerEditCampaignRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditInvest(rctx, args["input"].(model.ManagerEditInvestRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15036,7 +16117,7 @@ func (ec *executionContext) _Mutation_ManagerEditCampaign(ctx context.Context, f
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerEditMedia(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15052,15 +16133,39 @@ func (ec *executionContext) _Mutation_ManagerEditMedia(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerEditMedia_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerEditMedia(rctx, args["input"].(model.ManagerEditMediaRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditOffer(rctx, args["input"].(model.ManagerEditOfferRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15077,7 +16182,7 @@ func (ec *executionContext) _Mutation_ManagerEditMedia(ctx context.Context, fiel
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerRemoveLead(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditCategory(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15093,15 +16198,39 @@ func (ec *executionContext) _Mutation_ManagerRemoveLead(ctx context.Context, fie
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerRemoveLead_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditCategory_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerRemoveLead(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditCategory(rctx, args["input"].(model.ManagerEditCategoryRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15118,7 +16247,7 @@ func (ec *executionContext) _Mutation_ManagerRemoveLead(ctx context.Context, fie
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerRemoveComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15134,15 +16263,39 @@ func (ec *executionContext) _Mutation_ManagerRemoveComment(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerRemoveComment_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditContract_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerRemoveComment(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditContract(rctx, args["input"].(model.ManagerEditContractRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15159,7 +16312,7 @@ func (ec *executionContext) _Mutation_ManagerRemoveComment(ctx context.Context,
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerRemoveChecklist(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditCurrency(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15175,15 +16328,39 @@ func (ec *executionContext) _Mutation_ManagerRemoveChecklist(ctx context.Context
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerRemoveChecklist_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditCurrency_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerRemoveChecklist(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditCurrency(rctx, args["input"].(model.ManagerEditCurrencyRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15200,7 +16377,7 @@ func (ec *executionContext) _Mutation_ManagerRemoveChecklist(ctx context.Context
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerRemoveAppointment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditInvoice(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15216,15 +16393,39 @@ func (ec *executionContext) _Mutation_ManagerRemoveAppointment(ctx context.Conte
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerRemoveAppointment_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditInvoice_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerRemoveAppointment(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditInvoice(rctx, args["input"].(model.ManagerEditInvoiceRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15241,7 +16442,7 @@ func (ec *executionContext) _Mutation_ManagerRemoveAppointment(ctx context.Conte
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerRemoveCampaign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditLead(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15257,15 +16458,39 @@ func (ec *executionContext) _Mutation_ManagerRemoveCampaign(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerRemoveCampaign_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditLead_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerRemoveCampaign(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditLead(rctx, args["input"].(model.ManagerEditLeadRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15282,7 +16507,7 @@ func (ec *executionContext) _Mutation_ManagerRemoveCampaign(ctx context.Context,
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerRemoveInterest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15298,15 +16523,39 @@ func (ec *executionContext) _Mutation_ManagerRemoveInterest(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerRemoveInterest_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditComment_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerRemoveInterest(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditComment(rctx, args["input"].(model.ManagerEditCommentRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15323,7 +16572,7 @@ func (ec *executionContext) _Mutation_ManagerRemoveInterest(ctx context.Context,
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerRemoveManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditChecklist(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15339,15 +16588,39 @@ func (ec *executionContext) _Mutation_ManagerRemoveManager(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerRemoveManager_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditChecklist_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerRemoveManager(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditChecklist(rctx, args["input"].(model.ManagerEditChecklistRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15364,7 +16637,7 @@ func (ec *executionContext) _Mutation_ManagerRemoveManager(ctx context.Context,
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerRemoveMedia(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditAppointment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15380,15 +16653,39 @@ func (ec *executionContext) _Mutation_ManagerRemoveMedia(ctx context.Context, fi
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerRemoveMedia_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditAppointment_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerRemoveMedia(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditAppointment(rctx, args["input"].(model.ManagerEditAppointmentRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15405,7 +16702,7 @@ func (ec *executionContext) _Mutation_ManagerRemoveMedia(ctx context.Context, fi
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerDragMedia(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditCampaign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15421,32 +16718,56 @@ func (ec *executionContext) _Mutation_ManagerDragMedia(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerDragMedia_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditCampaign_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerDragMedia(rctx, args["input"].(model.DragRequest))
-	})
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditCampaign(rctx, args["input"].(model.ManagerEditCampaignRequest))
 		}
-		return graphql.Null
-	}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
 	res := resTmp.(*model.Result)
 	fc.Result = res
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ManagerAssignManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerEditMedia(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15462,15 +16783,39 @@ func (ec *executionContext) _Mutation_ManagerAssignManager(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ManagerAssignManager_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerEditMedia_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ManagerAssignManager(rctx, args["input"].(model.ManagerAssignManagerRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerEditMedia(rctx, args["input"].(model.ManagerEditMediaRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15487,7 +16832,7 @@ func (ec *executionContext) _Mutation_ManagerAssignManager(ctx context.Context,
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ValidateField(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerRemoveLead(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15503,15 +16848,39 @@ func (ec *executionContext) _Mutation_ValidateField(ctx context.Context, field g
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ValidateField_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerRemoveLead_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ValidateField(rctx, args["input"].(model.ValidateFieldRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerRemoveLead(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15523,12 +16892,12 @@ func (ec *executionContext) _Mutation_ValidateField(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.ValudationStatus)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNValudationStatus2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐValudationStatus(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_OfferInitDeal(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerRemoveComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15544,15 +16913,39 @@ func (ec *executionContext) _Mutation_OfferInitDeal(ctx context.Context, field g
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_OfferInitDeal_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerRemoveComment_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().OfferInitDeal(rctx, args["input"].(model.OfferInitDealRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerRemoveComment(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15569,7 +16962,7 @@ func (ec *executionContext) _Mutation_OfferInitDeal(ctx context.Context, field g
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_OfferGeneratePDF(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerRemoveChecklist(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15585,15 +16978,39 @@ func (ec *executionContext) _Mutation_OfferGeneratePDF(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_OfferGeneratePDF_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerRemoveChecklist_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().OfferGeneratePdf(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerRemoveChecklist(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15605,12 +17022,12 @@ func (ec *executionContext) _Mutation_OfferGeneratePDF(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.OfferPdf)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNOfferPDF2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐOfferPdf(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_OfferSign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerRemoveAppointment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15626,15 +17043,39 @@ func (ec *executionContext) _Mutation_OfferSign(ctx context.Context, field graph
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_OfferSign_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerRemoveAppointment_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().OfferSign(rctx, args["input"].(model.OfferSignRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerRemoveAppointment(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15646,12 +17087,12 @@ func (ec *executionContext) _Mutation_OfferSign(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.OfferSignResult)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNOfferSignResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐOfferSignResult(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_CancelDeal(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerRemoveCampaign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15667,15 +17108,39 @@ func (ec *executionContext) _Mutation_CancelDeal(ctx context.Context, field grap
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_CancelDeal_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerRemoveCampaign_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().CancelDeal(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerRemoveCampaign(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15692,7 +17157,7 @@ func (ec *executionContext) _Mutation_CancelDeal(ctx context.Context, field grap
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_RemoveDeal(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerRemoveInterest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15708,15 +17173,39 @@ func (ec *executionContext) _Mutation_RemoveDeal(ctx context.Context, field grap
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_RemoveDeal_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerRemoveInterest_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().RemoveDeal(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerRemoveInterest(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15733,7 +17222,7 @@ func (ec *executionContext) _Mutation_RemoveDeal(ctx context.Context, field grap
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_InvoiceSendToEmail(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerRemoveManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15749,15 +17238,39 @@ func (ec *executionContext) _Mutation_InvoiceSendToEmail(ctx context.Context, fi
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_InvoiceSendToEmail_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerRemoveManager_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().InvoiceSendToEmail(rctx, args["input"].(model.InvoiceSendToEmailRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerRemoveManager(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "managers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15774,7 +17287,7 @@ func (ec *executionContext) _Mutation_InvoiceSendToEmail(ctx context.Context, fi
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_SignIn(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerRemoveMedia(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15790,15 +17303,39 @@ func (ec *executionContext) _Mutation_SignIn(ctx context.Context, field graphql.
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_SignIn_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerRemoveMedia_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().SignIn(rctx, args["input"].(model.SignInRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerRemoveMedia(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15810,12 +17347,12 @@ func (ec *executionContext) _Mutation_SignIn(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.SignInResponse)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNSignInResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInResponse(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_SignUp(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerDragMedia(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15831,15 +17368,39 @@ func (ec *executionContext) _Mutation_SignUp(ctx context.Context, field graphql.
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_SignUp_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerDragMedia_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().SignUp(rctx, args["input"].(model.SignUpRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerDragMedia(rctx, args["input"].(model.DragRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15851,12 +17412,12 @@ func (ec *executionContext) _Mutation_SignUp(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.CreationResponse)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_Reset(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ManagerAssignManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15872,15 +17433,39 @@ func (ec *executionContext) _Mutation_Reset(ctx context.Context, field graphql.C
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_Reset_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ManagerAssignManager_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().Reset(rctx, args["input"].(model.ResetRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().ManagerAssignManager(rctx, args["input"].(model.ManagerAssignManagerRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "managers.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15897,7 +17482,7 @@ func (ec *executionContext) _Mutation_Reset(ctx context.Context, field graphql.C
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_ResetComplete(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ValidateField(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15913,7 +17498,7 @@ func (ec *executionContext) _Mutation_ResetComplete(ctx context.Context, field g
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_ResetComplete_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_ValidateField_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -15921,7 +17506,7 @@ func (ec *executionContext) _Mutation_ResetComplete(ctx context.Context, field g
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ResetComplete(rctx, args["input"].(model.ResetCompleteRequest))
+		return ec.resolvers.Mutation().ValidateField(rctx, args["input"].(model.ValidateFieldRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15933,12 +17518,12 @@ func (ec *executionContext) _Mutation_ResetComplete(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.VerifyResponse)
+	res := resTmp.(*model.ValudationStatus)
 	fc.Result = res
-	return ec.marshalNVerifyResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyResponse(ctx, field.Selections, res)
+	return ec.marshalNValudationStatus2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐValudationStatus(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_PhoneVerify(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_OfferInitDeal(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15954,7 +17539,7 @@ func (ec *executionContext) _Mutation_PhoneVerify(ctx context.Context, field gra
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_PhoneVerify_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_OfferInitDeal_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -15962,7 +17547,7 @@ func (ec *executionContext) _Mutation_PhoneVerify(ctx context.Context, field gra
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().PhoneVerify(rctx, args["input"].(model.PhoneVerifyRequest))
+		return ec.resolvers.Mutation().OfferInitDeal(rctx, args["input"].(model.OfferInitDealRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -15974,12 +17559,12 @@ func (ec *executionContext) _Mutation_PhoneVerify(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.PhoneVerifyResponse)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNPhoneVerifyResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐPhoneVerifyResponse(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_Verify(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_OfferGeneratePDF(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -15995,7 +17580,7 @@ func (ec *executionContext) _Mutation_Verify(ctx context.Context, field graphql.
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_Verify_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_OfferGeneratePDF_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -16003,7 +17588,7 @@ func (ec *executionContext) _Mutation_Verify(ctx context.Context, field graphql.
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().Verify(rctx, args["input"].(model.VerifyRequest))
+		return ec.resolvers.Mutation().OfferGeneratePdf(rctx, args["input"].(model.RecordRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16015,12 +17600,12 @@ func (ec *executionContext) _Mutation_Verify(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.VerifyResponse)
+	res := resTmp.(*model.OfferPdf)
 	fc.Result = res
-	return ec.marshalNVerifyResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyResponse(ctx, field.Selections, res)
+	return ec.marshalNOfferPDF2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐOfferPdf(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_VerifyResend(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_OfferSign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16036,7 +17621,7 @@ func (ec *executionContext) _Mutation_VerifyResend(ctx context.Context, field gr
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_VerifyResend_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_OfferSign_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -16044,7 +17629,7 @@ func (ec *executionContext) _Mutation_VerifyResend(ctx context.Context, field gr
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().VerifyResend(rctx, args["input"].(model.VerifyResendRequest))
+		return ec.resolvers.Mutation().OfferSign(rctx, args["input"].(model.OfferSignRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16056,12 +17641,12 @@ func (ec *executionContext) _Mutation_VerifyResend(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.VerifyResendResponse)
+	res := resTmp.(*model.OfferSignResult)
 	fc.Result = res
-	return ec.marshalNVerifyResendResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyResendResponse(ctx, field.Selections, res)
+	return ec.marshalNOfferSignResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐOfferSignResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_OfferPhoneVerify(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_CancelDeal(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16077,7 +17662,7 @@ func (ec *executionContext) _Mutation_OfferPhoneVerify(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_OfferPhoneVerify_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_CancelDeal_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -16085,7 +17670,7 @@ func (ec *executionContext) _Mutation_OfferPhoneVerify(ctx context.Context, fiel
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().OfferPhoneVerify(rctx, args["input"].(model.OfferPhoneVerifyRequest))
+		return ec.resolvers.Mutation().CancelDeal(rctx, args["input"].(model.RecordRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16102,7 +17687,7 @@ func (ec *executionContext) _Mutation_OfferPhoneVerify(ctx context.Context, fiel
 	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_OfferPhoneVerifyResend(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_RemoveDeal(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16118,7 +17703,7 @@ func (ec *executionContext) _Mutation_OfferPhoneVerifyResend(ctx context.Context
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_OfferPhoneVerifyResend_args(ctx, rawArgs)
+	args, err := ec.field_Mutation_RemoveDeal_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -16126,7 +17711,7 @@ func (ec *executionContext) _Mutation_OfferPhoneVerifyResend(ctx context.Context
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().OfferPhoneVerifyResend(rctx, args["input"].(model.RecordRequest))
+		return ec.resolvers.Mutation().RemoveDeal(rctx, args["input"].(model.RecordRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16138,12 +17723,12 @@ func (ec *executionContext) _Mutation_OfferPhoneVerifyResend(ctx context.Context
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.PhoneVerifyResponse)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNPhoneVerifyResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐPhoneVerifyResponse(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_OfferID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_InvoiceSendToEmail(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16151,16 +17736,23 @@ func (ec *executionContext) _Offer_OfferID(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_InvoiceSendToEmail_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.OfferID, nil
+		return ec.resolvers.Mutation().InvoiceSendToEmail(rctx, args["input"].(model.InvoiceSendToEmailRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16172,12 +17764,12 @@ func (ec *executionContext) _Offer_OfferID(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_SignIn(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16185,30 +17777,40 @@ func (ec *executionContext) _Offer_MemberID(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_SignIn_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MemberID, nil
+		return ec.resolvers.Mutation().SignIn(rctx, args["input"].(model.SignInRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*model.SignInResponse)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNSignInResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_InvestID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_SignInMFA(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16216,30 +17818,40 @@ func (ec *executionContext) _Offer_InvestID(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_SignInMFA_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.InvestID, nil
+		return ec.resolvers.Mutation().SignInMfa(rctx, args["input"].(model.SignInMFARequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*model.SignInResponse)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNSignInResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_CurrencyID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_SignInPasskeyBegin(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16247,30 +17859,40 @@ func (ec *executionContext) _Offer_CurrencyID(ctx context.Context, field graphql
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_SignInPasskeyBegin_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CurrencyID, nil
+		return ec.resolvers.Mutation().SignInPasskeyBegin(rctx, args["input"].(model.SignInPasskeyBeginRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*model.SignInPasskeyBeginResponse)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNSignInPasskeyBeginResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInPasskeyBeginResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_BankDetailsID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_SignInPasskey(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16278,30 +17900,40 @@ func (ec *executionContext) _Offer_BankDetailsID(ctx context.Context, field grap
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_SignInPasskey_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.BankDetailsID, nil
+		return ec.resolvers.Mutation().SignInPasskey(rctx, args["input"].(model.SignInPasskeyRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*model.SignInResponse)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNSignInResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_Title(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_Unlock(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16309,30 +17941,40 @@ func (ec *executionContext) _Offer_Title(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_Unlock_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return ec.resolvers.Mutation().Unlock(rctx, args["input"].(model.UnlockRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_Status(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_SignUp(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16340,30 +17982,40 @@ func (ec *executionContext) _Offer_Status(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_SignUp_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return ec.resolvers.Mutation().SignUp(rctx, args["input"].(model.SignUpRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.CreationResponse)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNCreationResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCreationResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_Subtitle(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_Reset(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16371,30 +18023,40 @@ func (ec *executionContext) _Offer_Subtitle(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_Reset_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Subtitle, nil
+		return ec.resolvers.Mutation().Reset(rctx, args["input"].(model.ResetRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.Result)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_Description(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_ResetComplete(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16402,30 +18064,40 @@ func (ec *executionContext) _Offer_Description(ctx context.Context, field graphq
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_ResetComplete_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return ec.resolvers.Mutation().ResetComplete(rctx, args["input"].(model.ResetCompleteRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.VerifyResponse)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNVerifyResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_Contract(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_PhoneVerify(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16433,30 +18105,40 @@ func (ec *executionContext) _Offer_Contract(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_PhoneVerify_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Contract, nil
+		return ec.resolvers.Mutation().PhoneVerify(rctx, args["input"].(model.PhoneVerifyRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Contract)
+	res := resTmp.(*model.PhoneVerifyResponse)
 	fc.Result = res
-	return ec.marshalOContract2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContract(ctx, field.Selections, res)
+	return ec.marshalNPhoneVerifyResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐPhoneVerifyResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Offer_FAQ(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_Verify(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16464,30 +18146,40 @@ func (ec *executionContext) _Offer_FAQ(ctx context.Context, field graphql.Collec
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Offer",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_Verify_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Faq, nil
+		return ec.resolvers.Mutation().Verify(rctx, args["input"].(model.VerifyRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Faq)
+	res := resTmp.(*model.VerifyResponse)
 	fc.Result = res
-	return ec.marshalOFAQ2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐFaq(ctx, field.Selections, res)
+	return ec.marshalNVerifyResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _OfferPDF_URL(ctx context.Context, field graphql.CollectedField, obj *model.OfferPdf) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_VerifyResend(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16495,16 +18187,23 @@ func (ec *executionContext) _OfferPDF_URL(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "OfferPDF",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_VerifyResend_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.URL, nil
+		return ec.resolvers.Mutation().VerifyResend(rctx, args["input"].(model.VerifyResendRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16516,12 +18215,12 @@ func (ec *executionContext) _OfferPDF_URL(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*model.VerifyResendResponse)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNVerifyResendResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐVerifyResendResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _OfferSignResult_Status(ctx context.Context, field graphql.CollectedField, obj *model.OfferSignResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_OfferPhoneVerify(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16529,7 +18228,219 @@ func (ec *executionContext) _OfferSignResult_Status(ctx context.Context, field g
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "OfferSignResult",
+		Object:   "Mutation",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_OfferPhoneVerify_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().OfferPhoneVerify(rctx, args["input"].(model.OfferPhoneVerifyRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Result)
+	fc.Result = res
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_OfferPhoneVerifyResend(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Mutation",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_OfferPhoneVerifyResend_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().OfferPhoneVerifyResend(rctx, args["input"].(model.RecordRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.PhoneVerifyResponse)
+	fc.Result = res
+	return ec.marshalNPhoneVerifyResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐPhoneVerifyResponse(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_OperatorJobUpdate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Mutation",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_OperatorJobUpdate_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().OperatorJobUpdate(rctx, args["input"].(model.OperatorJobUpdateRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "jobs.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Result)
+	fc.Result = res
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_OperatorJobTrigger(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Mutation",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_OperatorJobTrigger_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().OperatorJobTrigger(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "jobs.update")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Result); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Result`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Result)
+	fc.Result = res
+	return ec.marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐResult(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Offer_OfferID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Offer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -16538,7 +18449,7 @@ func (ec *executionContext) _OfferSignResult_Status(ctx context.Context, field g
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.OfferID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16550,12 +18461,12 @@ func (ec *executionContext) _OfferSignResult_Status(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _OfferSignResult_Message(ctx context.Context, field graphql.CollectedField, obj *model.OfferSignResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _Offer_MemberID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16563,7 +18474,7 @@ func (ec *executionContext) _OfferSignResult_Message(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "OfferSignResult",
+		Object:   "Offer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -16572,7 +18483,7 @@ func (ec *executionContext) _OfferSignResult_Message(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Message, nil
+		return obj.MemberID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16581,12 +18492,12 @@ func (ec *executionContext) _OfferSignResult_Message(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _OfferSignResult_Timeout(ctx context.Context, field graphql.CollectedField, obj *model.OfferSignResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _Offer_InvestID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16594,7 +18505,7 @@ func (ec *executionContext) _OfferSignResult_Timeout(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "OfferSignResult",
+		Object:   "Offer",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -16603,7 +18514,7 @@ func (ec *executionContext) _OfferSignResult_Timeout(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Timeout, nil
+		return obj.InvestID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16617,7 +18528,812 @@ func (ec *executionContext) _OfferSignResult_Timeout(ctx context.Context, field
 	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _PhoneVerifyResponse_Timeout(ctx context.Context, field graphql.CollectedField, obj *model.PhoneVerifyResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _Offer_CurrencyID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Offer",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CurrencyID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Offer_BankDetailsID(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Offer",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.BankDetailsID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Offer_Title(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Offer",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Title, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Offer_Status(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Offer",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Status, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Offer_Subtitle(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Offer",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Subtitle, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Offer_Description(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Offer",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Offer_Contract(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Offer",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Contract, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Contract)
+	fc.Result = res
+	return ec.marshalOContract2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContract(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Offer_FAQ(ctx context.Context, field graphql.CollectedField, obj *model.Offer) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Offer",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Faq, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Faq)
+	fc.Result = res
+	return ec.marshalOFAQ2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐFaq(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _OfferPDF_URL(ctx context.Context, field graphql.CollectedField, obj *model.OfferPdf) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "OfferPDF",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.URL, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _OfferSignResult_Status(ctx context.Context, field graphql.CollectedField, obj *model.OfferSignResult) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "OfferSignResult",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Status, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _OfferSignResult_Message(ctx context.Context, field graphql.CollectedField, obj *model.OfferSignResult) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "OfferSignResult",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Message, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _OfferSignResult_Timeout(ctx context.Context, field graphql.CollectedField, obj *model.OfferSignResult) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "OfferSignResult",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Timeout, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PhoneVerifyResponse_Timeout(ctx context.Context, field graphql.CollectedField, obj *model.PhoneVerifyResponse) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "PhoneVerifyResponse",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Timeout, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_Invest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_Invest_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Invest(rctx, args["input"].(model.RecordRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Invest)
+	fc.Result = res
+	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_InvestByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_InvestByOfferID_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().InvestByOfferID(rctx, args["input"].(*model.RecordRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Invest)
+	fc.Result = res
+	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_InterestListByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_InterestListByOfferID_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().InterestListByOfferID(rctx, args["input"].(*model.RecordRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Interest)
+	fc.Result = res
+	return ec.marshalNInterest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInterestᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_ManagerDealByContractID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_ManagerDealByContractID_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerDealByContractID(rctx, args["input"].(*model.ManagerDealByContractIDRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Deal); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Deal`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Deal)
+	fc.Result = res
+	return ec.marshalNDeal2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDeal(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_Offer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_Offer_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Offer(rctx, args["input"].(model.RecordRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Offer)
+	fc.Result = res
+	return ec.marshalNOffer2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐOffer(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_ContractByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_ContractByOfferID_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().ContractByOfferID(rctx, args["input"].(model.RecordRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Contract)
+	fc.Result = res
+	return ec.marshalNContract2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContract(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_DealByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_DealByOfferID_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().DealByOfferID(rctx, args["input"].(model.RecordRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Deal)
+	fc.Result = res
+	return ec.marshalNDeal2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDeal(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_InterestByDealID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_InterestByDealID_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().InterestByDealID(rctx, args["input"].(*model.RecordRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Interest)
+	fc.Result = res
+	return ec.marshalNInterest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInterest(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_InterestByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_InterestByOfferID_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().InterestByOfferID(rctx, args["input"].(*model.RecordRequest))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Interest)
+	fc.Result = res
+	return ec.marshalNInterest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInterest(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_Invoice(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16625,16 +19341,23 @@ func (ec *executionContext) _PhoneVerifyResponse_Timeout(ctx context.Context, fi
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "PhoneVerifyResponse",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_Invoice_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Timeout, nil
+		return ec.resolvers.Query().Invoice(rctx, args["input"].(model.RecordRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16646,12 +19369,12 @@ func (ec *executionContext) _PhoneVerifyResponse_Timeout(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*model.Invoice)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNInvoice2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvoice(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_Invest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_InvoiceByDealID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16667,7 +19390,7 @@ func (ec *executionContext) _Query_Invest(ctx context.Context, field graphql.Col
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_Invest_args(ctx, rawArgs)
+	args, err := ec.field_Query_InvoiceByDealID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -16675,7 +19398,7 @@ func (ec *executionContext) _Query_Invest(ctx context.Context, field graphql.Col
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Invest(rctx, args["input"].(model.RecordRequest))
+		return ec.resolvers.Query().InvoiceByDealID(rctx, args["input"].(*model.RecordRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16687,12 +19410,12 @@ func (ec *executionContext) _Query_Invest(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Invest)
+	res := resTmp.(*model.Invoice)
 	fc.Result = res
-	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
+	return ec.marshalNInvoice2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvoice(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_InvestByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_BankDetailsByInvoiceID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16708,7 +19431,7 @@ func (ec *executionContext) _Query_InvestByOfferID(ctx context.Context, field gr
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_InvestByOfferID_args(ctx, rawArgs)
+	args, err := ec.field_Query_BankDetailsByInvoiceID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -16716,7 +19439,7 @@ func (ec *executionContext) _Query_InvestByOfferID(ctx context.Context, field gr
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().InvestByOfferID(rctx, args["input"].(*model.RecordRequest))
+		return ec.resolvers.Query().BankDetailsByInvoiceID(rctx, args["input"].(*model.RecordRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16728,12 +19451,12 @@ func (ec *executionContext) _Query_InvestByOfferID(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Invest)
+	res := resTmp.(*model.BankDetails)
 	fc.Result = res
-	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
+	return ec.marshalNBankDetails2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetails(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_InterestListByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_OfferByInvoiceID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16749,7 +19472,7 @@ func (ec *executionContext) _Query_InterestListByOfferID(ctx context.Context, fi
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_InterestListByOfferID_args(ctx, rawArgs)
+	args, err := ec.field_Query_OfferByInvoiceID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -16757,7 +19480,7 @@ func (ec *executionContext) _Query_InterestListByOfferID(ctx context.Context, fi
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().InterestListByOfferID(rctx, args["input"].(*model.RecordRequest))
+		return ec.resolvers.Query().OfferByInvoiceID(rctx, args["input"].(model.RecordRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16769,12 +19492,12 @@ func (ec *executionContext) _Query_InterestListByOfferID(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Interest)
+	res := resTmp.(*model.Invest)
 	fc.Result = res
-	return ec.marshalNInterest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInterestᚄ(ctx, field.Selections, res)
+	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerDealByContractID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerBankDetails(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16790,15 +19513,39 @@ func (ec *executionContext) _Query_ManagerDealByContractID(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerDealByContractID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerBankDetails_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerDealByContractID(rctx, args["input"].(*model.ManagerDealByContractIDRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerBankDetails(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.BankDetails); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.BankDetails`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16810,12 +19557,12 @@ func (ec *executionContext) _Query_ManagerDealByContractID(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Deal)
+	res := resTmp.(*model.BankDetails)
 	fc.Result = res
-	return ec.marshalNDeal2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDeal(ctx, field.Selections, res)
+	return ec.marshalNBankDetails2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetails(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_Offer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerBankDetailsByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16831,15 +19578,39 @@ func (ec *executionContext) _Query_Offer(ctx context.Context, field graphql.Coll
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_Offer_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerBankDetailsByOfferID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Offer(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerBankDetailsByOfferID(rctx, args["input"].(*model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.BankDetails); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.BankDetails`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16851,12 +19622,12 @@ func (ec *executionContext) _Query_Offer(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Offer)
+	res := resTmp.(*model.BankDetails)
 	fc.Result = res
-	return ec.marshalNOffer2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐOffer(ctx, field.Selections, res)
+	return ec.marshalNBankDetails2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetails(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ContractByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16872,15 +19643,39 @@ func (ec *executionContext) _Query_ContractByOfferID(ctx context.Context, field
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ContractByOfferID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerInvest_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ContractByOfferID(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerInvest(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Invest); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Invest`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16892,12 +19687,12 @@ func (ec *executionContext) _Query_ContractByOfferID(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Contract)
+	res := resTmp.(*model.Invest)
 	fc.Result = res
-	return ec.marshalNContract2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContract(ctx, field.Selections, res)
+	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_DealByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerMediaByInvestID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16913,15 +19708,39 @@ func (ec *executionContext) _Query_DealByOfferID(ctx context.Context, field grap
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_DealByOfferID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerMediaByInvestID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DealByOfferID(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerMediaByInvestID(rctx, args["input"].(model.ManagerMediaByInvestIDRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Media); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Media`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16933,12 +19752,12 @@ func (ec *executionContext) _Query_DealByOfferID(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Deal)
+	res := resTmp.([]*model.Media)
 	fc.Result = res
-	return ec.marshalNDeal2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDeal(ctx, field.Selections, res)
+	return ec.marshalNMedia2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMediaᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_InterestByDealID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerInvestByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16954,15 +19773,39 @@ func (ec *executionContext) _Query_InterestByDealID(ctx context.Context, field g
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_InterestByDealID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerInvestByOfferID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().InterestByDealID(rctx, args["input"].(*model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerInvestByOfferID(rctx, args["input"].(*model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Invest); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Invest`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -16974,12 +19817,12 @@ func (ec *executionContext) _Query_InterestByDealID(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Interest)
+	res := resTmp.(*model.Invest)
 	fc.Result = res
-	return ec.marshalNInterest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInterest(ctx, field.Selections, res)
+	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_InterestByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -16995,15 +19838,39 @@ func (ec *executionContext) _Query_InterestByOfferID(ctx context.Context, field
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_InterestByOfferID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerOffer_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().InterestByOfferID(rctx, args["input"].(*model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerOffer(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.ManagerOffer); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.ManagerOffer`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17015,12 +19882,12 @@ func (ec *executionContext) _Query_InterestByOfferID(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Interest)
+	res := resTmp.(*model.ManagerOffer)
 	fc.Result = res
-	return ec.marshalNInterest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInterest(ctx, field.Selections, res)
+	return ec.marshalNManagerOffer2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐManagerOffer(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_Invoice(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerDeal(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17036,15 +19903,39 @@ func (ec *executionContext) _Query_Invoice(ctx context.Context, field graphql.Co
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_Invoice_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerDeal_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Invoice(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerDeal(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Deal); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Deal`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17056,12 +19947,12 @@ func (ec *executionContext) _Query_Invoice(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Invoice)
+	res := resTmp.(*model.Deal)
 	fc.Result = res
-	return ec.marshalNInvoice2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvoice(ctx, field.Selections, res)
+	return ec.marshalNDeal2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDeal(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_InvoiceByDealID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerInvoiceByDealID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17077,15 +19968,39 @@ func (ec *executionContext) _Query_InvoiceByDealID(ctx context.Context, field gr
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_InvoiceByDealID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerInvoiceByDealID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().InvoiceByDealID(rctx, args["input"].(*model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerInvoiceByDealID(rctx, args["input"].(*model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Invoice); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Invoice`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17102,7 +20017,7 @@ func (ec *executionContext) _Query_InvoiceByDealID(ctx context.Context, field gr
 	return ec.marshalNInvoice2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvoice(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_BankDetailsByInvoiceID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17118,15 +20033,39 @@ func (ec *executionContext) _Query_BankDetailsByInvoiceID(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_BankDetailsByInvoiceID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerContract_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().BankDetailsByInvoiceID(rctx, args["input"].(*model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerContract(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Contract); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Contract`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17138,12 +20077,12 @@ func (ec *executionContext) _Query_BankDetailsByInvoiceID(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.BankDetails)
+	res := resTmp.(*model.Contract)
 	fc.Result = res
-	return ec.marshalNBankDetails2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetails(ctx, field.Selections, res)
+	return ec.marshalNContract2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContract(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_OfferByInvoiceID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerCategory(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17159,15 +20098,39 @@ func (ec *executionContext) _Query_OfferByInvoiceID(ctx context.Context, field g
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_OfferByInvoiceID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerCategory_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().OfferByInvoiceID(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerCategory(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Category); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Category`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17179,12 +20142,12 @@ func (ec *executionContext) _Query_OfferByInvoiceID(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Invest)
+	res := resTmp.(*model.Category)
 	fc.Result = res
-	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
+	return ec.marshalNCategory2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCategory(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerBankDetails(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerCurrency(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17200,15 +20163,39 @@ func (ec *executionContext) _Query_ManagerBankDetails(ctx context.Context, field
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerBankDetails_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerCurrency_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerBankDetails(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerCurrency(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Currency); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Currency`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17220,12 +20207,12 @@ func (ec *executionContext) _Query_ManagerBankDetails(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.BankDetails)
+	res := resTmp.(*model.Currency)
 	fc.Result = res
-	return ec.marshalNBankDetails2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetails(ctx, field.Selections, res)
+	return ec.marshalNCurrency2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCurrency(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerBankDetailsByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerMember(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17241,15 +20228,39 @@ func (ec *executionContext) _Query_ManagerBankDetailsByOfferID(ctx context.Conte
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerBankDetailsByOfferID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerMember_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerBankDetailsByOfferID(rctx, args["input"].(*model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerMember(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "members.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Member); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Member`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17261,12 +20272,12 @@ func (ec *executionContext) _Query_ManagerBankDetailsByOfferID(ctx context.Conte
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.BankDetails)
+	res := resTmp.(*model.Member)
 	fc.Result = res
-	return ec.marshalNBankDetails2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetails(ctx, field.Selections, res)
+	return ec.marshalNMember2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMember(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerMemberByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17282,15 +20293,39 @@ func (ec *executionContext) _Query_ManagerInvest(ctx context.Context, field grap
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerInvest_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerMemberByOfferID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerInvest(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerMemberByOfferID(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "members.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Member); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Member`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17302,12 +20337,12 @@ func (ec *executionContext) _Query_ManagerInvest(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Invest)
+	res := resTmp.(*model.Member)
 	fc.Result = res
-	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
+	return ec.marshalNMember2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMember(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerMediaByInvestID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17323,15 +20358,39 @@ func (ec *executionContext) _Query_ManagerMediaByInvestID(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerMediaByInvestID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerManager_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerMediaByInvestID(rctx, args["input"].(model.ManagerMediaByInvestIDRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerManager(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "managers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Member); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Member`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17343,12 +20402,12 @@ func (ec *executionContext) _Query_ManagerMediaByInvestID(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Media)
+	res := resTmp.(*model.Member)
 	fc.Result = res
-	return ec.marshalNMedia2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMediaᚄ(ctx, field.Selections, res)
+	return ec.marshalNMember2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMember(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerInvestByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerLead(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17364,15 +20423,39 @@ func (ec *executionContext) _Query_ManagerInvestByOfferID(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerInvestByOfferID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerLead_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerInvestByOfferID(rctx, args["input"].(*model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerLead(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Lead); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Lead`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17384,12 +20467,12 @@ func (ec *executionContext) _Query_ManagerInvestByOfferID(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Invest)
+	res := resTmp.(*model.Lead)
 	fc.Result = res
-	return ec.marshalNInvest2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvest(ctx, field.Selections, res)
+	return ec.marshalNLead2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐLead(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerOffer(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17405,15 +20488,39 @@ func (ec *executionContext) _Query_ManagerOffer(ctx context.Context, field graph
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerOffer_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerComment_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerOffer(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerComment(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Comment); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Comment`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17425,12 +20532,12 @@ func (ec *executionContext) _Query_ManagerOffer(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.ManagerOffer)
+	res := resTmp.(*model.Comment)
 	fc.Result = res
-	return ec.marshalNManagerOffer2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐManagerOffer(ctx, field.Selections, res)
+	return ec.marshalNComment2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐComment(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerDeal(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerChecklist(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17446,15 +20553,39 @@ func (ec *executionContext) _Query_ManagerDeal(ctx context.Context, field graphq
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerDeal_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerChecklist_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerDeal(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerChecklist(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Checklist); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Checklist`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17466,12 +20597,12 @@ func (ec *executionContext) _Query_ManagerDeal(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Deal)
+	res := resTmp.(*model.Checklist)
 	fc.Result = res
-	return ec.marshalNDeal2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDeal(ctx, field.Selections, res)
+	return ec.marshalNChecklist2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐChecklist(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerInvoiceByDealID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerAppointment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17487,15 +20618,39 @@ func (ec *executionContext) _Query_ManagerInvoiceByDealID(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerInvoiceByDealID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerAppointment_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerInvoiceByDealID(rctx, args["input"].(*model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerAppointment(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Appointment); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Appointment`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17507,12 +20662,12 @@ func (ec *executionContext) _Query_ManagerInvoiceByDealID(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Invoice)
+	res := resTmp.(*model.Appointment)
 	fc.Result = res
-	return ec.marshalNInvoice2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvoice(ctx, field.Selections, res)
+	return ec.marshalNAppointment2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐAppointment(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerCampaign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17528,15 +20683,39 @@ func (ec *executionContext) _Query_ManagerContract(ctx context.Context, field gr
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerContract_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerCampaign_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerContract(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerCampaign(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Campaign); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/ianidi/exchange-server/graph/model.Campaign`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17548,12 +20727,12 @@ func (ec *executionContext) _Query_ManagerContract(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Contract)
+	res := resTmp.(*model.Campaign)
 	fc.Result = res
-	return ec.marshalNContract2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContract(ctx, field.Selections, res)
+	return ec.marshalNCampaign2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCampaign(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerCategory(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_OfferList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17568,16 +20747,9 @@ func (ec *executionContext) _Query_ManagerCategory(ctx context.Context, field gr
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerCategory_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerCategory(rctx, args["input"].(model.RecordRequest))
+		return ec.resolvers.Query().OfferList(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17589,12 +20761,12 @@ func (ec *executionContext) _Query_ManagerCategory(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Category)
+	res := resTmp.([]*model.Invest)
 	fc.Result = res
-	return ec.marshalNCategory2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCategory(ctx, field.Selections, res)
+	return ec.marshalNInvest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvestᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerCurrency(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_DealList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17609,16 +20781,9 @@ func (ec *executionContext) _Query_ManagerCurrency(ctx context.Context, field gr
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerCurrency_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerCurrency(rctx, args["input"].(model.RecordRequest))
+		return ec.resolvers.Query().DealList(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17630,12 +20795,12 @@ func (ec *executionContext) _Query_ManagerCurrency(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Currency)
+	res := resTmp.([]*model.Deal)
 	fc.Result = res
-	return ec.marshalNCurrency2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCurrency(ctx, field.Selections, res)
+	return ec.marshalNDeal2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDealᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerMember(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ContractList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17650,16 +20815,9 @@ func (ec *executionContext) _Query_ManagerMember(ctx context.Context, field grap
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerMember_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerMember(rctx, args["input"].(model.RecordRequest))
+		return ec.resolvers.Query().ContractList(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17671,12 +20829,12 @@ func (ec *executionContext) _Query_ManagerMember(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Member)
+	res := resTmp.([]*model.Contract)
 	fc.Result = res
-	return ec.marshalNMember2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMember(ctx, field.Selections, res)
+	return ec.marshalNContract2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContractᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerMemberByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_CategoryList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17691,16 +20849,9 @@ func (ec *executionContext) _Query_ManagerMemberByOfferID(ctx context.Context, f
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerMemberByOfferID_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerMemberByOfferID(rctx, args["input"].(model.RecordRequest))
+		return ec.resolvers.Query().CategoryList(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17712,12 +20863,12 @@ func (ec *executionContext) _Query_ManagerMemberByOfferID(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Member)
+	res := resTmp.([]*model.Category)
 	fc.Result = res
-	return ec.marshalNMember2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMember(ctx, field.Selections, res)
+	return ec.marshalNCategory2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCategoryᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_CurrencyList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17732,16 +20883,9 @@ func (ec *executionContext) _Query_ManagerManager(ctx context.Context, field gra
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerManager_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerManager(rctx, args["input"].(model.RecordRequest))
+		return ec.resolvers.Query().CurrencyList(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17753,12 +20897,12 @@ func (ec *executionContext) _Query_ManagerManager(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Member)
+	res := resTmp.([]*model.Currency)
 	fc.Result = res
-	return ec.marshalNMember2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMember(ctx, field.Selections, res)
+	return ec.marshalNCurrency2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCurrencyᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerLead(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_BalanceList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17772,17 +20916,10 @@ func (ec *executionContext) _Query_ManagerLead(ctx context.Context, field graphq
 		IsMethod: true,
 	}
 
-	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerLead_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
+	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerLead(rctx, args["input"].(model.RecordRequest))
+		return ec.resolvers.Query().BalanceList(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17794,12 +20931,12 @@ func (ec *executionContext) _Query_ManagerLead(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Lead)
+	res := resTmp.([]*model.Balance)
 	fc.Result = res
-	return ec.marshalNLead2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐLead(ctx, field.Selections, res)
+	return ec.marshalNBalance2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBalanceᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerComment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_TXList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17814,16 +20951,9 @@ func (ec *executionContext) _Query_ManagerComment(ctx context.Context, field gra
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerComment_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerComment(rctx, args["input"].(model.RecordRequest))
+		return ec.resolvers.Query().TXList(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17835,12 +20965,12 @@ func (ec *executionContext) _Query_ManagerComment(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Comment)
+	res := resTmp.([]*model.Tx)
 	fc.Result = res
-	return ec.marshalNComment2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐComment(ctx, field.Selections, res)
+	return ec.marshalNTX2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐTxᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerChecklist(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerBankDetailsList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17856,15 +20986,39 @@ func (ec *executionContext) _Query_ManagerChecklist(ctx context.Context, field g
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerChecklist_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerBankDetailsList_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerChecklist(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerBankDetailsList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.BankDetails); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.BankDetails`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17876,12 +21030,12 @@ func (ec *executionContext) _Query_ManagerChecklist(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Checklist)
+	res := resTmp.([]*model.BankDetails)
 	fc.Result = res
-	return ec.marshalNChecklist2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐChecklist(ctx, field.Selections, res)
+	return ec.marshalNBankDetails2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetailsᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerAppointment(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerInvestList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17897,15 +21051,39 @@ func (ec *executionContext) _Query_ManagerAppointment(ctx context.Context, field
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerAppointment_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerInvestList_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerAppointment(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerInvestList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Invest); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Invest`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17917,12 +21095,12 @@ func (ec *executionContext) _Query_ManagerAppointment(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Appointment)
+	res := resTmp.([]*model.Invest)
 	fc.Result = res
-	return ec.marshalNAppointment2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐAppointment(ctx, field.Selections, res)
+	return ec.marshalNInvest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvestᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerCampaign(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerOfferList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17938,15 +21116,39 @@ func (ec *executionContext) _Query_ManagerCampaign(ctx context.Context, field gr
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerCampaign_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerOfferList_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerCampaign(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerOfferList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.ManagerOffer); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.ManagerOffer`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17958,12 +21160,12 @@ func (ec *executionContext) _Query_ManagerCampaign(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Campaign)
+	res := resTmp.([]*model.ManagerOffer)
 	fc.Result = res
-	return ec.marshalNCampaign2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCampaign(ctx, field.Selections, res)
+	return ec.marshalNManagerOffer2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐManagerOfferᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_OfferList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerDealList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -17978,9 +21180,40 @@ func (ec *executionContext) _Query_OfferList(ctx context.Context, field graphql.
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_ManagerDealList_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().OfferList(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerDealList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Deal); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Deal`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -17992,12 +21225,12 @@ func (ec *executionContext) _Query_OfferList(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Invest)
+	res := resTmp.([]*model.Deal)
 	fc.Result = res
-	return ec.marshalNInvest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvestᚄ(ctx, field.Selections, res)
+	return ec.marshalNDeal2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDealᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_DealList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerDealListByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18012,9 +21245,40 @@ func (ec *executionContext) _Query_DealList(ctx context.Context, field graphql.C
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_ManagerDealListByOfferID_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DealList(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerDealListByOfferID(rctx, args["input"].(model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Deal); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Deal`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18031,7 +21295,7 @@ func (ec *executionContext) _Query_DealList(ctx context.Context, field graphql.C
 	return ec.marshalNDeal2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDealᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ContractList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerContractList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18046,9 +21310,40 @@ func (ec *executionContext) _Query_ContractList(ctx context.Context, field graph
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_ManagerContractList_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ContractList(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerContractList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Contract); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Contract`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18065,7 +21360,7 @@ func (ec *executionContext) _Query_ContractList(ctx context.Context, field graph
 	return ec.marshalNContract2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContractᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_CategoryList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerCategoryList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18080,9 +21375,40 @@ func (ec *executionContext) _Query_CategoryList(ctx context.Context, field graph
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_ManagerCategoryList_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().CategoryList(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerCategoryList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Category); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Category`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18099,7 +21425,7 @@ func (ec *executionContext) _Query_CategoryList(ctx context.Context, field graph
 	return ec.marshalNCategory2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCategoryᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_CurrencyList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerCurrencyList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18114,9 +21440,40 @@ func (ec *executionContext) _Query_CurrencyList(ctx context.Context, field graph
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_ManagerCurrencyList_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().CurrencyList(rctx)
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerCurrencyList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Currency); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Currency`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18133,7 +21490,7 @@ func (ec *executionContext) _Query_CurrencyList(ctx context.Context, field graph
 	return ec.marshalNCurrency2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCurrencyᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_BalanceList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerLeadList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18148,43 +21505,40 @@ func (ec *executionContext) _Query_BalanceList(ctx context.Context, field graphq
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().BalanceList(rctx)
-	})
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_ManagerLeadList_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
-	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerLeadList(rctx, args["input"].(*model.ListRequest))
 		}
-		return graphql.Null
-	}
-	res := resTmp.([]*model.Balance)
-	fc.Result = res
-	return ec.marshalNBalance2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBalanceᚄ(ctx, field.Selections, res)
-}
-
-func (ec *executionContext) _Query_TXList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	defer func() {
-		if r := recover(); r != nil {
-			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = graphql.Null
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
 		}
-	}()
-	fc := &graphql.FieldContext{
-		Object:   "Query",
-		Field:    field,
-		Args:     nil,
-		IsMethod: true,
-	}
 
-	ctx = graphql.WithFieldContext(ctx, fc)
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().TXList(rctx)
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Lead); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Lead`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18196,12 +21550,12 @@ func (ec *executionContext) _Query_TXList(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Tx)
+	res := resTmp.([]*model.Lead)
 	fc.Result = res
-	return ec.marshalNTX2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐTxᚄ(ctx, field.Selections, res)
+	return ec.marshalNLead2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐLeadᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerBankDetailsList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerCommentList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18217,15 +21571,39 @@ func (ec *executionContext) _Query_ManagerBankDetailsList(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerBankDetailsList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerCommentList_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerBankDetailsList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerCommentList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Comment); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Comment`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18237,12 +21615,12 @@ func (ec *executionContext) _Query_ManagerBankDetailsList(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.BankDetails)
+	res := resTmp.([]*model.Comment)
 	fc.Result = res
-	return ec.marshalNBankDetails2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetailsᚄ(ctx, field.Selections, res)
+	return ec.marshalNComment2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCommentᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerInvestList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerCommentListByLeadID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18258,15 +21636,39 @@ func (ec *executionContext) _Query_ManagerInvestList(ctx context.Context, field
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerInvestList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerCommentListByLeadID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerInvestList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerCommentListByLeadID(rctx, args["input"].(*model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Comment); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Comment`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18278,12 +21680,12 @@ func (ec *executionContext) _Query_ManagerInvestList(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Invest)
+	res := resTmp.([]*model.Comment)
 	fc.Result = res
-	return ec.marshalNInvest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvestᚄ(ctx, field.Selections, res)
+	return ec.marshalNComment2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCommentᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerOfferList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerChecklistList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18299,15 +21701,39 @@ func (ec *executionContext) _Query_ManagerOfferList(ctx context.Context, field g
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerOfferList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerChecklistList_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerOfferList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerChecklistList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Checklist); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Checklist`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18319,12 +21745,12 @@ func (ec *executionContext) _Query_ManagerOfferList(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.ManagerOffer)
+	res := resTmp.([]*model.Checklist)
 	fc.Result = res
-	return ec.marshalNManagerOffer2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐManagerOfferᚄ(ctx, field.Selections, res)
+	return ec.marshalNChecklist2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐChecklistᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerDealList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerAppointmentList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18340,15 +21766,39 @@ func (ec *executionContext) _Query_ManagerDealList(ctx context.Context, field gr
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerDealList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerAppointmentList_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerDealList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerAppointmentList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Appointment); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Appointment`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18360,12 +21810,12 @@ func (ec *executionContext) _Query_ManagerDealList(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Deal)
+	res := resTmp.([]*model.Appointment)
 	fc.Result = res
-	return ec.marshalNDeal2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDealᚄ(ctx, field.Selections, res)
+	return ec.marshalNAppointment2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐAppointmentᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerDealListByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerAppointmentListByLeadID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18381,15 +21831,39 @@ func (ec *executionContext) _Query_ManagerDealListByOfferID(ctx context.Context,
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerDealListByOfferID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerAppointmentListByLeadID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerDealListByOfferID(rctx, args["input"].(model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerAppointmentListByLeadID(rctx, args["input"].(*model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Appointment); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Appointment`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18401,12 +21875,12 @@ func (ec *executionContext) _Query_ManagerDealListByOfferID(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Deal)
+	res := resTmp.([]*model.Appointment)
 	fc.Result = res
-	return ec.marshalNDeal2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐDealᚄ(ctx, field.Selections, res)
+	return ec.marshalNAppointment2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐAppointmentᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerContractList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerCampaignList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18422,15 +21896,39 @@ func (ec *executionContext) _Query_ManagerContractList(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerContractList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerCampaignList_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerContractList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerCampaignList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "leads.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Campaign); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Campaign`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18442,12 +21940,12 @@ func (ec *executionContext) _Query_ManagerContractList(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Contract)
+	res := resTmp.([]*model.Campaign)
 	fc.Result = res
-	return ec.marshalNContract2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContractᚄ(ctx, field.Selections, res)
+	return ec.marshalNCampaign2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCampaignᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerCategoryList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerManagerList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18463,15 +21961,39 @@ func (ec *executionContext) _Query_ManagerCategoryList(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerCategoryList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerManagerList_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerCategoryList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerManagerList(rctx, args["input"].(*model.ListRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "managers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Member); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Member`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18483,12 +22005,12 @@ func (ec *executionContext) _Query_ManagerCategoryList(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Category)
+	res := resTmp.([]*model.Member)
 	fc.Result = res
-	return ec.marshalNCategory2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCategoryᚄ(ctx, field.Selections, res)
+	return ec.marshalNMember2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMemberᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerCurrencyList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerContractListByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18504,15 +22026,39 @@ func (ec *executionContext) _Query_ManagerCurrencyList(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerCurrencyList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerContractListByOfferID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerCurrencyList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerContractListByOfferID(rctx, args["input"].(*model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Contract); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Contract`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18524,12 +22070,12 @@ func (ec *executionContext) _Query_ManagerCurrencyList(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Currency)
+	res := resTmp.([]*model.Contract)
 	fc.Result = res
-	return ec.marshalNCurrency2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCurrencyᚄ(ctx, field.Selections, res)
+	return ec.marshalNContract2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContractᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerLeadList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerInterestListByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18545,15 +22091,39 @@ func (ec *executionContext) _Query_ManagerLeadList(ctx context.Context, field gr
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerLeadList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerInterestListByOfferID_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerLeadList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerInterestListByOfferID(rctx, args["input"].(*model.RecordRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Interest); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Interest`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18565,12 +22135,12 @@ func (ec *executionContext) _Query_ManagerLeadList(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Lead)
+	res := resTmp.([]*model.Interest)
 	fc.Result = res
-	return ec.marshalNLead2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐLeadᚄ(ctx, field.Selections, res)
+	return ec.marshalNInterest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInterestᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerCommentList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerSearchMember(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18586,15 +22156,39 @@ func (ec *executionContext) _Query_ManagerCommentList(ctx context.Context, field
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerCommentList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerSearchMember_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerCommentList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerSearchMember(rctx, args["input"].(*model.SearchRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "members.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Member); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Member`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18606,12 +22200,12 @@ func (ec *executionContext) _Query_ManagerCommentList(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Comment)
+	res := resTmp.([]*model.Member)
 	fc.Result = res
-	return ec.marshalNComment2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCommentᚄ(ctx, field.Selections, res)
+	return ec.marshalNMember2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMemberᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerCommentListByLeadID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerSearchMemberNoManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18627,15 +22221,39 @@ func (ec *executionContext) _Query_ManagerCommentListByLeadID(ctx context.Contex
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerCommentListByLeadID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerSearchMemberNoManager_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerCommentListByLeadID(rctx, args["input"].(*model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerSearchMemberNoManager(rctx, args["input"].(*model.SearchRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "members.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Member); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Member`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18647,12 +22265,12 @@ func (ec *executionContext) _Query_ManagerCommentListByLeadID(ctx context.Contex
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Comment)
+	res := resTmp.([]*model.Member)
 	fc.Result = res
-	return ec.marshalNComment2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCommentᚄ(ctx, field.Selections, res)
+	return ec.marshalNMember2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMemberᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerChecklistList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerSearchInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18668,15 +22286,39 @@ func (ec *executionContext) _Query_ManagerChecklistList(ctx context.Context, fie
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerChecklistList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerSearchInvest_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerChecklistList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerSearchInvest(rctx, args["input"].(*model.SearchRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Invest); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Invest`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18688,12 +22330,12 @@ func (ec *executionContext) _Query_ManagerChecklistList(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Checklist)
+	res := resTmp.([]*model.Invest)
 	fc.Result = res
-	return ec.marshalNChecklist2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐChecklistᚄ(ctx, field.Selections, res)
+	return ec.marshalNInvest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvestᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerAppointmentList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerSearchContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18709,15 +22351,39 @@ func (ec *executionContext) _Query_ManagerAppointmentList(ctx context.Context, f
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerAppointmentList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerSearchContract_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerAppointmentList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerSearchContract(rctx, args["input"].(*model.SearchRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Contract); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Contract`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18729,12 +22395,12 @@ func (ec *executionContext) _Query_ManagerAppointmentList(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Appointment)
+	res := resTmp.([]*model.Contract)
 	fc.Result = res
-	return ec.marshalNAppointment2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐAppointmentᚄ(ctx, field.Selections, res)
+	return ec.marshalNContract2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContractᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerAppointmentListByLeadID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerSearchCurrency(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18750,15 +22416,39 @@ func (ec *executionContext) _Query_ManagerAppointmentListByLeadID(ctx context.Co
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerAppointmentListByLeadID_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerSearchCurrency_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerAppointmentListByLeadID(rctx, args["input"].(*model.RecordRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerSearchCurrency(rctx, args["input"].(*model.SearchRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Currency); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Currency`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18770,12 +22460,12 @@ func (ec *executionContext) _Query_ManagerAppointmentListByLeadID(ctx context.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Appointment)
+	res := resTmp.([]*model.Currency)
 	fc.Result = res
-	return ec.marshalNAppointment2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐAppointmentᚄ(ctx, field.Selections, res)
+	return ec.marshalNCurrency2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCurrencyᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerCampaignList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerSearchBankDetails(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18791,15 +22481,39 @@ func (ec *executionContext) _Query_ManagerCampaignList(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerCampaignList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerSearchBankDetails_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerCampaignList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerSearchBankDetails(rctx, args["input"].(*model.SearchRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "offers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.BankDetails); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.BankDetails`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18811,12 +22525,12 @@ func (ec *executionContext) _Query_ManagerCampaignList(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Campaign)
+	res := resTmp.([]*model.BankDetails)
 	fc.Result = res
-	return ec.marshalNCampaign2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCampaignᚄ(ctx, field.Selections, res)
+	return ec.marshalNBankDetails2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetailsᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerManagerList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ManagerSearchManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18832,15 +22546,39 @@ func (ec *executionContext) _Query_ManagerManagerList(ctx context.Context, field
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerManagerList_args(ctx, rawArgs)
+	args, err := ec.field_Query_ManagerSearchManager_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerManagerList(rctx, args["input"].(*model.ListRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().ManagerSearchManager(rctx, args["input"].(*model.SearchRequest))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "managers.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.ManagerSearch); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.ManagerSearch`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18852,12 +22590,12 @@ func (ec *executionContext) _Query_ManagerManagerList(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Member)
+	res := resTmp.([]*model.ManagerSearch)
 	fc.Result = res
-	return ec.marshalNMember2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMemberᚄ(ctx, field.Selections, res)
+	return ec.marshalNManagerSearch2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐManagerSearchᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerContractListByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_Member(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18872,16 +22610,9 @@ func (ec *executionContext) _Query_ManagerContractListByOfferID(ctx context.Cont
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerContractListByOfferID_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerContractListByOfferID(rctx, args["input"].(*model.RecordRequest))
+		return ec.resolvers.Query().Member(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18893,12 +22624,12 @@ func (ec *executionContext) _Query_ManagerContractListByOfferID(ctx context.Cont
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Contract)
+	res := resTmp.(*model.Member)
 	fc.Result = res
-	return ec.marshalNContract2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContractᚄ(ctx, field.Selections, res)
+	return ec.marshalNMember2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMember(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerInterestListByOfferID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_alert(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18913,16 +22644,9 @@ func (ec *executionContext) _Query_ManagerInterestListByOfferID(ctx context.Cont
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerInterestListByOfferID_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerInterestListByOfferID(rctx, args["input"].(*model.RecordRequest))
+		return ec.resolvers.Query().Alert(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18934,12 +22658,12 @@ func (ec *executionContext) _Query_ManagerInterestListByOfferID(ctx context.Cont
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Interest)
+	res := resTmp.([]*model.Alert)
 	fc.Result = res
-	return ec.marshalNInterest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInterestᚄ(ctx, field.Selections, res)
+	return ec.marshalNAlert2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐAlertᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerSearchMember(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_InfoSince(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18955,7 +22679,7 @@ func (ec *executionContext) _Query_ManagerSearchMember(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerSearchMember_args(ctx, rawArgs)
+	args, err := ec.field_Query_InfoSince_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -18963,7 +22687,7 @@ func (ec *executionContext) _Query_ManagerSearchMember(ctx context.Context, fiel
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerSearchMember(rctx, args["input"].(*model.SearchRequest))
+		return ec.resolvers.Query().InfoSince(rctx, args["input"].(model.InfoSinceRequest))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -18975,12 +22699,12 @@ func (ec *executionContext) _Query_ManagerSearchMember(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Member)
+	res := resTmp.([]*model.Info)
 	fc.Result = res
-	return ec.marshalNMember2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMemberᚄ(ctx, field.Selections, res)
+	return ec.marshalNInfo2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInfoᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerSearchMemberNoManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_OperatorJobList(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -18995,16 +22719,33 @@ func (ec *executionContext) _Query_ManagerSearchMemberNoManager(ctx context.Cont
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerSearchMemberNoManager_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerSearchMemberNoManager(rctx, args["input"].(*model.SearchRequest))
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().OperatorJobList(rctx)
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			permission, err := ec.unmarshalNString2string(ctx, "jobs.read")
+			if err != nil {
+				return nil, err
+			}
+			if ec.directives.HasPermission == nil {
+				return nil, errors.New("directive hasPermission is not implemented")
+			}
+			return ec.directives.HasPermission(ctx, nil, directive0, permission)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, err
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.([]*model.Job); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/ianidi/exchange-server/graph/model.Job`, tmp)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19016,12 +22757,12 @@ func (ec *executionContext) _Query_ManagerSearchMemberNoManager(ctx context.Cont
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Member)
+	res := resTmp.([]*model.Job)
 	fc.Result = res
-	return ec.marshalNMember2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMemberᚄ(ctx, field.Selections, res)
+	return ec.marshalNJob2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐJobᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerSearchInvest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19037,7 +22778,7 @@ func (ec *executionContext) _Query_ManagerSearchInvest(ctx context.Context, fiel
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerSearchInvest_args(ctx, rawArgs)
+	args, err := ec.field_Query___type_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -19045,24 +22786,21 @@ func (ec *executionContext) _Query_ManagerSearchInvest(ctx context.Context, fiel
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerSearchInvest(rctx, args["input"].(*model.SearchRequest))
+		return ec.introspectType(args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Invest)
+	res := resTmp.(*introspection.Type)
 	fc.Result = res
-	return ec.marshalNInvest2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInvestᚄ(ctx, field.Selections, res)
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerSearchContract(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19077,33 +22815,23 @@ func (ec *executionContext) _Query_ManagerSearchContract(ctx context.Context, fi
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerSearchContract_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerSearchContract(rctx, args["input"].(*model.SearchRequest))
+		return ec.introspectSchema()
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Contract)
+	res := resTmp.(*introspection.Schema)
 	fc.Result = res
-	return ec.marshalNContract2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐContractᚄ(ctx, field.Selections, res)
+	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerSearchCurrency(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Result_Status(ctx context.Context, field graphql.CollectedField, obj *model.Result) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19111,23 +22839,16 @@ func (ec *executionContext) _Query_ManagerSearchCurrency(ctx context.Context, fi
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Query",
+		Object:   "Result",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerSearchCurrency_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerSearchCurrency(rctx, args["input"].(*model.SearchRequest))
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19139,12 +22860,12 @@ func (ec *executionContext) _Query_ManagerSearchCurrency(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Currency)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNCurrency2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐCurrencyᚄ(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerSearchBankDetails(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Result_Message(ctx context.Context, field graphql.CollectedField, obj *model.Result) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19152,40 +22873,30 @@ func (ec *executionContext) _Query_ManagerSearchBankDetails(ctx context.Context,
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Query",
+		Object:   "Result",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerSearchBankDetails_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerSearchBankDetails(rctx, args["input"].(*model.SearchRequest))
+		return obj.Message, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.BankDetails)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNBankDetails2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐBankDetailsᚄ(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_ManagerSearchManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInPasskeyBeginResponse_Status(ctx context.Context, field graphql.CollectedField, obj *model.SignInPasskeyBeginResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19193,23 +22904,16 @@ func (ec *executionContext) _Query_ManagerSearchManager(ctx context.Context, fie
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Query",
+		Object:   "SignInPasskeyBeginResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_ManagerSearchManager_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ManagerSearchManager(rctx, args["input"].(*model.SearchRequest))
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19221,12 +22925,12 @@ func (ec *executionContext) _Query_ManagerSearchManager(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.ManagerSearch)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNManagerSearch2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐManagerSearchᚄ(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_Member(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInPasskeyBeginResponse_Options(ctx context.Context, field graphql.CollectedField, obj *model.SignInPasskeyBeginResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19234,16 +22938,16 @@ func (ec *executionContext) _Query_Member(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Query",
+		Object:   "SignInPasskeyBeginResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Member(rctx)
+		return obj.Options, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19255,12 +22959,12 @@ func (ec *executionContext) _Query_Member(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Member)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNMember2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐMember(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_alert(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInResponse_Status(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19268,16 +22972,16 @@ func (ec *executionContext) _Query_alert(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Query",
+		Object:   "SignInResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Alert(rctx)
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19289,12 +22993,12 @@ func (ec *executionContext) _Query_alert(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Alert)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNAlert2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐAlertᚄ(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInResponse_Message(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19302,23 +23006,16 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Query",
+		Object:   "SignInResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query___type_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectType(args["name"].(string))
+		return obj.Message, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19327,12 +23024,12 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInResponse_Token(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19340,16 +23037,16 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Query",
+		Object:   "SignInResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectSchema()
+		return obj.Token, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19358,12 +23055,12 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Result_Status(ctx context.Context, field graphql.CollectedField, obj *model.Result) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInResponse_MFAToken(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19371,7 +23068,7 @@ func (ec *executionContext) _Result_Status(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Result",
+		Object:   "SignInResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -19380,24 +23077,21 @@ func (ec *executionContext) _Result_Status(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.MFAToken, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Result_Message(ctx context.Context, field graphql.CollectedField, obj *model.Result) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInResponse_MFAMethods(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19405,7 +23099,7 @@ func (ec *executionContext) _Result_Message(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:   "Result",
+		Object:   "SignInResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -19414,7 +23108,7 @@ func (ec *executionContext) _Result_Message(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Message, nil
+		return obj.MFAMethods, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19423,12 +23117,12 @@ func (ec *executionContext) _Result_Message(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _SignInResponse_Status(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInResponse_Captcha(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19445,24 +23139,21 @@ func (ec *executionContext) _SignInResponse_Status(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.Captcha, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*bool)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _SignInResponse_Message(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInResponse_Locked(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19479,7 +23170,7 @@ func (ec *executionContext) _SignInResponse_Message(ctx context.Context, field g
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Message, nil
+		return obj.Locked, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19488,12 +23179,12 @@ func (ec *executionContext) _SignInResponse_Message(ctx context.Context, field g
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*bool)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _SignInResponse_Token(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _SignInResponse_RetryAfter(ctx context.Context, field graphql.CollectedField, obj *model.SignInResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -19510,7 +23201,7 @@ func (ec *executionContext) _SignInResponse_Token(ctx context.Context, field gra
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Token, nil
+		return obj.RetryAfter, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -19519,9 +23210,9 @@ func (ec *executionContext) _SignInResponse_Token(ctx context.Context, field gra
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) _Subscription_newInfo(ctx context.Context, field graphql.CollectedField) (ret func() graphql.Marshaler) {
@@ -19881,6 +23572,68 @@ func (ec *executionContext) _TX_TimestampComplete(ctx context.Context, field gra
 	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _TX_Type(ctx context.Context, field graphql.CollectedField, obj *model.Tx) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "TX",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Type, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TX_TransferID(ctx context.Context, field graphql.CollectedField, obj *model.Tx) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "TX",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TransferID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _Upload_UploadID(ctx context.Context, field graphql.CollectedField, obj *model.Upload) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -20330,6 +24083,68 @@ func (ec *executionContext) _VerifyResponse_Token(ctx context.Context, field gra
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _VerifyResponse_MFAToken(ctx context.Context, field graphql.CollectedField, obj *model.VerifyResponse) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "VerifyResponse",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MFAToken, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _VerifyResponse_MFAMethods(ctx context.Context, field graphql.CollectedField, obj *model.VerifyResponse) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "VerifyResponse",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MFAMethods, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalOString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -21421,6 +25236,30 @@ func (ec *executionContext) unmarshalInputDragRequest(ctx context.Context, obj i
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputInfoSinceRequest(ctx context.Context, obj interface{}) (model.InfoSinceRequest, error) {
+	var it model.InfoSinceRequest
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "Seq":
+			var err error
+			it.Seq, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "SharedSeq":
+			var err error
+			it.SharedSeq, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputInvoiceSendToEmailRequest(ctx context.Context, obj interface{}) (model.InvoiceSendToEmailRequest, error) {
 	var it model.InvoiceSendToEmailRequest
 	var asMap = obj.(map[string]interface{})
@@ -22951,6 +26790,12 @@ func (ec *executionContext) unmarshalInputMemberEmailUpdateRequest(ctx context.C
 			if err != nil {
 				return it, err
 			}
+		case "MFACode":
+			var err error
+			it.MFACode, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -23095,6 +26940,36 @@ func (ec *executionContext) unmarshalInputOfferSignRequest(ctx context.Context,
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputOperatorJobUpdateRequest(ctx context.Context, obj interface{}) (model.OperatorJobUpdateRequest, error) {
+	var it model.OperatorJobUpdateRequest
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "JobID":
+			var err error
+			it.JobID, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "Interval":
+			var err error
+			it.Interval, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "Active":
+			var err error
+			it.Active, err = ec.unmarshalNBoolean2bool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputPhoneVerifyRequest(ctx context.Context, obj interface{}) (model.PhoneVerifyRequest, error) {
 	var it model.PhoneVerifyRequest
 	var asMap = obj.(map[string]interface{})
@@ -23215,6 +27090,72 @@ func (ec *executionContext) unmarshalInputSearchRequest(ctx context.Context, obj
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputSignInMFARequest(ctx context.Context, obj interface{}) (model.SignInMFARequest, error) {
+	var it model.SignInMFARequest
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "Token":
+			var err error
+			it.Token, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "Code":
+			var err error
+			it.Code, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputSignInPasskeyBeginRequest(ctx context.Context, obj interface{}) (model.SignInPasskeyBeginRequest, error) {
+	var it model.SignInPasskeyBeginRequest
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "Token":
+			var err error
+			it.Token, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputSignInPasskeyRequest(ctx context.Context, obj interface{}) (model.SignInPasskeyRequest, error) {
+	var it model.SignInPasskeyRequest
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "Token":
+			var err error
+			it.Token, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "Credential":
+			var err error
+			it.Credential, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputSignInRequest(ctx context.Context, obj interface{}) (model.SignInRequest, error) {
 	var it model.SignInRequest
 	var asMap = obj.(map[string]interface{})
@@ -23233,6 +27174,12 @@ func (ec *executionContext) unmarshalInputSignInRequest(ctx context.Context, obj
 			if err != nil {
 				return it, err
 			}
+		case "Captcha":
+			var err error
+			it.Captcha, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -23331,7 +27278,25 @@ func (ec *executionContext) unmarshalInputSignUpRequest(ctx context.Context, obj
 			}
 		case "StreetName":
 			var err error
-			it.StreetName, err = ec.unmarshalNString2string(ctx, v)
+			it.StreetName, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputUnlockRequest(ctx context.Context, obj interface{}) (model.UnlockRequest, error) {
+	var it model.UnlockRequest
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "Token":
+			var err error
+			it.Token, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -24073,6 +28038,16 @@ func (ec *executionContext) _Info(ctx context.Context, sel ast.SelectionSet, obj
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "Reason":
+			out.Values[i] = ec._Info_Reason(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "Seq":
+			out.Values[i] = ec._Info_Seq(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -24219,6 +28194,78 @@ func (ec *executionContext) _Invoice(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
+var jobImplementors = []string{"Job"}
+
+func (ec *executionContext) _Job(ctx context.Context, sel ast.SelectionSet, obj *model.Job) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, jobImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Job")
+		case "JobID":
+			out.Values[i] = ec._Job_JobID(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "MarketID":
+			out.Values[i] = ec._Job_MarketID(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "Title":
+			out.Values[i] = ec._Job_Title(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "Interval":
+			out.Values[i] = ec._Job_Interval(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "Active":
+			out.Values[i] = ec._Job_Active(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "Timestamp":
+			out.Values[i] = ec._Job_Timestamp(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "LastRun":
+			out.Values[i] = ec._Job_LastRun(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "LastStatus":
+			out.Values[i] = ec._Job_LastStatus(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "LastError":
+			out.Values[i] = ec._Job_LastError(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "LastDuration":
+			out.Values[i] = ec._Job_LastDuration(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var leadImplementors = []string{"Lead"}
 
 func (ec *executionContext) _Lead(ctx context.Context, sel ast.SelectionSet, obj *model.Lead) graphql.Marshaler {
@@ -24792,6 +28839,26 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "SignInMFA":
+			out.Values[i] = ec._Mutation_SignInMFA(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "SignInPasskeyBegin":
+			out.Values[i] = ec._Mutation_SignInPasskeyBegin(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "SignInPasskey":
+			out.Values[i] = ec._Mutation_SignInPasskey(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "Unlock":
+			out.Values[i] = ec._Mutation_Unlock(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
 		case "SignUp":
 			out.Values[i] = ec._Mutation_SignUp(ctx, field)
 			if out.Values[i] == graphql.Null {
@@ -24832,6 +28899,16 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "OperatorJobUpdate":
+			out.Values[i] = ec._Mutation_OperatorJobUpdate(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "OperatorJobTrigger":
+			out.Values[i] = ec._Mutation_OperatorJobTrigger(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -25914,6 +29991,34 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				}
 				return res
 			})
+		case "InfoSince":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_InfoSince(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "OperatorJobList":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_OperatorJobList(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
 		case "__type":
 			out.Values[i] = ec._Query___type(ctx, field)
 		case "__schema":
@@ -25958,6 +30063,38 @@ func (ec *executionContext) _Result(ctx context.Context, sel ast.SelectionSet, o
 	return out
 }
 
+var signInPasskeyBeginResponseImplementors = []string{"SignInPasskeyBeginResponse"}
+
+func (ec *executionContext) _SignInPasskeyBeginResponse(ctx context.Context, sel ast.SelectionSet, obj *model.SignInPasskeyBeginResponse) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, signInPasskeyBeginResponseImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SignInPasskeyBeginResponse")
+		case "Status":
+			out.Values[i] = ec._SignInPasskeyBeginResponse_Status(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "Options":
+			out.Values[i] = ec._SignInPasskeyBeginResponse_Options(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var signInResponseImplementors = []string{"SignInResponse"}
 
 func (ec *executionContext) _SignInResponse(ctx context.Context, sel ast.SelectionSet, obj *model.SignInResponse) graphql.Marshaler {
@@ -25978,6 +30115,16 @@ func (ec *executionContext) _SignInResponse(ctx context.Context, sel ast.Selecti
 			out.Values[i] = ec._SignInResponse_Message(ctx, field, obj)
 		case "Token":
 			out.Values[i] = ec._SignInResponse_Token(ctx, field, obj)
+		case "MFAToken":
+			out.Values[i] = ec._SignInResponse_MFAToken(ctx, field, obj)
+		case "MFAMethods":
+			out.Values[i] = ec._SignInResponse_MFAMethods(ctx, field, obj)
+		case "Captcha":
+			out.Values[i] = ec._SignInResponse_Captcha(ctx, field, obj)
+		case "Locked":
+			out.Values[i] = ec._SignInResponse_Locked(ctx, field, obj)
+		case "RetryAfter":
+			out.Values[i] = ec._SignInResponse_RetryAfter(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -26043,6 +30190,10 @@ func (ec *executionContext) _TX(ctx context.Context, sel ast.SelectionSet, obj *
 			out.Values[i] = ec._TX_TimestampCreated(ctx, field, obj)
 		case "TimestampComplete":
 			out.Values[i] = ec._TX_TimestampComplete(ctx, field, obj)
+		case "Type":
+			out.Values[i] = ec._TX_Type(ctx, field, obj)
+		case "TransferID":
+			out.Values[i] = ec._TX_TransferID(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -26174,6 +30325,10 @@ func (ec *executionContext) _VerifyResponse(ctx context.Context, sel ast.Selecti
 			out.Values[i] = ec._VerifyResponse_Message(ctx, field, obj)
 		case "Token":
 			out.Values[i] = ec._VerifyResponse_Token(ctx, field, obj)
+		case "MFAToken":
+			out.Values[i] = ec._VerifyResponse_MFAToken(ctx, field, obj)
+		case "MFAMethods":
+			out.Values[i] = ec._VerifyResponse_MFAMethods(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -27027,6 +31182,43 @@ func (ec *executionContext) marshalNInfo2githubᚗcomᚋianidiᚋexchangeᚑserv
 	return ec._Info(ctx, sel, &v)
 }
 
+func (ec *executionContext) marshalNInfo2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInfoᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Info) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNInfo2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInfo(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
 func (ec *executionContext) marshalNInfo2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInfo(ctx context.Context, sel ast.SelectionSet, v *model.Info) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
@@ -27037,6 +31229,10 @@ func (ec *executionContext) marshalNInfo2ᚖgithubᚗcomᚋianidiᚋexchangeᚑs
 	return ec._Info(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNInfoSinceRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐInfoSinceRequest(ctx context.Context, v interface{}) (model.InfoSinceRequest, error) {
+	return ec.unmarshalInputInfoSinceRequest(ctx, v)
+}
+
 func (ec *executionContext) unmarshalNInt2int(ctx context.Context, v interface{}) (int, error) {
 	return graphql.UnmarshalInt(v)
 }
@@ -27200,6 +31396,57 @@ func (ec *executionContext) unmarshalNInvoiceSendToEmailRequest2githubᚗcomᚋi
 	return ec.unmarshalInputInvoiceSendToEmailRequest(ctx, v)
 }
 
+func (ec *executionContext) marshalNJob2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐJob(ctx context.Context, sel ast.SelectionSet, v model.Job) graphql.Marshaler {
+	return ec._Job(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNJob2ᚕᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐJobᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Job) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNJob2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐJob(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
+func (ec *executionContext) marshalNJob2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐJob(ctx context.Context, sel ast.SelectionSet, v *model.Job) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._Job(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNLead2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐLead(ctx context.Context, sel ast.SelectionSet, v model.Lead) graphql.Marshaler {
 	return ec._Lead(ctx, sel, &v)
 }
@@ -27653,6 +31900,10 @@ func (ec *executionContext) marshalNOfferSignResult2ᚖgithubᚗcomᚋianidiᚋe
 	return ec._OfferSignResult(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNOperatorJobUpdateRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐOperatorJobUpdateRequest(ctx context.Context, v interface{}) (model.OperatorJobUpdateRequest, error) {
+	return ec.unmarshalInputOperatorJobUpdateRequest(ctx, v)
+}
+
 func (ec *executionContext) unmarshalNPhoneVerifyRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐPhoneVerifyRequest(ctx context.Context, v interface{}) (model.PhoneVerifyRequest, error) {
 	return ec.unmarshalInputPhoneVerifyRequest(ctx, v)
 }
@@ -27697,6 +31948,32 @@ func (ec *executionContext) marshalNResult2ᚖgithubᚗcomᚋianidiᚋexchange
 	return ec._Result(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNSignInMFARequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInMFARequest(ctx context.Context, v interface{}) (model.SignInMFARequest, error) {
+	return ec.unmarshalInputSignInMFARequest(ctx, v)
+}
+
+func (ec *executionContext) unmarshalNSignInPasskeyBeginRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInPasskeyBeginRequest(ctx context.Context, v interface{}) (model.SignInPasskeyBeginRequest, error) {
+	return ec.unmarshalInputSignInPasskeyBeginRequest(ctx, v)
+}
+
+func (ec *executionContext) marshalNSignInPasskeyBeginResponse2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInPasskeyBeginResponse(ctx context.Context, sel ast.SelectionSet, v model.SignInPasskeyBeginResponse) graphql.Marshaler {
+	return ec._SignInPasskeyBeginResponse(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNSignInPasskeyBeginResponse2ᚖgithubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInPasskeyBeginResponse(ctx context.Context, sel ast.SelectionSet, v *model.SignInPasskeyBeginResponse) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._SignInPasskeyBeginResponse(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNSignInPasskeyRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInPasskeyRequest(ctx context.Context, v interface{}) (model.SignInPasskeyRequest, error) {
+	return ec.unmarshalInputSignInPasskeyRequest(ctx, v)
+}
+
 func (ec *executionContext) unmarshalNSignInRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐSignInRequest(ctx context.Context, v interface{}) (model.SignInRequest, error) {
 	return ec.unmarshalInputSignInRequest(ctx, v)
 }
@@ -27784,6 +32061,10 @@ func (ec *executionContext) marshalNTX2ᚖgithubᚗcomᚋianidiᚋexchangeᚑser
 	return ec._TX(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNUnlockRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐUnlockRequest(ctx context.Context, v interface{}) (model.UnlockRequest, error) {
+	return ec.unmarshalInputUnlockRequest(ctx, v)
+}
+
 func (ec *executionContext) unmarshalNValidateFieldRequest2githubᚗcomᚋianidiᚋexchangeᚑserverᚋgraphᚋmodelᚐValidateFieldRequest(ctx context.Context, v interface{}) (model.ValidateFieldRequest, error) {
 	return ec.unmarshalInputValidateFieldRequest(ctx, v)
 }
@@ -28279,6 +32560,38 @@ func (ec *executionContext) marshalOString2string(ctx context.Context, sel ast.S
 	return graphql.MarshalString(v)
 }
 
+func (ec *executionContext) unmarshalOString2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOString2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
 	if v == nil {
 		return nil, nil
@@ -13,6 +13,7 @@ import (
 	"github.com/ianidi/exchange-server/internal/db"
 	"github.com/ianidi/exchange-server/internal/jwt"
 	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/rbac"
 	"github.com/jackc/pgtype"
 	"github.com/jmoiron/sqlx"
 	"github.com/spf13/cast"
@@ -62,7 +63,7 @@ func (manager *Manager) GetMember() error {
 
 	tokenString := jwt.ExtractToken(manager.c.Request)
 
-	metadata, err := h.TK.TokenMetadata(tokenString)
+	metadata, err := h.Authorize(tokenString)
 	if err != nil {
 		return err
 	}
@@ -462,6 +463,8 @@ func (manager *Manager) RemoveManager(input model.RecordRequest) (*model.Result,
 
 	tx := db.MustBegin()
 	tx.MustExec("UPDATE Member SET ManagerRole=$1 WHERE MemberID=$2", "", input.RecordID)
+	rbac.Unassign(tx, int64(input.RecordID), rbac.ROLE_MANAGER)
+	rbac.Unassign(tx, int64(input.RecordID), rbac.ROLE_CRM_ADMIN)
 	tx.Commit()
 
 	return &model.Result{
@@ -503,6 +506,7 @@ func (manager *Manager) AssignManager(input model.ManagerAssignManagerRequest) (
 
 	tx := db.MustBegin()
 	tx.MustExec("UPDATE Member SET ManagerRole=$1 WHERE MemberID=$2", "manager", input.MemberID)
+	rbac.Assign(tx, int64(input.MemberID), rbac.ROLE_MANAGER)
 	tx.Commit()
 
 	return &model.Result{
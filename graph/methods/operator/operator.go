@@ -0,0 +1,130 @@
+package operator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/graph/model"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/job"
+	"github.com/ianidi/exchange-server/internal/jwt"
+	"github.com/ianidi/exchange-server/internal/mfa"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+type Operator struct {
+	c         *gin.Context
+	Ctx       context.Context
+	Timestamp int64 //Current UNIX timestamp
+	Member    models.Member
+}
+
+func (operator *Operator) GinContextFromContext() error {
+	ginContext := operator.Ctx.Value("GinContextKey")
+	if ginContext == nil {
+		err := fmt.Errorf("could not retrieve gin.Context")
+		return err
+	}
+
+	gc, ok := ginContext.(*gin.Context)
+	if !ok {
+		err := fmt.Errorf("gin.Context has wrong type")
+		return err
+	}
+
+	operator.c = gc
+
+	return nil
+}
+
+//GetMember loads the sender, only operators and admins are allowed
+func (operator *Operator) GetMember() error {
+
+	db := db.GetDB()
+
+	var err error
+
+	err = operator.GinContextFromContext()
+	if err != nil {
+		return err
+	}
+
+	h := jwt.Service
+
+	tokenString := jwt.ExtractToken(operator.c.Request)
+
+	metadata, err := h.Authorize(tokenString)
+	if err != nil {
+		return err
+	}
+
+	MemberID := metadata.UserId
+
+	var member models.Member
+
+	if err := db.Get(&member, "SELECT * FROM Member WHERE MemberID=$1", MemberID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("NO_MEMBER_RECORD")
+		}
+		return err
+	}
+
+	//2 - operator, 3 - admin
+	if member.Role != 2 && member.Role != 3 {
+		return errors.New("ACCESS_DENIED")
+	}
+
+	//Operators sign in with MFA once it is required in settings
+	required, err := mfa.RequiredForRole(member.Role)
+	if err != nil {
+		return err
+	}
+
+	if required && !member.MFAEnabled {
+		return errors.New("MFA_ENROLLMENT_REQUIRED")
+	}
+
+	operator.Member = member
+
+	return nil
+}
+
+//JobList market jobs with last run status
+func (operator *Operator) JobList() ([]*model.Job, error) {
+	var result []*model.Job
+
+	rows, err := job.QueryJobs()
+	if err != nil {
+		return result, err
+	}
+
+	for _, row := range rows {
+		result = append(result, &model.Job{
+			JobID:        int(row.JobID),
+			MarketID:     int(row.MarketID),
+			Title:        row.Title,
+			Interval:     int(row.Interval),
+			Active:       row.Active,
+			Timestamp:    int(row.Timestamp),
+			LastRun:      int(row.LastRun),
+			LastStatus:   row.LastStatus,
+			LastError:    row.LastError,
+			LastDuration: int(row.LastDuration),
+		})
+	}
+
+	return result, nil
+}
+
+//JobUpdate enable or disable market job and change its interval
+func (operator *Operator) JobUpdate(input model.OperatorJobUpdateRequest) error {
+	return job.UpdateJob(operator.Member.MemberID, int64(input.JobID), int64(input.Interval), input.Active)
+}
+
+//JobTrigger run market job right away
+func (operator *Operator) JobTrigger(input model.RecordRequest) error {
+	return job.TriggerJob(operator.Member.MemberID, int64(input.RecordID))
+}
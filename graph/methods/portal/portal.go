@@ -8,7 +8,7 @@ import (
 	"crypto/sha512"
 	"database/sql"
 	"encoding/base64"
-	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"hash"
@@ -25,13 +25,16 @@ import (
 	"github.com/ianidi/exchange-server/graph/model"
 	"github.com/ianidi/exchange-server/internal/db"
 	"github.com/ianidi/exchange-server/internal/jwt"
+	"github.com/ianidi/exchange-server/internal/lockout"
+	"github.com/ianidi/exchange-server/internal/mfa"
 	"github.com/ianidi/exchange-server/internal/models"
 	"github.com/ianidi/exchange-server/internal/utils"
+	"github.com/ianidi/exchange-server/internal/verify"
+	"github.com/ianidi/exchange-server/internal/webauthn"
 	"github.com/jackc/pgtype"
 	"github.com/jmoiron/sqlx"
 	"github.com/muesli/crunchy"
 	"github.com/nyaruka/phonenumbers"
-	"github.com/sethvargo/go-password/password"
 	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 	"golang.org/x/crypto/bcrypt"
@@ -53,17 +56,12 @@ type Portal struct {
 
 	Verify models.Verify
 
-	Action           string
-	Method           string
-	Phone            string //Phone number
-	Email            string //Email address
-	EmailHash        string //Email address md5 hash
-	Password         string //Current (auth) or new (reset) member password
-	PasswordHash     string //Password hash
-	Code             string //OTP code
-	CodeHash         string //OTP code hash
-	ConfirmationLink string //Email confirmation link
-	Timeout          int64  //How frequently in seconds OTP codes can be requested
+	Action       string //Purpose of verification code, see internal/verify
+	Method       string //Channel of verification code
+	Phone        string //Phone number
+	Email        string //Email address
+	Password     string //Current (auth) or new (reset) member password
+	PasswordHash string //Password hash
 
 	InvoiceLink string //Invoice link for payment
 
@@ -114,7 +112,7 @@ func (portal *Portal) GetMember() error {
 
 	tokenString := jwt.ExtractToken(portal.c.Request)
 
-	metadata, err := h.TK.TokenMetadata(tokenString)
+	metadata, err := h.Authorize(tokenString)
 	if err != nil {
 		return err
 	}
@@ -261,14 +259,10 @@ func (portal *Portal) GetMemberIDFromToken(token string) (string, error) {
 
 	h := jwt.Service
 
-	metadata, err := h.TK.TokenMetadata(token)
+	metadata, err := h.Authorize(token)
 	if err != nil {
 		return "", err
 	}
-	// userId, err := h.RD.FetchAuth(metadata.TokenUuid)
-	// if err != nil {
-	// 	return "", err
-	// }
 
 	MemberID := metadata.UserId
 
@@ -474,6 +468,8 @@ func (portal *Portal) QueryTXList() ([]*model.Tx, error) {
 		DateComplete := txRow.DateComplete.Time.String()
 		TimestampCreated := cast.ToInt(txRow.TimestampCreated)
 		TimestampComplete := cast.ToInt(txRow.TimestampComplete)
+		Type := txRow.Type
+		TransferID := cast.ToInt(txRow.TransferID)
 
 		resRow := &model.Tx{
 			Txid:              TXID,
@@ -486,6 +482,8 @@ func (portal *Portal) QueryTXList() ([]*model.Tx, error) {
 			DateComplete:      &DateComplete,
 			TimestampCreated:  &TimestampCreated,
 			TimestampComplete: &TimestampComplete,
+			Type:              &Type,
+			TransferID:        &TransferID,
 		}
 
 		res = append(res, resRow)
@@ -1215,18 +1213,6 @@ func (portal *Portal) CreateDeal(Amount string, Duration string) (*model.Result,
 	}, nil
 }
 
-//Generate confirmation code
-func (portal *Portal) GenerateOTP() error {
-	code, err := password.Generate(6, 6, 0, true, false)
-	if err != nil {
-		return err
-	}
-
-	portal.Code = code
-
-	return nil
-}
-
 //Hash using bcrypt
 func (portal *Portal) HashBcrypt(str string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(str), 12)
@@ -1237,12 +1223,6 @@ func (portal *Portal) HashBcrypt(str string) (string, error) {
 	return cast.ToString(hash), nil
 }
 
-//Hash using md5
-func (portal *Portal) HashMD5(str string) (string, error) {
-	hash := md5.Sum([]byte(str))
-	return hex.EncodeToString(hash[:]), nil
-}
-
 //Parse phone number
 func (portal *Portal) ParsePhone(phone string) error {
 
@@ -1352,91 +1332,30 @@ func (portal *Portal) CheckEmailInUse() error {
 	return nil
 }
 
-//Find verification code sent in the last timeout value, in case found return time left until new code request
-func (portal *Portal) QueryVerifyTimeout() (int64, error) {
-	db := db.GetDB()
-
-	var verify models.Verify
-
-	if err := db.Get(&verify, "SELECT * FROM Verify WHERE MemberID=$1 AND Action=$2 AND Method=$3 AND Status=$4", portal.Member.MemberID, portal.Action, portal.Method, constants.STATUS_PENDING); err != nil {
-		if err != sql.ErrNoRows {
-			return 0, err
-		}
-	}
-
-	//Not enough time passed after the last code request
-	if verify.Created > portal.Timestamp-portal.Timeout {
-		return portal.Timeout - portal.Timestamp + verify.Created, errors.New("CODE_TIMEOUT")
-	}
-
-	return portal.Timeout, nil
-
-}
-
+//CreateVerify sends verification code of portal action, returns seconds until the next code can be requested
 func (portal *Portal) CreateVerify() (int, error) {
-	db := db.GetDB()
-
-	var err error
-	var Timeout int64
-
-	err = portal.GinContextFromContext()
+	err := portal.GinContextFromContext()
 	if err != nil {
 		return 0, err
 	}
 
-	if portal.Action == constants.ACTION_SIGN_CONTRACT || portal.Action == constants.ACTION_SIGNUP {
-		Timeout, err = portal.QueryVerifyTimeout()
-		if err != nil {
-			return cast.ToInt(Timeout), err
-		}
-	}
-
-	err = portal.GenerateOTP()
-	if err != nil {
-		return 0, err
+	request := verify.Request{
+		Purpose:  portal.Action,
+		Channel:  portal.Method,
+		MemberID: portal.Member.MemberID,
+		Email:    portal.Email,
+		Phone:    portal.Member.Phone,
+		IP:       portal.c.ClientIP(),
 	}
 
-	portal.CodeHash, err = portal.HashBcrypt(portal.Code)
-	if err != nil {
-		return 0, err
-	}
-
-	if portal.Action == constants.ACTION_SIGN_CONTRACT {
-		tx := db.MustBegin()
-		tx.MustExec("UPDATE Verify SET Status=$1 WHERE MemberID=$2 AND Action=$3 AND Method=$4 AND Status=$5", constants.STATUS_CANCELLED, portal.Member.MemberID, portal.Action, portal.Method, constants.STATUS_PENDING)
-		tx.MustExec("INSERT INTO Verify (MemberID, Action, Method, CodeHash, Phone, Status, IP, Created) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", portal.Member.MemberID, portal.Action, portal.Method, portal.CodeHash, portal.Phone, constants.STATUS_PENDING, portal.c.ClientIP(), portal.Timestamp)
-		tx.Commit()
-
-		// err = otp.SendSMS(portal.Phone, fmt.Sprintf("Your code is %s", portal.Code))
-		// if err != nil {
-		// 	return nil, err
-		// }
-		fmt.Println(portal.Code)
-	}
-
-	portal.EmailHash, err = portal.HashMD5(portal.Email)
-	if err != nil {
-		return 0, err
-	}
-
-	if portal.Action == constants.ACTION_SIGNUP && portal.Method == constants.METHOD_EMAIL {
-
-		tx := db.MustBegin()
-		tx.MustExec("UPDATE Verify SET Status=$1 WHERE Action=$2 AND Method=$3 AND Email=$4 AND Status=$5", constants.STATUS_CANCELLED, portal.Action, portal.Method, portal.Email, constants.STATUS_PENDING)
-		tx.MustExec("INSERT INTO Verify (MemberID, Action, Method, CodeHash, Email, EmailHash, Status, IP, Created) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", portal.Member.MemberID, portal.Action, portal.Method, portal.CodeHash, portal.Email, portal.EmailHash, constants.STATUS_PENDING, portal.c.ClientIP(), portal.Timestamp)
-		tx.Commit()
-	}
-
-	if portal.Action == constants.ACTION_RESET && portal.Method == constants.METHOD_EMAIL {
-
-		tx := db.MustBegin()
-		tx.MustExec("UPDATE Verify SET Status=$1 WHERE MemberID=$2 AND Action=$3 AND Method=$4 AND Status=$5", constants.STATUS_CANCELLED, portal.Member.MemberID, constants.ACTION_RESET, constants.METHOD_EMAIL, constants.STATUS_PENDING)
-		tx.MustExec("INSERT INTO Verify (MemberID, Action, Method, CodeHash, Email, EmailHash, Status, IP, Created) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", portal.Member.MemberID, portal.Action, portal.Method, portal.CodeHash, portal.Email, portal.EmailHash, constants.STATUS_PENDING, portal.c.ClientIP(), portal.Timestamp)
-		tx.Commit()
+	//Sign up and reset codes are sent as confirmation link
+	if portal.Method == constants.METHOD_EMAIL {
+		request.Link = "verify/" + portal.Action + "/" + portal.Method + "/"
 	}
 
-	return cast.ToInt(Timeout), nil
+	Timeout, err := verify.Send(request)
 
+	return cast.ToInt(Timeout), err
 }
 
 //Validate password
@@ -1480,26 +1399,14 @@ func (portal *Portal) ValidatePassword(password string, validateStrength bool) e
 	return nil
 }
 
-//Query verify by md5 hash (email)
-func (portal *Portal) QueryVerifyByHash(Hash string) error {
-	db := db.GetDB()
-
-	var verify models.Verify
-
-	//Find verify request
-	if err := db.Get(&verify, "SELECT * FROM Verify WHERE EmailHash=$1 AND Action=$2 AND Method=$3 AND Status=$4", Hash, portal.Action, portal.Method, constants.STATUS_PENDING); err != nil {
-		if err == sql.ErrNoRows {
-			return errors.New("INVALID_LINK")
-		}
+//CheckVerifyLink checks code of email confirmation link
+func (portal *Portal) CheckVerifyLink(Hash string, Code string) error {
+	request, err := verify.CheckLink(portal.Action, Hash, Code)
+	if err != nil {
 		return err
 	}
 
-	//Request is expired (day)
-	if verify.Created < portal.Timestamp-86400 {
-		return errors.New("EXPIRED_REQUEST")
-	}
-
-	portal.Verify = verify
+	portal.Verify = request
 
 	return nil
 }
@@ -1548,63 +1455,14 @@ func (portal *Portal) ValidateResetMethod(Method string) error {
 	return nil
 }
 
-//Query verify by md5 hash (email)
-func (portal *Portal) QueryVerifyByOfferID(OfferID int) error {
-	db := db.GetDB()
-
-	var deal models.Deal
-
-	//Find deal record
-	if err := db.Get(&deal, "SELECT * FROM Deal WHERE OfferID=$1 AND MemberID=$2 AND Status=$3", OfferID, portal.Member.MemberID, constants.STATUS_PENDING); err != nil {
-		if err == sql.ErrNoRows {
-			return errors.New("NO_DEAL_RECORD")
-		}
-		return err
-	}
-
-	portal.Deal = deal
-
-	var verify models.Verify
-
-	//Find verify request
-	if err := db.Get(&verify, "SELECT * FROM Verify WHERE MemberID=$1 AND Action=$2 AND Method=$3 AND Status=$4", portal.Member.MemberID, portal.Action, portal.Method, constants.STATUS_PENDING); err != nil {
-		if err == sql.ErrNoRows {
-			return errors.New("NO_VERIFY_RECORD")
-		}
+//CheckOfferVerify checks SMS code of contract signature, deal is queried with QueryDealByOfferID
+func (portal *Portal) CheckOfferVerify(Code string) error {
+	request, err := verify.Check(portal.Action, portal.Method, portal.Member.MemberID, Code)
+	if err != nil {
 		return err
 	}
 
-	//Request is expired (day)
-	if verify.Created < portal.Timestamp-86400 {
-		return errors.New("EXPIRED_REQUEST")
-	}
-
-	portal.Verify = verify
-	return nil
-}
-
-//Validate OTP code
-func (portal *Portal) ValidateOTPCode(code string) error {
-	db := db.GetDB()
-
-	if err := bcrypt.CompareHashAndPassword([]byte(portal.Verify.CodeHash), []byte(code)); err != nil {
-
-		tx := db.MustBegin()
-		tx.MustExec("UPDATE Verify SET Attempts=Attempts+$1 WHERE VerifyID=$2", 1, portal.Verify.VerifyID)
-		tx.Commit()
-
-		//Verify attempts exceeded
-		if portal.Verify.Attempts >= 3 {
-
-			tx := db.MustBegin()
-			tx.MustExec("UPDATE Verify SET Status=$1 WHERE VerifyID=$2", constants.STATUS_FAIL, portal.Verify.VerifyID)
-			tx.Commit()
-
-			return errors.New("TOO_MANY_ATTEMPTS")
-		}
-
-		return errors.New("INVALID_CODE")
-	}
+	portal.Verify = request
 
 	return nil
 }
@@ -1639,10 +1497,8 @@ func (portal *Portal) CheckMemberStatusIsDisabled() error {
 //Validate password
 func (portal *Portal) CheckPassword() error {
 
+	//Failed attempts are limited by internal/lockout
 	if err := bcrypt.CompareHashAndPassword([]byte(portal.Member.PasswordHash), []byte(portal.Password)); err != nil {
-
-		//TODO: bruteforce protection
-
 		return errors.New("INVALID_PASSWORD")
 	}
 
@@ -1665,20 +1521,6 @@ func (portal *Portal) QuerySettings() error {
 	return nil
 }
 
-//Generate email confirmation link
-func (portal *Portal) GenerateConfirmationLink() error {
-	var err error
-
-	err = portal.QuerySettings()
-	if err != nil {
-		return err
-	}
-
-	portal.ConfirmationLink = portal.Settings.PlatformURL + "verify/" + portal.Action + "/" + portal.Method + "/" + portal.EmailHash + "/" + portal.Code
-
-	return nil
-}
-
 //Generate invoice link
 func (portal *Portal) GenerateInvoiceLink() error {
 	var err error
@@ -1895,21 +1737,165 @@ func (portal *Portal) CreateMember() error {
 func (portal *Portal) GenerateAuthorizationToken(MemberID int64) (string, error) {
 	var err error
 
-	//Create new pairs of refresh and access tokens
-	ts, err := portal.ProfileHandler.TK.CreateToken(cast.ToString(MemberID))
+	err = portal.GinContextFromContext()
 	if err != nil {
 		return "", err
 	}
 
-	//Save the tokens metadata to redis
-	// err = portal.ProfileHandler.RD.CreateAuth(cast.ToString(MemberID), ts)
-	// if err != nil {
-	// 	return "", err
-	// }
+	//Create new session with pair of refresh and access tokens
+	ts, err := portal.ProfileHandler.CreateSession(cast.ToString(MemberID), portal.c.GetHeader(jwt.DeviceHeaderKey), portal.c.ClientIP(), portal.c.Request.UserAgent())
+	if err != nil {
+		return "", err
+	}
 
 	return ts.AccessToken, nil
 }
 
+//GenerateSignInToken returns authorization token, or mfa_pending token and methods of the second step of member with MFA enabled
+func (portal *Portal) GenerateSignInToken(MemberID int64) (*string, *string, []string, error) {
+	MFAToken, methods, err := portal.ProfileHandler.SigninToken(MemberID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if MFAToken != "" {
+		return nil, &MFAToken, methods, nil
+	}
+
+	Token, err := portal.GenerateAuthorizationToken(MemberID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return &Token, nil, nil, nil
+}
+
+//SignInAttempt returns sign in attempt of request for brute force protection
+func (portal *Portal) SignInAttempt(captcha *string) (lockout.Attempt, error) {
+	var attempt lockout.Attempt
+
+	err := portal.GinContextFromContext()
+	if err != nil {
+		return attempt, err
+	}
+
+	attempt = lockout.Attempt{
+		Email:     portal.Email,
+		IP:        portal.c.ClientIP(),
+		UserAgent: portal.c.Request.UserAgent(),
+	}
+
+	if captcha != nil {
+		attempt.Captcha = *captcha
+	}
+
+	return attempt, nil
+}
+
+//SignInRejected returns response of rejected sign in with flags for client
+func (portal *Portal) SignInRejected(err error) (*model.SignInResponse, error) {
+	e, ok := err.(*lockout.Error)
+	if !ok {
+		return nil, err
+	}
+
+	RetryAfter := int(e.Status.RetryAfter)
+
+	return &model.SignInResponse{
+		Status:     false,
+		Message:    &e.Message,
+		Captcha:    &e.Status.Captcha,
+		Locked:     &e.Status.Locked,
+		RetryAfter: &RetryAfter,
+	}, nil
+}
+
+//Unlock unlocks account with token of unlock link
+func (portal *Portal) Unlock(token string) error {
+	err := portal.GinContextFromContext()
+	if err != nil {
+		return err
+	}
+
+	return lockout.Unlock(token, portal.c.ClientIP(), portal.c.Request.UserAgent())
+}
+
+//SignInMFA exchanges mfa_pending token and TOTP or recovery code for authorization token
+func (portal *Portal) SignInMFA(token string, code string) (string, error) {
+	MemberID, mfaUuid, err := portal.ProfileHandler.VerifyPendingToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	err = mfa.Verify(cast.ToInt64(MemberID), code)
+	if err != nil {
+		return "", err
+	}
+
+	err = portal.ProfileHandler.DeletePendingToken(mfaUuid)
+	if err != nil {
+		return "", err
+	}
+
+	return portal.GenerateAuthorizationToken(cast.ToInt64(MemberID))
+}
+
+//SignInPasskeyBegin returns JSON options of navigator.credentials.get() for the second sign in step with passkey
+func (portal *Portal) SignInPasskeyBegin(token string) (string, error) {
+	MemberID, _, err := portal.ProfileHandler.VerifyPendingToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	options, err := webauthn.LoginOptions(webauthn.CEREMONY_MFA, cast.ToInt64(MemberID))
+	if err != nil {
+		return "", err
+	}
+
+	result, err := json.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
+//SignInPasskey exchanges mfa_pending token and JSON of PublicKeyCredential for authorization token
+func (portal *Portal) SignInPasskey(token string, credential string) (string, error) {
+	MemberID, mfaUuid, err := portal.ProfileHandler.VerifyPendingToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	var value webauthn.Credential
+
+	if err := json.Unmarshal([]byte(credential), &value); err != nil {
+		return "", errors.New("WEBAUTHN_INVALID_ENCODING")
+	}
+
+	if _, err := webauthn.FinishLogin(webauthn.CEREMONY_MFA, cast.ToInt64(MemberID), value); err != nil {
+		return "", err
+	}
+
+	err = portal.ProfileHandler.DeletePendingToken(mfaUuid)
+	if err != nil {
+		return "", err
+	}
+
+	return portal.GenerateAuthorizationToken(cast.ToInt64(MemberID))
+}
+
+//MFAStepUp verifies authenticator app code of purpose before sensitive action of member with MFA enabled
+func (portal *Portal) MFAStepUp(purpose string, code *string) error {
+	var MFACode string
+
+	if code != nil {
+		MFACode = *code
+	}
+
+	return verify.StepUp(purpose, portal.Member, MFACode)
+}
+
 func (portal *Portal) VerifyConfirm() error {
 	db := db.GetDB()
 
@@ -1917,16 +1903,31 @@ func (portal *Portal) VerifyConfirm() error {
 	if portal.Action == constants.ACTION_RESET {
 		tx := db.MustBegin()
 		tx.MustExec("UPDATE Member SET PasswordHash=$1 WHERE MemberID=$2", portal.PasswordHash, portal.Verify.MemberID)
-		tx.MustExec("UPDATE Verify SET Status=$1 WHERE VerifyID=$2", constants.STATUS_SUCCESS, portal.Verify.VerifyID)
-		tx.Commit()
+		if err := verify.Complete(tx, portal.Verify.VerifyID); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		//Password was reset, sign out all devices
+		if err := portal.ProfileHandler.RD.DeleteSessions(cast.ToString(portal.Verify.MemberID)); err != nil {
+			return err
+		}
 	}
 
 	//Confirm member email after visiting sign up confirmation link
 	if portal.Action == constants.ACTION_SIGNUP {
 		tx := db.MustBegin()
 		tx.MustExec("UPDATE Member SET Status=$1 WHERE MemberID=$2", constants.STATUS_ACTIVE, portal.Verify.MemberID)
-		tx.MustExec("UPDATE Verify SET Status=$1 WHERE VerifyID=$2", constants.STATUS_SUCCESS, portal.Verify.VerifyID)
-		tx.Commit()
+		if err := verify.Complete(tx, portal.Verify.VerifyID); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -1937,10 +1938,12 @@ func (portal *Portal) OfferPhoneVerify(Code string) error {
 
 	tx := db.MustBegin()
 	tx.MustExec("UPDATE Deal SET VerificationCode=$1, Status=$2, DateVerified=CURRENT_TIMESTAMP WHERE DealID=$3", Code, constants.STATUS_SIGNED, portal.Deal.DealID)
-	tx.MustExec("UPDATE Verify SET Status=$1 WHERE VerifyID=$2", constants.STATUS_SUCCESS, portal.Verify.VerifyID)
-	tx.Commit()
+	if err := verify.Complete(tx, portal.Verify.VerifyID); err != nil {
+		tx.Rollback()
+		return err
+	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (portal *Portal) CreateInvoice() error {
@@ -163,6 +163,13 @@ type Info struct {
 	Change        string `json:"Change"`
 	Sentiment     int    `json:"Sentiment"`
 	SentimentType string `json:"SentimentType"`
+	Reason        string `json:"Reason"`
+	Seq           int    `json:"Seq"`
+}
+
+type InfoSinceRequest struct {
+	Seq       int `json:"Seq"`
+	SharedSeq int `json:"SharedSeq"`
 }
 
 type Interest struct {
@@ -209,6 +216,19 @@ type InvoiceSendToEmailRequest struct {
 	Email     string `json:"Email"`
 }
 
+type Job struct {
+	JobID        int    `json:"JobID"`
+	MarketID     int    `json:"MarketID"`
+	Title        string `json:"Title"`
+	Interval     int    `json:"Interval"`
+	Active       bool   `json:"Active"`
+	Timestamp    int    `json:"Timestamp"`
+	LastRun      int    `json:"LastRun"`
+	LastStatus   string `json:"LastStatus"`
+	LastError    string `json:"LastError"`
+	LastDuration int    `json:"LastDuration"`
+}
+
 type Lead struct {
 	LeadID           int     `json:"LeadID"`
 	ManagerID        *int    `json:"ManagerID"`
@@ -583,7 +603,8 @@ type Member struct {
 }
 
 type MemberEmailUpdateRequest struct {
-	Email string `json:"Email"`
+	Email   string  `json:"Email"`
+	MFACode *string `json:"MFACode"`
 }
 
 type MemberPersonalUpdateRequest struct {
@@ -639,6 +660,12 @@ type OfferSignResult struct {
 	Timeout *int    `json:"Timeout"`
 }
 
+type OperatorJobUpdateRequest struct {
+	JobID    int  `json:"JobID"`
+	Interval int  `json:"Interval"`
+	Active   bool `json:"Active"`
+}
+
 type PhoneVerifyRequest struct {
 	Code   string `json:"Code"`
 	Action string `json:"Action"`
@@ -673,15 +700,40 @@ type SearchRequest struct {
 	Query string `json:"Query"`
 }
 
+type SignInMFARequest struct {
+	Token string `json:"Token"`
+	Code  string `json:"Code"`
+}
+
+type SignInPasskeyBeginRequest struct {
+	Token string `json:"Token"`
+}
+
+type SignInPasskeyBeginResponse struct {
+	Status  bool   `json:"Status"`
+	Options string `json:"Options"`
+}
+
+type SignInPasskeyRequest struct {
+	Token      string `json:"Token"`
+	Credential string `json:"Credential"`
+}
+
 type SignInRequest struct {
-	Email    string `json:"Email"`
-	Password string `json:"Password"`
+	Email    string  `json:"Email"`
+	Password string  `json:"Password"`
+	Captcha  *string `json:"Captcha"`
 }
 
 type SignInResponse struct {
-	Status  bool    `json:"Status"`
-	Message *string `json:"Message"`
-	Token   *string `json:"Token"`
+	Status     bool     `json:"Status"`
+	Message    *string  `json:"Message"`
+	Token      *string  `json:"Token"`
+	MFAToken   *string  `json:"MFAToken"`
+	MFAMethods []string `json:"MFAMethods"`
+	Captcha    *bool    `json:"Captcha"`
+	Locked     *bool    `json:"Locked"`
+	RetryAfter *int     `json:"RetryAfter"`
 }
 
 type SignUpRequest struct {
@@ -713,6 +765,12 @@ type Tx struct {
 	DateComplete      *string `json:"DateComplete"`
 	TimestampCreated  *int    `json:"TimestampCreated"`
 	TimestampComplete *int    `json:"TimestampComplete"`
+	Type              *string `json:"Type"`
+	TransferID        *int    `json:"TransferID"`
+}
+
+type UnlockRequest struct {
+	Token string `json:"Token"`
 }
 
 type Upload struct {
@@ -761,9 +819,11 @@ type VerifyResendResponse struct {
 }
 
 type VerifyResponse struct {
-	Status  bool    `json:"Status"`
-	Message *string `json:"Message"`
-	Token   *string `json:"Token"`
+	Status     bool     `json:"Status"`
+	Message    *string  `json:"Message"`
+	Token      *string  `json:"Token"`
+	MFAToken   *string  `json:"MFAToken"`
+	MFAMethods []string `json:"MFAMethods"`
 }
 
 type FamilyStatus string
@@ -15,13 +15,16 @@ import (
 	"github.com/ianidi/exchange-server/graph/generated"
 	"github.com/ianidi/exchange-server/graph/methods/constants"
 	"github.com/ianidi/exchange-server/graph/methods/manager"
+	"github.com/ianidi/exchange-server/graph/methods/operator"
 	"github.com/ianidi/exchange-server/graph/methods/portal"
 	"github.com/ianidi/exchange-server/graph/model"
 	"github.com/ianidi/exchange-server/internal/db"
-	"github.com/ianidi/exchange-server/internal/mail"
+	"github.com/ianidi/exchange-server/internal/outbox"
 	"github.com/ianidi/exchange-server/internal/redis"
 	"github.com/ianidi/exchange-server/internal/s3"
+	"github.com/ianidi/exchange-server/internal/task"
 	"github.com/ianidi/exchange-server/internal/utils"
+	"github.com/ianidi/exchange-server/internal/verify"
 	"github.com/spf13/cast"
 )
 
@@ -31,7 +34,6 @@ func (r *mutationResolver) MemberPersonalUpdate(ctx context.Context, input model
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 	}
 
 	err = portal.GetMember()
@@ -65,7 +67,6 @@ func (r *mutationResolver) MemberPhoneUpdate(ctx context.Context, input model.Me
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 	}
 
 	err = portal.GetMember()
@@ -100,7 +101,6 @@ func (r *mutationResolver) MemberEmailUpdate(ctx context.Context, input model.Me
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 	}
 
 	err = portal.GetMember()
@@ -113,6 +113,11 @@ func (r *mutationResolver) MemberEmailUpdate(ctx context.Context, input model.Me
 		return nil, err
 	}
 
+	err = portal.MFAStepUp(verify.PURPOSE_EMAIL_CHANGE, input.MFACode)
+	if err != nil {
+		return nil, err
+	}
+
 	//TODO:
 	// err = portal.CheckEmailInUse()
 	// if err != nil {
@@ -1088,7 +1093,6 @@ func (r *mutationResolver) OfferSign(ctx context.Context, input model.OfferSignR
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 		Action:    constants.ACTION_SIGN_CONTRACT,
 		Method:    constants.METHOD_PHONE,
 	}
@@ -1203,7 +1207,6 @@ func (r *mutationResolver) InvoiceSendToEmail(ctx context.Context, input model.I
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 		Action:    constants.ACTION_SIGN_CONTRACT,
 		Method:    constants.METHOD_PHONE,
 	}
@@ -1242,7 +1245,9 @@ func (r *mutationResolver) InvoiceSendToEmail(ctx context.Context, input model.I
 		return nil, err
 	}
 
-	mail.SendMail(portal.Member.Email, "Invoice #"+cast.ToString(portal.Invoice.InvoiceID), "Dear customer,", "Please pay your invoice by clicking on the button below.", "View invoice", portal.InvoiceLink, "Thank you for using our platform.")
+	if _, err := task.Enqueue(task.Email{Email: portal.Member.Email, Subject: "Invoice #" + cast.ToString(portal.Invoice.InvoiceID), Title: "Dear customer,", Content: "Please pay your invoice by clicking on the button below.", Button: "View invoice", Link: portal.InvoiceLink, Description: "Thank you for using our platform."}, ""); err != nil {
+		return nil, err
+	}
 
 	return &model.Result{
 		Status: true,
@@ -1256,7 +1261,6 @@ func (r *mutationResolver) SignIn(ctx context.Context, input model.SignInRequest
 		Ctx:            ctx,
 		ProfileHandler: r.ProfileHandler,
 		Timestamp:      time.Now().Unix(),
-		Timeout:        60,
 		Action:         constants.ACTION_SIGNIN,
 		Method:         constants.METHOD_EMAIL,
 	}
@@ -1266,6 +1270,16 @@ func (r *mutationResolver) SignIn(ctx context.Context, input model.SignInRequest
 		return nil, err
 	}
 
+	attempt, err := portal.SignInAttempt(input.Captcha)
+	if err != nil {
+		return nil, err
+	}
+
+	err = attempt.Check()
+	if err != nil {
+		return portal.SignInRejected(err)
+	}
+
 	err = portal.ValidatePassword(input.Password, true)
 	if err != nil {
 		return nil, err
@@ -1273,14 +1287,16 @@ func (r *mutationResolver) SignIn(ctx context.Context, input model.SignInRequest
 
 	err = portal.QueryMemberByEmail()
 	if err != nil {
-		return nil, err
+		return portal.SignInRejected(attempt.Fail(0, err))
 	}
 
 	err = portal.CheckPassword()
 	if err != nil {
-		return nil, err
+		return portal.SignInRejected(attempt.Fail(portal.Member.MemberID, err))
 	}
 
+	attempt.Success()
+
 	err = portal.CheckMemberStatusIsActive()
 	if err != nil {
 		Message := err.Error()
@@ -1296,7 +1312,29 @@ func (r *mutationResolver) SignIn(ctx context.Context, input model.SignInRequest
 		return nil, err
 	}
 
-	Token, err := portal.GenerateAuthorizationToken(portal.Member.MemberID)
+	Token, MFAToken, MFAMethods, err := portal.GenerateSignInToken(portal.Member.MemberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SignInResponse{
+		Status:     true,
+		Token:      Token,
+		MFAToken:   MFAToken,
+		MFAMethods: MFAMethods,
+	}, nil
+}
+
+func (r *mutationResolver) SignInMfa(ctx context.Context, input model.SignInMFARequest) (*model.SignInResponse, error) {
+	var err error
+
+	portal := portal.Portal{
+		Ctx:            ctx,
+		ProfileHandler: r.ProfileHandler,
+		Timestamp:      time.Now().Unix(),
+	}
+
+	Token, err := portal.SignInMFA(input.Token, input.Code)
 	if err != nil {
 		return nil, err
 	}
@@ -1307,13 +1345,70 @@ func (r *mutationResolver) SignIn(ctx context.Context, input model.SignInRequest
 	}, nil
 }
 
+func (r *mutationResolver) SignInPasskeyBegin(ctx context.Context, input model.SignInPasskeyBeginRequest) (*model.SignInPasskeyBeginResponse, error) {
+	var err error
+
+	portal := portal.Portal{
+		Ctx:            ctx,
+		ProfileHandler: r.ProfileHandler,
+		Timestamp:      time.Now().Unix(),
+	}
+
+	Options, err := portal.SignInPasskeyBegin(input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SignInPasskeyBeginResponse{
+		Status:  true,
+		Options: Options,
+	}, nil
+}
+
+func (r *mutationResolver) SignInPasskey(ctx context.Context, input model.SignInPasskeyRequest) (*model.SignInResponse, error) {
+	var err error
+
+	portal := portal.Portal{
+		Ctx:            ctx,
+		ProfileHandler: r.ProfileHandler,
+		Timestamp:      time.Now().Unix(),
+	}
+
+	Token, err := portal.SignInPasskey(input.Token, input.Credential)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SignInResponse{
+		Status: true,
+		Token:  &Token,
+	}, nil
+}
+
+func (r *mutationResolver) Unlock(ctx context.Context, input model.UnlockRequest) (*model.Result, error) {
+	var err error
+
+	portal := portal.Portal{
+		Ctx:       ctx,
+		Timestamp: time.Now().Unix(),
+	}
+
+	err = portal.Unlock(input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Result{
+		Status: true,
+	}, nil
+}
+
 func (r *mutationResolver) SignUp(ctx context.Context, input model.SignUpRequest) (*model.CreationResponse, error) {
 	var err error
 
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 		Action:    constants.ACTION_SIGNUP,
 		Method:    constants.METHOD_EMAIL,
 	}
@@ -1424,13 +1519,6 @@ func (r *mutationResolver) SignUp(ctx context.Context, input model.SignUpRequest
 		return nil, err
 	}
 
-	err = portal.GenerateConfirmationLink()
-	if err != nil {
-		return nil, err
-	}
-
-	mail.SendMail(portal.Email, "Account activation", "Dear customer,", "Please activate your account by clicking on the button below.", "Activate account", portal.ConfirmationLink, "Thank you for joining our platform.")
-
 	return &model.CreationResponse{
 		RecordID: cast.ToInt(portal.Member.MemberID),
 	}, nil
@@ -1442,7 +1530,6 @@ func (r *mutationResolver) Reset(ctx context.Context, input model.ResetRequest)
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 		Action:    constants.ACTION_RESET,
 		Method:    constants.METHOD_EMAIL,
 	}
@@ -1467,13 +1554,6 @@ func (r *mutationResolver) Reset(ctx context.Context, input model.ResetRequest)
 		return nil, err
 	}
 
-	err = portal.GenerateConfirmationLink()
-	if err != nil {
-		return nil, err
-	}
-
-	mail.SendMail(portal.Email, "Password reset", "Dear customer,", "Please reset your password by clicking on the button below.", "Reset password", portal.ConfirmationLink, "If you have received a password reset email without requesting one, we recommend that you take steps to secure your account.")
-
 	return &model.Result{
 		Status: true,
 	}, nil
@@ -1486,7 +1566,6 @@ func (r *mutationResolver) ResetComplete(ctx context.Context, input model.ResetC
 		Ctx:            ctx,
 		ProfileHandler: r.ProfileHandler,
 		Timestamp:      time.Now().Unix(),
-		Timeout:        60,
 	}
 
 	err = portal.ValidateResetAction(input.Action)
@@ -1499,12 +1578,7 @@ func (r *mutationResolver) ResetComplete(ctx context.Context, input model.ResetC
 		return nil, err
 	}
 
-	err = portal.QueryVerifyByHash(input.Hash)
-	if err != nil {
-		return nil, err
-	}
-
-	err = portal.ValidateOTPCode(input.Code)
+	err = portal.CheckVerifyLink(input.Hash, input.Code)
 	if err != nil {
 		return nil, err
 	}
@@ -1524,14 +1598,16 @@ func (r *mutationResolver) ResetComplete(ctx context.Context, input model.ResetC
 		return nil, err
 	}
 
-	Token, err := portal.GenerateAuthorizationToken(portal.Verify.MemberID)
+	Token, MFAToken, MFAMethods, err := portal.GenerateSignInToken(portal.Verify.MemberID)
 	if err != nil {
 		return nil, err
 	}
 
 	return &model.VerifyResponse{
-		Status: true,
-		Token:  &Token,
+		Status:     true,
+		Token:      Token,
+		MFAToken:   MFAToken,
+		MFAMethods: MFAMethods,
 	}, nil
 }
 
@@ -1541,7 +1617,6 @@ func (r *mutationResolver) PhoneVerify(ctx context.Context, input model.PhoneVer
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 		Action:    constants.ACTION_SIGN_CONTRACT,
 		Method:    constants.METHOD_PHONE,
 	}
@@ -1575,7 +1650,6 @@ func (r *mutationResolver) Verify(ctx context.Context, input model.VerifyRequest
 		Ctx:            ctx,
 		ProfileHandler: r.ProfileHandler,
 		Timestamp:      time.Now().Unix(),
-		Timeout:        60,
 	}
 
 	err = portal.ValidateVerifyAction(input.Action)
@@ -1588,12 +1662,7 @@ func (r *mutationResolver) Verify(ctx context.Context, input model.VerifyRequest
 		return nil, err
 	}
 
-	err = portal.QueryVerifyByHash(input.Hash)
-	if err != nil {
-		return nil, err
-	}
-
-	err = portal.ValidateOTPCode(input.Code)
+	err = portal.CheckVerifyLink(input.Hash, input.Code)
 	if err != nil {
 		return nil, err
 	}
@@ -1609,14 +1678,16 @@ func (r *mutationResolver) Verify(ctx context.Context, input model.VerifyRequest
 		return nil, err
 	}
 
-	Token, err := portal.GenerateAuthorizationToken(portal.Verify.MemberID)
+	Token, MFAToken, MFAMethods, err := portal.GenerateSignInToken(portal.Verify.MemberID)
 	if err != nil {
 		return nil, err
 	}
 
 	return &model.VerifyResponse{
-		Status: true,
-		Token:  &Token,
+		Status:     true,
+		Token:      Token,
+		MFAToken:   MFAToken,
+		MFAMethods: MFAMethods,
 	}, nil
 }
 
@@ -1626,7 +1697,6 @@ func (r *mutationResolver) VerifyResend(ctx context.Context, input model.VerifyR
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 		Action:    constants.ACTION_SIGNUP,
 		Method:    constants.METHOD_EMAIL,
 	}
@@ -1654,13 +1724,6 @@ func (r *mutationResolver) VerifyResend(ctx context.Context, input model.VerifyR
 		}, nil
 	}
 
-	err = portal.GenerateConfirmationLink()
-	if err != nil {
-		return nil, err
-	}
-
-	mail.SendMail(portal.Email, "Account activation", "Dear customer,", "Please activate your account by clicking on the button below.", "Activate account", portal.ConfirmationLink, "Thank you for joining our platform.")
-
 	return &model.VerifyResendResponse{
 		Status:  true,
 		Timeout: &Timeout,
@@ -1673,7 +1736,6 @@ func (r *mutationResolver) OfferPhoneVerify(ctx context.Context, input model.Off
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 		Action:    constants.ACTION_SIGN_CONTRACT,
 		Method:    constants.METHOD_PHONE,
 	}
@@ -1693,12 +1755,7 @@ func (r *mutationResolver) OfferPhoneVerify(ctx context.Context, input model.Off
 		return nil, err
 	}
 
-	err = portal.QueryVerifyByOfferID(input.OfferID)
-	if err != nil {
-		return nil, err
-	}
-
-	err = portal.ValidateOTPCode(input.Code)
+	err = portal.CheckOfferVerify(input.Code)
 	if err != nil {
 		return nil, err
 	}
@@ -1718,7 +1775,9 @@ func (r *mutationResolver) OfferPhoneVerify(ctx context.Context, input model.Off
 		return nil, err
 	}
 
-	mail.SendMail(portal.Member.Email, "Invoice #"+cast.ToString(portal.Invoice.InvoiceID), "Dear customer,", "Please pay your invoice by clicking on the button below.", "View invoice", portal.InvoiceLink, "Thank you for using our platform.")
+	if _, err := task.Enqueue(task.Email{Email: portal.Member.Email, Subject: "Invoice #" + cast.ToString(portal.Invoice.InvoiceID), Title: "Dear customer,", Content: "Please pay your invoice by clicking on the button below.", Button: "View invoice", Link: portal.InvoiceLink, Description: "Thank you for using our platform."}, ""); err != nil {
+		return nil, err
+	}
 
 	return &model.Result{
 		Status: true,
@@ -1731,7 +1790,6 @@ func (r *mutationResolver) OfferPhoneVerifyResend(ctx context.Context, input mod
 	portal := portal.Portal{
 		Ctx:       ctx,
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
 		Action:    constants.ACTION_SIGN_CONTRACT,
 		Method:    constants.METHOD_PHONE,
 	}
@@ -1748,6 +1806,52 @@ func (r *mutationResolver) OfferPhoneVerifyResend(ctx context.Context, input mod
 	}, nil
 }
 
+func (r *mutationResolver) OperatorJobUpdate(ctx context.Context, input model.OperatorJobUpdateRequest) (*model.Result, error) {
+	var err error
+
+	operator := operator.Operator{
+		Ctx:       ctx,
+		Timestamp: time.Now().Unix(),
+	}
+
+	err = operator.GetMember()
+	if err != nil {
+		return nil, err
+	}
+
+	err = operator.JobUpdate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Result{
+		Status: true,
+	}, nil
+}
+
+func (r *mutationResolver) OperatorJobTrigger(ctx context.Context, input model.RecordRequest) (*model.Result, error) {
+	var err error
+
+	operator := operator.Operator{
+		Ctx:       ctx,
+		Timestamp: time.Now().Unix(),
+	}
+
+	err = operator.GetMember()
+	if err != nil {
+		return nil, err
+	}
+
+	err = operator.JobTrigger(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Result{
+		Status: true,
+	}, nil
+}
+
 func (r *queryResolver) Invest(ctx context.Context, input model.RecordRequest) (*model.Invest, error) {
 	panic(fmt.Errorf("not implemented"))
 	return nil, nil
@@ -2891,6 +2995,38 @@ func (r *queryResolver) Alert(ctx context.Context) ([]*model.Alert, error) {
 	return alert, nil
 }
 
+func (r *queryResolver) InfoSince(ctx context.Context, input model.InfoSinceRequest) ([]*model.Info, error) {
+	var err error
+
+	portal := portal.Portal{
+		Ctx: ctx,
+	}
+
+	err = portal.GetMember()
+	if err != nil {
+		return nil, err
+	}
+
+	//Events missed by the subscription, client resyncs after a gap in Seq of its events (Seq) or events of everyone, MemberID 0 (SharedSeq)
+	return outbox.QueryInfoSince(portal.Member.MemberID, int64(input.Seq), int64(input.SharedSeq), 1000)
+}
+
+func (r *queryResolver) OperatorJobList(ctx context.Context) ([]*model.Job, error) {
+	var err error
+
+	operator := operator.Operator{
+		Ctx:       ctx,
+		Timestamp: time.Now().Unix(),
+	}
+
+	err = operator.GetMember()
+	if err != nil {
+		return nil, err
+	}
+
+	return operator.JobList()
+}
+
 func (r *subscriptionResolver) NewInfo(ctx context.Context) (<-chan *model.Info, error) {
 	var err error
 
@@ -2921,7 +3057,8 @@ func (r *subscriptionResolver) NewInfo(ctx context.Context) (<-chan *model.Info,
 
 				err := json.Unmarshal(msg.Message, &infoMsg)
 				if err == nil {
-					if ((infoMsg.Event == "trade" || infoMsg.Event == "alert") && MemberID == cast.ToString(infoMsg.MemberID)) || infoMsg.Event == "rate" {
+					//Same events as InfoSince returns, so the client sees every number of its streams
+					if outbox.Visible(infoMsg, cast.ToInt64(MemberID)) {
 						info <- infoMsg
 					}
 				}
@@ -0,0 +1,96 @@
+package account
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	shopspring "github.com/jackc/pgtype/ext/shopspring-numeric"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	//Account currency of members without CurrencyID
+	DEFAULT_CURRENCY = "USD"
+)
+
+//Currencies that also have a legacy balance column in Member table. They are kept in sync for existing readers
+var legacyColumns = map[string]bool{
+	"USD": true,
+	"EUR": true,
+}
+
+//QueryCurrency returns member account currency code
+func QueryCurrency(MemberID int64) (string, error) {
+	db := db.GetDB()
+
+	var code string
+
+	if err := db.Get(&code, "SELECT Currency.Code FROM Member INNER JOIN Currency ON Currency.CurrencyID=Member.CurrencyID WHERE Member.MemberID=$1", MemberID); err != nil {
+		if err != sql.ErrNoRows {
+			return code, err
+		}
+	}
+
+	if code == "" {
+		code = DEFAULT_CURRENCY
+	}
+
+	return code, nil
+}
+
+//QueryBalance returns member balance in currency
+func QueryBalance(MemberID int64, code string) (decimal.Decimal, error) {
+	db := db.GetDB()
+
+	var amount shopspring.Numeric
+
+	if err := db.Get(&amount, "SELECT Balance.Amount FROM Balance INNER JOIN Currency ON Currency.CurrencyID=Balance.CurrencyID WHERE Balance.MemberID=$1 AND Currency.Code=$2", MemberID, code); err != nil {
+		if err != sql.ErrNoRows {
+			return amount.Decimal, err
+		}
+	}
+
+	return amount.Decimal, nil
+}
+
+//QueryBalanceForUpdate returns member balance in currency and locks it until the end of transaction,
+//so a balance computed from it isn't overwritten by concurrent change
+func QueryBalanceForUpdate(tx *sqlx.Tx, MemberID int64, code string) (decimal.Decimal, error) {
+	var amount shopspring.Numeric
+
+	if err := tx.Get(&amount, "SELECT Balance.Amount FROM Balance INNER JOIN Currency ON Currency.CurrencyID=Balance.CurrencyID WHERE Balance.MemberID=$1 AND Currency.Code=$2 FOR UPDATE OF Balance", MemberID, code); err != nil {
+		if err != sql.ErrNoRows {
+			return amount.Decimal, err
+		}
+	}
+
+	return amount.Decimal, nil
+}
+
+//Credit adds amount (negative to debit) to member balance in currency within an existing transaction.
+//Money movements are posted through internal/ledger, which applies member account postings with Credit
+func Credit(tx *sqlx.Tx, MemberID int64, code string, amount decimal.Decimal) error {
+
+	var CurrencyID int64
+
+	if err := tx.Get(&CurrencyID, "SELECT CurrencyID FROM Currency WHERE Code=$1", code); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("INVALID_CURRENCY")
+		}
+		return err
+	}
+
+	res := tx.MustExec("UPDATE Balance SET Amount=Amount+$1, AmountNegative=(Amount+$1)<0 WHERE MemberID=$2 AND CurrencyID=$3", amount, MemberID, CurrencyID)
+
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		tx.MustExec("INSERT INTO Balance (MemberID, CurrencyID, Amount, AmountNegative) VALUES ($1, $2, $3, $4)", MemberID, CurrencyID, amount, amount.IsNegative())
+	}
+
+	if legacyColumns[code] {
+		tx.MustExec("UPDATE Member SET "+code+"="+code+"+$1 WHERE MemberID=$2", amount, MemberID)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/jmoiron/sqlx"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+//Entry is a single operator action recorded to the Audit table
+type Entry struct {
+	MemberID int64       //Operator who performed the action
+	Action   string      //Action name, e.g. asset.halt
+	Entity   string      //Affected entity, e.g. asset, trade
+	EntityID int64       //Affected entity ID
+	Reason   string      //Reason provided by operator
+	Data     interface{} //Action parameters, stored as JSON
+}
+
+//Record saves audit entry within an existing transaction
+func (entry Entry) Record(tx *sqlx.Tx) {
+	data, err := json.Marshal(entry.Data)
+	if err != nil || entry.Data == nil {
+		data = []byte("")
+	}
+
+	tx.MustExec("INSERT INTO Audit (MemberID, Action, Entity, EntityID, Reason, Data, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7)", entry.MemberID, entry.Action, entry.Entity, entry.EntityID, entry.Reason, string(data), time.Now().Unix())
+}
+
+//Save records audit entry in its own transaction
+func (entry Entry) Save() {
+	db := db.GetDB()
+
+	tx := db.MustBegin()
+	entry.Record(tx)
+	tx.Commit()
+}
@@ -6,7 +6,6 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"database/sql"
-	"encoding/hex"
 	"errors"
 	"hash"
 	"strings"
@@ -17,52 +16,19 @@ import (
 	"github.com/ianidi/exchange-server/internal/models"
 	"github.com/muesli/crunchy"
 	"github.com/nyaruka/phonenumbers"
-	"github.com/sethvargo/go-password/password"
 	"github.com/spf13/cast"
 	"golang.org/x/crypto/bcrypt"
 )
 
-const (
-	METHOD_PHONE     = "phone"
-	METHOD_EMAIL     = "email"
-	ACTION_SIGNIN    = "signin"
-	ACTION_SIGNUP    = "signup"
-	ACTION_CHANGE    = "change"
-	ACTION_RESET     = "reset"
-	STATUS_PENDING   = "pending"
-	STATUS_CANCELLED = "cancelled"
-	STATUS_FAIL      = "fail"
-	STATUS_SUCCESS   = "success"
-)
-
+//Identity - member of auth request, verification codes are handled by internal/verify
 type Identity struct {
-	Member           models.Member
-	Verify           models.Verify
-	Onboarding       models.Onboarding
-	Action           string
-	Method           string
-	Phone            string //Phone number
-	Email            string //Email address
-	EmailHash        string //Email address md5 hash
-	Password         string //Current (auth) or new (reset) member password
-	PasswordHash     string //Password hash
-	Code             string //OTP code
-	CodeHash         string //OTP code hash
-	ConfirmationLink string //Email confirmation link
-	Timestamp        int64  //Current UNIX timestamp
-	Timeout          int64  //How frequently in seconds OTP codes can be requested
-}
-
-//Generate confirmation code
-func (identity *Identity) GenerateOTP() error {
-	code, err := password.Generate(6, 6, 0, true, false)
-	if err != nil {
-		return err
-	}
-
-	identity.Code = code
-
-	return nil
+	Member       models.Member
+	Onboarding   models.Onboarding
+	Phone        string //Phone number
+	Email        string //Email address
+	Password     string //Current (auth) or new (reset) member password
+	PasswordHash string //Password hash
+	Timestamp    int64  //Current UNIX timestamp
 }
 
 //Hash using bcrypt
@@ -75,12 +41,6 @@ func (identity Identity) HashBcrypt(str string) (string, error) {
 	return cast.ToString(hash), nil
 }
 
-//Hash using md5
-func (identity Identity) HashMD5(str string) (string, error) {
-	hash := md5.Sum([]byte(str))
-	return hex.EncodeToString(hash[:]), nil
-}
-
 //Parse phone number
 func (identity *Identity) ParsePhone(phone string) error {
 
@@ -140,7 +100,7 @@ func (identity *Identity) QueryMemberByPhone() error {
 
 	var member models.Member
 
-	if err := db.Get(&member, "SELECT * FROM Member WHERE Phone=$1", identity.Email); err != nil {
+	if err := db.Get(&member, "SELECT * FROM Member WHERE Phone=$1", identity.Phone); err != nil {
 		if err == sql.ErrNoRows {
 			return errors.New("NO_MEMBER_RECORD")
 		}
@@ -190,27 +150,6 @@ func (identity Identity) CheckEmailInUse() error {
 	return nil
 }
 
-//Find verification code sent in the last timeout value, in case found return time left until new code request
-func (identity Identity) QueryVerifyTimeout() (int64, error) {
-	db := db.GetDB()
-
-	var verify models.Verify
-
-	if err := db.Get(&verify, "SELECT * FROM Verify WHERE MemberID=$1 AND Action=$2 AND Method=$3 AND Status=$4", identity.Member.MemberID, identity.Action, identity.Method, STATUS_PENDING); err != nil {
-		if err != sql.ErrNoRows {
-			return 0, err
-		}
-	}
-
-	//Not enough time passed after the last code request
-	if verify.Created > identity.Timestamp-identity.Timeout {
-		return identity.Timeout - identity.Timestamp + verify.Created, errors.New("CODE_TIMEOUT")
-	}
-
-	return 0, nil
-
-}
-
 //Validate password
 func (identity *Identity) CheckPassword(password string, validateStrength bool) error {
 
@@ -248,62 +187,11 @@ func (identity *Identity) CheckPassword(password string, validateStrength bool)
 	return nil
 }
 
-//Query verify by md5 hash (email)
-func (identity *Identity) QueryVerifyByHash(hash string) error {
-	db := db.GetDB()
-
-	var verify models.Verify
-
-	//Find verify request
-	if err := db.Get(&verify, "SELECT * FROM Verify WHERE EmailHash=$1 AND Action=$2 AND Method=$3 AND Status=$4", hash, identity.Action, identity.Method, STATUS_PENDING); err != nil {
-		if err == sql.ErrNoRows {
-			return errors.New("INVALID_LINK")
-		}
-		return err
-	}
-
-	//Request is expired (day)
-	if verify.Created < identity.Timestamp-86400 {
-		return errors.New("EXPIRED_LINK")
-	}
-
-	identity.Verify = verify
-	return nil
-}
-
-//Validate OTP code
-func (identity Identity) ValidateOTPCode(code string) error {
-	db := db.GetDB()
-
-	if err := bcrypt.CompareHashAndPassword([]byte(identity.Verify.CodeHash), []byte(code)); err != nil {
-
-		tx := db.MustBegin()
-		tx.MustExec("UPDATE Verify SET Attempts=Attempts+$1 WHERE VerifyID=$2", 1, identity.Verify.VerifyID)
-		tx.Commit()
-
-		//Verify attempts exceeded
-		if identity.Verify.Attempts >= 3 {
-
-			tx := db.MustBegin()
-			tx.MustExec("UPDATE Verify SET Status=$1 WHERE VerifyID=$2", STATUS_FAIL, identity.Verify.VerifyID)
-			tx.Commit()
-
-			return errors.New("TOO_MANY_ATTEMPTS")
-		}
-
-		return errors.New("INVALID_CODE")
-	}
-
-	return nil
-}
-
 //Validate password
 func (identity Identity) ValidatePassword() error {
 
+	//Failed attempts are limited by internal/lockout
 	if err := bcrypt.CompareHashAndPassword([]byte(identity.Member.PasswordHash), []byte(identity.Password)); err != nil {
-
-		//TODO: bruteforce protection
-
 		return errors.New("INVALID_PASSWORD")
 	}
 
@@ -338,17 +226,3 @@ func (identity *Identity) QueryOnboardingRecord(MemberID int64) error {
 
 	return nil
 }
-
-//Generate email confirmation link
-func (identity *Identity) GenerateConfirmationLink() error {
-
-	settings, err := identity.QuerySettings()
-	if err != nil {
-		return err
-	}
-
-	identity.ConfirmationLink = settings.PlatformURL + "verify/" + identity.Action + "/" + identity.EmailHash + "/" + identity.Code
-
-	return nil
-
-}
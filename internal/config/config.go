@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+//Config - typed settings of infrastructure and external services
+type Config struct {
+	Connection     string //PostgreSQL connection string
+	Port           string
+	TrustedProxies []string //Addresses of reverse proxies X-Forwarded-For is accepted from
+	JWT            JWT
+	Redis          Redis
+	S3             S3
+	SNS            SNS
+	GatewayAPI     GatewayAPI
+	Swagger        BasicAuth
+	Metrics        BasicAuth
+}
+
+//JWT - token settings. Tokens are signed with asymmetric keys rotated every Rotation (internal/jwk),
+//private keys are stored encrypted with Secret. Keys encrypted with previous secrets are decrypted with PreviousKeys and re-encrypted
+type JWT struct {
+	KeyID        string
+	Secret       string
+	PreviousKeys map[string]string //Key ID - secret
+	Algorithm    string            //RS256, EdDSA
+	Issuer       string
+	Audience     string
+	Rotation     time.Duration
+	AccessTTL    time.Duration
+	RefreshTTL   time.Duration
+}
+
+type Redis struct {
+	Host     string
+	Password string
+}
+
+type S3 struct {
+	Endpoint     string
+	Bucket       string
+	UploadBucket string
+	Key          string
+	Secret       string
+	CDNURL       string
+}
+
+//SNS - AWS SNS SMS credentials
+type SNS struct {
+	Region string
+	Key    string
+	Secret string
+}
+
+//GatewayAPI - gatewayapi.com SMS credentials
+type GatewayAPI struct {
+	Token  string
+	Key    string
+	Secret string
+}
+
+//BasicAuth - credentials of an internal endpoint, the endpoint is disabled without password
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+//Enabled reports whether credentials are set
+func (auth BasicAuth) Enabled() bool {
+	return auth.Username != "" && auth.Password != ""
+}
+
+//Get returns typed settings
+func Get() Config {
+	previousKeys, _ := parseKeys(viper.GetString("jwt_previous_keys"))
+
+	return Config{
+		Connection:     viper.GetString("connection"),
+		Port:           viper.GetString("port"),
+		TrustedProxies: list(viper.GetString("trusted_proxies")),
+		JWT: JWT{
+			KeyID:        viper.GetString("jwt_key_id"),
+			Secret:       viper.GetString("jwt_secret"),
+			PreviousKeys: previousKeys,
+			Algorithm:    viper.GetString("jwt_algorithm"),
+			Issuer:       viper.GetString("jwt_issuer"),
+			Audience:     viper.GetString("jwt_audience"),
+			Rotation:     viper.GetDuration("jwt_key_rotation"),
+			AccessTTL:    viper.GetDuration("jwt_access_ttl"),
+			RefreshTTL:   viper.GetDuration("jwt_refresh_ttl"),
+		},
+		Redis: Redis{
+			Host:     viper.GetString("redis_host"),
+			Password: viper.GetString("redis_password"),
+		},
+		S3: S3{
+			Endpoint:     viper.GetString("s3_endpoint"),
+			Bucket:       viper.GetString("s3_bucket"),
+			UploadBucket: viper.GetString("s3_upload_bucket"),
+			Key:          viper.GetString("s3_key"),
+			Secret:       viper.GetString("s3_secret"),
+			CDNURL:       viper.GetString("s3_cdn_url"),
+		},
+		SNS: SNS{
+			Region: viper.GetString("aws_region_sns"),
+			Key:    viper.GetString("aws_key_sns"),
+			Secret: viper.GetString("aws_secret_sns"),
+		},
+		GatewayAPI: GatewayAPI{
+			Token:  viper.GetString("gatewayapi_token"),
+			Key:    viper.GetString("gatewayapi_key"),
+			Secret: viper.GetString("gatewayapi_secret"),
+		},
+		Swagger: BasicAuth{
+			Username: viper.GetString("swagger_username"),
+			Password: viper.GetString("swagger_password"),
+		},
+		Metrics: BasicAuth{
+			Username: viper.GetString("metrics_username"),
+			Password: viper.GetString("metrics_password"),
+		},
+	}
+}
+
+//list parses comma separated list
+func list(value string) []string {
+	items := []string{}
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+//parseKeys parses "kid:secret,kid:secret" list of signing keys
+func parseKeys(value string) (map[string]string, error) {
+	keys := map[string]string{}
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return keys, errors.New("jwt_previous_keys: expected comma separated kid:secret list")
+		}
+
+		keys[parts[0]] = parts[1]
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+//Required - settings the server can't start without
+var Required = []string{
+	"connection",
+	"jwt_key_id",
+	"jwt_secret",
+	"redis_host",
+	"s3_endpoint",
+	"s3_bucket",
+	"s3_upload_bucket",
+	"pdf_host",
+	"s3_key",
+	"s3_secret",
+}
+
+//Secrets - settings redacted from logs
+var Secrets = []string{
+	"connection",
+	"jwt_secret",
+	"jwt_previous_keys",
+	"redis_password",
+	"s3_key",
+	"s3_secret",
+	"aws_key_sns",
+	"aws_secret_sns",
+	"gatewayapi_token",
+	"gatewayapi_key",
+	"gatewayapi_secret",
+	"swagger_password",
+	"metrics_password",
+	"captcha_secret",
+	"card_api_key",
+	"card_webhook_secret",
+	"bank_webhook_secret",
+	"payment_mock_secret",
+	"oidc_google_client_secret",
+	"oidc_apple_private_key",
+	"oidc_client_secret",
+}
+
+const (
+	MIN_JWT_SECRET   = 32 //Characters
+	MIN_JWT_ROTATION = 24 * time.Hour
+)
+
+//Load reads settings over defaults. Precedence: secrets directory, environment variables (JWT_SECRET for jwt_secret), config file, defaults
+func Load() error {
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	//Config file is optional unless its path is set with CONFIG_FILE
+	if file := os.Getenv("CONFIG_FILE"); file != "" {
+		viper.SetConfigFile(file)
+	} else {
+		viper.SetConfigName("config")
+		viper.AddConfigPath(".")
+		viper.AddConfigPath("/etc/exchange")
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return fmt.Errorf("config file: %v", err)
+		}
+	} else {
+		log.Printf("config file %s\n", viper.ConfigFileUsed())
+	}
+
+	count, err := readSecrets(viper.GetString("secrets_dir"))
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		log.Printf("%d secrets read from %s\n", count, viper.GetString("secrets_dir"))
+	}
+
+	initRedaction()
+
+	return Validate()
+}
+
+//readSecrets sets settings from files of secrets directory (Docker and Kubernetes secrets), file name is the setting name
+func readSecrets(dir string) (int, error) {
+	if dir == "" {
+		return 0, nil
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("secrets directory: %v", err)
+	}
+
+	count := 0
+
+	for _, file := range files {
+		//Kubernetes keeps versions of secrets in hidden ..data directories, the visible files link to them
+		if strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, file.Name())
+
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		value, err := ioutil.ReadFile(path)
+		if err != nil {
+			return count, fmt.Errorf("secret %s: %v", file.Name(), err)
+		}
+
+		viper.Set(strings.ToLower(file.Name()), strings.TrimSpace(string(value)))
+		count++
+	}
+
+	return count, nil
+}
+
+//Validate checks required settings and formats of secrets
+func Validate() error {
+	var missing []string
+
+	for _, key := range Required {
+		if strings.TrimSpace(viper.GetString(key)) == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required settings: %s (set them with environment variables, config file or secrets directory)", strings.Join(missing, ", "))
+	}
+
+	config := Get()
+
+	if len(config.JWT.Secret) < MIN_JWT_SECRET {
+		return fmt.Errorf("jwt_secret must be at least %d characters", MIN_JWT_SECRET)
+	}
+
+	previousKeys, err := parseKeys(viper.GetString("jwt_previous_keys"))
+	if err != nil {
+		return err
+	}
+
+	if _, ok := previousKeys[config.JWT.KeyID]; ok {
+		return fmt.Errorf("jwt_previous_keys: key %s is the current jwt_key_id", config.JWT.KeyID)
+	}
+
+	if config.JWT.Algorithm != "RS256" && config.JWT.Algorithm != "EdDSA" {
+		return errors.New("jwt_algorithm must be RS256 or EdDSA")
+	}
+
+	if config.JWT.Issuer == "" || config.JWT.Audience == "" {
+		return errors.New("jwt_issuer and jwt_audience must be set")
+	}
+
+	if config.JWT.Rotation < MIN_JWT_ROTATION {
+		return fmt.Errorf("jwt_key_rotation must be at least %s", MIN_JWT_ROTATION)
+	}
+
+	return nil
+}
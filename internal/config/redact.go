@@ -0,0 +1,87 @@
+package config
+
+import (
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	REDACTED       = "[REDACTED]"
+	MIN_REDACTABLE = 4 //Shorter values would redact unrelated text
+)
+
+var (
+	redactMu sync.RWMutex
+	replacer = strings.NewReplacer()
+)
+
+//initRedaction collects loaded secret values, password of connection string is redacted on its own as drivers log it apart
+func initRedaction() {
+	var values []string
+
+	for _, key := range Secrets {
+		value := viper.GetString(key)
+
+		if key == "connection" {
+			if u, err := url.Parse(value); err == nil && u.User != nil {
+				if password, ok := u.User.Password(); ok {
+					values = append(values, password)
+				}
+			}
+		}
+
+		if key == "jwt_previous_keys" {
+			keys, _ := parseKeys(value)
+			for _, secret := range keys {
+				values = append(values, secret)
+			}
+		}
+
+		values = append(values, value)
+	}
+
+	//Longer values first, so a secret containing another one is redacted whole
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	var pairs []string
+
+	for _, value := range values {
+		if len(value) >= MIN_REDACTABLE {
+			pairs = append(pairs, value, REDACTED)
+		}
+	}
+
+	redactMu.Lock()
+	replacer = strings.NewReplacer(pairs...)
+	redactMu.Unlock()
+}
+
+//Redact replaces secret values in text
+func Redact(text string) string {
+	redactMu.RLock()
+	defer redactMu.RUnlock()
+
+	return replacer.Replace(text)
+}
+
+type redactWriter struct {
+	w io.Writer
+}
+
+//Writer returns writer that redacts secret values, it wraps log and gin outputs
+func Writer(w io.Writer) io.Writer {
+	return redactWriter{w: w}
+}
+
+func (rw redactWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(rw.w, Redact(string(p))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -3,7 +3,7 @@ package db
 import (
 	"log"
 
-	"github.com/spf13/viper"
+	"github.com/ianidi/exchange-server/internal/config"
 
 	_ "github.com/jackc/pgx/stdlib"
 	"github.com/jmoiron/sqlx"
@@ -14,11 +14,12 @@ var DB *sqlx.DB
 
 // Init connection to the database
 func Init() *sqlx.DB {
-	if viper.GetString("connection") == "" {
-		log.Fatalln("Please pass the connection string using the -connection option")
+	connection := config.Get().Connection
+	if connection == "" {
+		log.Fatalln("Please pass the connection string using the CONNECTION environment variable")
 	}
 
-	db, err := sqlx.Connect("pgx", viper.GetString("connection"))
+	db, err := sqlx.Connect("pgx", connection)
 	if err != nil {
 		log.Fatalf("Unable to establish connection: %v\n", err)
 	}
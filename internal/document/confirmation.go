@@ -0,0 +1,76 @@
+package document
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/trade"
+)
+
+//Confirmation - trade confirmation note of opened or closed trade
+type Confirmation struct {
+	MemberID int64
+	Email    string
+	Event    string //open, closed
+	Ticker   string
+	Title    string
+	Trade    models.Trade
+}
+
+//QueryConfirmation collects confirmation of trade event
+func QueryConfirmation(TradeID int64, event string) (Confirmation, error) {
+	db := db.GetDB()
+
+	confirmation := Confirmation{
+		Event: event,
+	}
+
+	if event != trade.STATUS_OPEN && event != trade.STATUS_CLOSED {
+		return confirmation, errors.New("INVALID_PERIOD")
+	}
+
+	if err := db.Get(&confirmation.Trade, "SELECT * FROM Trade WHERE TradeID=$1", TradeID); err != nil {
+		if err == sql.ErrNoRows {
+			return confirmation, errors.New("NO_TRADE_RECORD")
+		}
+		return confirmation, err
+	}
+
+	if event == trade.STATUS_CLOSED && confirmation.Trade.Status != trade.STATUS_CLOSED {
+		return confirmation, errors.New("TRADE_NOT_CLOSED")
+	}
+
+	confirmation.MemberID = confirmation.Trade.MemberID
+
+	if err := db.Get(&confirmation.Email, "SELECT Email FROM Member WHERE MemberID=$1", confirmation.MemberID); err != nil {
+		return confirmation, err
+	}
+
+	var asset struct {
+		Ticker string
+		Title  string
+	}
+
+	if err := db.Get(&asset, "SELECT Ticker, Title FROM Asset WHERE AssetID=$1", confirmation.Trade.AssetID); err != nil && err != sql.ErrNoRows {
+		return confirmation, err
+	}
+
+	confirmation.Ticker = asset.Ticker
+	confirmation.Title = asset.Title
+
+	return confirmation, nil
+}
+
+//HTML renders confirmation with confirmation template
+func (confirmation Confirmation) HTML() (string, error) {
+	var html bytes.Buffer
+
+	if err := confirmationTemplate.Execute(&html, confirmation); err != nil {
+		return "", err
+	}
+
+	return html.String(), nil
+}
@@ -0,0 +1,185 @@
+package document
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/s3"
+	"github.com/ianidi/exchange-server/internal/task"
+	"github.com/ianidi/exchange-server/internal/utils"
+	"github.com/jmoiron/sqlx"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+//Document types
+const (
+	TYPE_STATEMENT    = "statement"    //Monthly account statement
+	TYPE_CONFIRMATION = "confirmation" //Trade confirmation note
+)
+
+//Document statuses
+const (
+	STATUS_PENDING = "pending"
+	STATUS_READY   = "ready"
+)
+
+//Task type of document rendering
+const TYPE_RENDER = "document"
+
+//Render task renders document PDF, uploads it to S3 and emails member
+type Render struct {
+	DocumentID int64
+}
+
+func (Render) Type() string {
+	return TYPE_RENDER
+}
+
+func init() {
+	task.Register(TYPE_RENDER, func(ctx context.Context, payload []byte) error {
+		var render Render
+		if err := json.Unmarshal(payload, &render); err != nil {
+			return err
+		}
+		return Generate(render.DocumentID)
+	})
+
+	task.RegisterRedactor(TYPE_RENDER, func(payload []byte) (interface{}, error) {
+		var render Render
+		err := json.Unmarshal(payload, &render)
+		return render, err
+	})
+}
+
+//Create adds member document and queues its rendering within a transaction. Existing document of the same type and period is left as is, created is false then
+func Create(tx *sqlx.Tx, MemberID int64, documentType string, period string, TradeID int64, email bool) (int64, bool, error) {
+	var DocumentID int64
+
+	now := time.Now().Unix()
+
+	if err := tx.Get(&DocumentID, "INSERT INTO Document (MemberID, Type, Period, TradeID, Status, Email, Created, Updated) VALUES ($1, $2, $3, $4, $5, $6, $7, $7) ON CONFLICT (MemberID, Type, Period, TradeID) DO NOTHING RETURNING DocumentID", MemberID, documentType, period, TradeID, STATUS_PENDING, email, now); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	if _, err := task.EnqueueTx(tx, Render{DocumentID: DocumentID}, fmt.Sprintf("document:%d", DocumentID)); err != nil {
+		return DocumentID, false, err
+	}
+
+	return DocumentID, true, nil
+}
+
+//Regenerate queues rendering of an existing document again, e.g. after statement data was corrected
+func Regenerate(tx *sqlx.Tx, DocumentID int64) error {
+	tx.MustExec("UPDATE Document SET Status=$1, Updated=$2 WHERE DocumentID=$3", STATUS_PENDING, time.Now().Unix(), DocumentID)
+
+	_, err := task.EnqueueTx(tx, Render{DocumentID: DocumentID}, fmt.Sprintf("document:%d:%d", DocumentID, time.Now().UnixNano()))
+
+	return err
+}
+
+//HTML renders document content
+func HTML(document models.Document) (string, error) {
+	switch document.Type {
+	case TYPE_STATEMENT:
+		statement, err := QueryStatement(document.MemberID, document.Period)
+		if err != nil {
+			return "", err
+		}
+		return statement.HTML()
+	case TYPE_CONFIRMATION:
+		confirmation, err := QueryConfirmation(document.TradeID, document.Period)
+		if err != nil {
+			return "", err
+		}
+		if confirmation.MemberID != document.MemberID {
+			return "", errors.New("NO_TRADE_RECORD")
+		}
+		return confirmation.HTML()
+	}
+
+	return "", errors.New("INVALID_DOCUMENT_TYPE")
+}
+
+//Generate renders document PDF, uploads it to S3 and emails member if requested
+func Generate(DocumentID int64) error {
+	db := db.GetDB()
+
+	var document models.Document
+
+	if err := db.Get(&document, "SELECT * FROM Document WHERE DocumentID=$1", DocumentID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("NO_DOCUMENT_RECORD")
+		}
+		return err
+	}
+
+	html, err := HTML(document)
+	if err != nil {
+		return err
+	}
+
+	pdf, err := utils.RenderPDF(html)
+	if err != nil {
+		return err
+	}
+
+	filename := Filename(document)
+
+	URL, err := s3.Upload(filename, pdf)
+	if err != nil {
+		return err
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Document SET Status=$1, Filename=$2, URL=$3, Updated=$4 WHERE DocumentID=$5", STATUS_READY, filename, URL, time.Now().Unix(), DocumentID)
+
+	if document.Email {
+		var Email string
+
+		if err := tx.Get(&Email, "SELECT Email FROM Member WHERE MemberID=$1", document.MemberID); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		subject := "Account statement " + document.Period
+		if document.Type == TYPE_CONFIRMATION {
+			subject = fmt.Sprintf("Trade confirmation #%d", document.TradeID)
+		}
+
+		if _, err := task.EnqueueTx(tx, task.Email{
+			Email:       Email,
+			Subject:     subject,
+			Title:       "Dear customer,",
+			Content:     "Your document " + subject + " is ready. You can download it by clicking on the button below.",
+			Button:      "Download",
+			Link:        URL,
+			Description: "Thank you for using our platform.",
+		}, fmt.Sprintf("document:%d:email", DocumentID)); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+//Filename - S3 key of document PDF. Uploads are public, so the key is not guessable
+func Filename(document models.Document) string {
+	name := document.Type + "-" + document.Period
+	if document.Type == TYPE_CONFIRMATION {
+		name = fmt.Sprintf("confirmation-%d-%s", document.TradeID, document.Period)
+	}
+
+	return fmt.Sprintf("documents/%d/%s-%s.pdf", document.MemberID, name, uuid.New().String())
+}
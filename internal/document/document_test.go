@@ -0,0 +1,224 @@
+package document
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/trade"
+	"github.com/ianidi/exchange-server/internal/utils"
+	"github.com/jackc/pgtype"
+	shopspring "github.com/jackc/pgtype/ext/shopspring-numeric"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+)
+
+//go test ./internal/document -update rewrites snapshots in testdata
+var update = flag.Bool("update", false, "update HTML snapshots")
+
+func amount(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func numeric(value string) shopspring.Numeric {
+	return shopspring.Numeric{Decimal: amount(value), Status: pgtype.Present}
+}
+
+func testStatement() Statement {
+	from, to, _ := ParsePeriod("2020-08")
+
+	return Statement{
+		MemberID:  42,
+		Email:     "member@example.com",
+		FirstName: "Jane",
+		LastName:  "Doe <script>",
+		Period:    "2020-08",
+		From:      from,
+		To:        to,
+		Balances: []StatementBalance{
+			{Currency: "USD", Opening: amount("1000"), Closing: amount("1234.5")},
+		},
+		Deposits: []StatementMovement{
+			{ID: 1, Timestamp: 1596456000, Type: "bank", Currency: "USD", Amount: amount("500")},
+		},
+		Withdraws: []StatementMovement{
+			{ID: 2, Timestamp: 1597060800, Currency: "USD", Amount: amount("-200")},
+		},
+		Trades: []StatementTrade{
+			{TradeID: 3, Ticker: "AAPL", Action: trade.ACTION_BUY, Qty: amount("2"), RateEntry: amount("430.1"), RateClosed: amount("497.48"), Profit: amount("134.76"), AccountCurrency: "USD", DateClosed: time.Date(2020, 8, 31, 20, 0, 0, 0, time.UTC)},
+		},
+		Charges: []StatementCharge{
+			{Type: "swap", Currency: "USD", Amount: amount("-0.26")},
+		},
+	}
+}
+
+func testConfirmation() Confirmation {
+	return Confirmation{
+		MemberID: 42,
+		Email:    "member@example.com",
+		Event:    trade.STATUS_CLOSED,
+		Ticker:   "EURUSD",
+		Title:    "Euro / US Dollar",
+		Trade: models.Trade{
+			TradeID:         7,
+			MemberID:        42,
+			Action:          trade.ACTION_SELL,
+			Qty:             numeric("0.1"),
+			Leverage:        numeric("10"),
+			RateEntry:       numeric("1.1832"),
+			RateClosed:      numeric("1.1795"),
+			Total:           numeric("1183.2"),
+			Profit:          numeric("37"),
+			DateClosed:      pgtype.Timestamptz{Time: time.Date(2020, 9, 1, 12, 30, 0, 0, time.UTC), Status: pgtype.Present},
+			Timestamp:       1598875200,
+			Status:          trade.STATUS_CLOSED,
+			AccountCurrency: "USD",
+		},
+	}
+}
+
+//snapshot compares rendered HTML with testdata/name
+func snapshot(t *testing.T, name string, html string) {
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		if err := ioutil.WriteFile(path, []byte(html), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(expected) != html {
+		t.Fatalf("%s differs from rendered HTML, run go test ./internal/document -update and review the diff\n%s", path, html)
+	}
+}
+
+func TestStatementHTML(t *testing.T) {
+	html, err := testStatement().HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot(t, "statement.html", html)
+}
+
+func TestStatementHTMLEmpty(t *testing.T) {
+	statement := testStatement()
+	statement.Balances, statement.Deposits, statement.Withdraws, statement.Trades, statement.Charges = nil, nil, nil, nil, nil
+
+	html, err := statement.HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot(t, "statement_empty.html", html)
+}
+
+func TestConfirmationHTML(t *testing.T) {
+	confirmation := testConfirmation()
+
+	html, err := confirmation.HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot(t, "confirmation_closed.html", html)
+
+	//Confirmation of opened trade has no close details
+	confirmation.Event = trade.STATUS_OPEN
+
+	html, err = confirmation.HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot(t, "confirmation_open.html", html)
+}
+
+func TestRenderPDF(t *testing.T) {
+	html, err := testStatement().HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pdf := []byte("%PDF-1.4 rendered statement")
+
+	//Gotenberg stand-in, converts posted index.html
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/convert/html" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("multipart form: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		file, header, err := r.FormFile("files")
+		if err != nil {
+			t.Errorf("files: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		content, _ := ioutil.ReadAll(file)
+
+		if header.Filename != "index.html" || string(content) != html {
+			t.Errorf("unexpected file %s", header.Filename)
+		}
+
+		//A4 without margins
+		for key, value := range map[string]float64{"paperWidth": 8.27, "paperHeight": 11.7, "marginTop": 0, "marginLeft": 0, "scale": 0.75} {
+			if field, err := strconv.ParseFloat(r.FormValue(key), 64); err != nil || field != value {
+				t.Errorf("%s is %q, want %v", key, r.FormValue(key), value)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(pdf)
+	}))
+	defer server.Close()
+
+	viper.Set("pdf_host", server.URL)
+	defer viper.Set("pdf_host", "")
+
+	result, err := utils.RenderPDF(html)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(result, pdf) {
+		t.Fatalf("got %q, want PDF of the stand-in", result)
+	}
+}
+
+func TestRenderPDFError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	viper.Set("pdf_host", server.URL)
+	defer viper.Set("pdf_host", "")
+
+	if _, err := utils.RenderPDF("<html></html>"); err == nil || err.Error() != "PDF_GENERATION_ERROR" {
+		t.Fatalf("got %v, want PDF_GENERATION_ERROR", err)
+	}
+}
@@ -0,0 +1,172 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/ledger"
+	"github.com/ianidi/exchange-server/internal/payment"
+	"github.com/ianidi/exchange-server/internal/trade"
+	"github.com/ianidi/exchange-server/internal/withdrawal"
+	"github.com/shopspring/decimal"
+)
+
+//Statement period format
+const PERIOD_FORMAT = "2006-01"
+
+//Statement - member account activity of a month
+type Statement struct {
+	MemberID  int64
+	Email     string
+	FirstName string
+	LastName  string
+	Period    string
+	From      time.Time
+	To        time.Time
+	Balances  []StatementBalance
+	Deposits  []StatementMovement
+	Withdraws []StatementMovement
+	Transfers []StatementMovement
+	Trades    []StatementTrade
+	Charges   []StatementCharge
+}
+
+//StatementBalance - opening and closing balance of member currency
+type StatementBalance struct {
+	Currency string
+	Opening  decimal.Decimal
+	Closing  decimal.Decimal
+}
+
+//StatementMovement - deposit, withdrawal or transfer
+type StatementMovement struct {
+	ID        int64
+	Timestamp int64
+	Type      string
+	Currency  string
+	Amount    decimal.Decimal //Negative - debited from member balance
+}
+
+//StatementTrade - trade closed during the period
+type StatementTrade struct {
+	TradeID         int64
+	Ticker          string
+	Action          string
+	Qty             decimal.Decimal
+	RateEntry       decimal.Decimal
+	RateClosed      decimal.Decimal
+	Profit          decimal.Decimal
+	AccountCurrency string
+	DateClosed      time.Time
+}
+
+//StatementCharge - swaps and fees charged by entry type
+type StatementCharge struct {
+	Type     string
+	Currency string
+	Amount   decimal.Decimal
+}
+
+//ParsePeriod returns start and end of statement month (UTC)
+func ParsePeriod(period string) (time.Time, time.Time, error) {
+	from, err := time.Parse(PERIOD_FORMAT, period)
+	if err != nil {
+		return from, from, errors.New("INVALID_PERIOD")
+	}
+
+	return from, from.AddDate(0, 1, 0), nil
+}
+
+//QueryStatement collects member statement of a month from the ledger, deposits, withdrawals, transfers and trades
+func QueryStatement(MemberID int64, period string) (Statement, error) {
+	db := db.GetDB()
+
+	statement := Statement{
+		MemberID: MemberID,
+		Period:   period,
+	}
+
+	from, to, err := ParsePeriod(period)
+	if err != nil {
+		return statement, err
+	}
+
+	statement.From = from
+	statement.To = to
+
+	start := from.Unix()
+	end := to.Unix()
+
+	var member struct {
+		Email     string
+		FirstName string
+		LastName  string
+	}
+
+	if err := db.Get(&member, "SELECT Email, FirstName, LastName FROM Member WHERE MemberID=$1", MemberID); err != nil {
+		return statement, err
+	}
+
+	statement.Email = member.Email
+	statement.FirstName = member.FirstName
+	statement.LastName = member.LastName
+
+	//Balances are member account postings before start and end of the period
+	if err := db.Select(&statement.Balances, `SELECT LedgerAccount.Currency,
+		COALESCE(SUM(LedgerPosting.Amount) FILTER (WHERE LedgerEntry.Timestamp<$1), 0) AS Opening,
+		COALESCE(SUM(LedgerPosting.Amount) FILTER (WHERE LedgerEntry.Timestamp<$2), 0) AS Closing
+		FROM LedgerAccount
+		LEFT JOIN LedgerPosting ON LedgerPosting.LedgerAccountID=LedgerAccount.LedgerAccountID
+		LEFT JOIN LedgerEntry ON LedgerEntry.LedgerEntryID=LedgerPosting.LedgerEntryID
+		WHERE LedgerAccount.Type=$3 AND LedgerAccount.MemberID=$4
+		GROUP BY LedgerAccount.Currency ORDER BY LedgerAccount.Currency ASC`, start, end, ledger.ACCOUNT_MEMBER, MemberID); err != nil {
+		return statement, err
+	}
+
+	if err := db.Select(&statement.Deposits, "SELECT DepositID AS ID, Updated AS Timestamp, Provider AS Type, Currency, Amount FROM Deposit WHERE MemberID=$1 AND Status=$2 AND Updated>=$3 AND Updated<$4 ORDER BY Updated ASC", MemberID, payment.STATUS_CREDITED, start, end); err != nil {
+		return statement, err
+	}
+
+	if err := db.Select(&statement.Withdraws, "SELECT WithdrawalID AS ID, Updated AS Timestamp, Status AS Type, Currency, -Amount AS Amount FROM Withdrawal WHERE MemberID=$1 AND Status=$2 AND Updated>=$3 AND Updated<$4 ORDER BY Updated ASC", MemberID, withdrawal.STATUS_COMPLETE, start, end); err != nil {
+		return statement, err
+	}
+
+	//Conversions are listed as two movements, debit of source and credit of target currency
+	if err := db.Select(&statement.Transfers, `SELECT TransferID AS ID, Timestamp, Type, Currency, -Amount AS Amount FROM Transfer WHERE MemberID=$1 AND Timestamp>=$2 AND Timestamp<$3
+		UNION ALL
+		SELECT TransferID AS ID, Timestamp, Type, RecipientCurrency AS Currency, RecipientAmount AS Amount FROM Transfer WHERE RecipientID=$1 AND Timestamp>=$2 AND Timestamp<$3
+		ORDER BY Timestamp ASC, ID ASC`, MemberID, start, end); err != nil {
+		return statement, err
+	}
+
+	if err := db.Select(&statement.Trades, `SELECT Trade.TradeID, COALESCE(Asset.Ticker, '') AS Ticker, Trade.Action, COALESCE(Trade.Qty, 0) AS Qty, COALESCE(Trade.RateEntry, 0) AS RateEntry, COALESCE(Trade.RateClosed, 0) AS RateClosed, COALESCE(Trade.Profit, 0) AS Profit, Trade.AccountCurrency, Trade.DateClosed
+		FROM Trade LEFT JOIN Asset ON Asset.AssetID=Trade.AssetID
+		WHERE Trade.MemberID=$1 AND Trade.Status=$2 AND Trade.DateClosed>=$3 AND Trade.DateClosed<$4 ORDER BY Trade.DateClosed ASC`, MemberID, trade.STATUS_CLOSED, from, to); err != nil {
+		return statement, err
+	}
+
+	//Swaps and fees are postings to the fees account of entries that debited the member
+	if err := db.Select(&statement.Charges, `SELECT LedgerEntry.Type, Fees.Currency, SUM(FeesPosting.Amount) AS Amount
+		FROM LedgerEntry
+		INNER JOIN LedgerPosting AS FeesPosting ON FeesPosting.LedgerEntryID=LedgerEntry.LedgerEntryID
+		INNER JOIN LedgerAccount AS Fees ON Fees.LedgerAccountID=FeesPosting.LedgerAccountID AND Fees.Type=$1
+		WHERE LedgerEntry.Timestamp>=$2 AND LedgerEntry.Timestamp<$3 AND EXISTS (SELECT 1 FROM LedgerPosting INNER JOIN LedgerAccount ON LedgerAccount.LedgerAccountID=LedgerPosting.LedgerAccountID WHERE LedgerPosting.LedgerEntryID=LedgerEntry.LedgerEntryID AND LedgerAccount.Type=$4 AND LedgerAccount.MemberID=$5 AND LedgerPosting.Amount<0)
+		GROUP BY LedgerEntry.Type, Fees.Currency ORDER BY LedgerEntry.Type ASC, Fees.Currency ASC`, ledger.ACCOUNT_FEES, start, end, ledger.ACCOUNT_MEMBER, MemberID); err != nil {
+		return statement, err
+	}
+
+	return statement, nil
+}
+
+//HTML renders statement with statement template
+func (statement Statement) HTML() (string, error) {
+	var html bytes.Buffer
+
+	if err := statementTemplate.Execute(&html, statement); err != nil {
+		return "", err
+	}
+
+	return html.String(), nil
+}
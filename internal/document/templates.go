@@ -0,0 +1,111 @@
+package document
+
+import (
+	"html/template"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/trade"
+	"github.com/shopspring/decimal"
+)
+
+var templateFuncs = template.FuncMap{
+	//UNIX timestamp or time as UTC date and time
+	"date": func(value interface{}) string {
+		switch t := value.(type) {
+		case int64:
+			return time.Unix(t, 0).UTC().Format("2006-01-02 15:04")
+		case time.Time:
+			return t.UTC().Format("2006-01-02 15:04")
+		}
+		return ""
+	},
+	"money": func(amount decimal.Decimal) string {
+		return amount.StringFixed(2)
+	},
+	"rate": func(rate decimal.Decimal) string {
+		return rate.String()
+	},
+	"action": func(action string) string {
+		if action == trade.ACTION_BUY {
+			return "Buy"
+		}
+		if action == trade.ACTION_SELL {
+			return "Sell"
+		}
+		return action
+	},
+}
+
+const documentStyle = `<style>
+body {font-family: Arial, sans-serif; font-size: 12px; padding: 40px;}
+h1 {font-size: 20px;}
+h2 {font-size: 15px; margin-top: 24px;}
+table {width: 100%; border-collapse: collapse;}
+th, td {border-bottom: 1px solid #ddd; padding: 4px 6px; text-align: left;}
+td.amount, th.amount {text-align: right;}
+</style>`
+
+var statementTemplate = template.Must(template.New("statement").Funcs(templateFuncs).Parse(`<html><head><meta charset="utf-8">` + documentStyle + `</head><body>
+<h1>Account statement {{.Period}}</h1>
+<p>{{.FirstName}} {{.LastName}} ({{.Email}})<br>Account #{{.MemberID}}<br>Period: {{date .From}} - {{date .To}} UTC</p>
+
+<h2>Balance</h2>
+<table>
+<tr><th>Currency</th><th class="amount">Opening balance</th><th class="amount">Closing balance</th></tr>
+{{range .Balances}}<tr><td>{{.Currency}}</td><td class="amount">{{money .Opening}}</td><td class="amount">{{money .Closing}}</td></tr>
+{{else}}<tr><td colspan="3">No balances</td></tr>
+{{end}}</table>
+
+<h2>Deposits</h2>
+<table>
+<tr><th>#</th><th>Date</th><th>Method</th><th class="amount">Amount</th></tr>
+{{range .Deposits}}<tr><td>{{.ID}}</td><td>{{date .Timestamp}}</td><td>{{.Type}}</td><td class="amount">{{money .Amount}} {{.Currency}}</td></tr>
+{{else}}<tr><td colspan="4">No deposits</td></tr>
+{{end}}</table>
+
+<h2>Withdrawals</h2>
+<table>
+<tr><th>#</th><th>Date</th><th class="amount">Amount</th></tr>
+{{range .Withdraws}}<tr><td>{{.ID}}</td><td>{{date .Timestamp}}</td><td class="amount">{{money .Amount}} {{.Currency}}</td></tr>
+{{else}}<tr><td colspan="3">No withdrawals</td></tr>
+{{end}}</table>
+
+<h2>Transfers and conversions</h2>
+<table>
+<tr><th>#</th><th>Date</th><th>Type</th><th class="amount">Amount</th></tr>
+{{range .Transfers}}<tr><td>{{.ID}}</td><td>{{date .Timestamp}}</td><td>{{.Type}}</td><td class="amount">{{money .Amount}} {{.Currency}}</td></tr>
+{{else}}<tr><td colspan="4">No transfers</td></tr>
+{{end}}</table>
+
+<h2>Closed trades</h2>
+<table>
+<tr><th>#</th><th>Closed</th><th>Asset</th><th>Action</th><th class="amount">Quantity</th><th class="amount">Open rate</th><th class="amount">Close rate</th><th class="amount">P&amp;L</th></tr>
+{{range .Trades}}<tr><td>{{.TradeID}}</td><td>{{date .DateClosed}}</td><td>{{.Ticker}}</td><td>{{action .Action}}</td><td class="amount">{{rate .Qty}}</td><td class="amount">{{rate .RateEntry}}</td><td class="amount">{{rate .RateClosed}}</td><td class="amount">{{money .Profit}} {{.AccountCurrency}}</td></tr>
+{{else}}<tr><td colspan="8">No closed trades</td></tr>
+{{end}}</table>
+
+<h2>Swaps and fees</h2>
+<table>
+<tr><th>Type</th><th class="amount">Amount</th></tr>
+{{range .Charges}}<tr><td>{{.Type}}</td><td class="amount">{{money .Amount}} {{.Currency}}</td></tr>
+{{else}}<tr><td colspan="2">No swaps or fees</td></tr>
+{{end}}</table>
+</body></html>`))
+
+var confirmationTemplate = template.Must(template.New("confirmation").Funcs(templateFuncs).Parse(`<html><head><meta charset="utf-8">` + documentStyle + `</head><body>
+<h1>Trade confirmation #{{.Trade.TradeID}}</h1>
+<p>{{.Email}}<br>Account #{{.MemberID}}</p>
+
+<table>
+<tr><th>Asset</th><td>{{.Ticker}} {{.Title}}</td></tr>
+<tr><th>Action</th><td>{{action .Trade.Action}}</td></tr>
+<tr><th>Quantity</th><td>{{rate .Trade.Qty.Decimal}}</td></tr>
+<tr><th>Leverage</th><td>{{rate .Trade.Leverage.Decimal}}</td></tr>
+<tr><th>Open rate</th><td>{{rate .Trade.RateEntry.Decimal}}</td></tr>
+<tr><th>Opened</th><td>{{date .Trade.Timestamp}}</td></tr>
+<tr><th>Total</th><td>{{money .Trade.Total.Decimal}} {{.Trade.AccountCurrency}}</td></tr>
+{{if eq .Event "closed"}}<tr><th>Close rate</th><td>{{rate .Trade.RateClosed.Decimal}}</td></tr>
+<tr><th>Closed</th><td>{{date .Trade.DateClosed.Time}}</td></tr>
+<tr><th>P&amp;L</th><td>{{money .Trade.Profit.Decimal}} {{.Trade.AccountCurrency}}</td></tr>
+{{end}}</table>
+</body></html>`))
@@ -0,0 +1,87 @@
+package fx
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	shopspring "github.com/jackc/pgtype/ext/shopspring-numeric"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	//Cross rates are calculated through this currency
+	CURRENCY_CROSS = "USD"
+)
+
+//Rate returns live conversion rate from one currency to another using forex assets rates
+func Rate(from string, to string) (decimal.Decimal, error) {
+
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+
+	rate, err := queryPairRate(from, to)
+	if err == nil {
+		return rate, nil
+	}
+
+	//Cross rate via USD (e.g. EUR => JPY = EURUSD * USDJPY)
+	if from != CURRENCY_CROSS && to != CURRENCY_CROSS {
+		fromCross, err := queryPairRate(from, CURRENCY_CROSS)
+		if err != nil {
+			return rate, err
+		}
+
+		crossTo, err := queryPairRate(CURRENCY_CROSS, to)
+		if err != nil {
+			return rate, err
+		}
+
+		return fromCross.Mul(crossTo), nil
+	}
+
+	return rate, err
+}
+
+//Convert amount from one currency to another (margin, profit, swaps)
+func Convert(amount decimal.Decimal, from string, to string) (decimal.Decimal, error) {
+
+	if amount.IsZero() || from == to {
+		return amount, nil
+	}
+
+	rate, err := Rate(from, to)
+	if err != nil {
+		return amount, err
+	}
+
+	return amount.Mul(rate), nil
+}
+
+//Query direct or inverse forex pair rate
+func queryPairRate(from string, to string) (decimal.Decimal, error) {
+	db := db.GetDB()
+
+	var rate shopspring.Numeric
+
+	//Direct pair (e.g. EUR => USD is EURUSD rate)
+	err := db.Get(&rate, "SELECT Rate FROM Asset WHERE MarketID=$1 AND Active=$2 AND BaseCurrency=$3 AND Currency=$4 AND Rate>0 LIMIT 1", 3, true, from, to)
+	if err == nil {
+		return rate.Decimal, nil
+	}
+	if err != sql.ErrNoRows {
+		return rate.Decimal, err
+	}
+
+	//Inverse pair (e.g. USD => EUR is 1 / EURUSD rate)
+	err = db.Get(&rate, "SELECT Rate FROM Asset WHERE MarketID=$1 AND Active=$2 AND BaseCurrency=$3 AND Currency=$4 AND Rate>0 LIMIT 1", 3, true, to, from)
+	if err == nil {
+		return decimal.NewFromInt(1).Div(rate.Decimal), nil
+	}
+	if err != sql.ErrNoRows {
+		return rate.Decimal, err
+	}
+
+	return rate.Decimal, errors.New("NO_CONVERSION_RATE")
+}
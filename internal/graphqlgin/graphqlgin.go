@@ -2,9 +2,11 @@ package graphqlgin
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
@@ -15,6 +17,9 @@ import (
 	"github.com/ianidi/exchange-server/graph"
 	"github.com/ianidi/exchange-server/graph/generated"
 	"github.com/ianidi/exchange-server/internal/jwt"
+	"github.com/ianidi/exchange-server/internal/rbac"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
 )
 
 // // Defining the Graphql handler
@@ -48,6 +53,7 @@ func GraphqlHandler(h *jwt.ProfileHandler, private bool) gin.HandlerFunc {
 		// Redis: Redis,
 
 	}}
+	c.Directives.HasPermission = HasPermission(h)
 	// c.Directives.Meta = func(ctx context.Context, obj interface{}, next graphql.Resolver, json *string, gorm *string, validate *string) (res interface{}, err error) {
 	// 	return next(ctx)
 	// }
@@ -73,6 +79,8 @@ func GraphqlHandler(h *jwt.ProfileHandler, private bool) gin.HandlerFunc {
 		},
 	})
 	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(viper.GetInt("graphql_complexity_limit")))
+	srv.Use(DepthLimit{Limit: viper.GetInt("graphql_depth_limit")})
 
 	// WebsocketInitFunc(func(ctx context.Context, initPayload InitPayload) (context.Context, error) {
 	// 	userId, err := validateAndGetUserID(payload["token"])
@@ -116,26 +124,42 @@ func GraphqlHandler(h *jwt.ProfileHandler, private bool) gin.HandlerFunc {
 				return
 			}
 
-			metadata, err := h.TK.ExtractTokenMetadata(c.Request)
+			metadata, err := h.Authorize(jwt.ExtractToken(c.Request))
 			if err != nil {
 				c.JSON(http.StatusUnauthorized, "unauthorized")
+				c.Abort()
 				return
 			}
-			// userId, err := h.RD.FetchAuth(metadata.TokenUuid)
-			// if err != nil {
-			// 	c.JSON(http.StatusUnauthorized, "unauthorized")
-			// 	return
-			// }
-
-			// fmt.Println(c.FullPath(), userId)
 
 			c.Set(jwt.MemberKey, metadata.UserId)
+			c.Set(jwt.SessionKey, metadata.SessionID)
 		}
 
 		srv.ServeHTTP(c.Writer, c.Request)
 	}
 }
 
+//HasPermission - @hasPermission directive, field is resolved if the signed in member has the permission
+func HasPermission(h *jwt.ProfileHandler) func(ctx context.Context, obj interface{}, next graphql.Resolver, permission string) (interface{}, error) {
+	return func(ctx context.Context, obj interface{}, next graphql.Resolver, permission string) (interface{}, error) {
+		gc, ok := ctx.Value("GinContextKey").(*gin.Context)
+		if !ok {
+			return nil, errors.New("ACCESS_DENIED")
+		}
+
+		metadata, err := h.Authorize(jwt.ExtractToken(gc.Request))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := rbac.Check(cast.ToInt64(metadata.UserId), permission); err != nil {
+			return nil, err
+		}
+
+		return next(ctx)
+	}
+}
+
 // Defining the Playground handler
 func PlaygroundHandler() gin.HandlerFunc {
 	h := playground.Handler("GraphQL", "/query")
@@ -0,0 +1,83 @@
+package graphqlgin
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+
+func init() {
+	//Rejected like invalid queries, with 422 status
+	errcode.RegisterErrorType(errDepthLimit, errcode.KindProtocol)
+}
+
+//DepthLimit rejects operations with selections nested deeper than Limit
+type DepthLimit struct {
+	Limit int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = DepthLimit{}
+
+func (d DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d DepthLimit) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	op := rc.Doc.Operations.ForName(rc.OperationName)
+	if op == nil {
+		return nil
+	}
+
+	depth := selectionDepth(rc.Doc, op.SelectionSet, map[string]bool{})
+
+	if depth > d.Limit {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.Limit)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+
+	return nil
+}
+
+//selectionDepth returns depth of the deepest field, fragments are nested at the level they are spread
+func selectionDepth(doc *ast.QueryDocument, selections ast.SelectionSet, visited map[string]bool) int {
+	depth := 0
+
+	for _, selection := range selections {
+		var child int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			child = 1 + selectionDepth(doc, selection.SelectionSet, visited)
+		case *ast.InlineFragment:
+			child = selectionDepth(doc, selection.SelectionSet, visited)
+		case *ast.FragmentSpread:
+			fragment := doc.Fragments.ForName(selection.Name)
+			if fragment == nil || visited[selection.Name] {
+				continue
+			}
+
+			visited[selection.Name] = true
+			child = selectionDepth(doc, fragment.SelectionSet, visited)
+			delete(visited, selection.Name)
+		}
+
+		if child > depth {
+			depth = child
+		}
+	}
+
+	return depth
+}
@@ -0,0 +1,102 @@
+package job
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/ianidi/exchange-server/internal/audit"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+//RATES_JOB scheduler job that runs market jobs from Job table
+const RATES_JOB = "rates"
+
+//QueryJobs returns market jobs with their last run status
+func QueryJobs() ([]models.Job, error) {
+	db := db.GetDB()
+
+	job := []models.Job{}
+
+	if err := db.Select(&job, "SELECT * FROM Job ORDER BY JobID ASC"); err != nil {
+		return job, err
+	}
+
+	return job, nil
+}
+
+//QueryJob returns market job by ID
+func QueryJob(JobID int64) (models.Job, error) {
+	db := db.GetDB()
+
+	var job models.Job
+
+	if err := db.Get(&job, "SELECT * FROM Job WHERE JobID=$1", JobID); err != nil {
+		if err == sql.ErrNoRows {
+			return job, errors.New("NO_JOB_RECORD")
+		}
+		return job, err
+	}
+
+	return job, nil
+}
+
+//UpdateJob enables or disables market job and changes its interval, picked up by the next RatesJob run
+func UpdateJob(MemberID int64, JobID int64, Interval int64, Active bool) error {
+	db := db.GetDB()
+
+	if Interval < 1 {
+		return errors.New("INVALID_INTERVAL")
+	}
+
+	if _, err := QueryJob(JobID); err != nil {
+		return err
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Job SET Interval=$1, Active=$2 WHERE JobID=$3", Interval, Active, JobID)
+	audit.Entry{
+		MemberID: MemberID,
+		Action:   "job.update",
+		Entity:   "job",
+		EntityID: JobID,
+		Data: map[string]interface{}{
+			"Interval": Interval,
+			"Active":   Active,
+		},
+	}.Record(tx)
+	tx.Commit()
+
+	return nil
+}
+
+//TriggerJob makes market job due and runs RatesJob right away
+func TriggerJob(MemberID int64, JobID int64) error {
+	db := db.GetDB()
+
+	job, err := QueryJob(JobID)
+	if err != nil {
+		return err
+	}
+
+	if !job.Active {
+		return errors.New("JOB_NOT_ACTIVE")
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Job SET Timestamp=$1 WHERE JobID=$2", 0, JobID)
+	audit.Entry{
+		MemberID: MemberID,
+		Action:   "job.trigger",
+		Entity:   "job",
+		EntityID: JobID,
+	}.Record(tx)
+	tx.Commit()
+
+	//Job is due now, a run in progress on another instance picks it up next time
+	if err := Trigger(RATES_JOB); err != nil && err.Error() != "JOB_ALREADY_RUNNING" {
+		return err
+	}
+
+	return nil
+}
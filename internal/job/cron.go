@@ -0,0 +1,212 @@
+package job
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+//Schedule returns the next activation time after a given time
+type Schedule interface {
+	Next(time.Time) time.Time
+}
+
+//IntervalSchedule runs every Interval, aligned to the interval since UNIX epoch
+type IntervalSchedule struct {
+	Interval time.Duration
+}
+
+//CronSchedule - parsed cron expression, each field is a bit set of allowed values
+type CronSchedule struct {
+	Minute  uint64
+	Hour    uint64
+	Day     uint64
+	Month   uint64
+	Weekday uint64
+	AnyDay  bool //Day of month is "*"
+	AnyWeek bool //Day of week is "*"
+}
+
+//Cron field bounds
+var cronBounds = []struct {
+	Min int
+	Max int
+}{
+	{0, 59}, //Minute
+	{0, 23}, //Hour
+	{1, 31}, //Day of month
+	{1, 12}, //Month
+	{0, 6},  //Day of week (0 - sunday)
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+//ParseSchedule parses "@every <duration>", a descriptor like "@hourly" or a 5 field cron expression (minute hour day month weekday)
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, err
+		}
+		if interval < time.Second {
+			return nil, errors.New("INVALID_SCHEDULE_INTERVAL")
+		}
+		return IntervalSchedule{Interval: interval}, nil
+	}
+
+	if expression, ok := cronDescriptors[spec]; ok {
+		spec = expression
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronBounds) {
+		return nil, errors.New("INVALID_CRON_EXPRESSION")
+	}
+
+	var bits [5]uint64
+
+	for i, field := range fields {
+		var err error
+		bits[i], err = parseCronField(field, cronBounds[i].Min, cronBounds[i].Max)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//Sunday can be written as 7
+	if bits[4]&(1<<7) > 0 {
+		bits[4] |= 1
+	}
+
+	return CronSchedule{
+		Minute:  bits[0],
+		Hour:    bits[1],
+		Day:     bits[2],
+		Month:   bits[3],
+		Weekday: bits[4],
+		AnyDay:  fields[2] == "*",
+		AnyWeek: fields[4] == "*",
+	}, nil
+}
+
+//parseCronField parses comma separated list of "*", "N", "N-M" with optional "/step"
+func parseCronField(field string, min int, max int) (uint64, error) {
+	var bits uint64
+
+	//Day of week accepts 7 as sunday
+	if max == 6 {
+		max = 7
+	}
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = cast.ToIntE(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.New("INVALID_CRON_EXPRESSION")
+			}
+			part = part[:i]
+		}
+
+		start, end := min, max
+
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+
+			var err error
+			start, err = cast.ToIntE(bounds[0])
+			if err != nil {
+				return 0, errors.New("INVALID_CRON_EXPRESSION")
+			}
+
+			end = start
+			if len(bounds) == 2 {
+				end, err = cast.ToIntE(bounds[1])
+				if err != nil {
+					return 0, errors.New("INVALID_CRON_EXPRESSION")
+				}
+			} else if step > 1 {
+				//"N/step" means from N to the end of range
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return 0, errors.New("INVALID_CRON_EXPRESSION")
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+//Next interval boundary after t. Time.Truncate aligns to the zero time, boundaries are counted from UNIX epoch instead
+func (schedule IntervalSchedule) Next(t time.Time) time.Time {
+	interval := int64(schedule.Interval)
+	elapsed := t.UnixNano()
+
+	return time.Unix(0, elapsed-elapsed%interval+interval).In(t.Location())
+}
+
+//Next minute after t matching cron expression
+func (schedule CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	//Expression may never match (e.g. 30th of February), give up after 5 years
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if schedule.Month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !schedule.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if schedule.Hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if schedule.Minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+//matchDay follows cron rule: if both day of month and day of week are restricted, either of them matches
+func (schedule CronSchedule) matchDay(t time.Time) bool {
+	day := schedule.Day&(1<<uint(t.Day())) > 0
+	weekday := schedule.Weekday&(1<<uint(t.Weekday())) > 0
+
+	if schedule.AnyDay || schedule.AnyWeek {
+		return day && weekday
+	}
+
+	return day || weekday
+}
@@ -0,0 +1,104 @@
+package job
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	//Tuesday
+	now := time.Date(2020, 9, 1, 10, 17, 30, 0, time.UTC)
+
+	for _, test := range []struct {
+		spec string
+		now  time.Time
+		next time.Time
+	}{
+		{"* * * * *", now, time.Date(2020, 9, 1, 10, 18, 0, 0, time.UTC)},
+		{"@hourly", now, time.Date(2020, 9, 1, 11, 0, 0, 0, time.UTC)},
+		{"@daily", now, time.Date(2020, 9, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", now, time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", now, time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", now, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		//Ranges and lists
+		{"15-20 10 * * *", now, time.Date(2020, 9, 1, 10, 18, 0, 0, time.UTC)},
+		{"0,45 9-11 * * *", now, time.Date(2020, 9, 1, 10, 45, 0, 0, time.UTC)},
+		{"5 9-10 * * *", now, time.Date(2020, 9, 2, 9, 5, 0, 0, time.UTC)},
+		//Steps
+		{"*/15 * * * *", now, time.Date(2020, 9, 1, 10, 30, 0, 0, time.UTC)},
+		{"10/20 * * * *", now, time.Date(2020, 9, 1, 10, 30, 0, 0, time.UTC)},
+		{"0 0-12/6 * * *", now, time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)},
+		//Day of week, sunday as 0 or 7
+		{"0 0 * * 5", now, time.Date(2020, 9, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", now, time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-5", now, time.Date(2020, 9, 2, 0, 0, 0, 0, time.UTC)},
+		//Day of month and day of week both restricted, either matches
+		{"0 0 15 * 5", now, time.Date(2020, 9, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 2 * 5", now, time.Date(2020, 9, 2, 0, 0, 0, 0, time.UTC)},
+		//Day of month with any weekday
+		{"0 0 31 * *", now, time.Date(2020, 10, 31, 0, 0, 0, 0, time.UTC)},
+		//Month and year rollover
+		{"0 0 1 * *", time.Date(2020, 12, 31, 23, 59, 0, 0, time.UTC), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 23 * * *", time.Date(2020, 9, 30, 23, 30, 0, 0, time.UTC), time.Date(2020, 10, 1, 23, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", now, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * 3 *", now, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)},
+		//Never matches
+		{"0 0 30 2 *", now, time.Time{}},
+	} {
+		schedule, err := ParseSchedule(test.spec)
+		if err != nil {
+			t.Errorf("%s: %v", test.spec, err)
+			continue
+		}
+
+		if next := schedule.Next(test.now); !next.Equal(test.next) {
+			t.Errorf("%s: next %v, want %v", test.spec, next, test.next)
+		}
+	}
+}
+
+func TestIntervalScheduleNext(t *testing.T) {
+	for _, test := range []struct {
+		spec string
+		now  time.Time
+		next time.Time
+	}{
+		{"@every 1m", time.Date(2020, 9, 1, 10, 17, 30, 0, time.UTC), time.Date(2020, 9, 1, 10, 18, 0, 0, time.UTC)},
+		{"@every 1h", time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC), time.Date(2020, 9, 1, 11, 0, 0, 0, time.UTC)},
+		//Boundaries are counted from UNIX epoch, not the zero time
+		{"@every 7m", time.Unix(7*60*1000+30, 0), time.Unix(7*60*1001, 0)},
+		{"@every 90s", time.Unix(90*1000, 0), time.Unix(90*1001, 0)},
+	} {
+		schedule, err := ParseSchedule(test.spec)
+		if err != nil {
+			t.Errorf("%s: %v", test.spec, err)
+			continue
+		}
+
+		if next := schedule.Next(test.now); !next.Equal(test.next) {
+			t.Errorf("%s: next %v, want %v", test.spec, next, test.next)
+		}
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"20-10 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every 1ms",
+		"@every soon",
+	} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("%q accepted", spec)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/document"
+	"github.com/ianidi/exchange-server/internal/ledger"
+	"github.com/ianidi/exchange-server/internal/trade"
+)
+
+//StatementJob creates monthly account statements of the previous month
+type StatementJob struct {
+}
+
+//Spec how often to run the job
+func (StatementJob) Spec() string {
+	return "0 4 1 * *"
+}
+
+func (StatementJob) Run(ctx context.Context) error {
+	db := db.GetDB()
+
+	now := time.Now().UTC()
+	period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0).Format(document.PERIOD_FORMAT)
+
+	//Members with a ledger account, statements are emailed to members with email notifications
+	var members []struct {
+		MemberID           int64
+		EmailNotifications bool
+	}
+
+	if err := db.Select(&members, "SELECT Member.MemberID, Member.EmailNotifications FROM Member WHERE EXISTS (SELECT 1 FROM LedgerAccount WHERE LedgerAccount.Type=$1 AND LedgerAccount.MemberID=Member.MemberID)", ledger.ACCOUNT_MEMBER); err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		tx := db.MustBegin()
+		if _, _, err := document.Create(tx, member.MemberID, document.TYPE_STATEMENT, period, 0, member.EmailNotifications); err != nil {
+			tx.Rollback()
+			return err
+		}
+		tx.Commit()
+	}
+
+	return nil
+}
+
+//ConfirmationJob creates confirmation notes of opened and closed trades
+type ConfirmationJob struct {
+}
+
+//Spec how often to run the job
+func (ConfirmationJob) Spec() string {
+	return "@every 1m"
+}
+
+const (
+	//Trades opened or closed earlier are not confirmed (e.g. before confirmations were introduced)
+	CONFIRMATION_LOOKBACK = 7 * 24 * time.Hour
+
+	CONFIRMATION_BATCH = 500
+)
+
+func (ConfirmationJob) Run(ctx context.Context) error {
+	db := db.GetDB()
+
+	since := time.Now().Add(-CONFIRMATION_LOOKBACK)
+
+	var trades []struct {
+		TradeID            int64
+		MemberID           int64
+		Status             string
+		EmailNotifications bool
+	}
+
+	if err := db.Select(&trades, `SELECT Trade.TradeID, Trade.MemberID, Trade.Status, Member.EmailNotifications FROM Trade
+		INNER JOIN Member ON Member.MemberID=Trade.MemberID
+		WHERE ((Trade.Status=$1 AND Trade.Timestamp>=$2) OR (Trade.Status=$3 AND Trade.DateClosed>=$4))
+		AND NOT EXISTS (SELECT 1 FROM Document WHERE Document.TradeID=Trade.TradeID AND Document.Type=$5 AND Document.Period=Trade.Status)
+		ORDER BY Trade.TradeID ASC LIMIT $6`, trade.STATUS_OPEN, since.Unix(), trade.STATUS_CLOSED, since, document.TYPE_CONFIRMATION, CONFIRMATION_BATCH); err != nil {
+		return err
+	}
+
+	for _, row := range trades {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		tx := db.MustBegin()
+		if _, _, err := document.Create(tx, row.MemberID, document.TYPE_CONFIRMATION, row.Status, row.TradeID, row.EmailNotifications); err != nil {
+			tx.Rollback()
+			return err
+		}
+		tx.Commit()
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package job
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/metrics"
+)
+
+//Price providers
+const (
+	PROVIDER_FCS         = "fcs"
+	PROVIDER_IEX         = "iex"
+	PROVIDER_CRYPTONATOR = "cryptonator"
+)
+
+var (
+	feedLatency       = metrics.NewHistogram("feed_tick_latency_seconds", "Price provider request latency.", []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}, "provider")
+	feedErrors        = metrics.NewCounter("feed_errors_total", "Price provider request errors.", "provider")
+	feedTicks         = metrics.NewCounter("feed_ticks_total", "Asset rate updates applied.", "asset", "market")
+	feedRejected      = metrics.NewCounter("feed_rejected_ticks_total", "Ticks rejected by asset rate update.", "provider", "reason")
+	feedUpdateErrors  = metrics.NewCounter("feed_update_errors_total", "Asset rate updates that failed, rejected ticks included.", "provider")
+	feedAssetAge      = metrics.NewGauge("feed_asset_last_update_age_seconds", "Seconds since asset rate was last updated.", "asset", "market")
+	feedProviderAge   = metrics.NewGauge("feed_provider_last_success_age_seconds", "Seconds since the last successful price provider request.", "provider")
+	feedStaleAssets   = metrics.NewGauge("feed_stale_assets", "Assets without rate updates during market trading session.", "market")
+	providerSuccessMu sync.Mutex
+	providerSuccess   = map[string]time.Time{}
+)
+
+func init() {
+	metrics.RegisterCollector(CollectFeedMetrics)
+}
+
+//ObserveProvider records price provider request latency and result
+func ObserveProvider(provider string, start time.Time, err error) {
+	feedLatency.Observe(time.Since(start).Seconds(), provider)
+
+	if err != nil {
+		feedErrors.Inc(provider)
+		log.Println("Provider error", provider, err)
+		return
+	}
+
+	providerSuccessMu.Lock()
+	providerSuccess[provider] = time.Now()
+	providerSuccessMu.Unlock()
+}
+
+//UpdateError counts and logs asset rate update that failed. ID is the asset ticker or FCS ID of the tick
+func UpdateError(rate Rate, ID interface{}, err error) {
+	feedUpdateErrors.Inc(rate.Provider())
+	log.Println("Rate update error", rate.Provider(), ID, rate.RateString, err)
+}
+
+//RejectTick counts a tick of price provider that was not applied to asset and passes the error through
+func RejectTick(provider string, reason string, err error) error {
+	feedRejected.Inc(provider, reason)
+	return err
+}
+
+//CollectFeedMetrics refreshes last update ages, called before metrics are exported
+func CollectFeedMetrics() {
+	db := db.GetDB()
+
+	now := time.Now()
+
+	var asset []struct {
+		Ticker   string
+		MarketID int64
+		Updated  int64
+	}
+
+	if err := db.Select(&asset, "SELECT Ticker, MarketID, Updated FROM Asset WHERE Tradable=$1 AND Active=$2", true, true); err == nil {
+		feedAssetAge.Reset()
+		for _, assetRow := range asset {
+			feedAssetAge.Set(float64(now.Unix()-assetRow.Updated), assetRow.Ticker, fmt.Sprint(assetRow.MarketID))
+		}
+	}
+
+	if stale, err := QueryStaleAssets(now); err == nil {
+		count := map[int64]int{}
+		for _, staleRow := range stale {
+			count[staleRow.MarketID]++
+		}
+		feedStaleAssets.Reset()
+		for MarketID, assets := range count {
+			feedStaleAssets.Set(float64(assets), fmt.Sprint(MarketID))
+		}
+	}
+
+	providerSuccessMu.Lock()
+	defer providerSuccessMu.Unlock()
+
+	for provider, success := range providerSuccess {
+		feedProviderAge.Set(now.Sub(success).Seconds(), provider)
+	}
+}
@@ -1,23 +1,239 @@
 package job
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
 
-// Job provides the ability to execute timed jobs in their own goroutine.
+	"github.com/ianidi/exchange-server/internal/db"
+)
+
+// Job provides the ability to execute scheduled jobs in their own goroutine.
 type Job interface {
-	// Run is called when the job is triggered.
-	Run()
+	// Run is called when the job is triggered. ctx is cancelled when the
+	// server shuts down or the job lease is lost.
+	Run(ctx context.Context) error
+
+	// Spec returns the job schedule: a cron expression ("*/5 * * * *"),
+	// a descriptor ("@hourly") or an interval ("@every 20s").
+	Spec() string
+}
+
+const (
+	//Job lease expires unless renewed by a running job (crashed instance)
+	LEASE_TTL = time.Minute
+
+	JOB_RUN_RUNNING = "running"
+	JOB_RUN_SUCCESS = "success"
+	JOB_RUN_FAILED  = "failed"
+)
+
+var (
+	schedulerCtx, schedulerCancel = context.WithCancel(context.Background())
+	schedulerWG                   sync.WaitGroup
+	registryMu                    sync.Mutex
+	registry                      = map[string]entry{}
+)
+
+//Scheduled job
+type entry struct {
+	Name     string
+	Job      Job
+	Schedule Schedule
+}
+
+// RegisterJob schedules a job for execution. Name must be unique, it is
+// used for the distributed lease and run history.
+func RegisterJob(name string, j Job) {
+	schedule, err := ParseSchedule(j.Spec())
+	if err != nil {
+		log.Println("RegisterJob error", name, j.Spec(), err)
+		return
+	}
+
+	e := entry{
+		Name:     name,
+		Job:      j,
+		Schedule: schedule,
+	}
+
+	registryMu.Lock()
+	registry[name] = e
+	registryMu.Unlock()
+
+	schedulerWG.Add(1)
+	go func() {
+		defer schedulerWG.Done()
+		e.loop(schedulerCtx)
+	}()
+}
+
+// Shutdown stops scheduling, cancels running jobs and waits until they
+// return or ctx expires.
+func Shutdown(ctx context.Context) error {
+	schedulerCancel()
+
+	done := make(chan struct{})
+	go func() {
+		schedulerWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Trigger runs a registered job right away on this instance, outside of
+// its schedule. It fails if the job is running on any instance.
+func Trigger(name string) error {
+	if schedulerCtx.Err() != nil {
+		return errors.New("SCHEDULER_STOPPED")
+	}
+
+	registryMu.Lock()
+	e, ok := registry[name]
+	registryMu.Unlock()
+
+	if !ok {
+		return errors.New("JOB_NOT_REGISTERED")
+	}
+
+	lease, ok, err := AcquireLease("job:"+e.Name+":lease", LEASE_TTL)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("JOB_ALREADY_RUNNING")
+	}
+
+	schedulerWG.Add(1)
+	go func() {
+		defer schedulerWG.Done()
+		e.hold(schedulerCtx, lease)
+	}()
+
+	return nil
+}
+
+func (e entry) loop(ctx context.Context) {
+	for {
+		next := e.Schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Println("Job is never scheduled", e.Name)
+			return
+		}
 
-	// SleepTime returns the amount of time to sleep before running
-	// the job again.
-	SleepTime() time.Duration
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		e.execute(ctx, next)
+	}
+}
+
+//execute runs the job once per scheduled time across all instances
+func (e entry) execute(ctx context.Context, slot time.Time) {
+	//Every instance wakes up at the same scheduled time, only the first one runs it
+	window := e.Schedule.Next(slot).Sub(slot)
+	if window < time.Second {
+		window = time.Second
+	}
+
+	_, ok, err := AcquireLease(fmt.Sprintf("job:%s:slot:%d", e.Name, slot.Unix()), window)
+	if err != nil {
+		log.Println("Job lease error", e.Name, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	//Previous run may still be in progress on another instance
+	lease, ok, err := AcquireLease("job:"+e.Name+":lease", LEASE_TTL)
+	if err != nil {
+		log.Println("Job lease error", e.Name, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	e.hold(ctx, lease)
+}
+
+//hold runs the job while keeping its lease alive, the run is cancelled if the lease is lost
+func (e entry) hold(ctx context.Context, lease Lease) {
+	defer lease.Release()
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stop, lost := lease.KeepAlive()
+	defer stop()
+
+	go func() {
+		select {
+		case <-lost:
+			cancel()
+		case <-runCtx.Done():
+		}
+	}()
+
+	e.record(runCtx)
 }
 
-// RegisterJob schedules a job for execution
-func RegisterJob(j Job) {
-	go func(j Job) {
-		for {
-			j.Run()
-			time.Sleep(j.SleepTime())
+//record runs the job and stores its start, end, duration and error in JobRun
+func (e entry) record(ctx context.Context) {
+	db := db.GetDB()
+
+	started := time.Now()
+
+	var JobRunID int64
+
+	if err := db.Get(&JobRunID, "INSERT INTO JobRun (Name, Instance, Timestamp, Status) VALUES ($1, $2, $3, $4) RETURNING JobRunID", e.Name, Instance, started.Unix(), JOB_RUN_RUNNING); err != nil {
+		log.Println("JobRun error", e.Name, err)
+	}
+
+	err := e.run(ctx)
+
+	status := JOB_RUN_SUCCESS
+	message := ""
+	if err != nil {
+		status = JOB_RUN_FAILED
+		message = err.Error()
+		log.Println("Job error", e.Name, err)
+	}
+
+	finished := time.Now()
+
+	if JobRunID != 0 {
+		if _, err := db.Exec("UPDATE JobRun SET Finished=$1, Duration=$2, Status=$3, Error=$4 WHERE JobRunID=$5", finished.Unix(), finished.Sub(started).Milliseconds(), status, message, JobRunID); err != nil {
+			log.Println("JobRun error", e.Name, err)
+		}
+	}
+}
+
+//run calls the job, panic is recovered and returned as error
+func (e entry) run(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("PANIC: %v", r)
+			log.Println("Job panic", e.Name, r, string(debug.Stack()))
 		}
-	}(j)
+	}()
+
+	return e.Job.Run(ctx)
 }
@@ -0,0 +1,21 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/jwk"
+)
+
+//JWKJob rotates token signing keys every jwt_key_rotation, the next key is published ahead in JWKS
+type JWKJob struct {
+}
+
+//Spec how often to run the job
+func (JWKJob) Spec() string {
+	return "@every 10m"
+}
+
+func (JWKJob) Run(ctx context.Context) error {
+	return jwk.Rotate(time.Now())
+}
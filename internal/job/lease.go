@@ -0,0 +1,103 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/spf13/cast"
+)
+
+//Lease - distributed lock in Redis, only the owner instance may run the job while it holds the lease
+type Lease struct {
+	Key   string
+	Owner string
+	TTL   time.Duration
+}
+
+//Instance identifies this server process among replicas
+var Instance = instanceName()
+
+//Lease is only renewed or released by its owner
+var (
+	renewScript   = radix.NewEvalScript(1, `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("PEXPIRE", KEYS[1], ARGV[2]) else return 0 end`)
+	releaseScript = radix.NewEvalScript(1, `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`)
+)
+
+func instanceName() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
+
+//AcquireLease takes the lease if nobody holds it, false if it is held by another instance
+func AcquireLease(key string, ttl time.Duration) (Lease, bool, error) {
+	lease := Lease{
+		Key:   key,
+		Owner: Instance,
+		TTL:   ttl,
+	}
+
+	pool := redis.GetRedis()
+	if pool == nil {
+		return lease, false, errors.New("REDIS_NOT_AVAILABLE")
+	}
+
+	var res string
+	reply := radix.MaybeNil{Rcv: &res}
+
+	if err := pool.Do(radix.Cmd(&reply, "SET", key, lease.Owner, "NX", "PX", cast.ToString(ttl.Milliseconds()))); err != nil {
+		return lease, false, err
+	}
+
+	return lease, !reply.Nil, nil
+}
+
+//Renew extends the lease, false if it expired and was taken by another instance
+func (lease Lease) Renew() (bool, error) {
+	var res int
+
+	if err := redis.GetRedis().Do(renewScript.Cmd(&res, lease.Key, lease.Owner, cast.ToString(lease.TTL.Milliseconds()))); err != nil {
+		return false, err
+	}
+
+	return res == 1, nil
+}
+
+//Release gives the lease up if it is still held by its owner
+func (lease Lease) Release() error {
+	var res int
+
+	return redis.GetRedis().Do(releaseScript.Cmd(&res, lease.Key, lease.Owner))
+}
+
+//KeepAlive renews the lease until stop is called, lost is closed if the lease can't be renewed
+func (lease Lease) KeepAlive() (stop func(), lost <-chan struct{}) {
+	done := make(chan struct{})
+	lostCh := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(lease.TTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if ok, err := lease.Renew(); err != nil || !ok {
+					fmt.Println("Lease lost", lease.Key, err)
+					close(lostCh)
+					return
+				}
+			}
+		}
+	}()
+
+	return func() { close(done) }, lostCh
+}
@@ -0,0 +1,49 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/outbox"
+)
+
+//OutboxJob publishes events recorded in the Outbox table to redis
+type OutboxJob struct {
+}
+
+const (
+	//Pause between relay passes when there are no new events
+	OUTBOX_POLL_INTERVAL = 200 * time.Millisecond
+
+	//Relay keeps running within a scheduled run, so events are published without waiting for the next run
+	OUTBOX_RUN_DURATION = 55 * time.Second
+)
+
+//Spec how often to run the job
+func (OutboxJob) Spec() string {
+	return "@every 1m"
+}
+
+func (OutboxJob) Run(ctx context.Context) error {
+	deadline := time.Now().Add(OUTBOX_RUN_DURATION)
+
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		published, err := outbox.Relay()
+		if err != nil {
+			return err
+		}
+
+		//Continue right away while there is a backlog
+		if published > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(OUTBOX_POLL_INTERVAL):
+		}
+	}
+
+	return nil
+}
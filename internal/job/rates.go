@@ -1,33 +1,33 @@
 package job
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ianidi/exchange-server/graph/model"
 	"github.com/ianidi/exchange-server/internal/db"
 	"github.com/ianidi/exchange-server/internal/models"
-	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/ianidi/exchange-server/internal/outbox"
+	"github.com/ianidi/exchange-server/internal/spread"
 	"github.com/ianidi/exchange-server/internal/trade"
 	shopspring "github.com/jackc/pgtype/ext/shopspring-numeric"
-	jsoniter "github.com/json-iterator/go"
 	"github.com/parnurzeal/gorequest"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/cast"
 )
 
-var json = jsoniter.ConfigCompatibleWithStandardLibrary
-
 type RatesJob struct {
 }
 
-//SleepTime how often to run the job
-func (RatesJob) SleepTime() time.Duration {
-	return time.Second * 20
+//Spec how often to run the job
+func (RatesJob) Spec() string {
+	return "@every 20s"
 }
 
 const (
@@ -50,9 +50,10 @@ type Rate struct {
 	Change     decimal.Decimal    //24H change (%)
 	Timestamp  int64              //UNIX timestamp
 	FcsID      string
+	Spread     spread.Spread //Effective asset spread
 }
 
-func (RatesJob) Run() {
+func (RatesJob) Run(ctx context.Context) error {
 	db := db.GetDB()
 
 	var rate Rate
@@ -60,7 +61,7 @@ func (RatesJob) Run() {
 
 	rate.Settings, err = rate.QuerySettings()
 	if err != nil {
-		return
+		return err
 	}
 
 	rate.Timestamp = time.Now().Unix()
@@ -68,12 +69,18 @@ func (RatesJob) Run() {
 	var job []models.Job
 
 	if err := db.Select(&job, "SELECT * FROM Job WHERE (Timestamp + Interval) < $1 AND Active=$2", rate.Timestamp, true); err != nil {
-		return
+		return err
 	}
 
 	for _, jobRow := range job {
 
-		rate.Timestamp = time.Now().Unix()
+		//Server is shutting down, remaining markets are updated by the next run
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		started := time.Now()
+		rate.Timestamp = started.Unix()
 		var err error
 
 		rate.Interval = jobRow.Interval
@@ -88,15 +95,24 @@ func (RatesJob) Run() {
 			err = rate.FcsUpdate()
 		}
 
+		status := JOB_RUN_SUCCESS
+		message := ""
+		if err != nil {
+			status = JOB_RUN_FAILED
+			message = err.Error()
+		}
+
+		tx := db.MustBegin()
 		//Update the last completion time if job succeeded
 		if err == nil {
-			tx := db.MustBegin()
 			tx.Exec("UPDATE Job SET Timestamp=$1 WHERE JobID=$2", rate.Timestamp, jobRow.JobID)
-			tx.Commit()
 		}
+		tx.Exec("UPDATE Job SET LastRun=$1, LastStatus=$2, LastError=$3, LastDuration=$4 WHERE JobID=$5", started.Unix(), status, message, time.Since(started).Milliseconds(), jobRow.JobID)
+		tx.Commit()
 
 	}
 
+	return nil
 }
 
 func (rate Rate) IexUpdate() error {
@@ -121,7 +137,7 @@ func (rate Rate) IexUpdate() error {
 		if err == nil {
 			err = rate.Update()
 			if err != nil {
-				fmt.Println("DefaultUpdate error", rate.Asset.Ticker, rate.RateString, err)
+				UpdateError(rate, rate.Asset.Ticker, err)
 			}
 		}
 
@@ -152,7 +168,7 @@ func (rate Rate) DefaultUpdateOld() error {
 		if err == nil {
 			err = rate.Update()
 			if err != nil {
-				fmt.Println("DefaultUpdate error", rate.Asset.Ticker, rate.RateString, err)
+				UpdateError(rate, rate.Asset.Ticker, err)
 			}
 		}
 
@@ -161,11 +177,31 @@ func (rate Rate) DefaultUpdateOld() error {
 	return nil
 }
 
+//Provider returns price provider of the rate
+func (rate Rate) Provider() string {
+	if rate.FcsID != "" {
+		return PROVIDER_FCS
+	}
+
+	if rate.Asset.MarketID == 1 {
+		return PROVIDER_CRYPTONATOR
+	}
+
+	return PROVIDER_IEX
+}
+
 func (rate Rate) QueryRateStringFromAPI() (string, error) {
 
 	var err error
 	var rateString string
 
+	provider := rate.Provider()
+
+	start := time.Now()
+	defer func() {
+		ObserveProvider(provider, start, err)
+	}()
+
 	if rate.Asset.MarketID == 1 {
 		//Crypto
 		rateString, err = rate.QueryCryptonator()
@@ -195,6 +231,8 @@ func (rate Rate) FcsUpdate() error {
 	var err error
 	var res FCSStockRes
 
+	start := time.Now()
+
 	//Crypto
 	if rate.MarketID == 1 {
 		res, err = rate.QueryCrypto()
@@ -215,10 +253,15 @@ func (rate Rate) FcsUpdate() error {
 		res, err = rate.QueryIndices()
 	}
 
+	ObserveProvider(PROVIDER_FCS, start, err)
+
 	if err != nil {
 		return err
 	}
 
+	//Wait for all asset updates so the job run covers them
+	var wg sync.WaitGroup
+
 	for _, resRow := range res.Response {
 
 		// rate.Asset.Ticker = resRow.Symbol
@@ -226,9 +269,17 @@ func (rate Rate) FcsUpdate() error {
 		rate.RateString = cast.ToString(resRow.Price)
 
 		// fmt.Println(rate.FcsID, rate.RateString)
-		go rate.Update()
+		wg.Add(1)
+		go func(rate Rate) {
+			defer wg.Done()
+			if err := rate.Update(); err != nil {
+				UpdateError(rate, rate.FcsID, err)
+			}
+		}(rate)
 	}
 
+	wg.Wait()
+
 	return nil
 }
 
@@ -241,38 +292,46 @@ func (rate Rate) Update() error {
 
 	rate.Timestamp = time.Now().Unix()
 
+	//Rejected ticks are counted by provider, labels per asset would grow with every unknown FCS ID
+	provider := rate.Provider()
+
 	rate.Rate, err = decimal.NewFromString(rate.RateString)
 	if err != nil {
 
-		return err
+		return RejectTick(provider, "INVALID_RATE", err)
 	}
 
 	//If new rate is 0, don't update this asset
 	if rate.Rate.IsZero() {
-		return errors.New("RATE_IS_ZERO")
+		return RejectTick(provider, "RATE_IS_ZERO", errors.New("RATE_IS_ZERO"))
 	}
 
 	if rate.FcsID != "" {
 		rate.Asset, err = rate.QueryAssetByFcsID()
 		if err != nil {
 
-			return err
+			return RejectTick(provider, "UNKNOWN_ASSET", err)
 		}
 	} else {
 		rate.Asset, err = rate.QueryAssetByTicker()
 		if err != nil {
 
-			return err
+			return RejectTick(provider, "UNKNOWN_ASSET", err)
 		}
 	}
 
+	//Operator pinned a manual rate, it overrides feed rate until it expires
+	if rate.Asset.ManualRateUntil > rate.Timestamp && !rate.Asset.ManualRate.Decimal.IsZero() {
+		rate.Rate = rate.Asset.ManualRate.Decimal
+	}
+
 	//Rate cut down to asset decimals
 	rate.Rate, err = decimal.NewFromString(rate.Rate.StringFixed(rate.Asset.DecimalScale))
 
 	var count int
 
 	//Record rate every 3 hours
-	if err := db.Get(&count, "SELECT count(*) FROM Rate WHERE AssetID=$1 AND Timestamp>$2", rate.Asset.AssetID, rate.Timestamp-10800); err != nil {
+	if err := db.Get(&count, "SELECT count(*) FROM (SELECT 1 FROM Rate WHERE AssetID=$1 AND Timestamp>$2 LIMIT 1) AS Recent", rate.Asset.AssetID, rate.Timestamp-10800); err != nil {
 		if err != sql.ErrNoRows {
 
 			return err
@@ -281,13 +340,12 @@ func (rate Rate) Update() error {
 
 	if count == 0 {
 		tx := db.MustBegin()
+		//Old entries are downsampled and pruned by RetentionJob
 		tx.Exec("INSERT INTO Rate (AssetID, Rate, Timestamp) VALUES ($1, $2, $3)", rate.Asset.AssetID, rate.Rate, rate.Timestamp)
-		//Clean up entries older than 24h ago
-		//TODO: tx.Exec("DELETE * FROM Rate WHERE AssetID=$1 AND Timestamp<$2", rate.Asset.AssetID, rate.Timestamp-86400)
 		tx.Commit()
 	}
 
-	if err := db.Get(&rate.DayAgoRate, "SELECT Rate FROM Rate WHERE AssetID=$1 AND Timestamp>$2 ORDER BY RateID ASC LIMIT 1", rate.Asset.AssetID, rate.Timestamp-86400); err != nil {
+	if err := db.Get(&rate.DayAgoRate, "SELECT Rate FROM Rate WHERE AssetID=$1 AND Timestamp>$2 ORDER BY Timestamp ASC LIMIT 1", rate.Asset.AssetID, rate.Timestamp-86400); err != nil {
 		if err != sql.ErrNoRows {
 
 			return err
@@ -302,18 +360,23 @@ func (rate Rate) Update() error {
 	//24h change = (100 * rate / dayAgoRate) - 100
 	rate.Change = rate.Rate.Mul(decimal.NewFromInt(100)).Div(rate.DayAgoRate.Decimal).Sub(decimal.NewFromInt(100))
 
-	//Forex spread has different formula (counted in pips e.g. 0.0005)
-	if rate.Asset.MarketID == 3 {
-		rate.RateBuy = rate.Rate.Add(rate.Asset.BuySpread.Decimal)
-		rate.RateSell = rate.Rate.Sub(rate.Asset.SellSpread.Decimal)
-	} else {
-		rate.RateBuy = rate.Rate.Add(rate.Rate.Mul(rate.Asset.BuySpread.Decimal.Div(decimal.NewFromInt(100))))
-		rate.RateSell = rate.Rate.Sub(rate.Rate.Mul(rate.Asset.SellSpread.Decimal.Div(decimal.NewFromInt(100))))
+	//Effective spread: asset base spread widened by realised volatility and session schedule
+	policy := spread.Policy{
+		Asset:     rate.Asset,
+		Timestamp: rate.Timestamp,
 	}
 
-	tx := db.MustBegin()
-	tx.MustExec("UPDATE Asset SET Rate=$1, RateBuy=$2, RateSell=$3, Change=$4, Updated=$5 WHERE AssetID=$6", rate.Rate, rate.RateBuy, rate.RateSell, rate.Change.StringFixed(2), rate.Timestamp, rate.Asset.AssetID)
-	tx.Commit()
+	rate.Spread, err = policy.Determine()
+	if err != nil {
+		return err
+	}
+
+	rate.RateBuy, rate.RateSell = spread.Apply(rate.Asset.MarketID, rate.Rate, rate.Spread.Buy, rate.Spread.Sell)
+
+	//Asset record is used by order updates below
+	rate.Asset.Rate.Decimal = rate.Rate
+	rate.Asset.RateBuy.Decimal = rate.RateBuy
+	rate.Asset.RateSell.Decimal = rate.RateSell
 
 	//Rate didn't change, stop further update
 	// if rate.Rate == rate.Asset.Rate.Decimal {
@@ -333,19 +396,20 @@ func (rate Rate) Update() error {
 		SentimentType: rate.Asset.SentimentType,
 	}
 
-	msg, err := json.Marshal(ms)
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Asset SET Rate=$1, RateBuy=$2, RateSell=$3, Change=$4, Updated=$5 WHERE AssetID=$6", rate.Rate, rate.RateBuy, rate.RateSell, rate.Change.StringFixed(2), rate.Timestamp, rate.Asset.AssetID)
+	//Record tick with effective spread
+	tx.MustExec("INSERT INTO Tick (AssetID, Rate, RateBuy, RateSell, BuySpread, SellSpread, Volatility, Multiplier, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", rate.Asset.AssetID, rate.Rate, rate.RateBuy, rate.RateSell, rate.Spread.Buy, rate.Spread.Sell, rate.Spread.Volatility, rate.Spread.Multiplier, rate.Timestamp)
+	//ws notify, published by the outbox relay once the update commits
+	outbox.Info(ms).Record(tx)
+	tx.Commit()
 
-	if err == nil {
-		ch := redis.Channel{
-			Name:    "info",
-			Message: string(msg),
-		}
-		ch.PubToChannel()
-	}
-	//ws
+	feedTicks.Inc(rate.Asset.Ticker, cast.ToString(rate.Asset.MarketID))
 
-	//Open pending limit orders that meet rate requirements
-	rate.OpenPendingLimitOrders()
+	//Open pending limit orders that meet rate requirements (not while trading is halted)
+	if !rate.Asset.Halted {
+		rate.OpenPendingLimitOrders()
+	}
 
 	//Update price alerts that meet rate requirements
 	rate.UpdateAlert()
@@ -415,7 +479,7 @@ func (rate Rate) UpdateOrders() error {
 			order, err = order.CalculateProfit()
 			if err == nil {
 				//Close order that meets stop loss / take profit requirements
-				if order.Status == trade.STATUS_OPEN {
+				if order.Status == trade.STATUS_OPEN && !rate.Asset.Halted {
 					order.CloseSLTP()
 				}
 			}
@@ -443,25 +507,13 @@ func (rate Rate) OpenPendingLimitOrders() error {
 			tx.MustExec("UPDATE Wallet SET Balance=Balance+$1 WHERE MemberID=$2 AND AssetID=$3", orderRow.Qty.Decimal, orderRow.MemberID, orderRow.AssetID)
 		}
 		tx.MustExec("UPDATE Trade SET Status=$1 WHERE TradeID=$2", trade.STATUS_OPEN, orderRow.TradeID)
-		tx.Commit()
-
 		//ws notify
-		ms := model.Info{
+		outbox.Info(model.Info{
 			MemberID: int(orderRow.MemberID),
 			Event:    "trade",
 			Value:    "limit",
-		}
-
-		msg, err := json.Marshal(ms)
-
-		if err == nil {
-			ch := redis.Channel{
-				Name:    "info",
-				Message: string(msg),
-			}
-			ch.PubToChannel()
-		}
-		//ws
+		}).Record(tx)
+		tx.Commit()
 	}
 
 	return nil
@@ -481,24 +533,12 @@ func (rate Rate) UpdateAlert() error {
 	for _, alertRow := range alert {
 		tx := db.MustBegin()
 		tx.MustExec("UPDATE Alert SET Status=$1, Datetime=CURRENT_TIMESTAMP WHERE AlertID=$2", true, alertRow.AlertID)
-		tx.Commit()
-
 		//ws notify
-		ms := model.Info{
+		outbox.Info(model.Info{
 			MemberID: int(alertRow.MemberID),
 			Event:    "alert",
-		}
-
-		msg, err := json.Marshal(ms)
-
-		if err == nil {
-			ch := redis.Channel{
-				Name:    "info",
-				Message: string(msg),
-			}
-			ch.PubToChannel()
-		}
-		//ws
+		}).Record(tx)
+		tx.Commit()
 	}
 
 	return nil
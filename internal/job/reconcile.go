@@ -0,0 +1,246 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/ledger"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/payment"
+	"github.com/ianidi/exchange-server/internal/task"
+	"github.com/ianidi/exchange-server/internal/trade"
+	"github.com/ianidi/exchange-server/internal/withdrawal"
+	"github.com/shopspring/decimal"
+)
+
+//ReconcileJob recomputes member balances and wallet positions from deposits, withdrawals and trade history and records values that don't add up
+type ReconcileJob struct {
+}
+
+//Spec how often to run the job
+func (ReconcileJob) Spec() string {
+	return "0 3 * * *"
+}
+
+//Reconciliation checks
+const (
+	RECONCILE_LEDGER        = "ledger"        //Ledger account balance vs sum of its postings
+	RECONCILE_BALANCE       = "balance"       //Member balance vs member account postings (opening balance, deposits, withdrawals, trades)
+	RECONCILE_LEGACY        = "legacy"        //Member USD/EUR columns vs member balance
+	RECONCILE_MARGIN        = "margin"        //Margin account vs margin of open and pending orders
+	RECONCILE_HOLD          = "hold"          //Hold account vs pending and approved withdrawals
+	RECONCILE_WALLET        = "wallet"        //Wallet quantity vs open long orders
+	RECONCILE_TRADE_HISTORY = "trade.history" //Trade result vs its History records
+	RECONCILE_TRADE_LEDGER  = "trade.ledger"  //Trade result vs its ledger postings
+	RECONCILE_DEPOSIT       = "deposit"       //Deposit amount vs its ledger postings
+	RECONCILE_WITHDRAWAL    = "withdrawal"    //Withdrawal amount vs its ledger postings
+	RECONCILE_TRANSFER      = "transfer"      //Transfer and conversion amounts vs their ledger postings
+)
+
+//Member balance effect of a trade: closed - profit, open and pending - margin held, cancelled - nothing
+const tradeResult = "CASE WHEN Trade.Status='" + trade.STATUS_CLOSED + "' THEN COALESCE(Trade.Profit, 0) WHEN Trade.Status IN ('" + trade.STATUS_OPEN + "', '" + trade.STATUS_PENDING + "') THEN -COALESCE(Trade.Total*Trade.MarginRate, 0) ELSE 0 END"
+
+//Member balance effect of a withdrawal: declined - nothing, held or complete - amount paid out or reserved
+const withdrawalResult = "CASE WHEN Withdrawal.Status='" + withdrawal.STATUS_DECLINED + "' THEN 0 WHEN Withdrawal.Held OR Withdrawal.Status='" + withdrawal.STATUS_COMPLETE + "' THEN -COALESCE(Withdrawal.Amount, 0) ELSE 0 END"
+
+//Member balance effect of a transfer per member account: recipient currency credited, sender currency debited
+const transferResult = "CASE WHEN LedgerAccount.MemberID=Transfer.RecipientID AND LedgerAccount.Currency=Transfer.RecipientCurrency THEN Transfer.RecipientAmount ELSE 0 END - CASE WHEN LedgerAccount.MemberID=Transfer.MemberID AND LedgerAccount.Currency=Transfer.Currency THEN Transfer.Amount ELSE 0 END"
+
+//Checks select discrepancies as MemberID, Currency, AssetID, Entity, EntityID, Expected, Actual
+var reconcileChecks = []struct {
+	Type  string
+	Query string
+}{
+	{
+		Type: RECONCILE_LEDGER,
+		Query: `SELECT LedgerAccount.MemberID, LedgerAccount.Currency, 0 AS AssetID, LedgerAccount.Type AS Entity, LedgerAccount.LedgerAccountID AS EntityID, COALESCE(SUM(LedgerPosting.Amount), 0) AS Expected, LedgerAccount.Balance AS Actual
+			FROM LedgerAccount LEFT JOIN LedgerPosting ON LedgerPosting.LedgerAccountID=LedgerAccount.LedgerAccountID
+			GROUP BY LedgerAccount.LedgerAccountID HAVING COALESCE(SUM(LedgerPosting.Amount), 0)<>LedgerAccount.Balance`,
+	},
+	{
+		Type: RECONCILE_BALANCE,
+		Query: `SELECT COALESCE(Posted.MemberID, Stored.MemberID) AS MemberID, COALESCE(Posted.Currency, Stored.Code) AS Currency, 0 AS AssetID, 'member' AS Entity, COALESCE(Posted.MemberID, Stored.MemberID) AS EntityID, COALESCE(Posted.Amount, 0) AS Expected, COALESCE(Stored.Amount, 0) AS Actual
+			FROM (SELECT LedgerAccount.MemberID, LedgerAccount.Currency, SUM(LedgerPosting.Amount) AS Amount FROM LedgerAccount INNER JOIN LedgerPosting ON LedgerPosting.LedgerAccountID=LedgerAccount.LedgerAccountID WHERE LedgerAccount.Type='` + ledger.ACCOUNT_MEMBER + `' GROUP BY LedgerAccount.MemberID, LedgerAccount.Currency) AS Posted
+			FULL OUTER JOIN (SELECT Balance.MemberID, Currency.Code, SUM(Balance.Amount) AS Amount FROM Balance INNER JOIN Currency ON Currency.CurrencyID=Balance.CurrencyID GROUP BY Balance.MemberID, Currency.Code) AS Stored
+			ON Stored.MemberID=Posted.MemberID AND Stored.Code=Posted.Currency
+			WHERE COALESCE(Posted.Amount, 0)<>COALESCE(Stored.Amount, 0)`,
+	},
+	{
+		Type: RECONCILE_LEGACY,
+		Query: `SELECT Member.MemberID, Currency.Code AS Currency, 0 AS AssetID, 'member' AS Entity, Member.MemberID AS EntityID, COALESCE(SUM(Balance.Amount), 0) AS Expected, COALESCE(CASE WHEN Currency.Code='USD' THEN Member.USD ELSE Member.EUR END, 0) AS Actual
+			FROM Member CROSS JOIN Currency LEFT JOIN Balance ON Balance.MemberID=Member.MemberID AND Balance.CurrencyID=Currency.CurrencyID
+			WHERE Currency.Code IN ('USD', 'EUR')
+			GROUP BY Member.MemberID, Currency.Code HAVING COALESCE(SUM(Balance.Amount), 0)<>COALESCE(CASE WHEN Currency.Code='USD' THEN Member.USD ELSE Member.EUR END, 0)`,
+	},
+	{
+		Type: RECONCILE_MARGIN,
+		Query: `SELECT COALESCE(Held.MemberID, Account.MemberID) AS MemberID, COALESCE(Held.AccountCurrency, Account.Currency) AS Currency, 0 AS AssetID, 'member' AS Entity, COALESCE(Held.MemberID, Account.MemberID) AS EntityID, COALESCE(Held.Amount, 0) AS Expected, COALESCE(Account.Balance, 0) AS Actual
+			FROM (SELECT MemberID, AccountCurrency, SUM(Total*MarginRate) AS Amount FROM Trade WHERE Status IN ('` + trade.STATUS_OPEN + `', '` + trade.STATUS_PENDING + `') GROUP BY MemberID, AccountCurrency) AS Held
+			FULL OUTER JOIN (SELECT * FROM LedgerAccount WHERE Type='` + ledger.ACCOUNT_MARGIN + `') AS Account
+			ON Account.MemberID=Held.MemberID AND Account.Currency=Held.AccountCurrency
+			WHERE COALESCE(Held.Amount, 0)<>COALESCE(Account.Balance, 0)`,
+	},
+	{
+		Type: RECONCILE_HOLD,
+		Query: `SELECT COALESCE(Held.MemberID, Account.MemberID) AS MemberID, COALESCE(Held.Currency, Account.Currency) AS Currency, 0 AS AssetID, 'member' AS Entity, COALESCE(Held.MemberID, Account.MemberID) AS EntityID, COALESCE(Held.Amount, 0) AS Expected, COALESCE(Account.Balance, 0) AS Actual
+			FROM (SELECT MemberID, Currency, SUM(Amount) AS Amount FROM Withdrawal WHERE Held AND Status IN ('` + withdrawal.STATUS_PENDING + `', '` + withdrawal.STATUS_APPROVED + `') GROUP BY MemberID, Currency) AS Held
+			FULL OUTER JOIN (SELECT * FROM LedgerAccount WHERE Type='` + ledger.ACCOUNT_HOLD + `') AS Account
+			ON Account.MemberID=Held.MemberID AND Account.Currency=Held.Currency
+			WHERE COALESCE(Held.Amount, 0)<>COALESCE(Account.Balance, 0)`,
+	},
+	{
+		Type: RECONCILE_WALLET,
+		Query: `SELECT COALESCE(Held.MemberID, Stored.MemberID) AS MemberID, '' AS Currency, COALESCE(Held.AssetID, Stored.AssetID) AS AssetID, 'wallet' AS Entity, COALESCE(Stored.WalletID, 0) AS EntityID, COALESCE(Held.Qty, 0) AS Expected, COALESCE(Stored.Balance, 0) AS Actual
+			FROM (SELECT MemberID, AssetID, SUM(Qty) AS Qty FROM Trade WHERE Status='` + trade.STATUS_OPEN + `' AND Action='` + trade.ACTION_BUY + `' GROUP BY MemberID, AssetID) AS Held
+			FULL OUTER JOIN (SELECT MemberID, AssetID, MIN(WalletID) AS WalletID, SUM(Balance) AS Balance FROM Wallet GROUP BY MemberID, AssetID) AS Stored
+			ON Stored.MemberID=Held.MemberID AND Stored.AssetID=Held.AssetID
+			WHERE COALESCE(Held.Qty, 0)<>COALESCE(Stored.Balance, 0)`,
+	},
+	{
+		Type: RECONCILE_TRADE_HISTORY,
+		Query: `SELECT Trade.MemberID, Trade.AccountCurrency AS Currency, Trade.AssetID, 'trade' AS Entity, Trade.TradeID AS EntityID, ` + tradeResult + ` AS Expected, COALESCE(SUM(History.Profit), 0) AS Actual
+			FROM Trade INNER JOIN History ON History.TradeID=Trade.TradeID
+			GROUP BY Trade.TradeID HAVING ` + tradeResult + `<>COALESCE(SUM(History.Profit), 0)`,
+	},
+	{
+		//Trades opened before the ledger are covered by opening balances
+		Type: RECONCILE_TRADE_LEDGER,
+		Query: `SELECT Trade.MemberID, Trade.AccountCurrency AS Currency, Trade.AssetID, 'trade' AS Entity, Trade.TradeID AS EntityID, ` + tradeResult + ` AS Expected, COALESCE(SUM(LedgerPosting.Amount), 0) AS Actual
+			FROM Trade INNER JOIN LedgerEntry ON LedgerEntry.Entity='trade' AND LedgerEntry.EntityID=Trade.TradeID
+			INNER JOIN LedgerPosting ON LedgerPosting.LedgerEntryID=LedgerEntry.LedgerEntryID
+			INNER JOIN LedgerAccount ON LedgerAccount.LedgerAccountID=LedgerPosting.LedgerAccountID AND LedgerAccount.Type='` + ledger.ACCOUNT_MEMBER + `'
+			WHERE EXISTS (SELECT 1 FROM LedgerEntry AS Opened WHERE Opened.Entity='trade' AND Opened.EntityID=Trade.TradeID AND Opened.Type='` + ledger.ENTRY_TRADE_OPEN + `')
+			GROUP BY Trade.TradeID HAVING ` + tradeResult + `<>COALESCE(SUM(LedgerPosting.Amount), 0)`,
+	},
+	{
+		//Deposits approved before the ledger are covered by opening balances
+		Type: RECONCILE_DEPOSIT,
+		Query: `SELECT Deposit.MemberID, LedgerAccount.Currency, 0 AS AssetID, 'deposit' AS Entity, Deposit.DepositID AS EntityID, CASE WHEN Deposit.Status='` + payment.STATUS_CREDITED + `' THEN COALESCE(Deposit.Amount, 0) ELSE 0 END AS Expected, SUM(LedgerPosting.Amount) AS Actual
+			FROM Deposit INNER JOIN LedgerEntry ON LedgerEntry.Entity='deposit' AND LedgerEntry.EntityID=Deposit.DepositID
+			INNER JOIN LedgerPosting ON LedgerPosting.LedgerEntryID=LedgerEntry.LedgerEntryID
+			INNER JOIN LedgerAccount ON LedgerAccount.LedgerAccountID=LedgerPosting.LedgerAccountID AND LedgerAccount.Type='` + ledger.ACCOUNT_MEMBER + `'
+			GROUP BY Deposit.DepositID, LedgerAccount.Currency HAVING CASE WHEN Deposit.Status='` + payment.STATUS_CREDITED + `' THEN COALESCE(Deposit.Amount, 0) ELSE 0 END<>SUM(LedgerPosting.Amount)`,
+	},
+	{
+		Type: RECONCILE_WITHDRAWAL,
+		Query: `SELECT Withdrawal.MemberID, LedgerAccount.Currency, 0 AS AssetID, 'withdrawal' AS Entity, Withdrawal.WithdrawalID AS EntityID, ` + withdrawalResult + ` AS Expected, SUM(LedgerPosting.Amount) AS Actual
+			FROM Withdrawal INNER JOIN LedgerEntry ON LedgerEntry.Entity='withdrawal' AND LedgerEntry.EntityID=Withdrawal.WithdrawalID
+			INNER JOIN LedgerPosting ON LedgerPosting.LedgerEntryID=LedgerEntry.LedgerEntryID
+			INNER JOIN LedgerAccount ON LedgerAccount.LedgerAccountID=LedgerPosting.LedgerAccountID AND LedgerAccount.Type='` + ledger.ACCOUNT_MEMBER + `'
+			GROUP BY Withdrawal.WithdrawalID, LedgerAccount.Currency HAVING ` + withdrawalResult + `<>SUM(LedgerPosting.Amount)`,
+	},
+	{
+		Type: RECONCILE_TRANSFER,
+		Query: `SELECT LedgerAccount.MemberID, LedgerAccount.Currency, 0 AS AssetID, 'transfer' AS Entity, Transfer.TransferID AS EntityID, ` + transferResult + ` AS Expected, SUM(LedgerPosting.Amount) AS Actual
+			FROM Transfer INNER JOIN LedgerEntry ON LedgerEntry.Entity='transfer' AND LedgerEntry.EntityID=Transfer.TransferID
+			INNER JOIN LedgerPosting ON LedgerPosting.LedgerEntryID=LedgerEntry.LedgerEntryID
+			INNER JOIN LedgerAccount ON LedgerAccount.LedgerAccountID=LedgerPosting.LedgerAccountID AND LedgerAccount.Type='` + ledger.ACCOUNT_MEMBER + `'
+			GROUP BY Transfer.TransferID, LedgerAccount.MemberID, LedgerAccount.Currency HAVING ` + transferResult + `<>SUM(LedgerPosting.Amount)`,
+	},
+}
+
+//Discrepancy - stored value that differs from the recomputed one
+type Discrepancy struct {
+	MemberID int64
+	Currency string
+	AssetID  int64
+	Entity   string
+	EntityID int64
+	Expected decimal.Decimal
+	Actual   decimal.Decimal
+}
+
+func (ReconcileJob) Run(ctx context.Context) error {
+	run := time.Now().Unix()
+
+	found, err := Reconcile(ctx, run)
+	if err != nil {
+		return err
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	return ReconcileAlert(run, found)
+}
+
+//Reconcile runs all checks and records discrepancies found, returns number of discrepancies per check
+func Reconcile(ctx context.Context, run int64) (map[string]int, error) {
+	db := db.GetDB()
+
+	found := map[string]int{}
+
+	for _, check := range reconcileChecks {
+		if ctx.Err() != nil {
+			return found, ctx.Err()
+		}
+
+		var discrepancy []Discrepancy
+
+		if err := db.SelectContext(ctx, &discrepancy, check.Query); err != nil {
+			return found, fmt.Errorf("%s: %v", check.Type, err)
+		}
+
+		if len(discrepancy) == 0 {
+			continue
+		}
+
+		tx := db.MustBegin()
+		for _, row := range discrepancy {
+			tx.MustExec("INSERT INTO Reconciliation (Run, Type, MemberID, Currency, AssetID, Entity, EntityID, Expected, Actual, Difference, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)", run, check.Type, row.MemberID, row.Currency, row.AssetID, row.Entity, row.EntityID, row.Expected, row.Actual, row.Actual.Sub(row.Expected), time.Now().Unix())
+		}
+		tx.Commit()
+
+		found[check.Type] = len(discrepancy)
+	}
+
+	return found, nil
+}
+
+//ReconcileAlert emails operators a summary of reconciliation run with links to discrepancies of each check
+func ReconcileAlert(run int64, found map[string]int) error {
+	db := db.GetDB()
+
+	var settings models.Settings
+
+	if err := db.Get(&settings, "SELECT * FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		return err
+	}
+
+	if settings.ReconciliationEmail == "" {
+		return nil
+	}
+
+	link := fmt.Sprintf("%soperator/reconciliation?run=%d", settings.PlatformURL, run)
+
+	total := 0
+
+	var lines []string
+
+	for _, check := range reconcileChecks {
+		if found[check.Type] == 0 {
+			continue
+		}
+
+		total += found[check.Type]
+
+		lines = append(lines, fmt.Sprintf("%s: %d (%s&type=%s)", check.Type, found[check.Type], link, check.Type))
+	}
+
+	email := task.Email{
+		Email:   settings.ReconciliationEmail,
+		Subject: fmt.Sprintf("Balance reconciliation: %d discrepancies", total),
+		Title:   "Balance reconciliation",
+		Content: fmt.Sprintf("Reconciliation run of %s found %d discrepancies. %s", time.Unix(run, 0).Format("2006-01-02 15:04:05"), total, strings.Join(lines, "; ")),
+		Button:  "Open reconciliation",
+		Link:    link,
+	}
+
+	_, err := task.Enqueue(email, fmt.Sprintf("reconcile:%d", run))
+
+	return err
+}
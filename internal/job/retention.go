@@ -0,0 +1,184 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/task"
+)
+
+//RetentionJob creates time partitions ahead of time, downsamples and prunes old rates by RetentionPolicy
+type RetentionJob struct {
+}
+
+//Spec how often to run the job
+func (RetentionJob) Spec() string {
+	return "@hourly"
+}
+
+const (
+	//How many monthly partitions are created ahead of current month
+	PARTITIONS_AHEAD = 2
+)
+
+//Time partitioned table
+type partitionedTable struct {
+	Name  string //Table name
+	Epoch bool   //Partition key is a UNIX timestamp
+}
+
+var partitionedTables = []partitionedTable{
+	{Name: "rate", Epoch: true},
+	{Name: "history", Epoch: false},
+}
+
+//Tables retention policy can be applied to, with their primary key (empty - rows are only pruned, there is no asset to downsample by)
+var retentionTables = map[string]string{
+	"rate":           "RateID",
+	"tick":           "TickID",
+	"jobrun":         "",
+	"task":           "",
+	"outbox":         "",
+	"reconciliation": "",
+}
+
+//Condition of rows MaxPeriod applies to, rows still in use are kept regardless of age: tasks waiting for a run and events not published yet
+var retentionFinished = map[string]string{
+	"task":   "Status IN ('" + task.STATUS_DONE + "', '" + task.STATUS_DEAD + "')",
+	"outbox": "Published>0",
+}
+
+func (RetentionJob) Run(ctx context.Context) error {
+	now := time.Now()
+
+	for _, table := range partitionedTables {
+		if err := CreatePartitions(table, now); err != nil {
+			fmt.Println("RetentionJob partition error", table.Name, err)
+		}
+	}
+
+	db := db.GetDB()
+
+	var policy []models.RetentionPolicy
+
+	if err := db.Select(&policy, "SELECT * FROM RetentionPolicy WHERE Active=$1", true); err != nil {
+		return err
+	}
+
+	var result error
+
+	for _, policyRow := range policy {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := ApplyRetention(policyRow, now.Unix()); err != nil {
+			fmt.Println("RetentionJob retention error", policyRow.TableName, err)
+			result = err
+		}
+	}
+
+	return result
+}
+
+//CreatePartitions creates monthly partitions (UTC) for current and upcoming months.
+//Rows of the month already stored in the DEFAULT partition are moved to the new partition by create_month_partition
+func CreatePartitions(table partitionedTable, now time.Time) error {
+	db := db.GetDB()
+
+	//First day of month, so adding months isn't normalized past a shorter month
+	now = now.UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i <= PARTITIONS_AHEAD; i++ {
+		if _, err := db.Exec("SELECT create_month_partition($1, $2, $3)", table.Name, month.AddDate(0, i, 0), table.Epoch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//ApplyRetention prunes rows older than MaxPeriod and keeps one row per asset and DownsampleInterval for rows older than RawPeriod
+func ApplyRetention(policy models.RetentionPolicy, now int64) error {
+	db := db.GetDB()
+
+	table := strings.ToLower(policy.TableName)
+
+	key, ok := retentionTables[table]
+	if !ok {
+		return fmt.Errorf("RETENTION_TABLE_NOT_SUPPORTED: %s", policy.TableName)
+	}
+
+	var cutoff int64
+
+	if policy.MaxPeriod > 0 {
+		cutoff = now - policy.MaxPeriod
+
+		//Whole monthly partitions are dropped, the rest of expired rows is deleted
+		if err := DropPartitions(table, cutoff); err != nil {
+			return err
+		}
+
+		query := "DELETE FROM " + table + " WHERE Timestamp<$1"
+		if condition, ok := retentionFinished[table]; ok {
+			query += " AND " + condition
+		}
+
+		if _, err := db.Exec(query, cutoff); err != nil {
+			return err
+		}
+	}
+
+	if policy.RawPeriod > 0 && policy.DownsampleInterval > 0 && key != "" {
+		to := now - policy.RawPeriod
+
+		//Continue from the bucket where the previous run stopped
+		from := policy.Processed - policy.Processed%policy.DownsampleInterval
+		if from < cutoff {
+			from = cutoff
+		}
+
+		if from >= to {
+			return nil
+		}
+
+		tx := db.MustBegin()
+		tx.MustExec("DELETE FROM "+table+" WHERE "+key+" IN (SELECT "+key+" FROM (SELECT "+key+", row_number() OVER (PARTITION BY AssetID, Timestamp/$1 ORDER BY Timestamp ASC) AS Position FROM "+table+" WHERE Timestamp>=$2 AND Timestamp<$3) AS Bucket WHERE Position>1)", policy.DownsampleInterval, from, to)
+		tx.MustExec("UPDATE RetentionPolicy SET Processed=$1 WHERE RetentionPolicyID=$2", to, policy.RetentionPolicyID)
+		tx.Commit()
+	}
+
+	return nil
+}
+
+//DropPartitions drops monthly partitions (UTC) that end before cutoff
+func DropPartitions(table string, cutoff int64) error {
+	db := db.GetDB()
+
+	var partitions []string
+
+	if err := db.Select(&partitions, "SELECT child.relname FROM pg_inherits INNER JOIN pg_class parent ON parent.oid=pg_inherits.inhparent INNER JOIN pg_class child ON child.oid=pg_inherits.inhrelid WHERE parent.relname=$1", table); err != nil {
+		return err
+	}
+
+	for _, partition := range partitions {
+		//Partitions are named table_YYYYMM
+		month, err := time.ParseInLocation("200601", strings.TrimPrefix(partition, table+"_"), time.UTC)
+		if err != nil {
+			continue
+		}
+
+		if month.AddDate(0, 1, 0).Unix() <= cutoff {
+			if _, err := db.Exec("DROP TABLE IF EXISTS " + partition); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,290 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/ianidi/exchange-server/internal/task"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/parnurzeal/gorequest"
+	"github.com/spf13/cast"
+)
+
+//StaleJob alerts operators when a market stops receiving rates during its trading session
+type StaleJob struct {
+}
+
+//Spec how often to run the job
+func (StaleJob) Spec() string {
+	return "@every 1m"
+}
+
+//Redis set of markets an alert was sent for, cleared once rates flow again (shared by all instances)
+const STALE_ALERTED_KEY = "job:stale:alerted"
+
+//StaleAsset - asset without rate updates during market trading session
+type StaleAsset struct {
+	AssetID  int64
+	Ticker   string
+	MarketID int64
+	Updated  int64 //Last rate update (UNIX timestamp)
+	Age      int64 //Seconds since last rate update
+	Interval int64 //Market update interval (seconds)
+}
+
+//StaleMarket - market where none of the assets received rate updates during trading session
+type StaleMarket struct {
+	MarketID int64
+	Title    string
+	Assets   int   //Number of tradable assets
+	Updated  int64 //Last rate update of any asset (UNIX timestamp)
+	Age      int64 //Seconds since last rate update
+}
+
+func (StaleJob) Run(ctx context.Context) error {
+	market, err := QueryStaleMarkets(time.Now())
+	if err != nil {
+		return err
+	}
+
+	pool := redis.GetRedis()
+
+	var alerted []string
+
+	if err := pool.Do(radix.Cmd(&alerted, "SMEMBERS", STALE_ALERTED_KEY)); err != nil {
+		return err
+	}
+
+	stale := map[string]bool{}
+
+	for _, marketRow := range market {
+		MarketID := cast.ToString(marketRow.MarketID)
+		stale[MarketID] = true
+
+		if contains(alerted, MarketID) {
+			continue
+		}
+
+		if err := marketRow.Alert(); err != nil {
+			log.Println("StaleJob alert error", marketRow.Title, err)
+			continue
+		}
+
+		if err := pool.Do(radix.Cmd(nil, "SADD", STALE_ALERTED_KEY, MarketID)); err != nil {
+			return err
+		}
+	}
+
+	//Market recovered, alert again next time it goes stale
+	for _, MarketID := range alerted {
+		if !stale[MarketID] {
+			if err := pool.Do(radix.Cmd(nil, "SREM", STALE_ALERTED_KEY, MarketID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+//Alert notifies operators by email and webhook configured in settings
+func (market StaleMarket) Alert() error {
+	db := db.GetDB()
+
+	var settings models.Settings
+
+	if err := db.Get(&settings, "SELECT * FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf("No rate updates for market %s during trading session since %s (%d assets).", market.Title, time.Unix(market.Updated, 0).Format("2006-01-02 15:04:05"), market.Assets)
+
+	if settings.StaleAlertEmail != "" {
+		email := task.Email{
+			Email:   settings.StaleAlertEmail,
+			Subject: "Stale market: " + market.Title,
+			Title:   "Price feed alert",
+			Content: content,
+			Button:  "Open platform",
+			Link:    settings.PlatformURL,
+		}
+
+		//One email per stale episode even if the webhook below fails and the alert is repeated
+		if _, err := task.Enqueue(email, fmt.Sprintf("stale:%d:%d", market.MarketID, market.Updated)); err != nil {
+			return err
+		}
+	}
+
+	if settings.StaleAlertWebhook != "" {
+		_, _, errs := gorequest.New().Post(settings.StaleAlertWebhook).
+			Timeout(10*time.Second).
+			Set("Content-Type", "application/json").
+			Send(map[string]interface{}{
+				"event":    "stale",
+				"MarketID": market.MarketID,
+				"Title":    market.Title,
+				"Assets":   market.Assets,
+				"Updated":  market.Updated,
+				"Age":      market.Age,
+				"text":     content,
+			}).
+			End()
+		if len(errs) > 0 {
+			return errs[0]
+		}
+	}
+
+	return nil
+}
+
+//QueryStaleAssets returns tradable assets of open markets that had no rate updates for StaleIntervals update intervals
+func QueryStaleAssets(now time.Time) ([]StaleAsset, error) {
+	stale, _, err := queryStale(now)
+	return stale, err
+}
+
+//QueryStaleMarkets returns open markets where all tradable assets are stale
+func QueryStaleMarkets(now time.Time) ([]StaleMarket, error) {
+	_, market, err := queryStale(now)
+	return market, err
+}
+
+func queryStale(now time.Time) ([]StaleAsset, []StaleMarket, error) {
+	db := db.GetDB()
+
+	stale := []StaleAsset{}
+	staleMarket := []StaleMarket{}
+
+	var market []models.Market
+
+	if err := db.Select(&market, "SELECT * FROM Market ORDER BY MarketID ASC"); err != nil {
+		return stale, staleMarket, err
+	}
+
+	var asset []StaleAsset
+
+	//Markets may have several feed jobs, the slowest one defines expected update interval
+	if err := db.Select(&asset, "SELECT Asset.AssetID, Asset.Ticker, Asset.MarketID, Asset.Updated, Feed.Interval FROM Asset INNER JOIN (SELECT MarketID, MAX(Interval) AS Interval FROM Job WHERE Active=$1 GROUP BY MarketID) AS Feed ON Feed.MarketID=Asset.MarketID WHERE Asset.Tradable=$1 AND Asset.Active=$1 ORDER BY Asset.AssetID ASC", true); err != nil {
+		return stale, staleMarket, err
+	}
+
+	for _, marketRow := range market {
+		if marketRow.StaleIntervals <= 0 {
+			continue
+		}
+
+		start, open := SessionStart(marketRow, now)
+		if !open {
+			continue
+		}
+
+		total := 0
+		var updated int64
+		var marketStale []StaleAsset
+
+		for _, assetRow := range asset {
+			if assetRow.MarketID != marketRow.MarketID {
+				continue
+			}
+
+			total++
+			if assetRow.Updated > updated {
+				updated = assetRow.Updated
+			}
+
+			//Updates before the session opened are not expected, count from session start
+			last := assetRow.Updated
+			if !start.IsZero() && start.Unix() > last {
+				last = start.Unix()
+			}
+
+			if now.Unix()-last > assetRow.Interval*marketRow.StaleIntervals {
+				assetRow.Age = now.Unix() - assetRow.Updated
+				marketStale = append(marketStale, assetRow)
+			}
+		}
+
+		stale = append(stale, marketStale...)
+
+		if total > 0 && len(marketStale) == total {
+			staleMarket = append(staleMarket, StaleMarket{
+				MarketID: marketRow.MarketID,
+				Title:    marketRow.Title,
+				Assets:   total,
+				Updated:  updated,
+				Age:      now.Unix() - updated,
+			})
+		}
+	}
+
+	return stale, staleMarket, nil
+}
+
+//SessionStart returns when the current trading session of market opened (zero time for 24/7 markets), false if market is closed.
+//Sessions are scheduled in UTC, whatever time zone the server runs in
+func SessionStart(market models.Market, now time.Time) (time.Time, bool) {
+	now = now.UTC()
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	minute := now.Hour()*60 + now.Minute()
+
+	//All day session
+	if market.StartMinute == market.EndMinute {
+		if market.Weekdays == "" {
+			return time.Time{}, true
+		}
+
+		if !TradingDay(market, now.Weekday()) {
+			return time.Time{}, false
+		}
+
+		//Session lasts since the first of consecutive trading days
+		for i := 0; i < 7 && TradingDay(market, midnight.AddDate(0, 0, -1).Weekday()); i++ {
+			midnight = midnight.AddDate(0, 0, -1)
+		}
+
+		return midnight, true
+	}
+
+	//Session passes midnight, after midnight it belongs to the previous day
+	if market.StartMinute > market.EndMinute && minute < market.EndMinute {
+		yesterday := midnight.AddDate(0, 0, -1)
+		return yesterday.Add(time.Duration(market.StartMinute) * time.Minute), TradingDay(market, yesterday.Weekday())
+	}
+
+	if market.StartMinute > market.EndMinute {
+		return midnight.Add(time.Duration(market.StartMinute) * time.Minute), minute >= market.StartMinute && TradingDay(market, now.Weekday())
+	}
+
+	return midnight.Add(time.Duration(market.StartMinute) * time.Minute), minute >= market.StartMinute && minute < market.EndMinute && TradingDay(market, now.Weekday())
+}
+
+//TradingDay checks if market trades on weekday
+func TradingDay(market models.Market, weekday time.Weekday) bool {
+	if market.Weekdays == "" {
+		return true
+	}
+
+	for _, day := range strings.Split(market.Weekdays, ",") {
+		if cast.ToInt(strings.TrimSpace(day)) == int(weekday) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+func TestSessionStart(t *testing.T) {
+	//US session 13:30 - 20:00 UTC on weekdays
+	stocks := models.Market{Weekdays: "1,2,3,4,5", StartMinute: 810, EndMinute: 1200}
+
+	//Weekdays all day
+	forex := models.Market{Weekdays: "1,2,3,4,5"}
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	for _, test := range []struct {
+		name   string
+		market models.Market
+		now    time.Time
+		start  time.Time
+		open   bool
+	}{
+		{"session", stocks, time.Date(2020, 9, 1, 14, 0, 0, 0, time.UTC), time.Date(2020, 9, 1, 13, 30, 0, 0, time.UTC), true},
+		{"before session", stocks, time.Date(2020, 9, 1, 13, 0, 0, 0, time.UTC), time.Date(2020, 9, 1, 13, 30, 0, 0, time.UTC), false},
+		{"after session", stocks, time.Date(2020, 9, 1, 20, 0, 0, 0, time.UTC), time.Date(2020, 9, 1, 13, 30, 0, 0, time.UTC), false},
+		//17:00 in Moscow is 14:00 UTC
+		{"server time", stocks, time.Date(2020, 9, 1, 17, 0, 0, 0, moscow), time.Date(2020, 9, 1, 13, 30, 0, 0, time.UTC), true},
+		{"monday in server time, sunday in UTC", forex, time.Date(2020, 9, 7, 1, 0, 0, 0, moscow), time.Time{}, false},
+		{"week since monday", forex, time.Date(2020, 9, 3, 12, 0, 0, 0, time.UTC), time.Date(2020, 8, 31, 0, 0, 0, 0, time.UTC), true},
+	} {
+		start, open := SessionStart(test.market, test.now)
+
+		if open != test.open || (open && !start.Equal(test.start)) {
+			t.Errorf("%s: got %v %v, want %v %v", test.name, start, open, test.start, test.open)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package jwk
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"math/big"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+//Algorithms of signing keys
+const (
+	ALG_RS256 = "RS256"
+	ALG_EDDSA = "EdDSA"
+
+	RSA_BITS = 2048
+)
+
+//Key - parsed signing key, Private is nil when it can't be decrypted (its secret was removed from jwt_previous_keys)
+type Key struct {
+	KID       string
+	Algorithm string
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+	Activates int64
+	Expires   int64
+}
+
+//Method returns jwt signing method of the key
+func (key Key) Method() jwt.SigningMethod {
+	if key.Algorithm == ALG_EDDSA {
+		return SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+//JWK - public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	KTY string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	KID string `json:"kid"`
+	N   string `json:"n,omitempty"`   //RSA modulus
+	E   string `json:"e,omitempty"`   //RSA exponent
+	CRV string `json:"crv,omitempty"` //EC, OKP curve
+	X   string `json:"x,omitempty"`   //EC coordinate, OKP public key
+	Y   string `json:"y,omitempty"`   //EC coordinate
+}
+
+//Set - JWKS document of /.well-known/jwks.json
+type Set struct {
+	Keys []JWK `json:"keys"`
+}
+
+//JWK returns public key of the key
+func (key Key) JWK() (JWK, error) {
+	result := JWK{Use: "sig", Alg: key.Algorithm, KID: key.KID}
+
+	switch public := key.Public.(type) {
+	case *rsa.PublicKey:
+		result.KTY = "RSA"
+		result.N = encode(public.N.Bytes())
+		result.E = encode(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		result.KTY = "OKP"
+		result.CRV = "Ed25519"
+		result.X = encode(public)
+	default:
+		return result, errors.New("unsupported key type")
+	}
+
+	return result, nil
+}
+
+//PublicKey parses public key of JWK published by another issuer (RSA, EC P-256, Ed25519)
+func (key JWK) PublicKey() (crypto.PublicKey, error) {
+	switch key.KTY {
+	case "RSA":
+		n, err := decode(key.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decode(key.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if key.CRV != "P-256" {
+			return nil, errors.New("unsupported curve " + key.CRV)
+		}
+
+		x, err := decode(key.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decode(key.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		public := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !public.Curve.IsOnCurve(public.X, public.Y) {
+			return nil, errors.New("invalid EC key")
+		}
+
+		return public, nil
+	case "OKP":
+		x, err := decode(key.X)
+		if err != nil || key.CRV != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("unsupported OKP key")
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, errors.New("unsupported key type " + key.KTY)
+}
+
+//generate creates private key of the algorithm
+func generate(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case ALG_RS256:
+		return rsa.GenerateKey(rand.Reader, RSA_BITS)
+	case ALG_EDDSA:
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		return private, err
+	}
+	return nil, errors.New("unsupported algorithm " + algorithm)
+}
+
+//parsePrivate parses PKCS #8 key
+func parsePrivate(der []byte) (crypto.Signer, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported key type")
+	}
+
+	return signer, nil
+}
+
+func encode(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+func decode(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(value)
+}
@@ -0,0 +1,333 @@
+package jwk
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ianidi/exchange-server/internal/config"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+const (
+	PUBLISH_AHEAD = time.Hour       //New key is published in JWKS before it signs tokens, so verifiers refresh their cache
+	RELOAD        = time.Minute     //Keys are reloaded from DB at most every RELOAD
+	RELOAD_KID    = 5 * time.Second //Unknown kid reloads keys at most every RELOAD_KID
+	LOCK_ID       = 7364001         //Advisory lock of rotation, instances rotate one at a time
+)
+
+var (
+	mu       sync.RWMutex
+	keys     []Key //Ordered by Activates, newest first
+	loadedAt time.Time
+)
+
+//Init creates the first signing key if there is none and loads keys
+func Init() error {
+	return Rotate(time.Now())
+}
+
+//Rotate creates next key PUBLISH_AHEAD before the current key is jwt_key_rotation old, sets expiration of replaced keys,
+//re-encrypts private keys with current jwt_secret and removes expired keys
+func Rotate(now time.Time) error {
+	db := db.GetDB()
+
+	settings := config.Get().JWT
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", LOCK_ID); err != nil {
+		return err
+	}
+
+	var stored []models.JWTKey
+
+	if err := tx.Select(&stored, "SELECT * FROM JWTKey WHERE Expires=0 OR Expires>$1 ORDER BY Activates DESC", now.Unix()); err != nil {
+		return err
+	}
+
+	//The first key signs at once
+	activates := now
+	if len(stored) > 0 {
+		activates = now.Add(PUBLISH_AHEAD)
+	}
+
+	if len(stored) == 0 || time.Unix(stored[0].Activates, 0).Add(settings.Rotation).Before(activates) {
+		key, err := create(settings, activates)
+		if err != nil {
+			return err
+		}
+
+		//Replaced keys are accepted until refresh tokens signed with them expire
+		tx.MustExec("UPDATE JWTKey SET Expires=$1 WHERE Expires=0", activates.Add(settings.RefreshTTL).Add(PUBLISH_AHEAD).Unix())
+
+		tx.MustExec("INSERT INTO JWTKey (KID, Algorithm, PrivateKey, EncryptionKeyID, PublicKey, Created, Activates) VALUES ($1, $2, $3, $4, $5, $6, $7)", key.KID, key.Algorithm, key.PrivateKey, key.EncryptionKeyID, key.PublicKey, key.Created, key.Activates)
+	}
+
+	//Keys encrypted with previous jwt_secret
+	for _, key := range stored {
+		if key.EncryptionKeyID == settings.KeyID {
+			continue
+		}
+
+		der, err := decrypt(settings, key)
+		if err != nil {
+			log.Println("jwk re-encryption error", key.KID, err)
+			continue
+		}
+
+		encrypted, err := encrypt(settings.Secret, der)
+		if err != nil {
+			return err
+		}
+
+		tx.MustExec("UPDATE JWTKey SET PrivateKey=$1, EncryptionKeyID=$2 WHERE KID=$3", encrypted, settings.KeyID, key.KID)
+	}
+
+	tx.MustExec("DELETE FROM JWTKey WHERE Expires>0 AND Expires<=$1", now.Unix())
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return load()
+}
+
+//create generates key activated at the time
+func create(settings config.JWT, activates time.Time) (models.JWTKey, error) {
+	var key models.JWTKey
+
+	private, err := generate(settings.Algorithm)
+	if err != nil {
+		return key, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return key, err
+	}
+
+	public, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return key, err
+	}
+
+	encrypted, err := encrypt(settings.Secret, der)
+	if err != nil {
+		return key, err
+	}
+
+	key = models.JWTKey{
+		KID:             uuid.New().String(),
+		Algorithm:       settings.Algorithm,
+		PrivateKey:      encrypted,
+		EncryptionKeyID: settings.KeyID,
+		PublicKey:       base64.StdEncoding.EncodeToString(public),
+		Created:         time.Now().Unix(),
+		Activates:       activates.Unix(),
+	}
+
+	return key, nil
+}
+
+//load parses unexpired keys
+func load() error {
+	db := db.GetDB()
+
+	settings := config.Get().JWT
+
+	var stored []models.JWTKey
+
+	if err := db.Select(&stored, "SELECT * FROM JWTKey WHERE Expires=0 OR Expires>$1 ORDER BY Activates DESC", time.Now().Unix()); err != nil {
+		return err
+	}
+
+	result := []Key{}
+
+	for _, item := range stored {
+		der, err := base64.StdEncoding.DecodeString(item.PublicKey)
+		if err != nil {
+			return fmt.Errorf("jwk %s: %v", item.KID, err)
+		}
+
+		public, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			return fmt.Errorf("jwk %s: %v", item.KID, err)
+		}
+
+		key := Key{
+			KID:       item.KID,
+			Algorithm: item.Algorithm,
+			Public:    public,
+			Activates: item.Activates,
+			Expires:   item.Expires,
+		}
+
+		if der, err := decrypt(settings, item); err == nil {
+			key.Private, err = parsePrivate(der)
+			if err != nil {
+				return fmt.Errorf("jwk %s: %v", item.KID, err)
+			}
+		}
+
+		result = append(result, key)
+	}
+
+	mu.Lock()
+	keys = result
+	loadedAt = time.Now()
+	mu.Unlock()
+
+	return nil
+}
+
+//current returns loaded keys, reloaded after maxAge
+func current(maxAge time.Duration) []Key {
+	mu.RLock()
+	result, age := keys, time.Since(loadedAt)
+	mu.RUnlock()
+
+	if age < maxAge {
+		return result
+	}
+
+	if err := load(); err != nil {
+		log.Println("jwk load error", err)
+		return result
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return keys
+}
+
+//Signing returns the key tokens are signed with, the newest activated one
+func Signing() (Key, error) {
+	now := time.Now().Unix()
+
+	for _, key := range current(RELOAD) {
+		if key.Activates > now {
+			continue
+		}
+
+		if key.Private == nil {
+			return key, errors.New("jwk " + key.KID + ": private key can't be decrypted with jwt_secret")
+		}
+
+		return key, nil
+	}
+
+	return Key{}, errors.New("no signing key")
+}
+
+//Lookup returns published key of kid
+func Lookup(kid string) (Key, bool) {
+	find := func(list []Key) (Key, bool) {
+		now := time.Now().Unix()
+
+		for _, key := range list {
+			if key.KID == kid && (key.Expires == 0 || key.Expires > now) {
+				return key, true
+			}
+		}
+		return Key{}, false
+	}
+
+	if key, ok := find(current(RELOAD)); ok {
+		return key, true
+	}
+
+	//Key created by another instance
+	return find(current(RELOAD_KID))
+}
+
+//Published returns JWKS of unexpired keys, including the next key before it activates
+func Published() (Set, error) {
+	set := Set{Keys: []JWK{}}
+
+	now := time.Now().Unix()
+
+	for _, key := range current(RELOAD) {
+		if key.Expires != 0 && key.Expires <= now {
+			continue
+		}
+
+		value, err := key.JWK()
+		if err != nil {
+			return set, err
+		}
+
+		set.Keys = append(set.Keys, value)
+	}
+
+	return set, nil
+}
+
+//encrypt encrypts private key with AES-256-GCM key derived from secret
+func encrypt(secret string, der []byte) (string, error) {
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, der, nil)), nil
+}
+
+//decrypt decrypts private key with the secret it was encrypted with
+func decrypt(settings config.JWT, key models.JWTKey) ([]byte, error) {
+	secret := settings.Secret
+	if key.EncryptionKeyID != settings.KeyID {
+		var ok bool
+		if secret, ok = settings.PreviousKeys[key.EncryptionKeyID]; !ok {
+			return nil, errors.New("unknown encryption key " + key.EncryptionKeyID)
+		}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("invalid private key")
+	}
+
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
+
+func newGCM(secret string) (cipher.AEAD, error) {
+	hash := sha256.Sum256([]byte(secret))
+
+	block, err := aes.NewCipher(hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -1,24 +1,60 @@
 package jwt
 
 import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/ianidi/exchange-server/internal/redis"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/mediocregopher/radix/v3"
 )
 
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
 const (
 	// MemberKey is used to set a user into gin.Context.
 	MemberKey              = "MemberID"
 	AuthorizationHeaderKey = "Authorization"
 	RefreshHeaderKey       = "Refresh"
+	DeviceHeaderKey        = "X-Device-ID"
+)
+
+//Redis keys of session store
+const (
+	SESSION_KEY         = "session:"         //session:<SessionID> - Session JSON
+	SESSION_MEMBER_KEY  = "session:member:"  //session:member:<MemberID> - set of member SessionIDs
+	SESSION_ACCESS_KEY  = "session:access:"  //session:access:<TokenUuid> - MemberID
+	SESSION_REFRESH_KEY = "session:refresh:" //session:refresh:<RefreshUuid> - SessionID, REFRESH_USED+SessionID once rotated
+
+	//Prefix of rotated refresh token value, presenting it again revokes the session
+	REFRESH_USED = "used:"
 )
 
+//useRefreshScript marks refresh token as used in one step and returns its previous value, nil if token is revoked or expired.
+//Value of used token is returned unchanged, ARGV[1] - REFRESH_USED, ARGV[2] - seconds the used token is kept
+var useRefreshScript = radix.NewEvalScript(1, `
+local value = redis.call("GET", KEYS[1])
+if not value then return false end
+if string.sub(value, 1, string.len(ARGV[1])) == ARGV[1] then return value end
+redis.call("SET", KEYS[1], ARGV[1] .. value, "EX", ARGV[2])
+return value
+`)
+
 var Service *ProfileHandler
 
 type AuthInterface interface {
-	// CreateAuth(string, *TokenDetails) error
+	CreateAuth(string, *TokenDetails) error
 	FetchAuth(string) (string, error)
+	UseRefresh(string, int64) (string, error)
 	DeleteRefresh(string) error
-	// DeleteTokens(*AccessDetails) error
+	DeleteTokens(*AccessDetails) error
+	FetchSession(string) (*Session, error)
+	QuerySessions(string) ([]*Session, error)
+	DeleteSession(string, string) error
+	DeleteSessions(string) error
 }
 
 type service struct {
@@ -43,90 +79,239 @@ func NewAuth() *service {
 }
 
 type AccessDetails struct {
-	// TokenUuid string
-	UserId string
+	TokenUuid   string
+	SessionID   string
+	UserId      string
+	Role        int64    //Member.Role when the token was issued
+	Permissions []string //rbac permissions when the token was issued
 }
 
 type TokenDetails struct {
 	AccessToken  string
 	RefreshToken string
-	// TokenUuid    string
-	RefreshUuid string
-	AtExpires   int64
-	RtExpires   int64
+	TokenUuid    string
+	RefreshUuid  string
+	AtExpires    int64
+	RtExpires    int64
+	Session      *Session
+}
+
+//Session - signed in device, all refresh tokens rotated from one sign in belong to the same session
+type Session struct {
+	SessionID   string
+	MemberID    string
+	DeviceID    string
+	IP          string
+	UserAgent   string
+	Created     int64
+	Updated     int64  //Last refresh
+	Expires     int64  //Refresh token expiration
+	TokenUuid   string //Current access token
+	RefreshUuid string //Current refresh token
 }
 
-//Save token metadata to Redis
-// func (tk *service) CreateAuth(userId string, td *TokenDetails) error {
-// 	// at := time.Unix(td.AtExpires, 0) //converting Unix to UTC(to Time object)
-// 	// rt := time.Unix(td.RtExpires, 0)
-// 	// now := time.Now()
+//Save session and its token metadata to Redis. Tokens of the previous rotation of the session are deleted
+func (tk *service) CreateAuth(userId string, td *TokenDetails) error {
+	session := td.Session
+	if session == nil {
+		return errors.New("INVALID_SESSION")
+	}
+
+	now := time.Now().Unix()
 
-// 	var err error
+	previousToken := session.TokenUuid
+
+	session.MemberID = userId
+	session.TokenUuid = td.TokenUuid
+	session.RefreshUuid = td.RefreshUuid
+	session.Updated = now
+	session.Expires = td.RtExpires
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if err := tk.client.Do(radix.FlatCmd(nil, "SET", SESSION_KEY+session.SessionID, string(data), "EX", ttl(td.RtExpires))); err != nil {
+		return err
+	}
+
+	if err := tk.client.Do(radix.FlatCmd(nil, "SADD", SESSION_MEMBER_KEY+userId, session.SessionID)); err != nil {
+		return err
+	}
 
-// 	now := time.Now().Unix()
+	if err := tk.client.Do(radix.FlatCmd(nil, "SET", SESSION_ACCESS_KEY+td.TokenUuid, userId, "EX", ttl(td.AtExpires))); err != nil {
+		return err
+	}
 
-// 	var atCreated string
-// 	err = tk.client.Do(radix.Cmd(&atCreated, "SET", td.TokenUuid, userId, "EX", cast.ToString(td.AtExpires-now)))
-// 	if err != nil {
-// 		return err
-// 	}
+	if err := tk.client.Do(radix.FlatCmd(nil, "SET", SESSION_REFRESH_KEY+td.RefreshUuid, session.SessionID, "EX", ttl(td.RtExpires))); err != nil {
+		return err
+	}
 
-// 	var rtCreated string
-// 	err = tk.client.Do(radix.Cmd(&rtCreated, "SET", td.RefreshUuid, userId, "EX", cast.ToString(td.RtExpires-now)))
-// 	if err != nil {
-// 		return err
-// 	}
+	if previousToken != "" && previousToken != td.TokenUuid {
+		if err := tk.client.Do(radix.Cmd(nil, "DEL", SESSION_ACCESS_KEY+previousToken)); err != nil {
+			return err
+		}
+	}
 
-// 	if atCreated == "0" || rtCreated == "0" {
-// 		return errors.New("no record inserted")
-// 	}
-// 	return nil
-// }
+	return nil
+}
 
 //Check the metadata saved
 func (tk *service) FetchAuth(tokenUuid string) (string, error) {
 	var MemberID string
-	err := tk.client.Do(radix.Cmd(&MemberID, "GET", tokenUuid))
-	if err != nil {
+
+	if tokenUuid == "" {
+		return "", errors.New("unauthorized")
+	}
+
+	mn := radix.MaybeNil{Rcv: &MemberID}
+	if err := tk.client.Do(radix.Cmd(&mn, "GET", SESSION_ACCESS_KEY+tokenUuid)); err != nil {
 		return "", err
 	}
 
+	//Session was revoked or token rotated
+	if mn.Nil {
+		return "", errors.New("unauthorized")
+	}
+
 	return MemberID, nil
 }
 
-//Once a user row in the token table
-// func (tk *service) DeleteTokens(authD *AccessDetails) error {
-// 	//get the refresh uuid
-// 	refreshUuid := fmt.Sprintf("%s++%s", authD.TokenUuid, authD.UserId)
-// 	//delete access token
-// 	var deletedAt int64
-// 	err := tk.client.Do(radix.Cmd(&deletedAt, "DEL", authD.TokenUuid))
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	//delete refresh token
-// 	var deletedRt int64
-// 	err = tk.client.Do(radix.Cmd(&deletedRt, "DEL", refreshUuid))
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	//When the record is deleted, the return value is 1
-// 	if deletedAt != 1 || deletedRt != 1 {
-// 		return errors.New("something went wrong")
-// 	}
-// 	return nil
-// }
+//UseRefresh marks refresh token as used and returns its SessionID. A token used before means it was stolen or replayed: REFRESH_REUSED is returned with the SessionID, so the session is revoked
+func (tk *service) UseRefresh(refreshUuid string, expires int64) (string, error) {
+	var value string
+
+	//Used token is kept until it would expire to detect its reuse
+	mn := radix.MaybeNil{Rcv: &value}
+	if err := tk.client.Do(useRefreshScript.Cmd(&mn, SESSION_REFRESH_KEY+refreshUuid, REFRESH_USED, strconv.FormatInt(ttl(expires), 10))); err != nil {
+		return "", err
+	}
+
+	if mn.Nil {
+		//Session was revoked or token expired
+		return "", errors.New("REFRESH_REVOKED")
+	}
+
+	if strings.HasPrefix(value, REFRESH_USED) {
+		return strings.TrimPrefix(value, REFRESH_USED), errors.New("REFRESH_REUSED")
+	}
+
+	return value, nil
+}
 
 func (tk *service) DeleteRefresh(refreshUuid string) error {
 	//delete refresh token
 	var deleted int64
-	err := tk.client.Do(radix.Cmd(&deleted, "DEL", refreshUuid))
+	err := tk.client.Do(radix.Cmd(&deleted, "DEL", SESSION_REFRESH_KEY+refreshUuid))
 	if err != nil || deleted == 0 {
 		return err
 	}
 
 	return nil
 }
+
+//DeleteTokens revokes session of the access token
+func (tk *service) DeleteTokens(authD *AccessDetails) error {
+	if authD.SessionID == "" {
+		//delete access token of unknown session
+		return tk.client.Do(radix.Cmd(nil, "DEL", SESSION_ACCESS_KEY+authD.TokenUuid))
+	}
+
+	return tk.DeleteSession(authD.UserId, authD.SessionID)
+}
+
+//FetchSession returns session by ID
+func (tk *service) FetchSession(SessionID string) (*Session, error) {
+	var data string
+
+	mn := radix.MaybeNil{Rcv: &data}
+	if err := tk.client.Do(radix.Cmd(&mn, "GET", SESSION_KEY+SessionID)); err != nil {
+		return nil, err
+	}
+
+	if mn.Nil {
+		return nil, errors.New("NO_SESSION_RECORD")
+	}
+
+	var session Session
+
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+//QuerySessions returns active sessions of member, most recently used first. Expired sessions are removed from member set
+func (tk *service) QuerySessions(userId string) ([]*Session, error) {
+	var IDs []string
+
+	if err := tk.client.Do(radix.Cmd(&IDs, "SMEMBERS", SESSION_MEMBER_KEY+userId)); err != nil {
+		return nil, err
+	}
+
+	sessions := []*Session{}
+
+	for _, SessionID := range IDs {
+		session, err := tk.FetchSession(SessionID)
+		if err != nil {
+			tk.client.Do(radix.Cmd(nil, "SREM", SESSION_MEMBER_KEY+userId, SessionID))
+			continue
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Updated > sessions[j].Updated
+	})
+
+	return sessions, nil
+}
+
+//DeleteSession revokes session with its current access and refresh tokens
+func (tk *service) DeleteSession(userId string, SessionID string) error {
+	session, err := tk.FetchSession(SessionID)
+	if err == nil {
+		if session.MemberID != userId {
+			return errors.New("NO_SESSION_RECORD")
+		}
+
+		if err := tk.client.Do(radix.Cmd(nil, "DEL", SESSION_ACCESS_KEY+session.TokenUuid, SESSION_REFRESH_KEY+session.RefreshUuid)); err != nil {
+			return err
+		}
+	}
+
+	if err := tk.client.Do(radix.Cmd(nil, "DEL", SESSION_KEY+SessionID)); err != nil {
+		return err
+	}
+
+	return tk.client.Do(radix.Cmd(nil, "SREM", SESSION_MEMBER_KEY+userId, SessionID))
+}
+
+//DeleteSessions revokes all member sessions (e.g. after password reset)
+func (tk *service) DeleteSessions(userId string) error {
+	var IDs []string
+
+	if err := tk.client.Do(radix.Cmd(&IDs, "SMEMBERS", SESSION_MEMBER_KEY+userId)); err != nil {
+		return err
+	}
+
+	for _, SessionID := range IDs {
+		if err := tk.DeleteSession(userId, SessionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//ttl returns seconds left until UNIX timestamp, at least one second
+func ttl(expires int64) int64 {
+	seconds := expires - time.Now().Unix()
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
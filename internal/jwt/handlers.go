@@ -2,7 +2,6 @@ package jwt
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 	"time"
 
@@ -10,11 +9,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/ianidi/exchange-server/internal/auth"
 	"github.com/ianidi/exchange-server/internal/db"
-	"github.com/ianidi/exchange-server/internal/mail"
-	"github.com/ianidi/exchange-server/internal/otp"
+	"github.com/ianidi/exchange-server/internal/lockout"
 	"github.com/ianidi/exchange-server/internal/utils"
+	"github.com/ianidi/exchange-server/internal/verify"
 	"github.com/spf13/cast"
-	"github.com/spf13/viper"
 )
 
 // ProfileHandler struct
@@ -31,15 +29,13 @@ func (h *ProfileHandler) Signin(c *gin.Context) {
 
 	identity := auth.Identity{
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
-		Action:    auth.ACTION_SIGNIN,
-		Method:    auth.METHOD_EMAIL,
 	}
 
 	var query struct {
 		Email    string `json:"Email" binding:"required"`
 		Password string `json:"Password" binding:"required"`
 		Admin    bool   `json:"Admin"`
+		Captcha  string `json:"Captcha"` //Required once captcha flag is returned
 	}
 
 	if utils.Error(c, utils.ShouldBindJSON(c, &query)) {
@@ -50,43 +46,65 @@ func (h *ProfileHandler) Signin(c *gin.Context) {
 		return
 	}
 
+	attempt := lockout.Attempt{
+		Email:     identity.Email,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Captcha:   query.Captcha,
+	}
+
+	if lockoutError(c, attempt.Check()) {
+		return
+	}
+
 	if utils.Error(c, identity.CheckPassword(query.Password, false)) {
 		return
 	}
 
-	if utils.Error(c, identity.QueryMemberByEmail()) {
+	if err := identity.QueryMemberByEmail(); err != nil {
+		lockoutError(c, attempt.Fail(0, err))
 		return
 	}
 
-	if utils.Error(c, identity.ValidatePassword()) {
+	if err := identity.ValidatePassword(); err != nil {
+		lockoutError(c, attempt.Fail(identity.Member.MemberID, err))
 		return
 	}
 
+	attempt.Success()
+
 	if query.Admin && identity.Member.Role < 2 {
 		utils.Error(c, errors.New("INVALID_ROLE"))
 		return
 	}
 
-	if utils.Error(c, h.RespondAuthorizationHeader(c, identity.Member.MemberID)) {
+	h.respondSignin(c, identity.Member.MemberID)
+}
+
+// Logout revokes session of the access token
+// @Summary revoke current session
+// @Description Logout
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @ID Auth-Logout
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /auth/logout [post]
+func (h *ProfileHandler) Logout(c *gin.Context) {
+	metadata, err := h.TK.ExtractTokenMetadata(c.Request)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if utils.Error(c, h.RD.DeleteTokens(metadata)) {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"status": true})
 }
 
-func (h *ProfileHandler) Logout(c *gin.Context) {
-	//If metadata is passed and the tokens valid, delete them from the redis store
-	// metadata, _ := h.TK.ExtractTokenMetadata(c.Request)
-	// if metadata != nil {
-	// 	deleteErr := h.RD.DeleteTokens(metadata)
-	// 	if deleteErr != nil {
-	// 		c.JSON(http.StatusBadRequest, deleteErr.Error())
-	// 		return
-	// 	}
-	// }
-	c.JSON(http.StatusOK, "Successfully logged out")
-}
-
 func (h *ProfileHandler) Refresh(c *gin.Context) {
 	mapToken := map[string]string{}
 	if err := c.ShouldBindJSON(&mapToken); err != nil {
@@ -96,12 +114,7 @@ func (h *ProfileHandler) Refresh(c *gin.Context) {
 	refreshToken := mapToken["refresh_token"]
 
 	//verify the token
-	token, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(viper.GetString("jwt_secret")), nil
-	})
+	token, err := parseToken(refreshToken)
 	//if there is an error, the token must have expired
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, "Refresh token expired")
@@ -114,61 +127,170 @@ func (h *ProfileHandler) Refresh(c *gin.Context) {
 	}
 	//Since token is valid, get the uuid:
 	claims, ok := token.Claims.(jwt.MapClaims) //the token claims should conform to MapClaims
-	if ok && token.Valid {
-		refreshUuid, ok := claims["refresh_uuid"].(string) //convert the interface to string
-		if !ok {
-			c.JSON(http.StatusUnprocessableEntity, err)
-			return
-		}
-		userId, roleOk := claims["user_id"].(string)
-		if roleOk == false {
-			c.JSON(http.StatusUnprocessableEntity, "unauthorized")
-			return
-		}
-		//Delete the previous Refresh Token
-		delErr := h.RD.DeleteRefresh(refreshUuid)
-		if delErr != nil { //if any goes wrong
-			c.JSON(http.StatusUnauthorized, "unauthorized")
-			return
-		}
+	if !ok || !token.Valid {
+		utils.Error(c, errors.New("REFRESH_EXPIRED"))
+		return
+	}
 
-		if utils.Error(c, h.RespondAuthorizationHeader(c, cast.ToInt64(userId))) {
-			return
+	refreshUuid, ok := claims["refresh_uuid"].(string) //convert the interface to string
+	if !ok {
+		c.JSON(http.StatusUnprocessableEntity, "unauthorized")
+		return
+	}
+	SessionID, sessionOk := claims["session_id"].(string)
+	userId, userOk := claims["user_id"].(string)
+	if sessionOk == false || userOk == false {
+		c.JSON(http.StatusUnprocessableEntity, "unauthorized")
+		return
+	}
+
+	//Mark the refresh token as used, each refresh token can be exchanged once
+	usedSessionID, err := h.RD.UseRefresh(refreshUuid, cast.ToInt64(claims["exp"]))
+	if err != nil {
+		//Reuse of a rotated token, revoke the whole session as the token may be stolen
+		if err.Error() == "REFRESH_REUSED" && usedSessionID != "" {
+			h.RD.DeleteSession(userId, usedSessionID)
 		}
+		c.JSON(http.StatusUnauthorized, gin.H{"status": false, "error": err.Error()})
+		return
+	}
 
-		c.JSON(http.StatusOK, gin.H{"status": true})
-	} else {
+	if usedSessionID != SessionID {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": false, "error": "REFRESH_REVOKED"})
+		return
+	}
 
-		if utils.Error(c, errors.New("REFRESH_EXPIRED")) {
-			return
-		}
+	session, err := h.RD.FetchSession(SessionID)
+	if err != nil || session.MemberID != userId {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": false, "error": "REFRESH_REVOKED"})
+		return
 	}
+
+	session.IP = c.ClientIP()
+	session.UserAgent = c.Request.UserAgent()
+
+	ts, err := h.RotateSession(userId, session)
+	if utils.Error(c, err) {
+		return
+	}
+
+	respondSession(c, ts)
+
+	c.JSON(http.StatusOK, gin.H{"status": true})
 }
 
+//RespondAuthorizationHeader creates a new session of the request device and sets its tokens into response headers
 func (h *ProfileHandler) RespondAuthorizationHeader(c *gin.Context, MemberID int64) error {
-	var err error
-
-	//Create new pairs of refresh and access tokens
-	ts, err := h.TK.CreateToken(cast.ToString(MemberID))
+	ts, err := h.CreateSession(cast.ToString(MemberID), c.GetHeader(DeviceHeaderKey), c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
 		return err
 	}
 
-	//Save the tokens metadata to redis
-	// err = h.RD.CreateAuth(cast.ToString(MemberID), ts)
-	// if err != nil {
-	// 	return err
-	// }
-
-	c.Header(AuthorizationHeaderKey, ts.AccessToken)
-	c.Header(RefreshHeaderKey, ts.RefreshToken)
+	respondSession(c, ts)
 
 	return nil
 }
 
+// SessionGet list of active member sessions
+// @Summary list of active member sessions
+// @Description Sessions
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @ID Auth-Session-Get
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /auth/session [get]
+func (h *ProfileHandler) SessionGet(c *gin.Context) {
+	sessions, err := h.RD.QuerySessions(c.GetString(MemberKey))
+	if utils.Error(c, err) {
+		return
+	}
+
+	type SessionResult struct {
+		SessionID string
+		DeviceID  string
+		IP        string
+		UserAgent string
+		Created   int64
+		Updated   int64
+		Expires   int64
+		Current   bool //Session of the request
+	}
+
+	result := []SessionResult{}
+
+	for _, session := range sessions {
+		result = append(result, SessionResult{
+			SessionID: session.SessionID,
+			DeviceID:  session.DeviceID,
+			IP:        session.IP,
+			UserAgent: session.UserAgent,
+			Created:   session.Created,
+			Updated:   session.Updated,
+			Expires:   session.Expires,
+			Current:   session.SessionID == c.GetString(SessionKey),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "result": result})
+}
+
+// SessionRevoke revoke member session
+// @Summary revoke member session
+// @Description Revoke session, its access and refresh tokens stop working
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @ID Auth-Session-Revoke
+// @Param   SessionID	query		string		false		"Session ID"
+// @Param   All				query		bool			false		"Revoke all sessions except current"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /auth/session/revoke [post]
+func (h *ProfileHandler) SessionRevoke(c *gin.Context) {
+	var query struct {
+		SessionID string `json:"SessionID"`
+		All       bool   `json:"All"`
+	}
+
+	if utils.Error(c, utils.ShouldBindJSON(c, &query)) {
+		return
+	}
+
+	MemberID := c.GetString(MemberKey)
+
+	if query.All {
+		if utils.Error(c, h.RevokeSessions(MemberID, c.GetString(SessionKey))) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": true})
+		return
+	}
+
+	if query.SessionID == "" {
+		utils.Error(c, errors.New("INVALID_SESSION"))
+		return
+	}
+
+	if _, err := h.RD.FetchSession(query.SessionID); err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	if utils.Error(c, h.RD.DeleteSession(MemberID, query.SessionID)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true})
+}
+
 // AuthVerify auth verify email
+// Confirmation and reset links prove control of the email only, so they don't replace the second factor:
+// members with MFA enabled get mfa_pending token and methods of the second step, as after password sign in
 // @Summary auth verify email
-// @Description Auth verify
+// @Description Auth verify, members with MFA enabled get mfa_pending token
 // @Tags Auth, Verify
 // @Accept  json
 // @Produce  json
@@ -187,8 +309,6 @@ func (h *ProfileHandler) AuthVerify(c *gin.Context) {
 
 	identity := auth.Identity{
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
-		Method:    auth.METHOD_EMAIL,
 	}
 
 	var query struct {
@@ -202,23 +322,18 @@ func (h *ProfileHandler) AuthVerify(c *gin.Context) {
 		return
 	}
 
-	if query.Action != auth.ACTION_SIGNUP && query.Action != auth.ACTION_RESET {
+	if query.Action != verify.PURPOSE_SIGNUP && query.Action != verify.PURPOSE_RESET {
 		utils.Error(c, errors.New("INVALID_ACTION"))
 		return
 	}
 
-	identity.Action = query.Action
-
-	if utils.Error(c, identity.QueryVerifyByHash(query.Hash)) {
-		return
-	}
-
-	if utils.Error(c, identity.ValidateOTPCode(query.Code)) {
+	request, err := verify.CheckLink(query.Action, query.Hash, query.Code)
+	if utils.Error(c, err) {
 		return
 	}
 
 	//Reset member password
-	if identity.Action == auth.ACTION_RESET {
+	if query.Action == verify.PURPOSE_RESET {
 		if utils.Error(c, identity.CheckPassword(query.Password, true)) {
 			return
 		}
@@ -229,36 +344,39 @@ func (h *ProfileHandler) AuthVerify(c *gin.Context) {
 		}
 
 		tx := db.MustBegin()
-		tx.MustExec("UPDATE Member SET PasswordHash=$1 WHERE MemberID=$2", identity.PasswordHash, identity.Verify.MemberID)
-		tx.MustExec("UPDATE Verify SET Status=$1 WHERE VerifyID=$2", auth.STATUS_SUCCESS, identity.Verify.VerifyID)
-		tx.Commit()
+		tx.MustExec("UPDATE Member SET PasswordHash=$1 WHERE MemberID=$2", identity.PasswordHash, request.MemberID)
+		if err := verify.Complete(tx, request.VerifyID); err != nil {
+			tx.Rollback()
+			utils.Error(c, err)
+			return
+		}
+		if utils.Error(c, tx.Commit()) {
+			return
+		}
 
-		if utils.Error(c, h.RespondAuthorizationHeader(c, identity.Verify.MemberID)) {
+		//Password was reset, sign out all devices
+		if utils.Error(c, h.RD.DeleteSessions(cast.ToString(request.MemberID))) {
 			return
 		}
 	}
 
 	//Confirm member email after visiting sign up confirmation link
-	if identity.Action == auth.ACTION_SIGNUP {
+	if query.Action == verify.PURPOSE_SIGNUP {
 
-		// if utils.Error(c, identity.QueryOnboardingRecord(identity.Verify.MemberID)) {
+		// if utils.Error(c, identity.QueryOnboardingRecord(request.MemberID)) {
 		// 	return
 		// }
 
 		// if identity.Onboarding.Email != true {
 		// 	tx := db.MustBegin()
-		// 	tx.MustExec("UPDATE Onboarding SET Email=$1 WHERE MemberID=$2", true, identity.Verify.MemberID)
+		// 	tx.MustExec("UPDATE Onboarding SET Email=$1 WHERE MemberID=$2", true, request.MemberID)
 		// 	tx.Commit()
 		// }
 
 		//TODO: verify record being marked as success on full onboarding completion
-
-		if utils.Error(c, h.RespondAuthorizationHeader(c, identity.Verify.MemberID)) {
-			return
-		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": true})
+	h.respondSignin(c, request.MemberID)
 }
 
 // AuthSignup member signup
@@ -280,9 +398,6 @@ func (h *ProfileHandler) AuthSignup(c *gin.Context) {
 
 	identity := auth.Identity{
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
-		Action:    auth.ACTION_SIGNUP,
-		Method:    auth.METHOD_EMAIL,
 	}
 
 	var query struct {
@@ -321,31 +436,20 @@ func (h *ProfileHandler) AuthSignup(c *gin.Context) {
 		return
 	}
 
-	if utils.Error(c, identity.GenerateOTP()) {
-		return
-	}
-
-	identity.CodeHash, err = identity.HashBcrypt(identity.Code)
-	if utils.Error(c, err) {
-		return
-	}
-
-	identity.EmailHash, err = identity.HashMD5(identity.Email)
-	if utils.Error(c, err) {
-		return
-	}
-
-	tx = db.MustBegin()
 	// TODO: tx.MustExec("INSERT INTO Onboarding (MemberID, Email, Contract, Phone, Password) VALUES ($1, $2, $3, $4, $5)", identity.Member.MemberID, false, false, false, true)
-	tx.MustExec("INSERT INTO Verify (MemberID, Action, Method, CodeHash, Email, EmailHash, Status, IP, Created) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", identity.Member.MemberID, identity.Action, identity.Method, identity.CodeHash, identity.Email, identity.EmailHash, auth.STATUS_PENDING, c.ClientIP(), identity.Timestamp)
-	tx.Commit()
 
-	if utils.Error(c, identity.GenerateConfirmationLink()) {
+	//Activation link is sent by email
+	if _, err := verify.Send(verify.Request{
+		Purpose:  verify.PURPOSE_SIGNUP,
+		Channel:  verify.CHANNEL_EMAIL,
+		MemberID: identity.Member.MemberID,
+		Email:    identity.Email,
+		IP:       c.ClientIP(),
+		Link:     "verify/" + verify.PURPOSE_SIGNUP + "/",
+	}); utils.Error(c, err) {
 		return
 	}
 
-	mail.SendMail(identity.Email, "Account activation", "Dear customer,", "Please activate your account by clicking on the button below.", "Activate account", identity.ConfirmationLink, "Thank you for joining our platform.")
-
 	c.JSON(http.StatusOK, gin.H{"status": true})
 }
 
@@ -361,15 +465,8 @@ func (h *ProfileHandler) AuthSignup(c *gin.Context) {
 // @Failure 400 {object} Error
 // @Router /auth/signup/resend [post]
 func (h *ProfileHandler) AuthSignupResend(c *gin.Context) {
-	db := db.GetDB()
-
-	var err error
-
 	identity := auth.Identity{
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
-		Action:    auth.ACTION_SIGNUP,
-		Method:    auth.METHOD_EMAIL,
 	}
 
 	var query struct {
@@ -388,16 +485,6 @@ func (h *ProfileHandler) AuthSignupResend(c *gin.Context) {
 		return
 	}
 
-	timeout, err := identity.QueryVerifyTimeout()
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  false,
-			"error":   err.Error(),
-			"timeout": timeout,
-		})
-		return
-	}
-
 	if utils.Error(c, identity.QueryOnboardingRecord(identity.Member.MemberID)) {
 		return
 	}
@@ -408,31 +495,23 @@ func (h *ProfileHandler) AuthSignupResend(c *gin.Context) {
 		return
 	}
 
-	if utils.Error(c, identity.GenerateOTP()) {
-		return
-	}
-
-	identity.CodeHash, err = identity.HashBcrypt(identity.Code)
-	if utils.Error(c, err) {
-		return
-	}
-
-	identity.EmailHash, err = identity.HashMD5(identity.Email)
-	if utils.Error(c, err) {
-		return
-	}
-
-	tx := db.MustBegin()
-	tx.MustExec("UPDATE Verify SET Status=$1 WHERE MemberID=$2 AND Action=$3 AND Method=$4 AND Status=$5", auth.STATUS_CANCELLED, identity.Member.MemberID, identity.Action, identity.Method, auth.STATUS_PENDING)
-	tx.MustExec("INSERT INTO Verify (MemberID, Action, Method, CodeHash, Email, EmailHash, Status, IP, Created) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", identity.Member.MemberID, identity.Action, identity.Method, identity.CodeHash, identity.Email, identity.EmailHash, auth.STATUS_PENDING, c.ClientIP(), identity.Timestamp)
-	tx.Commit()
-
-	if utils.Error(c, identity.GenerateConfirmationLink()) {
+	timeout, err := verify.Send(verify.Request{
+		Purpose:  verify.PURPOSE_SIGNUP,
+		Channel:  verify.CHANNEL_EMAIL,
+		MemberID: identity.Member.MemberID,
+		Email:    identity.Email,
+		IP:       c.ClientIP(),
+		Link:     "verify/" + verify.PURPOSE_SIGNUP + "/",
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  false,
+			"error":   err.Error(),
+			"timeout": timeout,
+		})
 		return
 	}
 
-	mail.SendMail(identity.Email, "Account activation", "Dear customer,", "Please activate your account by clicking on the button below.", "Activate account", identity.ConfirmationLink, "Thank you for joining our platform.")
-
 	c.JSON(http.StatusOK, gin.H{"status": true})
 }
 
@@ -448,15 +527,8 @@ func (h *ProfileHandler) AuthSignupResend(c *gin.Context) {
 // @Failure 400 {object} Error
 // @Router /auth/phone/request [post]
 func (h *ProfileHandler) AuthPhoneRequest(c *gin.Context) {
-	db := db.GetDB()
-
-	var err error
-
 	identity := auth.Identity{
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
-		Action:    auth.ACTION_SIGNIN,
-		Method:    auth.METHOD_PHONE,
 	}
 
 	var query struct {
@@ -475,7 +547,13 @@ func (h *ProfileHandler) AuthPhoneRequest(c *gin.Context) {
 		return
 	}
 
-	timeout, err := identity.QueryVerifyTimeout()
+	timeout, err := verify.Send(verify.Request{
+		Purpose:  verify.PURPOSE_SIGNIN,
+		Channel:  verify.CHANNEL_PHONE,
+		MemberID: identity.Member.MemberID,
+		Phone:    identity.Phone,
+		IP:       c.ClientIP(),
+	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  false,
@@ -485,28 +563,9 @@ func (h *ProfileHandler) AuthPhoneRequest(c *gin.Context) {
 		return
 	}
 
-	if utils.Error(c, identity.GenerateOTP()) {
-		return
-	}
-
-	identity.CodeHash, err = identity.HashBcrypt(identity.Code)
-	if utils.Error(c, err) {
-		return
-	}
-
-	tx := db.MustBegin()
-	tx.MustExec("UPDATE Verify SET Status=$1 WHERE MemberID=$2 AND Action=$3 AND Method=$4 AND Status=$5", auth.STATUS_CANCELLED, identity.Member.MemberID, identity.Action, identity.Method, auth.STATUS_PENDING)
-	tx.MustExec("INSERT INTO Verify (MemberID, Action, Method, CodeHash, Phone, Status, IP, Created) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", identity.Member.MemberID, identity.Action, identity.Method, identity.CodeHash, identity.Phone, auth.STATUS_PENDING, c.ClientIP(), identity.Timestamp)
-	tx.Commit()
-
-	err = otp.SendSMS(identity.Phone, fmt.Sprintf("Your code is %s", identity.Code))
-	if utils.Error(c, err) {
-		return
-	}
-
 	c.JSON(200, gin.H{
 		"status":  true,
-		"timeout": identity.Timeout,
+		"timeout": timeout,
 	})
 }
 
@@ -522,15 +581,8 @@ func (h *ProfileHandler) AuthPhoneRequest(c *gin.Context) {
 // @Failure 400 {object} Error
 // @Router /auth/reset [post]
 func (h *ProfileHandler) AuthReset(c *gin.Context) {
-	db := db.GetDB()
-
-	var err error
-
 	identity := auth.Identity{
 		Timestamp: time.Now().Unix(),
-		Timeout:   60,
-		Action:    auth.ACTION_RESET,
-		Method:    auth.METHOD_EMAIL,
 	}
 
 	var query struct {
@@ -559,7 +611,14 @@ func (h *ProfileHandler) AuthReset(c *gin.Context) {
 		return
 	}
 
-	timeout, err := identity.QueryVerifyTimeout()
+	timeout, err := verify.Send(verify.Request{
+		Purpose:  verify.PURPOSE_RESET,
+		Channel:  verify.CHANNEL_EMAIL,
+		MemberID: identity.Member.MemberID,
+		Email:    identity.Email,
+		IP:       c.ClientIP(),
+		Link:     "verify/" + verify.PURPOSE_RESET + "/",
+	})
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  false,
@@ -569,30 +628,5 @@ func (h *ProfileHandler) AuthReset(c *gin.Context) {
 		return
 	}
 
-	if utils.Error(c, identity.GenerateOTP()) {
-		return
-	}
-
-	identity.CodeHash, err = identity.HashBcrypt(identity.Code)
-	if utils.Error(c, err) {
-		return
-	}
-
-	identity.EmailHash, err = identity.HashMD5(identity.Email)
-	if utils.Error(c, err) {
-		return
-	}
-
-	tx := db.MustBegin()
-	tx.MustExec("UPDATE Verify SET Status=$1 WHERE MemberID=$2 AND Action=$3 AND Method=$4 AND Status=$5", auth.STATUS_CANCELLED, identity.Member.MemberID, auth.ACTION_RESET, auth.METHOD_EMAIL, auth.STATUS_PENDING)
-	tx.MustExec("INSERT INTO Verify (MemberID, Action, Method, CodeHash, Email, EmailHash, Status, IP, Created) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", identity.Member.MemberID, auth.ACTION_RESET, auth.METHOD_EMAIL, identity.CodeHash, identity.Email, identity.EmailHash, auth.STATUS_PENDING, c.ClientIP(), identity.Timestamp)
-	tx.Commit()
-
-	if utils.Error(c, identity.GenerateConfirmationLink()) {
-		return
-	}
-
-	mail.SendMail(identity.Email, "Password reset", "Dear customer,", "Please reset your password by clicking on the button below.", "Reset password", identity.ConfirmationLink, "If you have received a password reset email without requesting one, we recommend that you take steps to secure your account.")
-
 	c.JSON(http.StatusOK, gin.H{"status": true})
 }
@@ -0,0 +1,135 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ianidi/exchange-server/internal/config"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/jwk"
+	"github.com/ianidi/exchange-server/internal/rbac"
+	"github.com/ianidi/exchange-server/internal/utils"
+	"github.com/spf13/cast"
+)
+
+//Tokens are signed with asymmetric keys of internal/jwk selected by "kid" header, other services verify them with /.well-known/jwks.json
+
+//IAT_LEEWAY - allowed clock difference of instances issuing tokens
+const IAT_LEEWAY = 60 //Seconds
+
+//signToken signs claims with the current key, standard claims are added
+func signToken(claims jwt.MapClaims) (string, error) {
+	settings := config.Get().JWT
+
+	key, err := jwk.Signing()
+	if err != nil {
+		return "", err
+	}
+
+	claims["iss"] = settings.Issuer
+	claims["aud"] = settings.Audience
+	claims["iat"] = time.Now().Unix()
+	if _, ok := claims["jti"]; !ok {
+		claims["jti"] = uuid.New().String()
+	}
+
+	token := jwt.NewWithClaims(key.Method(), claims)
+	token.Header["kid"] = key.KID
+
+	return token.SignedString(key.Private)
+}
+
+//parseToken verifies token with the key of its kid and validates standard claims
+func parseToken(tokenString string) (*jwt.Token, error) {
+	//Time claims are validated below, iat with allowed clock difference
+	parser := jwt.Parser{SkipClaimsValidation: true}
+
+	token, err := parser.Parse(tokenString, verificationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("unauthorized")
+	}
+
+	settings := config.Get().JWT
+
+	now := time.Now().Unix()
+
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("Token is expired")
+	}
+
+	if !claims.VerifyIssuer(settings.Issuer, true) || !claims.VerifyAudience(settings.Audience, true) || !claims.VerifyIssuedAt(now+IAT_LEEWAY, true) {
+		return nil, errors.New("unauthorized")
+	}
+
+	if jti, ok := claims["jti"].(string); !ok || jti == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	return token, nil
+}
+
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := jwk.Lookup(kid)
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+
+	//Algorithm of the key, not of the token header
+	if token.Method.Alg() != key.Method().Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.Public, nil
+}
+
+//memberClaims adds role and permissions of member to access token claims. They are informational for other services,
+//permissions are checked with rbac on each request here
+func memberClaims(claims jwt.MapClaims, MemberID string) error {
+	db := db.GetDB()
+
+	var Role int64
+
+	if err := db.Get(&Role, "SELECT Role FROM Member WHERE MemberID=$1", cast.ToInt64(MemberID)); err != nil {
+		return err
+	}
+
+	permissions, err := rbac.QueryPermissions(cast.ToInt64(MemberID))
+	if err != nil {
+		return err
+	}
+
+	claims["role"] = Role
+	claims["permissions"] = permissions
+
+	return nil
+}
+
+// JWKS public keys of member tokens
+// @Summary public keys of member tokens
+// @Description JSON Web Key Set to verify tokens signed by the server, the next key is published before it is used
+// @Tags Auth
+// @Produce  json
+// @ID JWKS
+// @Success 200 {object} jwk.Set
+// @Router /.well-known/jwks.json [get]
+func JWKS(c *gin.Context) {
+	set, err := jwk.Published()
+	if utils.Error(c, err) {
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, set)
+}
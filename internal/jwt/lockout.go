@@ -0,0 +1,63 @@
+package jwt
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/lockout"
+	"github.com/ianidi/exchange-server/internal/utils"
+)
+
+//lockoutError responds with error of rejected sign in, flags tell client to show CAPTCHA or wait
+func lockoutError(c *gin.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	e, ok := err.(*lockout.Error)
+	if !ok {
+		return utils.Error(c, err)
+	}
+
+	code := http.StatusBadRequest
+	if e.Status.Locked || e.Status.RetryAfter > 0 {
+		code = http.StatusTooManyRequests
+	}
+
+	c.JSON(code, gin.H{
+		"status":      false,
+		"error":       e.Message,
+		"captcha":     e.Status.Captcha,
+		"locked":      e.Status.Locked,
+		"retry_after": e.Status.RetryAfter,
+	})
+
+	return true
+}
+
+// Unlock unlocks account locked after failed sign ins
+// @Summary unlock account with link sent by email
+// @Description Unlock
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @ID Auth-Unlock
+// @Param   Token	query		string		true		"Token of unlock link"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /auth/unlock [post]
+func (h *ProfileHandler) Unlock(c *gin.Context) {
+	var query struct {
+		Token string `json:"Token" binding:"required"`
+	}
+
+	if utils.Error(c, utils.ShouldBindJSON(c, &query)) {
+		return
+	}
+
+	if utils.Error(c, lockout.Unlock(query.Token, c.ClientIP(), c.Request.UserAgent())) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true})
+}
@@ -0,0 +1,175 @@
+package jwt
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ianidi/exchange-server/internal/mfa"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/ianidi/exchange-server/internal/utils"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/spf13/cast"
+)
+
+const (
+	//mfa_pending token is issued after password check of member with MFA enabled, it is exchanged for a session with TOTP, recovery code or passkey
+	MFA_PENDING_TTL = 5 * time.Minute
+	MFA_PENDING_KEY = "session:mfa:" //session:mfa:<MFAUuid> - MemberID
+)
+
+//CreatePendingToken issues short-lived mfa_pending token of member, it is not accepted as access token
+func (h *ProfileHandler) CreatePendingToken(MemberID string) (string, error) {
+	mfaUuid := uuid.New().String()
+	expires := time.Now().Add(MFA_PENDING_TTL).Unix()
+
+	claims := jwt.MapClaims{}
+	claims["mfa_uuid"] = mfaUuid
+	claims["jti"] = mfaUuid
+	claims["mfa_pending"] = true
+	claims["user_id"] = MemberID
+	claims["exp"] = expires
+
+	token, err := signToken(claims)
+	if err != nil {
+		return "", err
+	}
+
+	if err := redis.GetRedis().Do(radix.FlatCmd(nil, "SET", MFA_PENDING_KEY+mfaUuid, MemberID, "EX", int64(MFA_PENDING_TTL.Seconds()))); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+//VerifyPendingToken returns MemberID and uuid of valid mfa_pending token
+func (h *ProfileHandler) VerifyPendingToken(tokenString string) (string, string, error) {
+	token, err := parseToken(tokenString)
+	if err != nil {
+		return "", "", errors.New("MFA_TOKEN_EXPIRED")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", "", errors.New("MFA_TOKEN_EXPIRED")
+	}
+
+	pending, _ := claims["mfa_pending"].(bool)
+	mfaUuid, uuidOk := claims["mfa_uuid"].(string)
+	userId, userOk := claims["user_id"].(string)
+	if !pending || !uuidOk || !userOk {
+		return "", "", errors.New("MFA_TOKEN_EXPIRED")
+	}
+
+	var MemberID string
+
+	mn := radix.MaybeNil{Rcv: &MemberID}
+	if err := redis.GetRedis().Do(radix.Cmd(&mn, "GET", MFA_PENDING_KEY+mfaUuid)); err != nil {
+		return "", "", err
+	}
+
+	//Token was used or expired
+	if mn.Nil || MemberID != userId {
+		return "", "", errors.New("MFA_TOKEN_EXPIRED")
+	}
+
+	return MemberID, mfaUuid, nil
+}
+
+//DeletePendingToken makes mfa_pending token single use, concurrent use of the same token fails
+func (h *ProfileHandler) DeletePendingToken(mfaUuid string) error {
+	var deleted int64
+
+	if err := redis.GetRedis().Do(radix.Cmd(&deleted, "DEL", MFA_PENDING_KEY+mfaUuid)); err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return errors.New("MFA_TOKEN_EXPIRED")
+	}
+
+	return nil
+}
+
+//SigninToken returns mfa_pending token and methods of the second step of member with MFA enabled, otherwise an empty string
+func (h *ProfileHandler) SigninToken(MemberID int64) (string, []string, error) {
+	methods, err := mfa.Methods(MemberID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(methods) == 0 {
+		return "", nil, nil
+	}
+
+	token, err := h.CreatePendingToken(cast.ToString(MemberID))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, methods, nil
+}
+
+//respondSignin completes sign in with a new session, members with MFA enabled get mfa_pending token and methods of the second step
+func (h *ProfileHandler) respondSignin(c *gin.Context, MemberID int64) {
+	token, methods, err := h.SigninToken(MemberID)
+	if utils.Error(c, err) {
+		return
+	}
+
+	if token != "" {
+		c.JSON(http.StatusOK, gin.H{"status": true, "mfa": true, "token": token, "methods": methods})
+		return
+	}
+
+	if utils.Error(c, h.RespondAuthorizationHeader(c, MemberID)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true})
+}
+
+// SigninMFA second sign in step of member with MFA enabled
+// @Summary second sign in step with TOTP or recovery code
+// @Description Signin MFA
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @ID Auth-Signin-MFA
+// @Param   Token	query		string		true		"mfa_pending token"
+// @Param   Code	query		string		true		"TOTP or recovery code"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /auth/signin/mfa [post]
+func (h *ProfileHandler) SigninMFA(c *gin.Context) {
+	var query struct {
+		Token string `json:"Token" binding:"required"`
+		Code  string `json:"Code" binding:"required"`
+	}
+
+	if utils.Error(c, utils.ShouldBindJSON(c, &query)) {
+		return
+	}
+
+	MemberID, mfaUuid, err := h.VerifyPendingToken(query.Token)
+	if utils.Error(c, err) {
+		return
+	}
+
+	if utils.Error(c, mfa.Verify(cast.ToInt64(MemberID), query.Code)) {
+		return
+	}
+
+	if utils.Error(c, h.DeletePendingToken(mfaUuid)) {
+		return
+	}
+
+	if utils.Error(c, h.RespondAuthorizationHeader(c, cast.ToInt64(MemberID))) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true})
+}
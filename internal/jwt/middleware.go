@@ -15,18 +15,15 @@ func TokenAuthMiddleware(h *ProfileHandler) gin.HandlerFunc {
 			return
 		}
 
-		metadata, err := h.TK.ExtractTokenMetadata(c.Request)
+		metadata, err := h.Authorize(ExtractToken(c.Request))
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, "unauthorized")
+			c.Abort()
 			return
 		}
-		// MemberID, err := h.RD.FetchAuth(metadata.TokenUuid)
-		// if err != nil {
-		// 	c.JSON(http.StatusUnauthorized, "unauthorized")
-		// 	return
-		// }
 
 		c.Set(MemberKey, metadata.UserId)
+		c.Set(SessionKey, metadata.SessionID)
 
 		c.Next()
 	}
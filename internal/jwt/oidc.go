@@ -0,0 +1,163 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/oidc"
+	"github.com/ianidi/exchange-server/internal/utils"
+)
+
+//Social sign in: the browser is redirected to the provider, the provider redirects back to callback which redirects
+//to the platform with one-time login code, the client exchanges the code for tokens with /auth/signin/oidc
+
+// OIDCProviders social sign in providers
+// @Summary social sign in providers
+// @Description OpenID Connect providers, sign in starts with GET /auth/oidc/{provider}
+// @Tags Auth, OIDC
+// @Produce  json
+// @ID Auth-OIDC-Providers
+// @Success 200 {object} Success
+// @Router /auth/oidc [get]
+func (h *ProfileHandler) OIDCProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": true, "result": oidc.Names()})
+}
+
+// OIDCBegin redirect to the provider sign in page
+// @Summary redirect to the provider sign in page
+// @Description Starts authorization code flow with PKCE, the browser is redirected to the provider
+// @Tags Auth, OIDC
+// @ID Auth-OIDC-Begin
+// @Param   provider	path		string		true		"google, apple, oidc"
+// @Param   Email			query		string		false		"Email of the account, passed to the provider as login_hint"
+// @Success 302
+// @Failure 400 {object} Error
+// @Router /auth/oidc/{provider} [get]
+func (h *ProfileHandler) OIDCBegin(c *gin.Context) {
+	location, err := oidc.Begin(c.Param("provider"), c.Query("Email"))
+	if utils.Error(c, err) {
+		return
+	}
+
+	c.Redirect(http.StatusFound, location)
+}
+
+// OIDCCallback authorization response of the provider
+// @Summary authorization response of the provider
+// @Description The provider redirects (Apple posts) the code here, the browser is redirected to PlatformURL/oidc/callback with Code or Error query parameter
+// @Tags Auth, OIDC
+// @ID Auth-OIDC-Callback
+// @Param   provider	path		string		true		"google, apple, oidc"
+// @Param   state			query		string		true		"State of authorization request"
+// @Param   code			query		string		true		"Authorization code"
+// @Success 302
+// @Router /auth/oidc/{provider}/callback [get]
+// @Router /auth/oidc/{provider}/callback [post]
+func (h *ProfileHandler) OIDCCallback(c *gin.Context) {
+	db := db.GetDB()
+
+	var settings models.Settings
+
+	if err := db.Get(&settings, "SELECT * FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	location := settings.PlatformURL + "oidc/callback?"
+
+	code, err := h.oidcLogin(c)
+	if err != nil {
+		c.Redirect(http.StatusFound, location+url.Values{"Error": {oidcError(err)}}.Encode())
+		return
+	}
+
+	c.Redirect(http.StatusFound, location+url.Values{"Code": {code}}.Encode())
+}
+
+//oidcLogin verifies authorization response and returns login code of the member
+func (h *ProfileHandler) oidcLogin(c *gin.Context) (string, error) {
+	name := c.Param("provider")
+
+	//Apple posts the response with response_mode=form_post
+	value, code, failure := c.Query("state"), c.Query("code"), c.Query("error")
+	if c.Request.Method == http.MethodPost {
+		value, code, failure = c.PostForm("state"), c.PostForm("code"), c.PostForm("error")
+	}
+
+	if failure != "" {
+		return "", errors.New("OIDC_ACCESS_DENIED")
+	}
+
+	claims, err := oidc.Finish(name, value, code)
+	if err != nil {
+		return "", err
+	}
+
+	//Apple sends name of the member with the first authorization only, it is not in ID token
+	if name == oidc.PROVIDER_APPLE && c.PostForm("user") != "" {
+		var user struct {
+			Name struct {
+				FirstName string `json:"firstName"`
+				LastName  string `json:"lastName"`
+			} `json:"name"`
+		}
+
+		if err := json.Unmarshal([]byte(c.PostForm("user")), &user); err == nil {
+			claims.FirstName, claims.LastName = user.Name.FirstName, user.Name.LastName
+		}
+	}
+
+	MemberID, err := oidc.Resolve(name, claims, c.ClientIP())
+	if err != nil {
+		return "", err
+	}
+
+	return oidc.NewLoginCode(MemberID)
+}
+
+//oidcError returns error code for the platform, other errors are logged
+func oidcError(err error) string {
+	message := err.Error()
+
+	if message == strings.ToUpper(message) && !strings.Contains(message, " ") {
+		return message
+	}
+
+	fmt.Println("oidc sign in error", message)
+
+	return "OIDC_SIGNIN_FAILED"
+}
+
+// OIDCSignin sign in with login code of social sign in
+// @Summary sign in with login code of social sign in
+// @Description Exchanges Code of PlatformURL/oidc/callback for tokens, members with MFA enabled get mfa_pending token
+// @Tags Auth, OIDC
+// @Accept  json
+// @Produce  json
+// @ID Auth-Signin-OIDC
+// @Param   Code	query		string		true		"Login code"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /auth/signin/oidc [post]
+func (h *ProfileHandler) OIDCSignin(c *gin.Context) {
+	var query struct {
+		Code string `json:"Code" binding:"required"`
+	}
+
+	if utils.Error(c, utils.ShouldBindJSON(c, &query)) {
+		return
+	}
+
+	MemberID, err := oidc.UseLoginCode(query.Code)
+	if utils.Error(c, err) {
+		return
+	}
+
+	h.respondSignin(c, MemberID)
+}
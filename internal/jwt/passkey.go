@@ -0,0 +1,163 @@
+package jwt
+
+import (
+	"database/sql"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/utils"
+	"github.com/ianidi/exchange-server/internal/webauthn"
+	"github.com/spf13/cast"
+)
+
+// PasskeyBegin options of passwordless sign in with passkey
+// @Summary options of passwordless sign in with passkey
+// @Description Passkey sign in options for navigator.credentials.get()
+// @Tags Auth, Passkey
+// @Accept  json
+// @Produce  json
+// @ID Auth-Passkey-Begin
+// @Param   Email	query		string		false		"Email, passkeys of the member are offered (discoverable credentials otherwise)"
+// @Success 200 {object} webauthn.RequestOptions
+// @Failure 400 {object} Error
+// @Router /auth/passkey/begin [post]
+func (h *ProfileHandler) PasskeyBegin(c *gin.Context) {
+	db := db.GetDB()
+
+	var query struct {
+		Email string `json:"Email"`
+	}
+
+	//Body is optional
+	if err := c.ShouldBindJSON(&query); err != nil && err != io.EOF {
+		utils.Error(c, err)
+		return
+	}
+
+	//Unknown email gets discoverable credentials options, so members can't be enumerated
+	var MemberID int64
+
+	if query.Email != "" {
+		if err := db.Get(&MemberID, "SELECT MemberID FROM Member WHERE Email=$1", strings.ToLower(query.Email)); err != nil && err != sql.ErrNoRows {
+			utils.Error(c, err)
+			return
+		}
+	}
+
+	options, err := webauthn.LoginOptions(webauthn.CEREMONY_LOGIN, MemberID)
+	if utils.Error(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "result": options})
+}
+
+// PasskeySignin passwordless sign in with passkey
+// @Summary passwordless sign in with passkey
+// @Description Passkey sign in with navigator.credentials.get() response, user verification is required
+// @Tags Auth, Passkey
+// @Accept  json
+// @Produce  json
+// @ID Auth-Passkey-Signin
+// @Param   Credential	query		webauthn.Credential		true		"PublicKeyCredential"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /auth/passkey [post]
+func (h *ProfileHandler) PasskeySignin(c *gin.Context) {
+	var query struct {
+		Credential webauthn.Credential `json:"Credential"`
+	}
+
+	if utils.Error(c, utils.ShouldBindJSON(c, &query)) {
+		return
+	}
+
+	credential, err := webauthn.FinishLogin(webauthn.CEREMONY_LOGIN, 0, query.Credential)
+	if utils.Error(c, err) {
+		return
+	}
+
+	if utils.Error(c, h.RespondAuthorizationHeader(c, credential.MemberID)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true})
+}
+
+// SigninPasskeyBegin options of the second sign in step with passkey
+// @Summary options of the second sign in step with passkey
+// @Description Passkey second step options for navigator.credentials.get()
+// @Tags Auth, Passkey
+// @Accept  json
+// @Produce  json
+// @ID Auth-Signin-Passkey-Begin
+// @Param   Token	query		string		true		"mfa_pending token"
+// @Success 200 {object} webauthn.RequestOptions
+// @Failure 400 {object} Error
+// @Router /auth/signin/passkey/begin [post]
+func (h *ProfileHandler) SigninPasskeyBegin(c *gin.Context) {
+	var query struct {
+		Token string `json:"Token" binding:"required"`
+	}
+
+	if utils.Error(c, utils.ShouldBindJSON(c, &query)) {
+		return
+	}
+
+	MemberID, _, err := h.VerifyPendingToken(query.Token)
+	if utils.Error(c, err) {
+		return
+	}
+
+	options, err := webauthn.LoginOptions(webauthn.CEREMONY_MFA, cast.ToInt64(MemberID))
+	if utils.Error(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "result": options})
+}
+
+// SigninPasskey second sign in step with passkey
+// @Summary second sign in step with passkey
+// @Description Signin passkey
+// @Tags Auth, Passkey
+// @Accept  json
+// @Produce  json
+// @ID Auth-Signin-Passkey
+// @Param   Token				query		string								true		"mfa_pending token"
+// @Param   Credential	query		webauthn.Credential		true		"PublicKeyCredential"
+// @Success 200 {object} Success
+// @Failure 400 {object} Error
+// @Router /auth/signin/passkey [post]
+func (h *ProfileHandler) SigninPasskey(c *gin.Context) {
+	var query struct {
+		Token      string              `json:"Token" binding:"required"`
+		Credential webauthn.Credential `json:"Credential"`
+	}
+
+	if utils.Error(c, utils.ShouldBindJSON(c, &query)) {
+		return
+	}
+
+	MemberID, mfaUuid, err := h.VerifyPendingToken(query.Token)
+	if utils.Error(c, err) {
+		return
+	}
+
+	if _, err := webauthn.FinishLogin(webauthn.CEREMONY_MFA, cast.ToInt64(MemberID), query.Credential); utils.Error(c, err) {
+		return
+	}
+
+	if utils.Error(c, h.DeletePendingToken(mfaUuid)) {
+		return
+	}
+
+	if utils.Error(c, h.RespondAuthorizationHeader(c, cast.ToInt64(MemberID))) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true})
+}
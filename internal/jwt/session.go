@@ -0,0 +1,95 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SessionKey is used to set a session of the access token into gin.Context.
+const SessionKey = "SessionID"
+
+//CreateSession signs in member on a device and issues the first pair of tokens of the session
+func (h *ProfileHandler) CreateSession(MemberID string, DeviceID string, IP string, UserAgent string) (*TokenDetails, error) {
+	if DeviceID == "" {
+		DeviceID = uuid.New().String()
+	}
+
+	session := &Session{
+		SessionID: uuid.New().String(),
+		DeviceID:  DeviceID,
+		IP:        IP,
+		UserAgent: UserAgent,
+		Created:   time.Now().Unix(),
+	}
+
+	return h.RotateSession(MemberID, session)
+}
+
+//RotateSession issues a new pair of tokens of the session, previous access token is revoked
+func (h *ProfileHandler) RotateSession(MemberID string, session *Session) (*TokenDetails, error) {
+	ts, err := h.TK.CreateToken(MemberID, session.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.Session = session
+
+	//Save the tokens metadata to redis
+	if err := h.RD.CreateAuth(MemberID, ts); err != nil {
+		return nil, err
+	}
+
+	return ts, nil
+}
+
+//Authorize validates access token and checks its session was not revoked
+func (h *ProfileHandler) Authorize(tokenString string) (*AccessDetails, error) {
+	metadata, err := h.TK.TokenMetadata(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	MemberID, err := h.RD.FetchAuth(metadata.TokenUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if MemberID != metadata.UserId {
+		return nil, errors.New("unauthorized")
+	}
+
+	return metadata, nil
+}
+
+//RevokeSessions revokes member sessions except the given one (e.g. current session after password change)
+func (h *ProfileHandler) RevokeSessions(MemberID string, except string) error {
+	if except == "" {
+		return h.RD.DeleteSessions(MemberID)
+	}
+
+	sessions, err := h.RD.QuerySessions(MemberID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.SessionID == except {
+			continue
+		}
+
+		if err := h.RD.DeleteSession(MemberID, session.SessionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//respondSession sets tokens of the session into response headers
+func respondSession(c *gin.Context, ts *TokenDetails) {
+	c.Header(AuthorizationHeaderKey, ts.AccessToken)
+	c.Header(RefreshHeaderKey, ts.RefreshToken)
+}
@@ -2,12 +2,12 @@ package jwt
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 )
 
@@ -18,7 +18,7 @@ func NewToken() *tokenservice {
 }
 
 type TokenInterface interface {
-	CreateToken(MemberID string) (*TokenDetails, error)
+	CreateToken(MemberID string, SessionID string) (*TokenDetails, error)
 	ExtractTokenMetadata(*http.Request) (*AccessDetails, error)
 	TokenMetadata(string) (*AccessDetails, error)
 }
@@ -26,37 +26,40 @@ type TokenInterface interface {
 //Token implements the TokenInterface
 var _ TokenInterface = &tokenservice{}
 
-func (t *tokenservice) CreateToken(MemberID string) (*TokenDetails, error) {
+func (t *tokenservice) CreateToken(MemberID string, SessionID string) (*TokenDetails, error) {
 	td := &TokenDetails{}
-	td.AtExpires = time.Now().Add(time.Minute * 86400).Unix() //expires after 30 min TODO: 2 months
-	// td.TokenUuid = uuid.NewV4().String()
+	td.AtExpires = time.Now().Add(viper.GetDuration("jwt_access_ttl")).Unix()
+	td.TokenUuid = uuid.New().String()
 
-	td.RtExpires = time.Now().Add(time.Hour * 24 * 7).Unix()
-	// td.RefreshUuid = td.TokenUuid + "++" + MemberID
+	td.RtExpires = time.Now().Add(viper.GetDuration("jwt_refresh_ttl")).Unix()
+	td.RefreshUuid = uuid.New().String()
 
 	var err error
 	//Creating Access Token
 	atClaims := jwt.MapClaims{}
-	// atClaims["access_uuid"] = td.TokenUuid
+	atClaims["access_uuid"] = td.TokenUuid
+	atClaims["jti"] = td.TokenUuid
+	atClaims["session_id"] = SessionID
 	atClaims["user_id"] = MemberID
+	atClaims["sub"] = MemberID
 	atClaims["exp"] = td.AtExpires
-	at := jwt.NewWithClaims(jwt.SigningMethodHS256, atClaims)
-	td.AccessToken, err = at.SignedString([]byte(viper.GetString("jwt_secret")))
+	if err := memberClaims(atClaims, MemberID); err != nil {
+		return nil, err
+	}
+	td.AccessToken, err = signToken(atClaims)
 	if err != nil {
 		return nil, err
 	}
 
 	//Creating Refresh Token
-	td.RtExpires = time.Now().Add(time.Hour * 24 * 7).Unix()
-	// td.RefreshUuid = td.TokenUuid + "++" + MemberID
-
 	rtClaims := jwt.MapClaims{}
 	rtClaims["refresh_uuid"] = td.RefreshUuid
+	rtClaims["jti"] = td.RefreshUuid
+	rtClaims["session_id"] = SessionID
 	rtClaims["user_id"] = MemberID
 	rtClaims["exp"] = td.RtExpires
-	rt := jwt.NewWithClaims(jwt.SigningMethodHS256, rtClaims)
 
-	td.RefreshToken, err = rt.SignedString([]byte(viper.GetString("jwt_secret")))
+	td.RefreshToken, err = signToken(rtClaims)
 	if err != nil {
 		return nil, err
 	}
@@ -88,12 +91,7 @@ func TokenValidNoExtraction(tokenString string) error {
 }
 
 func verifyToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(viper.GetString("jwt_secret")), nil
-	})
+	token, err := parseToken(tokenString)
 	if err != nil {
 		return nil, err
 	}
@@ -114,15 +112,29 @@ func extract(token *jwt.Token) (*AccessDetails, error) {
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if ok && token.Valid {
-		// accessUuid, ok := claims["access_uuid"].(string)
+		accessUuid, ok := claims["access_uuid"].(string)
+		SessionID, sessionOk := claims["session_id"].(string)
 		MemberID, userOk := claims["user_id"].(string)
-		if userOk == false { //ok == false ||
+		Role, roleOk := claims["role"].(float64)
+		permissions, permissionsOk := claims["permissions"].([]interface{})
+		if ok == false || sessionOk == false || userOk == false || roleOk == false || permissionsOk == false || claims["sub"] != MemberID {
 			return nil, errors.New("unauthorized")
 		} else {
-			return &AccessDetails{
-				// TokenUuid: accessUuid,
-				UserId: MemberID,
-			}, nil
+			details := &AccessDetails{
+				TokenUuid:   accessUuid,
+				SessionID:   SessionID,
+				UserId:      MemberID,
+				Role:        int64(Role),
+				Permissions: []string{},
+			}
+			for _, permission := range permissions {
+				value, ok := permission.(string)
+				if !ok {
+					return nil, errors.New("unauthorized")
+				}
+				details.Permissions = append(details.Permissions, value)
+			}
+			return details, nil
 		}
 	}
 	return nil, errors.New("something went wrong")
@@ -0,0 +1,217 @@
+package ledger
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/account"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+//Account types
+const (
+	ACCOUNT_MEMBER  = "member"  //Member cash balance
+	ACCOUNT_MARGIN  = "margin"  //Member funds held by open and pending orders
+	ACCOUNT_HOUSE   = "house"   //House P&L, counterparty of member trading profit and loss
+	ACCOUNT_FEES    = "fees"    //Commissions charged to members
+	ACCOUNT_TRANSIT = "transit" //Deposits and withdrawals in transit with payment providers
+	ACCOUNT_HOLD    = "hold"    //Member funds held by requested withdrawals
+	ACCOUNT_OPENING = "opening" //Balances carried over from before the ledger
+)
+
+//Entry types
+const (
+	ENTRY_TRADE_OPEN         = "trade.open"
+	ENTRY_TRADE_CLOSE        = "trade.close"
+	ENTRY_TRADE_CANCEL       = "trade.cancel"
+	ENTRY_TRADE_REPRICE      = "trade.reprice"
+	ENTRY_DEPOSIT            = "deposit"
+	ENTRY_WITHDRAWAL         = "withdrawal"
+	ENTRY_WITHDRAWAL_HOLD    = "withdrawal.hold"
+	ENTRY_WITHDRAWAL_RELEASE = "withdrawal.release"
+	ENTRY_TRANSFER           = "transfer"
+	ENTRY_CONVERSION         = "conversion"
+)
+
+//Account identifies a ledger account. Amounts posted to an account increase its balance, negative amounts decrease it
+type Account struct {
+	Type     string
+	MemberID int64 //0 for house accounts
+	Currency string
+}
+
+//Member cash account
+func Member(MemberID int64, currency string) Account {
+	return Account{Type: ACCOUNT_MEMBER, MemberID: MemberID, Currency: currency}
+}
+
+//Margin account holding member funds used by orders
+func Margin(MemberID int64, currency string) Account {
+	return Account{Type: ACCOUNT_MARGIN, MemberID: MemberID, Currency: currency}
+}
+
+//Hold account of member funds reserved by withdrawals
+func Hold(MemberID int64, currency string) Account {
+	return Account{Type: ACCOUNT_HOLD, MemberID: MemberID, Currency: currency}
+}
+
+//House P&L account
+func House(currency string) Account {
+	return Account{Type: ACCOUNT_HOUSE, Currency: currency}
+}
+
+//Fees account
+func Fees(currency string) Account {
+	return Account{Type: ACCOUNT_FEES, Currency: currency}
+}
+
+//Transit account of payment providers
+func Transit(currency string) Account {
+	return Account{Type: ACCOUNT_TRANSIT, Currency: currency}
+}
+
+//Posting moves amount to (negative - from) an account
+type Posting struct {
+	Account Account
+	Amount  decimal.Decimal
+}
+
+//Entry is a balanced journal entry: amounts of all postings add up to zero
+type Entry struct {
+	Type        string
+	Entity      string //Source record, e.g. trade or deposit
+	EntityID    int64
+	Description string
+	Postings    []Posting
+}
+
+//Post records entry and updates account balances within the transaction of the change it describes.
+//Member account balances are applied to Balance table (and legacy Member columns) in the same transaction.
+func (entry Entry) Post(tx *sqlx.Tx) error {
+	var postings []Posting
+
+	currency := ""
+	sum := decimal.Zero
+
+	for _, posting := range entry.Postings {
+		if posting.Amount.IsZero() {
+			continue
+		}
+
+		if currency == "" {
+			currency = posting.Account.Currency
+		}
+
+		if posting.Account.Currency != currency || currency == "" {
+			return errors.New("LEDGER_CURRENCY_MISMATCH")
+		}
+
+		sum = sum.Add(posting.Amount)
+		postings = append(postings, posting)
+	}
+
+	//Nothing to move
+	if len(postings) == 0 {
+		return nil
+	}
+
+	if !sum.IsZero() {
+		return errors.New("LEDGER_UNBALANCED")
+	}
+
+	now := time.Now().Unix()
+
+	var LedgerEntryID int64
+
+	if err := tx.Get(&LedgerEntryID, "INSERT INTO LedgerEntry (Type, Entity, EntityID, Currency, Description, Timestamp) VALUES ($1, $2, $3, $4, $5, $6) RETURNING LedgerEntryID", entry.Type, entry.Entity, entry.EntityID, currency, entry.Description, now); err != nil {
+		return err
+	}
+
+	for _, posting := range postings {
+		var ledgerAccount models.LedgerAccount
+
+		if _, err := tx.Exec("INSERT INTO LedgerAccount (Type, MemberID, Currency) VALUES ($1, $2, $3) ON CONFLICT (Type, MemberID, Currency) DO NOTHING", posting.Account.Type, posting.Account.MemberID, posting.Account.Currency); err != nil {
+			return err
+		}
+
+		if err := tx.Get(&ledgerAccount, "UPDATE LedgerAccount SET Balance=Balance+$1, Updated=$2 WHERE Type=$3 AND MemberID=$4 AND Currency=$5 RETURNING *", posting.Amount, now, posting.Account.Type, posting.Account.MemberID, posting.Account.Currency); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec("INSERT INTO LedgerPosting (LedgerEntryID, LedgerAccountID, Amount, BalanceAfter) VALUES ($1, $2, $3, $4)", LedgerEntryID, ledgerAccount.LedgerAccountID, posting.Amount, ledgerAccount.Balance.Decimal); err != nil {
+			return err
+		}
+
+		if posting.Account.Type == ACCOUNT_MEMBER {
+			if err := account.Credit(tx, posting.Account.MemberID, posting.Account.Currency, posting.Amount); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+//Transfer is an entry moving amount from one account to another
+func Transfer(entryType string, entity string, EntityID int64, from Account, to Account, amount decimal.Decimal) Entry {
+	return Entry{
+		Type:     entryType,
+		Entity:   entity,
+		EntityID: EntityID,
+		Postings: []Posting{
+			{Account: from, Amount: amount.Neg()},
+			{Account: to, Amount: amount},
+		},
+	}
+}
+
+//Mismatch - member balance that differs from its ledger account
+type Mismatch struct {
+	MemberID int64
+	Currency string
+	Ledger   decimal.Decimal //Ledger account balance
+	Balance  decimal.Decimal //Balance table amount
+}
+
+//QueryMismatch compares member balances with the ledger
+func QueryMismatch() ([]Mismatch, error) {
+	db := db.GetDB()
+
+	mismatch := []Mismatch{}
+
+	if err := db.Select(&mismatch, `SELECT COALESCE(LedgerAccount.MemberID, Stored.MemberID) AS MemberID, COALESCE(LedgerAccount.Currency, Stored.Code) AS Currency, COALESCE(LedgerAccount.Balance, 0) AS Ledger, COALESCE(Stored.Amount, 0) AS Balance
+		FROM (SELECT * FROM LedgerAccount WHERE Type=$1) AS LedgerAccount
+		FULL OUTER JOIN (SELECT Balance.MemberID, Currency.Code, SUM(Balance.Amount) AS Amount FROM Balance INNER JOIN Currency ON Currency.CurrencyID=Balance.CurrencyID GROUP BY Balance.MemberID, Currency.Code) AS Stored
+		ON Stored.MemberID=LedgerAccount.MemberID AND Stored.Code=LedgerAccount.Currency
+		WHERE COALESCE(LedgerAccount.Balance, 0)<>COALESCE(Stored.Amount, 0)
+		ORDER BY MemberID ASC`, ACCOUNT_MEMBER); err != nil {
+		return nil, err
+	}
+
+	return mismatch, nil
+}
+
+//QueryTrialBalance returns sum of all account balances per currency, non-zero sum means the ledger is corrupted
+func QueryTrialBalance() (map[string]decimal.Decimal, error) {
+	db := db.GetDB()
+
+	var rows []struct {
+		Currency string
+		Balance  decimal.Decimal
+	}
+
+	if err := db.Select(&rows, "SELECT Currency, SUM(Balance) AS Balance FROM LedgerAccount GROUP BY Currency ORDER BY Currency ASC"); err != nil {
+		return nil, err
+	}
+
+	total := map[string]decimal.Decimal{}
+
+	for _, row := range rows {
+		total[row.Currency] = row.Balance
+	}
+
+	return total, nil
+}
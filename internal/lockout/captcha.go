@@ -0,0 +1,45 @@
+package lockout
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/parnurzeal/gorequest"
+	"github.com/spf13/viper"
+)
+
+//VerifyCaptcha verifies CAPTCHA response token with siteverify API (hCaptcha, reCAPTCHA). Verification is skipped if captcha_secret is not set
+func VerifyCaptcha(token string, IP string) error {
+	secret := viper.GetString("captcha_secret")
+	if secret == "" {
+		return nil
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+
+	resp, _, errs := gorequest.New().Post(viper.GetString("captcha_verify_url")).
+		Timeout(10 * time.Second).
+		Type("form").
+		Send(map[string]string{
+			"secret":   secret,
+			"response": token,
+			"remoteip": IP,
+		}).
+		EndStruct(&result)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("CAPTCHA_PROVIDER_ERROR")
+	}
+
+	if !result.Success {
+		return errors.New("CAPTCHA_INVALID")
+	}
+
+	return nil
+}
@@ -0,0 +1,378 @@
+package lockout
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/ianidi/exchange-server/internal/task"
+	"github.com/mediocregopher/radix/v3"
+)
+
+const (
+	//Failed sign ins from IP address within IP_WINDOW before further attempts are rejected
+	IP_LIMIT  = 30
+	IP_WINDOW = 15 * time.Minute
+
+	//Failed sign ins of account within ACCOUNT_WINDOW before the account is locked for LOCK_DURATION
+	ACCOUNT_LIMIT  = 10
+	ACCOUNT_WINDOW = time.Hour
+	LOCK_DURATION  = 30 * time.Minute
+
+	//CAPTCHA is required after failed sign ins of account or IP address
+	CAPTCHA_AFTER = 3
+
+	//Progressive delay between attempts after DELAY_AFTER failures: 1s, 2s, 4s ... MAX_DELAY
+	DELAY_AFTER = 3
+	MAX_DELAY   = 30 * time.Second
+
+	//Lifetime of unlock link sent by email
+	UNLOCK_TTL = 24 * time.Hour
+
+	IP_KEY      = "lockout:ip:"      //lockout:ip:<IP> - failures count
+	ACCOUNT_KEY = "lockout:account:" //lockout:account:<Email> - failures count
+	DELAY_KEY   = "lockout:delay:"   //lockout:delay:<Email> - next attempt is accepted once the key expires
+	LOCKED_KEY  = "lockout:locked:"  //lockout:locked:<Email> - MemberID of locked account
+	UNLOCK_KEY  = "lockout:unlock:"  //lockout:unlock:<token> - Email of locked account
+)
+
+//Security event types
+const (
+	EVENT_LOGIN_FAILED = "login.failed"
+	EVENT_LOCKED       = "account.locked"
+	EVENT_UNLOCKED     = "account.unlocked"
+)
+
+//Attempt - sign in attempt with password
+type Attempt struct {
+	Email     string
+	IP        string
+	UserAgent string
+	Captcha   string //CAPTCHA response token
+}
+
+//Status - flags of rejected sign in returned to client
+type Status struct {
+	Captcha    bool  //CAPTCHA has to be solved with the next attempt
+	RetryAfter int64 //Seconds until the next attempt is accepted
+	Locked     bool  //Account is locked, unlock link is sent by email
+}
+
+//Error - rejected sign in with flags for client
+type Error struct {
+	Message string
+	Status  Status
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+//Check rejects attempt of locked account, attempt within progressive delay or rate limit and attempt without required CAPTCHA
+func (attempt Attempt) Check() error {
+	pool := redis.GetRedis()
+
+	var locked int64
+
+	if err := pool.Do(radix.Cmd(&locked, "PTTL", LOCKED_KEY+attempt.Email)); err != nil {
+		return err
+	}
+
+	if locked > 0 {
+		return &Error{Message: "ACCOUNT_LOCKED", Status: Status{Locked: true, RetryAfter: seconds(locked)}}
+	}
+
+	status, err := attempt.status()
+	if err != nil {
+		return err
+	}
+
+	if status.RetryAfter > 0 {
+		return &Error{Message: "TOO_MANY_ATTEMPTS", Status: status}
+	}
+
+	if status.Captcha {
+		if attempt.Captcha == "" {
+			return &Error{Message: "CAPTCHA_REQUIRED", Status: status}
+		}
+
+		if err := VerifyCaptcha(attempt.Captcha, attempt.IP); err != nil {
+			return &Error{Message: err.Error(), Status: status}
+		}
+	}
+
+	return nil
+}
+
+//Fail counts failed sign in, locks the account after ACCOUNT_LIMIT failures and returns reason with flags for the next attempt
+func (attempt Attempt) Fail(MemberID int64, reason error) error {
+	pool := redis.GetRedis()
+
+	if _, err := count(IP_KEY+attempt.IP, IP_WINDOW); err != nil {
+		return err
+	}
+
+	account, err := count(ACCOUNT_KEY+attempt.Email, ACCOUNT_WINDOW)
+	if err != nil {
+		return err
+	}
+
+	attempt.Record(MemberID, EVENT_LOGIN_FAILED, reason.Error())
+
+	if account >= ACCOUNT_LIMIT {
+		if err := attempt.lock(MemberID); err != nil {
+			return err
+		}
+
+		return &Error{Message: "ACCOUNT_LOCKED", Status: Status{Locked: true, RetryAfter: int64(LOCK_DURATION.Seconds())}}
+	}
+
+	if account >= DELAY_AFTER {
+		delay := time.Second << uint(account-DELAY_AFTER)
+		if delay > MAX_DELAY {
+			delay = MAX_DELAY
+		}
+
+		if err := pool.Do(radix.FlatCmd(nil, "SET", DELAY_KEY+attempt.Email, MemberID, "PX", delay.Milliseconds())); err != nil {
+			return err
+		}
+	}
+
+	status, err := attempt.status()
+	if err != nil {
+		return err
+	}
+
+	return &Error{Message: reason.Error(), Status: status}
+}
+
+//Success resets failures of the account, failures of IP address expire with IP_WINDOW
+func (attempt Attempt) Success() {
+	redis.GetRedis().Do(radix.Cmd(nil, "DEL", ACCOUNT_KEY+attempt.Email, DELAY_KEY+attempt.Email))
+}
+
+//Record saves security event of the attempt
+func (attempt Attempt) Record(MemberID int64, Type string, Data string) {
+	db := db.GetDB()
+
+	tx := db.MustBegin()
+	tx.MustExec("INSERT INTO SecurityEvent (MemberID, Email, IP, UserAgent, Type, Data, Created) VALUES ($1, $2, $3, $4, $5, $6, $7)", MemberID, attempt.Email, attempt.IP, attempt.UserAgent, Type, Data, time.Now().Unix())
+	tx.Commit()
+}
+
+//Unlock unlocks account with token of unlock link
+func Unlock(token string, IP string, UserAgent string) error {
+	pool := redis.GetRedis()
+
+	key := UNLOCK_KEY + token
+
+	var email string
+
+	mn := radix.MaybeNil{Rcv: &email}
+	if err := pool.Do(radix.Cmd(&mn, "GET", key)); err != nil {
+		return err
+	}
+
+	var deleted int64
+
+	if err := pool.Do(radix.Cmd(&deleted, "DEL", key)); err != nil {
+		return err
+	}
+
+	if mn.Nil || deleted == 0 {
+		return errors.New("UNLOCK_EXPIRED")
+	}
+
+	return Attempt{Email: email, IP: IP, UserAgent: UserAgent}.unlock("email")
+}
+
+//UnlockAccount unlocks account by operator
+func UnlockAccount(email string, IP string, UserAgent string) error {
+	var locked int64
+
+	if err := redis.GetRedis().Do(radix.Cmd(&locked, "EXISTS", LOCKED_KEY+email)); err != nil {
+		return err
+	}
+
+	if locked == 0 {
+		return errors.New("ACCOUNT_NOT_LOCKED")
+	}
+
+	return Attempt{Email: email, IP: IP, UserAgent: UserAgent}.unlock("operator")
+}
+
+//QueryStatus returns lockout state of account
+func QueryStatus(email string) (Status, int64, error) {
+	pool := redis.GetRedis()
+
+	attempt := Attempt{Email: email}
+
+	status, err := attempt.status()
+	if err != nil {
+		return status, 0, err
+	}
+
+	var locked int64
+
+	if err := pool.Do(radix.Cmd(&locked, "PTTL", LOCKED_KEY+email)); err != nil {
+		return status, 0, err
+	}
+
+	if locked > 0 {
+		status.Locked = true
+		status.RetryAfter = seconds(locked)
+	}
+
+	var failures int64
+
+	mn := radix.MaybeNil{Rcv: &failures}
+	if err := pool.Do(radix.Cmd(&mn, "GET", ACCOUNT_KEY+email)); err != nil {
+		return status, 0, err
+	}
+
+	status.Captcha = failures >= CAPTCHA_AFTER
+
+	return status, failures, nil
+}
+
+//status returns progressive delay or IP rate limit of the attempt
+func (attempt Attempt) status() (Status, error) {
+	pool := redis.GetRedis()
+
+	var status Status
+
+	var ip, account int64
+
+	mn := radix.MaybeNil{Rcv: &ip}
+	if err := pool.Do(radix.Cmd(&mn, "GET", IP_KEY+attempt.IP)); err != nil {
+		return status, err
+	}
+
+	mn = radix.MaybeNil{Rcv: &account}
+	if err := pool.Do(radix.Cmd(&mn, "GET", ACCOUNT_KEY+attempt.Email)); err != nil {
+		return status, err
+	}
+
+	status.Captcha = ip >= CAPTCHA_AFTER || account >= CAPTCHA_AFTER
+
+	if ip >= IP_LIMIT {
+		var ttl int64
+
+		if err := pool.Do(radix.Cmd(&ttl, "PTTL", IP_KEY+attempt.IP)); err != nil {
+			return status, err
+		}
+
+		status.RetryAfter = seconds(ttl)
+
+		return status, nil
+	}
+
+	var delay int64
+
+	if err := pool.Do(radix.Cmd(&delay, "PTTL", DELAY_KEY+attempt.Email)); err != nil {
+		return status, err
+	}
+
+	status.RetryAfter = seconds(delay)
+
+	return status, nil
+}
+
+//lock locks account and sends unlock link to member
+func (attempt Attempt) lock(MemberID int64) error {
+	pool := redis.GetRedis()
+
+	if err := pool.Do(radix.FlatCmd(nil, "SET", LOCKED_KEY+attempt.Email, MemberID, "EX", int64(LOCK_DURATION.Seconds()))); err != nil {
+		return err
+	}
+
+	pool.Do(radix.Cmd(nil, "DEL", ACCOUNT_KEY+attempt.Email, DELAY_KEY+attempt.Email))
+
+	attempt.Record(MemberID, EVENT_LOCKED, "")
+
+	//Unknown email is locked as well, so members can't be enumerated, but there is nobody to notify
+	if MemberID == 0 {
+		return nil
+	}
+
+	value := make([]byte, 32)
+
+	if _, err := rand.Read(value); err != nil {
+		return err
+	}
+
+	token := hex.EncodeToString(value)
+
+	if err := pool.Do(radix.FlatCmd(nil, "SET", UNLOCK_KEY+token, attempt.Email, "EX", int64(UNLOCK_TTL.Seconds()))); err != nil {
+		return err
+	}
+
+	db := db.GetDB()
+
+	var settings models.Settings
+
+	if err := db.Get(&settings, "SELECT * FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		return err
+	}
+
+	_, err := task.Enqueue(task.Email{
+		Email:       attempt.Email,
+		Subject:     "Account locked",
+		Title:       "Dear customer,",
+		Content:     fmt.Sprintf("Your account was locked for %d minutes after %d failed sign in attempts, the last one from IP address %s. If it was you, unlock your account by clicking on the button below.", int64(LOCK_DURATION.Minutes()), ACCOUNT_LIMIT, attempt.IP),
+		Button:      "Unlock account",
+		Link:        settings.PlatformURL + "unlock/" + token,
+		Description: "If it wasn't you, we recommend that you change your password once the account is unlocked.",
+	}, "")
+
+	return err
+}
+
+func (attempt Attempt) unlock(by string) error {
+	pool := redis.GetRedis()
+
+	var MemberID int64
+
+	mn := radix.MaybeNil{Rcv: &MemberID}
+	if err := pool.Do(radix.Cmd(&mn, "GET", LOCKED_KEY+attempt.Email)); err != nil {
+		return err
+	}
+
+	if err := pool.Do(radix.Cmd(nil, "DEL", LOCKED_KEY+attempt.Email, ACCOUNT_KEY+attempt.Email, DELAY_KEY+attempt.Email)); err != nil {
+		return err
+	}
+
+	attempt.Record(MemberID, EVENT_UNLOCKED, by)
+
+	return nil
+}
+
+//count increments failures counter, the window starts with the first failure
+func count(key string, window time.Duration) (int64, error) {
+	pool := redis.GetRedis()
+
+	var value int64
+
+	if err := pool.Do(radix.Cmd(&value, "INCR", key)); err != nil {
+		return 0, err
+	}
+
+	if value == 1 {
+		pool.Do(radix.FlatCmd(nil, "EXPIRE", key, int64(window.Seconds())))
+	}
+
+	return value, nil
+}
+
+//seconds converts PTTL reply to whole seconds, missing key is 0
+func seconds(ms int64) int64 {
+	if ms <= 0 {
+		return 0
+	}
+	return (ms + 999) / 1000
+}
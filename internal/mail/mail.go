@@ -15,7 +15,7 @@ import (
 )
 
 //SendMail отправить письмо по электронной почте
-func SendMail(email string, subject string, title string, content string, button string, link string, description string) {
+func SendMail(email string, subject string, title string, content string, button string, link string, description string) error {
 	db := db.GetDB()
 
 	var settings models.Settings
@@ -24,7 +24,7 @@ func SendMail(email string, subject string, title string, content string, button
 
 	if err != nil {
 		fmt.Print(err)
-		return
+		return err
 	}
 
 	// Create a new email - specify the SMTP host and auth
@@ -515,7 +515,7 @@ func SendMail(email string, subject string, title string, content string, button
 
 	if err != nil {
 		fmt.Print(err)
-		return
+		return err
 	}
 
 	logo, err := os.Open("./email/logo.png")
@@ -523,7 +523,7 @@ func SendMail(email string, subject string, title string, content string, button
 
 	if err != nil {
 		fmt.Print(err)
-		return
+		return err
 	}
 
 	arrow, err := os.Open("./email/arrow.png")
@@ -531,7 +531,7 @@ func SendMail(email string, subject string, title string, content string, button
 
 	if err != nil {
 		fmt.Print(err)
-		return
+		return err
 	}
 
 	var logoReader io.Reader
@@ -545,13 +545,15 @@ func SendMail(email string, subject string, title string, content string, button
 	//"If you have further questions, please feel free to use the live chat."
 	if err := template.ExecuteTemplate(mail.HTML(), "htmlEmail", map[string]string{"title": title, "content": content, "button": button, "link": link, "description": description, "footer": "©" + cast.ToString(time.Now().Year()) + " " + settings.Title}); err != nil {
 		fmt.Print(err)
-		return
+		return err
 	}
 
 	//mail.Plain().Set(content)
 
 	if err := mail.Send(); err != nil {
 		fmt.Print(err)
-		return
+		return err
 	}
+
+	return nil
 }
@@ -0,0 +1,276 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+//Metric types of Prometheus text exposition format
+const (
+	TYPE_COUNTER   = "counter"
+	TYPE_GAUGE     = "gauge"
+	TYPE_HISTOGRAM = "histogram"
+)
+
+var (
+	mu         sync.Mutex
+	families   = map[string]*family{}
+	collectors []func()
+)
+
+//Metric family: all samples of one metric name
+type family struct {
+	Name    string
+	Help    string
+	Type    string
+	Labels  []string  //Label names
+	Buckets []float64 //Histogram upper bounds
+	Samples map[string]*sample
+}
+
+//Sample of a metric family with a specific set of label values
+type sample struct {
+	LabelValues []string
+	Value       float64
+	Counts      []uint64 //Histogram observations per bucket
+	Sum         float64
+	Count       uint64
+}
+
+//Counter only goes up
+type Counter struct {
+	family *family
+}
+
+//Gauge can be set to any value
+type Gauge struct {
+	family *family
+}
+
+//Histogram counts observations in buckets
+type Histogram struct {
+	family *family
+}
+
+func register(name string, help string, kind string, buckets []float64, labels []string) *family {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if f, ok := families[name]; ok {
+		return f
+	}
+
+	f := &family{
+		Name:    name,
+		Help:    help,
+		Type:    kind,
+		Labels:  labels,
+		Buckets: buckets,
+		Samples: map[string]*sample{},
+	}
+
+	families[name] = f
+
+	return f
+}
+
+//NewCounter registers a counter with label names
+func NewCounter(name string, help string, labels ...string) *Counter {
+	return &Counter{family: register(name, help, TYPE_COUNTER, nil, labels)}
+}
+
+//NewGauge registers a gauge with label names
+func NewGauge(name string, help string, labels ...string) *Gauge {
+	return &Gauge{family: register(name, help, TYPE_GAUGE, nil, labels)}
+}
+
+//NewHistogram registers a histogram with bucket upper bounds and label names
+func NewHistogram(name string, help string, buckets []float64, labels ...string) *Histogram {
+	sort.Float64s(buckets)
+	return &Histogram{family: register(name, help, TYPE_HISTOGRAM, buckets, labels)}
+}
+
+//RegisterCollector adds a function that refreshes gauges right before metrics are exported
+func RegisterCollector(collector func()) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	collectors = append(collectors, collector)
+}
+
+//get returns sample for label values, must be called with mu locked
+func (f *family) get(values []string) *sample {
+	key := strings.Join(values, "\xff")
+
+	s, ok := f.Samples[key]
+	if !ok {
+		s = &sample{LabelValues: append([]string{}, values...)}
+		if f.Type == TYPE_HISTOGRAM {
+			s.Counts = make([]uint64, len(f.Buckets))
+		}
+		f.Samples[key] = s
+	}
+
+	return s
+}
+
+//Inc increments counter by 1
+func (c *Counter) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+//Add increments counter by value
+func (c *Counter) Add(value float64, values ...string) {
+	if value < 0 {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	c.family.get(values).Value += value
+}
+
+//Set gauge value
+func (g *Gauge) Set(value float64, values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	g.family.get(values).Value = value
+}
+
+//Reset removes all gauge samples (e.g. before a collector fills it again)
+func (g *Gauge) Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	g.family.Samples = map[string]*sample{}
+}
+
+//Observe adds an observation to histogram
+func (h *Histogram) Observe(value float64, values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	s := h.family.get(values)
+
+	for i, bound := range h.family.Buckets {
+		if value <= bound {
+			s.Counts[i]++
+		}
+	}
+
+	s.Sum += value
+	s.Count++
+}
+
+//Export writes all metrics in Prometheus text exposition format
+func Export() []byte {
+	mu.Lock()
+	refresh := append([]func(){}, collectors...)
+	mu.Unlock()
+
+	for _, collector := range refresh {
+		collector()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	var names []string
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+
+	for _, name := range names {
+		f := families[name]
+
+		fmt.Fprintf(&buf, "# HELP %s %s\n", f.Name, escape(f.Help, false))
+		fmt.Fprintf(&buf, "# TYPE %s %s\n", f.Name, f.Type)
+
+		var keys []string
+		for key := range f.Samples {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s := f.Samples[key]
+
+			if f.Type != TYPE_HISTOGRAM {
+				fmt.Fprintf(&buf, "%s%s %s\n", f.Name, labels(f.Labels, s.LabelValues, "", ""), format(s.Value))
+				continue
+			}
+
+			for i, bound := range f.Buckets {
+				fmt.Fprintf(&buf, "%s_bucket%s %d\n", f.Name, labels(f.Labels, s.LabelValues, "le", format(bound)), s.Counts[i])
+			}
+			fmt.Fprintf(&buf, "%s_bucket%s %d\n", f.Name, labels(f.Labels, s.LabelValues, "le", "+Inf"), s.Count)
+			fmt.Fprintf(&buf, "%s_sum%s %s\n", f.Name, labels(f.Labels, s.LabelValues, "", ""), format(s.Sum))
+			fmt.Fprintf(&buf, "%s_count%s %d\n", f.Name, labels(f.Labels, s.LabelValues, "", ""), s.Count)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+//Handler serves metrics to Prometheus scraper
+func Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Data(200, "text/plain; version=0.0.4; charset=utf-8", Export())
+	}
+}
+
+//labels formats label set, extra label (e.g. histogram "le") is appended if name is not empty
+func labels(names []string, values []string, extraName string, extraValue string) string {
+	var pairs []string
+
+	for i, name := range names {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		pairs = append(pairs, name+`="`+escape(value, true)+`"`)
+	}
+
+	if extraName != "" {
+		pairs = append(pairs, extraName+`="`+extraValue+`"`)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escape(value string, quote bool) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, "\n", `\n`, -1)
+	if quote {
+		value = strings.Replace(value, `"`, `\"`, -1)
+	}
+	return value
+}
+
+func format(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	if math.IsInf(value, -1) {
+		return "-Inf"
+	}
+	if math.IsNaN(value) {
+		return "NaN"
+	}
+	return fmt.Sprintf("%g", value)
+}
@@ -0,0 +1,410 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/spf13/cast"
+)
+
+const (
+	//Failed code entries of member before verification is locked for ATTEMPTS_WINDOW
+	MAX_ATTEMPTS    = 5
+	ATTEMPTS_WINDOW = 15 * time.Minute
+	ATTEMPTS_KEY    = "mfa:attempts:"
+
+	//Recovery codes issued on activation, each can be used once instead of TOTP code
+	RECOVERY_CODES    = 10
+	RECOVERY_LENGTH   = 10
+	RECOVERY_ALPHABET = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	//Account name prefix in authenticator app
+	ISSUER = "Exchange"
+)
+
+//Enrollment - secret of authenticator app, MFA is enabled once a valid code is entered
+type Enrollment struct {
+	Secret string
+	URI    string //otpauth:// provisioning URI for QR code
+}
+
+//Enroll creates new TOTP secret of member. Previous unconfirmed secret is replaced
+func Enroll(MemberID int64) (Enrollment, error) {
+	db := db.GetDB()
+
+	var enrollment Enrollment
+
+	var member struct {
+		Email      string
+		MFAEnabled bool
+	}
+
+	if err := db.Get(&member, "SELECT Email, MFAEnabled FROM Member WHERE MemberID=$1", MemberID); err != nil {
+		if err == sql.ErrNoRows {
+			return enrollment, errors.New("NO_MEMBER_RECORD")
+		}
+		return enrollment, err
+	}
+
+	if member.MFAEnabled {
+		return enrollment, errors.New("MFA_ALREADY_ENABLED")
+	}
+
+	secret, err := GenerateSecret()
+	if err != nil {
+		return enrollment, err
+	}
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE Member SET MFASecret=$1, MFAStep=$2 WHERE MemberID=$3", secret, 0, MemberID)
+	tx.Commit()
+
+	enrollment.Secret = secret
+	enrollment.URI = ProvisioningURI(ISSUER, member.Email, secret)
+
+	return enrollment, nil
+}
+
+//Activate enables MFA after the first valid code of enrolled secret and returns recovery codes
+func Activate(MemberID int64, code string) ([]string, error) {
+	db := db.GetDB()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := lockMember(tx, MemberID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if member.MFAEnabled {
+		tx.Rollback()
+		return nil, errors.New("MFA_ALREADY_ENABLED")
+	}
+
+	if member.MFASecret == "" {
+		tx.Rollback()
+		return nil, errors.New("MFA_NOT_ENROLLED")
+	}
+
+	if err := checkAttempts(MemberID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	step, ok := Validate(member.MFASecret, code, member.MFAStep)
+	if !ok {
+		tx.Rollback()
+		return nil, failAttempt(MemberID)
+	}
+
+	tx.MustExec("UPDATE Member SET MFAEnabled=$1, MFAStep=$2 WHERE MemberID=$3", true, step, MemberID)
+
+	codes, err := createRecovery(tx, MemberID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	resetAttempts(MemberID)
+
+	return codes, nil
+}
+
+//Disable turns MFA off after a valid TOTP or recovery code
+func Disable(MemberID int64, code string) error {
+	db := db.GetDB()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err := verify(tx, MemberID, code); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.MustExec("UPDATE Member SET MFASecret=$1, MFAEnabled=$2, MFAStep=$3 WHERE MemberID=$4", "", false, 0, MemberID)
+	tx.MustExec("DELETE FROM MFARecovery WHERE MemberID=$1", MemberID)
+
+	return tx.Commit()
+}
+
+//Recovery replaces recovery codes of member after a valid TOTP or recovery code
+func Recovery(MemberID int64, code string) ([]string, error) {
+	db := db.GetDB()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verify(tx, MemberID, code); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	codes, err := createRecovery(tx, MemberID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+//Verify checks TOTP or recovery code of member with MFA enabled (second sign in step, step-up before sensitive actions)
+func Verify(MemberID int64, code string) error {
+	db := db.GetDB()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err := verify(tx, MemberID, code); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+//Second sign in step methods
+const (
+	METHOD_TOTP    = "totp"    //TOTP or recovery code
+	METHOD_PASSKEY = "passkey" //WebAuthn passkey
+)
+
+//Methods returns second step methods of member, members without methods sign in with password only
+func Methods(MemberID int64) ([]string, error) {
+	db := db.GetDB()
+
+	var member struct {
+		MFAEnabled bool
+		Passkeys   int64
+	}
+
+	if err := db.Get(&member, "SELECT MFAEnabled, (SELECT count(*) FROM WebAuthnCredential WHERE WebAuthnCredential.MemberID=Member.MemberID) AS Passkeys FROM Member WHERE MemberID=$1", MemberID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("NO_MEMBER_RECORD")
+		}
+		return nil, err
+	}
+
+	methods := []string{}
+
+	if member.MFAEnabled {
+		methods = append(methods, METHOD_TOTP)
+	}
+
+	if member.Passkeys > 0 {
+		methods = append(methods, METHOD_PASSKEY)
+	}
+
+	return methods, nil
+}
+
+//Enabled - member signs in with the second step
+func Enabled(MemberID int64) (bool, error) {
+	methods, err := Methods(MemberID)
+	if err != nil {
+		return false, err
+	}
+
+	return len(methods) > 0, nil
+}
+
+//RequiredForRole - operators and admins (Role >= 2) must use MFA if it is required in settings
+func RequiredForRole(Role int64) (bool, error) {
+	db := db.GetDB()
+
+	if Role < 2 {
+		return false, nil
+	}
+
+	var required bool
+
+	if err := db.Get(&required, "SELECT MFARequiredOperator FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		return false, err
+	}
+
+	return required, nil
+}
+
+//QueryRecoveryLeft returns number of unused recovery codes of member
+func QueryRecoveryLeft(MemberID int64) (int64, error) {
+	db := db.GetDB()
+
+	var count int64
+
+	if err := db.Get(&count, "SELECT count(*) FROM MFARecovery WHERE MemberID=$1 AND Used=$2", MemberID, 0); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+//verify checks code within member row lock, so a code is accepted once
+func verify(tx *sqlx.Tx, MemberID int64, code string) error {
+	member, err := lockMember(tx, MemberID)
+	if err != nil {
+		return err
+	}
+
+	if !member.MFAEnabled {
+		return errors.New("MFA_NOT_ENABLED")
+	}
+
+	if err := checkAttempts(MemberID); err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+
+	if len(code) == DIGITS {
+		step, ok := Validate(member.MFASecret, code, member.MFAStep)
+		if !ok {
+			return failAttempt(MemberID)
+		}
+
+		tx.MustExec("UPDATE Member SET MFAStep=$1 WHERE MemberID=$2", step, MemberID)
+
+		resetAttempts(MemberID)
+
+		return nil
+	}
+
+	res := tx.MustExec("UPDATE MFARecovery SET Used=$1 WHERE MemberID=$2 AND CodeHash=$3 AND Used=$4", time.Now().Unix(), MemberID, hashRecovery(code), 0)
+
+	if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+		return failAttempt(MemberID)
+	}
+
+	resetAttempts(MemberID)
+
+	return nil
+}
+
+func lockMember(tx *sqlx.Tx, MemberID int64) (models.Member, error) {
+	var member models.Member
+
+	if err := tx.Get(&member, "SELECT * FROM Member WHERE MemberID=$1 FOR UPDATE", MemberID); err != nil {
+		if err == sql.ErrNoRows {
+			return member, errors.New("NO_MEMBER_RECORD")
+		}
+		return member, err
+	}
+
+	return member, nil
+}
+
+//createRecovery replaces member recovery codes and returns the new codes, only hashes are stored
+func createRecovery(tx *sqlx.Tx, MemberID int64) ([]string, error) {
+	tx.MustExec("DELETE FROM MFARecovery WHERE MemberID=$1", MemberID)
+
+	codes := []string{}
+
+	for i := 0; i < RECOVERY_CODES; i++ {
+		code, err := generateRecovery()
+		if err != nil {
+			return nil, err
+		}
+
+		tx.MustExec("INSERT INTO MFARecovery (MemberID, CodeHash, Created) VALUES ($1, $2, $3)", MemberID, hashRecovery(code), time.Now().Unix())
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+//generateRecovery returns random code formatted as xxxxx-xxxxx
+func generateRecovery() (string, error) {
+	random := make([]byte, RECOVERY_LENGTH)
+
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	var code strings.Builder
+
+	for i, b := range random {
+		if i == RECOVERY_LENGTH/2 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(RECOVERY_ALPHABET[int(b)%len(RECOVERY_ALPHABET)])
+	}
+
+	return code.String(), nil
+}
+
+//hashRecovery normalizes entered code (case, dashes and spaces) and returns its sha256 hash
+func hashRecovery(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func checkAttempts(MemberID int64) error {
+	var attempts int64
+
+	mn := radix.MaybeNil{Rcv: &attempts}
+	if err := redis.GetRedis().Do(radix.Cmd(&mn, "GET", ATTEMPTS_KEY+cast.ToString(MemberID))); err != nil {
+		return err
+	}
+
+	if attempts >= MAX_ATTEMPTS {
+		return errors.New("MFA_TOO_MANY_ATTEMPTS")
+	}
+
+	return nil
+}
+
+//failAttempt counts failed code entry and returns MFA_INVALID_CODE
+func failAttempt(MemberID int64) error {
+	pool := redis.GetRedis()
+
+	key := ATTEMPTS_KEY + cast.ToString(MemberID)
+
+	var attempts int64
+
+	if err := pool.Do(radix.Cmd(&attempts, "INCR", key)); err != nil {
+		return err
+	}
+
+	if attempts == 1 {
+		pool.Do(radix.FlatCmd(nil, "EXPIRE", key, int64(ATTEMPTS_WINDOW.Seconds())))
+	}
+
+	return errors.New("MFA_INVALID_CODE")
+}
+
+func resetAttempts(MemberID int64) {
+	redis.GetRedis().Do(radix.Cmd(nil, "DEL", ATTEMPTS_KEY+cast.ToString(MemberID)))
+}
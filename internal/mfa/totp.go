@@ -0,0 +1,99 @@
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+//TOTP parameters (RFC 6238 defaults supported by authenticator apps)
+const (
+	PERIOD = 30 //Seconds of time step
+	DIGITS = 6
+	SKEW   = 1 //Accepted steps before and after current step (clock drift)
+
+	SECRET_SIZE = 20 //Bytes of secret (160 bits, RFC 4226 recommendation)
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+//GenerateSecret returns random base32 encoded secret
+func GenerateSecret() (string, error) {
+	secret := make([]byte, SECRET_SIZE)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+//Step returns TOTP time step of the time
+func Step(t time.Time) int64 {
+	return t.Unix() / PERIOD
+}
+
+//Code returns TOTP code of the time step (RFC 4226 HOTP with time step counter)
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	//Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%06d", value%1000000), nil
+}
+
+//Validate checks code against steps around current time and returns the matched step. Steps up to lastStep were used before and are rejected
+func Validate(secret string, code string, lastStep int64) (int64, bool) {
+	if len(code) != DIGITS {
+		return 0, false
+	}
+
+	current := Step(time.Now())
+
+	for step := current - SKEW; step <= current+SKEW; step++ {
+		if step <= lastStep {
+			continue
+		}
+
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+//ProvisioningURI returns otpauth:// URI to be shown as QR code in authenticator app
+func ProvisioningURI(issuer string, account string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(DIGITS))
+	query.Set("period", fmt.Sprint(PERIOD))
+
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+account) + "?" + query.Encode()
+}
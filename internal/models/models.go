@@ -7,38 +7,44 @@ import (
 
 // Member
 type Member struct {
-	MemberID           int64
-	Email              string
-	ManagerID          int64
-	IP                 string `json:"-"`
-	FirstName          string
-	LastName           string
-	Gender             string
-	FamilyStatus       string
-	MaidenName         string
-	Citizenship        string
-	Country            string
-	City               string
-	Zip                string
-	Address1           string
-	Address2           string
-	StreetNumber       string
-	StreetName         string
-	Image              string
-	Birthday           pgtype.Timestamptz
-	EmailNotifications bool
-	Phone              string
-	Created            int64  `json:"-"`
-	Role               int64  `json:"-"`
-	PasswordHash       string `json:"-"`
-	CurrencyID         int64  `json:"-"`
-	USD                shopspring.Numeric
-	EUR                shopspring.Numeric
-	LeverageAllowed    shopspring.Numeric
-	StopLossAllowed    shopspring.Numeric //Maximum allowed StopLoss % for this member
-	TakeProfitAllowed  shopspring.Numeric //Maximum allowed TakeProfit % for this member
-	Status             string
-	ManagerRole        string
+	MemberID               int64
+	Email                  string
+	ManagerID              int64
+	IP                     string `json:"-"`
+	FirstName              string
+	LastName               string
+	Gender                 string
+	FamilyStatus           string
+	MaidenName             string
+	Citizenship            string
+	Country                string
+	City                   string
+	Zip                    string
+	Address1               string
+	Address2               string
+	StreetNumber           string
+	StreetName             string
+	Image                  string
+	Birthday               pgtype.Timestamptz
+	EmailNotifications     bool
+	Phone                  string
+	Created                int64  `json:"-"`
+	Role                   int64  `json:"-"`
+	PasswordHash           string `json:"-"`
+	CurrencyID             int64  `json:"-"`
+	USD                    shopspring.Numeric
+	EUR                    shopspring.Numeric
+	LeverageAllowed        shopspring.Numeric
+	StopLossAllowed        shopspring.Numeric //Maximum allowed StopLoss % for this member
+	TakeProfitAllowed      shopspring.Numeric //Maximum allowed TakeProfit % for this member
+	Status                 string
+	ManagerRole            string
+	MemberGroupID          int64  `json:"-"`
+	TransferSendAllowed    bool   `json:"-"` //Member can transfer funds to other members
+	TransferReceiveAllowed bool   `json:"-"` //Member can receive funds from other members
+	MFASecret              string `json:"-"` //TOTP secret (base32)
+	MFAEnabled             bool   //Two-factor authentication is enabled
+	MFAStep                int64  `json:"-"` //Last accepted TOTP time step
 }
 
 // Verify - OTP verification
@@ -46,12 +52,12 @@ type Verify struct {
 	VerifyID  int64
 	MemberID  int64
 	CodeHash  string //OTP code hash
-	Method    string //Phone/email
-	Action    string //Auth/reset/confirm
+	Method    string //Channel (email, phone), see internal/verify
+	Action    string //Purpose (signup, reset, email_change, phone_change, sign_contract...)
 	Email     string //Email being verified
 	EmailHash string //Email md5 hash
 	Phone     string //Phone being verified
-	Status    string //Status (pending, cancelled, success)
+	Status    string //Status (pending, cancelled, fail, success)
 	Attempts  int    //Code entry attempts
 	IP        string //IP address
 	Created   int64  //UNIX timestamp
@@ -92,6 +98,16 @@ type Settings struct {
 	APIKeyIEX                  string             //IEXCloud API key
 	APIKeyFCS                  string             //FCS API key
 	DefaultCurrencyID          int64              //Default currency for member balances
+	StaleAlertEmail            string             //Email notified when a market stops receiving rates
+	StaleAlertWebhook          string             //Webhook URL notified when a market stops receiving rates
+	ReconciliationEmail        string             //Email receiving daily balance reconciliation summary
+	DepositBankDetailsID       int64              //Bank account members transfer deposits to
+	DepositAutoCreditLimit     shopspring.Numeric //Confirmed deposits above the limit are credited by operator
+	WithdrawDailyLimit         shopspring.Numeric //Default daily withdrawal limit of members without group limit (0 - unlimited)
+	WithdrawMonthlyLimit       shopspring.Numeric //Default monthly withdrawal limit of members without group limit (0 - unlimited)
+	WithdrawApprovalThreshold  shopspring.Numeric //Withdrawals above the amount are approved by two operators
+	ConversionFee              shopspring.Numeric //Fee of conversion between member currencies (% of converted amount)
+	MFARequiredOperator        bool               //Two-factor authentication is required of operators and admins
 }
 
 // News
@@ -134,38 +150,40 @@ type History struct {
 
 //Trade
 type Trade struct {
-	TradeID        int64 //TradeID of existing order
-	MemberID       int64 `json:"-"`
-	AssetID        int64
-	Type           string             //l/m limit/market
-	Action         string             //b/s buy/sell
-	MemberRate     shopspring.Numeric //Member defined asset rate in case of limit order
-	MarketRate     shopspring.Numeric //Current market rate of asset
-	RateEntry      shopspring.Numeric //Rate for member with buy/sell % fee at order creation time
-	RateClosed     shopspring.Numeric //Rate for member with buy/sell % fee at order closure time
-	Qty            shopspring.Numeric //How much asset qty / forex lots member wants to purchase
-	OnePip         shopspring.Numeric //One forex pip (0.0001 for all Forex lots, 0.01 for JPY)
-	PipsRateEntry  shopspring.Numeric //How much pips Forex lot rate had at order creation time
-	PipsRateClosed shopspring.Numeric //How much pips Forex lot rate had at order closure time
-	PipValue       shopspring.Numeric `json:"-"` //How much money does cost 1 forex pip
-	ForexAmount    shopspring.Numeric `json:"-"` //How much Forex lot member bought / sold (for display in dashboard)
-	Leverage       shopspring.Numeric //Leverage (example: 1x, 10x) with which asset is being purchased
-	TotalReal      shopspring.Numeric `json:"-"` //Real total order market cost (without applying leverage)
-	DateOpen       pgtype.Timestamptz
-	DateClosed     pgtype.Timestamptz
-	Total          shopspring.Numeric //Total order cost for member balance (leverage applied)
-	BalanceAsset   shopspring.Numeric `json:"-"` //How much asset member has on his balance
-	BalanceEntry   shopspring.Numeric //Member USD/EUR balance at order placement time
-	BalanceClosed  shopspring.Numeric //Member USD/EUR balance after order is closed
-	StopLoss       shopspring.Numeric //Stop loss %
-	TakeProfit     shopspring.Numeric //Take profit %
-	Profit         shopspring.Numeric //Order total profit that member earned (or lost)
-	ProfitAbs      shopspring.Numeric //Absolute (no negative sign) profit value
-	ProfitNegative bool               //Order total profit is less than 0
-	Gain           shopspring.Numeric //How much % profit asset gained (or lost) since order creation
-	ClosedBySystem bool               //Order was closed by system because of stop loss / take profit
-	Status         string             //Order status on placement - pending (=> cancelled) => open => closed
-	Timestamp      int64              `json:"-"` //UNIX timestamp
+	TradeID         int64 //TradeID of existing order
+	MemberID        int64 `json:"-"`
+	AssetID         int64
+	Type            string             //l/m limit/market
+	Action          string             //b/s buy/sell
+	MemberRate      shopspring.Numeric //Member defined asset rate in case of limit order
+	MarketRate      shopspring.Numeric //Current market rate of asset
+	RateEntry       shopspring.Numeric //Rate for member with buy/sell % fee at order creation time
+	RateClosed      shopspring.Numeric //Rate for member with buy/sell % fee at order closure time
+	Qty             shopspring.Numeric //How much asset qty / forex lots member wants to purchase
+	OnePip          shopspring.Numeric //One forex pip (0.0001 for all Forex lots, 0.01 for JPY)
+	PipsRateEntry   shopspring.Numeric //How much pips Forex lot rate had at order creation time
+	PipsRateClosed  shopspring.Numeric //How much pips Forex lot rate had at order closure time
+	PipValue        shopspring.Numeric `json:"-"` //How much money does cost 1 forex pip
+	ForexAmount     shopspring.Numeric `json:"-"` //How much Forex lot member bought / sold (for display in dashboard)
+	Leverage        shopspring.Numeric //Leverage (example: 1x, 10x) with which asset is being purchased
+	TotalReal       shopspring.Numeric `json:"-"` //Real total order market cost (without applying leverage)
+	DateOpen        pgtype.Timestamptz
+	DateClosed      pgtype.Timestamptz
+	Total           shopspring.Numeric //Total order cost for member balance (leverage applied)
+	BalanceAsset    shopspring.Numeric `json:"-"` //How much asset member has on his balance
+	BalanceEntry    shopspring.Numeric //Member USD/EUR balance at order placement time
+	BalanceClosed   shopspring.Numeric //Member USD/EUR balance after order is closed
+	StopLoss        shopspring.Numeric //Stop loss %
+	TakeProfit      shopspring.Numeric //Take profit %
+	Profit          shopspring.Numeric //Order total profit that member earned (or lost)
+	ProfitAbs       shopspring.Numeric //Absolute (no negative sign) profit value
+	ProfitNegative  bool               //Order total profit is less than 0
+	Gain            shopspring.Numeric //How much % profit asset gained (or lost) since order creation
+	ClosedBySystem  bool               //Order was closed by system because of stop loss / take profit
+	Status          string             //Order status on placement - pending (=> cancelled) => open => closed
+	Timestamp       int64              `json:"-"` //UNIX timestamp
+	AccountCurrency string             //Member account currency the order is settled in
+	MarginRate      shopspring.Numeric `json:"-"` //Conversion rate of order margin into account currency at order creation time
 }
 
 //Fave
@@ -177,33 +195,40 @@ type Fave struct {
 
 //Asset
 type Asset struct {
-	AssetID         int64
-	MarketID        int64
-	Ticker          string
-	TickerTV        string
-	Title           string
-	Description     string
-	Icon            string
-	BuySpread       shopspring.Numeric `json:"-"`
-	SellSpread      shopspring.Numeric `json:"-"`
-	RateBuy         shopspring.Numeric
-	RateSell        shopspring.Numeric
-	Sentiment       int
-	SentimentType   string
-	Tradable        bool
-	Position        int
-	Active          bool `json:"-"`
-	Rate            shopspring.Numeric
-	Change          shopspring.Numeric
-	Updated         int64 `json:"-"`
-	Performance     []Rate
-	DecimalScale    int32
-	Currency        string `json:"-"`
-	BaseCurrency    string `json:"-"`
-	PipDecimals     int    `json:"-"`
-	LeverageAllowed shopspring.Numeric
-	TVWidget        bool
-	FcsID           int64 `json:"-"`
+	AssetID             int64
+	MarketID            int64
+	Ticker              string
+	TickerTV            string
+	Title               string
+	Description         string
+	Icon                string
+	BuySpread           shopspring.Numeric `json:"-"`
+	SellSpread          shopspring.Numeric `json:"-"`
+	RateBuy             shopspring.Numeric
+	RateSell            shopspring.Numeric
+	Sentiment           int
+	SentimentType       string
+	Tradable            bool
+	Position            int
+	Active              bool `json:"-"`
+	Rate                shopspring.Numeric
+	Change              shopspring.Numeric
+	Updated             int64 `json:"-"`
+	Performance         []Rate
+	DecimalScale        int32
+	Currency            string `json:"-"`
+	BaseCurrency        string `json:"-"`
+	PipDecimals         int    `json:"-"`
+	LeverageAllowed     shopspring.Numeric
+	TVWidget            bool
+	FcsID               int64              `json:"-"`
+	Halted              bool               //Trading is halted by operator
+	HaltReason          string             //Reason of trading halt
+	ManualRate          shopspring.Numeric `json:"-"` //Rate pinned by operator
+	ManualRateUntil     int64              `json:"-"` //UNIX timestamp until which the pinned rate is used
+	VolatilityWindow    int64              `json:"-"` //Period of tick history used to calculate realised volatility (seconds)
+	VolatilityFactor    shopspring.Numeric `json:"-"` //How much 1% of realised volatility widens the spread
+	MaxSpreadMultiplier shopspring.Numeric `json:"-"` //Max spread widening multiplier (0 - unlimited)
 }
 
 //Rate
@@ -229,12 +254,16 @@ type Alert struct {
 
 //Job
 type Job struct {
-	JobID     int64
-	MarketID  int64
-	Title     string
-	Timestamp int64
-	Interval  int64
-	Active    bool
+	JobID        int64
+	MarketID     int64
+	Title        string
+	Timestamp    int64
+	Interval     int64
+	Active       bool
+	LastRun      int64  //Last run start (UNIX timestamp)
+	LastStatus   string //Last run status: success, failed
+	LastError    string //Last run error
+	LastDuration int64  //Last run duration (milliseconds)
 }
 
 //Onboarding
@@ -275,6 +304,7 @@ type Currency struct {
 	CurrencyID pgtype.Int8
 	Title      string
 	Symbol     string
+	Code       string //ISO 4217 code
 }
 
 type Offer struct {
@@ -377,6 +407,8 @@ type TX struct {
 	DateComplete      pgtype.Timestamptz
 	TimestampCreated  int64
 	TimestampComplete int64
+	Type              string //transfer, conversion
+	TransferID        int64
 }
 
 type Balance struct {
@@ -490,3 +522,297 @@ type Manager struct {
 	MemberID  pgtype.Varchar
 	Role      pgtype.Text
 }
+
+//Audit
+type Audit struct {
+	AuditID   int64
+	MemberID  int64
+	Action    string
+	Entity    string
+	EntityID  int64
+	Reason    string
+	Data      string
+	Timestamp int64
+}
+
+//Tick - asset rate update with effective spread
+type Tick struct {
+	TickID     int64
+	AssetID    int64
+	Rate       shopspring.Numeric
+	RateBuy    shopspring.Numeric
+	RateSell   shopspring.Numeric
+	BuySpread  shopspring.Numeric //Effective buy spread
+	SellSpread shopspring.Numeric //Effective sell spread
+	Volatility shopspring.Numeric //Realised volatility (%)
+	Multiplier shopspring.Numeric //Multiplier applied to asset base spread
+	Timestamp  int64
+}
+
+//SpreadSession - spread schedule
+type SpreadSession struct {
+	SpreadSessionID int64
+	Title           string
+	MarketID        int64  //0 - all markets
+	AssetID         int64  //0 - all assets
+	Weekdays        string //Comma separated weekdays (0 - sunday), empty - every day
+	StartMinute     int    //Session start (minutes since midnight)
+	EndMinute       int    //Session end (minutes since midnight)
+	Multiplier      shopspring.Numeric
+	Active          bool
+}
+
+//MemberGroup
+type MemberGroup struct {
+	MemberGroupID        int64
+	Title                string
+	Markup               shopspring.Numeric //Spread markup (% of effective spread)
+	WithdrawDailyLimit   shopspring.Numeric //0 - default limit from Settings
+	WithdrawMonthlyLimit shopspring.Numeric //0 - default limit from Settings
+}
+
+//RetentionPolicy - how long rows of a table are kept
+type RetentionPolicy struct {
+	RetentionPolicyID  int64
+	TableName          string
+	RawPeriod          int64 //Rows newer than this period (seconds) are kept in full resolution
+	DownsampleInterval int64 //Older rows are downsampled to one row per asset per interval (seconds), 0 - no downsampling
+	MaxPeriod          int64 //Rows older than this period (seconds) are deleted, 0 - kept forever
+	Processed          int64 //UNIX timestamp up to which rows are downsampled
+	Active             bool
+}
+
+//Market - asset market with its trading session
+type Market struct {
+	MarketID       int64
+	Title          string
+	Weekdays       string //Comma separated trading weekdays in UTC (0 - sunday), empty - every day
+	StartMinute    int    //Session start (minutes since midnight UTC)
+	EndMinute      int    //Session end (minutes since midnight UTC), equal to start - all day
+	StaleIntervals int64  //Market is stale after this many update intervals without rates
+}
+
+//JobRun - scheduled job run history
+type JobRun struct {
+	JobRunID  int64
+	Name      string //Job name
+	Instance  string //Server instance that ran the job (hostname:pid)
+	Timestamp int64  //Start (UNIX timestamp)
+	Finished  int64  //End (UNIX timestamp), 0 - still running
+	Duration  int64  //Duration (milliseconds)
+	Status    string //running, success, failed
+	Error     string
+}
+
+//Task - queued background task (email, sms, upload)
+type Task struct {
+	TaskID         int64
+	Type           string
+	Payload        string //Task JSON
+	Status         string //pending, running, done, dead
+	Attempts       int
+	MaxAttempts    int
+	RunAt          int64 //Next attempt (UNIX timestamp)
+	LockedUntil    int64 //Running task is claimed again after this time
+	LastError      string
+	IdempotencyKey pgtype.Text
+	Timestamp      int64 //Created (UNIX timestamp)
+	Updated        int64
+}
+
+//Outbox - event recorded with a state change, published to redis by the relay
+type Outbox struct {
+	OutboxID  int64
+	Channel   string
+	MemberID  int64  //Recipient member, 0 - everyone
+	Payload   string //Message JSON
+	Sequence  int64  //Publishing order, 0 - not numbered yet
+	Published int64  //Publish time (UNIX timestamp), 0 - not published yet
+	Timestamp int64
+}
+
+//LedgerAccount - ledger account balance
+type LedgerAccount struct {
+	LedgerAccountID int64
+	Type            string //member, margin, house, fees, transit, opening
+	MemberID        int64  //0 for house accounts
+	Currency        string
+	Balance         shopspring.Numeric
+	Updated         int64
+}
+
+//LedgerEntry - journal entry, its postings add up to zero
+type LedgerEntry struct {
+	LedgerEntryID int64
+	Type          string
+	Entity        string
+	EntityID      int64
+	Currency      string
+	Description   string
+	Timestamp     int64
+}
+
+//LedgerPosting - amount moved to (negative - from) a ledger account
+type LedgerPosting struct {
+	LedgerPostingID int64
+	LedgerEntryID   int64
+	LedgerAccountID int64
+	Amount          shopspring.Numeric
+	BalanceAfter    shopspring.Numeric //Account balance after the posting
+}
+
+//Reconciliation - discrepancy between a stored value and the value recomputed from deposits, withdrawals and trade history
+type Reconciliation struct {
+	ReconciliationID int64
+	Run              int64  //Reconciliation run start (UNIX timestamp)
+	Type             string //Check that found the discrepancy
+	MemberID         int64
+	Currency         string
+	AssetID          int64
+	Entity           string //Record the discrepancy was found for, e.g. trade or member
+	EntityID         int64
+	Expected         shopspring.Numeric //Recomputed value
+	Actual           shopspring.Numeric //Stored value
+	Difference       shopspring.Numeric //Actual - Expected
+	Timestamp        int64
+}
+
+//Deposit - member deposit through a payment provider
+type Deposit struct {
+	DepositID     int64
+	MemberID      int64
+	Amount        shopspring.Numeric
+	Status        string //pending, confirmed, credited, failed
+	Provider      string //bank, card, mock, manual
+	Reference     string //Payment reference quoted by member
+	TransactionID string //Provider transaction ID
+	Currency      string
+	Reason        string //Failure reason
+	Created       int64
+	Updated       int64
+}
+
+//Withdrawal - member withdrawal request
+type Withdrawal struct {
+	WithdrawalID int64
+	MemberID     int64
+	Amount       shopspring.Numeric
+	Status       string //pending, approved, complete, declined
+	Currency     string
+	Held         bool   //Amount is held on member hold account until the withdrawal is complete or declined
+	ApprovedBy   int64  //Operator who approved withdrawal above approval threshold
+	CompletedBy  int64  //Operator who completed or declined withdrawal
+	Reason       string //Decline reason
+	Created      int64
+	Updated      int64
+}
+
+//Transfer - conversion between member currencies or transfer to another member
+type Transfer struct {
+	TransferID        int64
+	Type              string //conversion, transfer
+	MemberID          int64
+	RecipientID       int64 //Same as MemberID for conversion
+	Currency          string
+	Amount            shopspring.Numeric //Amount debited from sender
+	RecipientCurrency string
+	RecipientAmount   shopspring.Numeric //Amount credited to recipient
+	Rate              shopspring.Numeric //Conversion rate
+	Fee               shopspring.Numeric //Conversion fee in Currency
+	Timestamp         int64
+}
+
+//Document - member statement or trade confirmation PDF
+type Document struct {
+	DocumentID int64
+	MemberID   int64  `json:"-"`
+	Type       string //statement, confirmation
+	Period     string //Statement month (2020-09) or confirmed trade status (open, closed)
+	TradeID    int64
+	Status     string //pending, ready
+	Filename   string `json:"-"`
+	URL        string
+	Email      bool //Email member once ready
+	Created    int64
+	Updated    int64
+}
+
+//PaymentEvent - payment provider webhook event
+type PaymentEvent struct {
+	PaymentEventID int64
+	Provider       string
+	TransactionID  string
+	Status         string
+	DepositID      int64
+	Payload        string
+	Timestamp      int64
+}
+
+//WebAuthnCredential - member passkey (WebAuthn public key credential)
+type WebAuthnCredential struct {
+	WebAuthnCredentialID int64
+	MemberID             int64  `json:"-"`
+	CredentialID         string //base64url credential ID
+	PublicKey            string `json:"-"` //base64url COSE public key
+	SignCount            int64  `json:"-"` //Authenticator signature counter
+	AAGUID               string //Authenticator model
+	Name                 string
+	Created              int64
+	LastUsed             int64
+}
+
+//Role - named group of permissions assigned to members
+type Role struct {
+	RoleID      int64
+	Title       string
+	Description string
+	Created     int64
+}
+
+//RolePermission - permission granted by role
+type RolePermission struct {
+	RoleID     int64
+	Permission string
+}
+
+//MemberRole - role assigned to member
+type MemberRole struct {
+	MemberID int64
+	RoleID   int64
+	Created  int64
+}
+
+//SecurityEvent - sign in security event (failed sign in, lockout, unlock)
+type SecurityEvent struct {
+	SecurityEventID int64
+	MemberID        int64
+	Email           string
+	IP              string
+	UserAgent       string
+	Type            string
+	Data            string
+	Created         int64
+}
+
+//JWTKey - asymmetric token signing key
+type JWTKey struct {
+	KID             string
+	Algorithm       string //RS256, EdDSA
+	PrivateKey      string `json:"-"` //base64 encrypted PKCS #8 key
+	EncryptionKeyID string `json:"-"` //jwt_key_id of jwt_secret the private key is encrypted with
+	PublicKey       string //base64 PKIX key
+	Created         int64
+	Activates       int64 //Signs tokens from
+	Expires         int64 //Accepted until, 0 - current key
+}
+
+//OIDCIdentity - external OpenID Connect identity linked to member
+type OIDCIdentity struct {
+	OIDCIdentityID int64
+	MemberID       int64 `json:"-"`
+	Provider       string
+	Subject        string `json:"-"` //sub claim of the provider
+	Email          string
+	Created        int64
+	LastUsed       int64
+}
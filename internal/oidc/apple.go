@@ -0,0 +1,56 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+//APPLE_SECRET_TTL - lifetime of generated client secret, it is created for each code exchange
+const APPLE_SECRET_TTL = 5 * time.Minute
+
+//AppleKey - Sign in with Apple private key of the developer account
+type AppleKey struct {
+	TeamID     string
+	KeyID      string
+	PrivateKey string //Contents of AuthKey_<KeyID>.p8
+}
+
+//clientSecret returns client secret of token request, Apple requires a JWT signed with the developer key
+func (provider *Provider) clientSecret() (string, error) {
+	if provider.Apple == nil {
+		return provider.ClientSecret, nil
+	}
+
+	block, _ := pem.Decode([]byte(provider.Apple.PrivateKey))
+	if block == nil {
+		return "", errors.New("apple private key must be PEM encoded")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return "", errors.New("apple private key must be EC key")
+	}
+
+	now := time.Now()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": provider.Apple.TeamID,
+		"iat": now.Unix(),
+		"exp": now.Add(APPLE_SECRET_TTL).Unix(),
+		"aud": ISSUER_APPLE,
+		"sub": provider.ClientID,
+	})
+	token.Header["kid"] = provider.Apple.KeyID
+
+	return token.SignedString(key)
+}
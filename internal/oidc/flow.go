@@ -0,0 +1,276 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ianidi/exchange-server/graph/methods/constants"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+//Redis keys of sign in flow
+const (
+	STATE_KEY = "oidc:state:" //oidc:state:<state> - State JSON of authorization request
+	LOGIN_KEY = "oidc:login:" //oidc:login:<code> - MemberID, exchanged for tokens by the client
+
+	STATE_TTL = 10 * time.Minute
+	LOGIN_TTL = 2 * time.Minute
+
+	NAME_LENGTH = 30 //Member.FirstName, Member.LastName
+)
+
+//State - authorization request of a sign in, kept until the provider redirects back
+type State struct {
+	Provider string
+	Verifier string //PKCE code verifier
+	Nonce    string
+}
+
+//RedirectURI returns callback URL of the provider
+func RedirectURI(name string) string {
+	return viper.GetString("public_url") + "auth/oidc/" + name + "/callback"
+}
+
+//Begin starts authorization code flow with PKCE and returns URL the member is redirected to
+func Begin(name string, hint string) (string, error) {
+	provider, err := GetProvider(name)
+	if err != nil {
+		return "", err
+	}
+
+	values, err := random(3)
+	if err != nil {
+		return "", err
+	}
+
+	state := State{Provider: name, Verifier: values[0], Nonce: values[1]}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+
+	value := values[2]
+
+	if err := redis.GetRedis().Do(radix.FlatCmd(nil, "SET", STATE_KEY+value, string(data), "EX", int64(STATE_TTL.Seconds()))); err != nil {
+		return "", err
+	}
+
+	challenge := sha256.Sum256([]byte(state.Verifier))
+
+	return provider.AuthURL(RedirectURI(name), value, state.Nonce, base64.RawURLEncoding.EncodeToString(challenge[:]), hint)
+}
+
+//Finish exchanges authorization code of the state and returns verified claims. State can be used once
+func Finish(name string, value string, code string) (Claims, error) {
+	var claims Claims
+
+	provider, err := GetProvider(name)
+	if err != nil {
+		return claims, err
+	}
+
+	if value == "" || code == "" {
+		return claims, errors.New("OIDC_INVALID_STATE")
+	}
+
+	pool := redis.GetRedis()
+
+	var data string
+
+	mn := radix.MaybeNil{Rcv: &data}
+	if err := pool.Do(radix.Cmd(&mn, "GET", STATE_KEY+value)); err != nil {
+		return claims, err
+	}
+
+	var deleted int64
+
+	if err := pool.Do(radix.Cmd(&deleted, "DEL", STATE_KEY+value)); err != nil {
+		return claims, err
+	}
+
+	if mn.Nil || deleted == 0 {
+		return claims, errors.New("OIDC_INVALID_STATE")
+	}
+
+	var state State
+
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return claims, err
+	}
+
+	if state.Provider != name {
+		return claims, errors.New("OIDC_INVALID_STATE")
+	}
+
+	idToken, err := provider.Exchange(code, state.Verifier, RedirectURI(name))
+	if err != nil {
+		return claims, err
+	}
+
+	return provider.Verify(idToken, state.Nonce)
+}
+
+//Resolve returns member of external identity. Unknown identity is linked to member with its verified email,
+//new member is created with onboarding record if there is none
+func Resolve(name string, claims Claims, IP string) (int64, error) {
+	db := db.GetDB()
+
+	now := time.Now().Unix()
+
+	var identity models.OIDCIdentity
+
+	err := db.Get(&identity, "SELECT * FROM OIDCIdentity WHERE Provider=$1 AND Subject=$2", name, claims.Subject)
+	if err == nil {
+		if err := checkStatus(identity.MemberID); err != nil {
+			return 0, err
+		}
+
+		tx := db.MustBegin()
+		tx.MustExec("UPDATE OIDCIdentity SET LastUsed=$1 WHERE OIDCIdentityID=$2", now, identity.OIDCIdentityID)
+		tx.Commit()
+
+		return identity.MemberID, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	//Linking by email is safe only if the provider verified it
+	Email := strings.ToLower(strings.TrimSpace(claims.Email))
+	if Email == "" || !claims.EmailVerified {
+		return 0, errors.New("OIDC_EMAIL_NOT_VERIFIED")
+	}
+
+	var member models.Member
+
+	err = db.Get(&member, "SELECT * FROM Member WHERE Email=$1", Email)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	if err == nil && member.Status == constants.STATUS_DISABLED {
+		return 0, errors.New("ACCOUNT_DISABLED")
+	}
+
+	tx := db.MustBegin()
+
+	MemberID := member.MemberID
+
+	if err == sql.ErrNoRows {
+		if err := tx.Get(&MemberID, "INSERT INTO Member (Email, FirstName, LastName, IP, Created, Status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING MemberID", Email, truncate(claims.FirstName), truncate(claims.LastName), IP, now, constants.STATUS_ACTIVE); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		//Email is confirmed by the provider, password is not set
+		tx.MustExec("INSERT INTO Onboarding (MemberID, Email, Contract, Phone, Password) VALUES ($1, $2, $3, $4, $5)", MemberID, true, false, false, false)
+	} else if member.Status == constants.STATUS_NOACTIVE {
+		//Unconfirmed account could be registered by anyone with the email, its password is not trusted (pre-hijack)
+		tx.MustExec("UPDATE Member SET Status=$1, PasswordHash=$2 WHERE MemberID=$3", constants.STATUS_ACTIVE, "", MemberID)
+		tx.MustExec("UPDATE Onboarding SET Email=$1, Password=$2 WHERE MemberID=$3", true, false, MemberID)
+	}
+
+	tx.MustExec("INSERT INTO OIDCIdentity (MemberID, Provider, Subject, Email, Created, LastUsed) VALUES ($1, $2, $3, $4, $5, $6)", MemberID, name, claims.Subject, Email, now, now)
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return MemberID, nil
+}
+
+//NewLoginCode issues one-time code of signed in member, the client exchanges it for tokens
+func NewLoginCode(MemberID int64) (string, error) {
+	values, err := random(1)
+	if err != nil {
+		return "", err
+	}
+
+	code := values[0]
+
+	if err := redis.GetRedis().Do(radix.FlatCmd(nil, "SET", LOGIN_KEY+code, MemberID, "EX", int64(LOGIN_TTL.Seconds()))); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+//UseLoginCode returns member of login code and deletes it
+func UseLoginCode(code string) (int64, error) {
+	pool := redis.GetRedis()
+
+	var MemberID string
+
+	mn := radix.MaybeNil{Rcv: &MemberID}
+	if err := pool.Do(radix.Cmd(&mn, "GET", LOGIN_KEY+code)); err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+
+	if err := pool.Do(radix.Cmd(&deleted, "DEL", LOGIN_KEY+code)); err != nil {
+		return 0, err
+	}
+
+	if mn.Nil || deleted == 0 {
+		return 0, errors.New("OIDC_LOGIN_EXPIRED")
+	}
+
+	return cast.ToInt64(MemberID), nil
+}
+
+func checkStatus(MemberID int64) error {
+	db := db.GetDB()
+
+	var Status string
+
+	if err := db.Get(&Status, "SELECT Status FROM Member WHERE MemberID=$1", MemberID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("NO_MEMBER_RECORD")
+		}
+		return err
+	}
+
+	if Status == constants.STATUS_DISABLED {
+		return errors.New("ACCOUNT_DISABLED")
+	}
+
+	return nil
+}
+
+//random returns count values of 32 random bytes encoded as base64url
+func random(count int) ([]string, error) {
+	values := []string{}
+
+	for i := 0; i < count; i++ {
+		value := make([]byte, 32)
+		if _, err := rand.Read(value); err != nil {
+			return nil, err
+		}
+
+		values = append(values, base64.RawURLEncoding.EncodeToString(value))
+	}
+
+	return values, nil
+}
+
+func truncate(value string) string {
+	runes := []rune(strings.TrimSpace(value))
+	if len(runes) > NAME_LENGTH {
+		runes = runes[:NAME_LENGTH]
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,171 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/jwk"
+)
+
+//Mock issuer settings, the issuer signs in any email without a password
+const (
+	MOCK_CLIENT_ID = "mock"
+	MOCK_EMAIL     = "mock@example.com" //Email of sign in without login_hint
+	MOCK_KID       = "mock"
+	MOCK_CODE_TTL  = time.Minute
+	MOCK_TOKEN_TTL = 5 * time.Minute
+)
+
+//Mock - local OpenID Connect issuer for development and tests, authorization requests are approved at once
+type Mock struct {
+	Issuer string //public_url + "oidc/mock"
+
+	key   *rsa.PrivateKey
+	mu    sync.Mutex
+	codes map[string]mockCode
+}
+
+type mockCode struct {
+	RedirectURI string
+	Challenge   string
+	Nonce       string
+	Email       string
+	Expires     time.Time
+}
+
+//NewMock creates mock issuer with a new signing key
+func NewMock(issuer string) (*Mock, error) {
+	key, err := rsa.GenerateKey(rand.Reader, jwk.RSA_BITS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mock{Issuer: strings.TrimSuffix(issuer, "/"), key: key, codes: map[string]mockCode{}}, nil
+}
+
+//Routes registers endpoints of mock issuer
+func (mock *Mock) Routes(group *gin.RouterGroup) {
+	group.GET("/.well-known/openid-configuration", mock.Discovery)
+	group.GET("/authorize", mock.Authorize)
+	group.POST("/token", mock.Token)
+	group.GET("/jwks", mock.JWKS)
+}
+
+//Discovery returns provider metadata
+func (mock *Mock) Discovery(c *gin.Context) {
+	c.JSON(http.StatusOK, Discovery{
+		Issuer:                mock.Issuer,
+		AuthorizationEndpoint: mock.Issuer + "/authorize",
+		TokenEndpoint:         mock.Issuer + "/token",
+		JWKSURI:               mock.Issuer + "/jwks",
+	})
+}
+
+//Authorize approves authorization request of login_hint email and redirects back with the code
+func (mock *Mock) Authorize(c *gin.Context) {
+	if c.Query("response_type") != "code" || c.Query("client_id") != MOCK_CLIENT_ID || c.Query("code_challenge_method") != "S256" || c.Query("code_challenge") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request"})
+		return
+	}
+
+	redirectURI, err := url.Parse(c.Query("redirect_uri"))
+	if err != nil || c.Query("redirect_uri") != RedirectURI(PROVIDER_MOCK) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request"})
+		return
+	}
+
+	email := c.Query("login_hint")
+	if email == "" {
+		email = MOCK_EMAIL
+	}
+
+	values, err := random(1)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+
+	code := values[0]
+
+	mock.mu.Lock()
+	now := time.Now()
+	for key, item := range mock.codes {
+		if now.After(item.Expires) {
+			delete(mock.codes, key)
+		}
+	}
+	mock.codes[code] = mockCode{
+		RedirectURI: c.Query("redirect_uri"),
+		Challenge:   c.Query("code_challenge"),
+		Nonce:       c.Query("nonce"),
+		Email:       strings.ToLower(email),
+		Expires:     now.Add(MOCK_CODE_TTL),
+	}
+	mock.mu.Unlock()
+
+	query := redirectURI.Query()
+	query.Set("code", code)
+	query.Set("state", c.Query("state"))
+	redirectURI.RawQuery = query.Encode()
+
+	c.Redirect(http.StatusFound, redirectURI.String())
+}
+
+//Token exchanges code for ID token, code verifier must match the challenge
+func (mock *Mock) Token(c *gin.Context) {
+	code := c.PostForm("code")
+
+	mock.mu.Lock()
+	item, ok := mock.codes[code]
+	delete(mock.codes, code)
+	mock.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(c.PostForm("code_verifier")))
+
+	if !ok || time.Now().After(item.Expires) || c.PostForm("grant_type") != "authorization_code" || c.PostForm("client_id") != MOCK_CLIENT_ID || c.PostForm("redirect_uri") != item.RedirectURI || base64.RawURLEncoding.EncodeToString(challenge[:]) != item.Challenge {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            mock.Issuer,
+		"aud":            MOCK_CLIENT_ID,
+		"sub":            "mock|" + item.Email,
+		"email":          item.Email,
+		"email_verified": true,
+		"nonce":          item.Nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(MOCK_TOKEN_TTL).Unix(),
+	})
+	token.Header["kid"] = MOCK_KID
+
+	idToken, err := token.SignedString(mock.key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token_type": "Bearer", "id_token": idToken, "expires_in": int64(MOCK_TOKEN_TTL.Seconds())})
+}
+
+//JWKS returns public key of the issuer
+func (mock *Mock) JWKS(c *gin.Context) {
+	key, err := jwk.Key{KID: MOCK_KID, Algorithm: jwk.ALG_RS256, Public: &mock.key.PublicKey}.JWK()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, jwk.Set{Keys: []jwk.JWK{key}})
+}
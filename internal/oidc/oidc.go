@@ -0,0 +1,369 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/ianidi/exchange-server/internal/jwk"
+	"github.com/parnurzeal/gorequest"
+)
+
+//Providers of social sign in
+const (
+	PROVIDER_GOOGLE = "google"
+	PROVIDER_APPLE  = "apple"
+	PROVIDER_OIDC   = "oidc" //Generic OpenID Connect provider
+	PROVIDER_MOCK   = "mock" //Local mock issuer for development and tests
+
+	ISSUER_GOOGLE = "https://accounts.google.com"
+	ISSUER_APPLE  = "https://appleid.apple.com"
+)
+
+const (
+	TIMEOUT       = 10 * time.Second //Requests to providers
+	KEYS_TTL      = time.Hour        //Provider signing keys are refetched after KEYS_TTL or on unknown kid
+	KEYS_RETRY    = 10 * time.Second //Unknown kid refetches keys at most every KEYS_RETRY
+	CLOCK_SKEW    = 60               //Seconds
+	DEFAULT_SCOPE = "openid email profile"
+)
+
+//Provider - OpenID Connect provider, endpoints are discovered from its issuer
+type Provider struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Apple        *AppleKey //Sign in with Apple client secret is a JWT signed with the key
+	Scope        string    //DEFAULT_SCOPE if empty
+	ResponseMode string    //form_post - provider posts the code to callback (Apple)
+
+	mu        sync.Mutex
+	discovery *Discovery
+	keys      map[string]crypto.PublicKey
+	keysAt    time.Time
+}
+
+//Discovery - provider metadata of /.well-known/openid-configuration
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+//Claims - verified ID token claims of the sign in
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+}
+
+var providers = map[string]*Provider{}
+
+//Register adds OpenID Connect provider
+func Register(name string, provider *Provider) {
+	providers[name] = provider
+}
+
+//GetProvider returns registered provider
+func GetProvider(name string) (*Provider, error) {
+	provider, ok := providers[name]
+	if !ok {
+		return nil, errors.New("INVALID_PROVIDER")
+	}
+
+	return provider, nil
+}
+
+//Names returns registered providers
+func Names() []string {
+	names := []string{}
+
+	for name := range providers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+//Discover returns provider metadata, it is fetched once
+func (provider *Provider) Discover() (Discovery, error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if provider.discovery != nil {
+		return *provider.discovery, nil
+	}
+
+	var discovery Discovery
+
+	resp, _, errs := gorequest.New().Get(strings.TrimSuffix(provider.Issuer, "/") + "/.well-known/openid-configuration").
+		Timeout(TIMEOUT).
+		EndStruct(&discovery)
+	if len(errs) > 0 {
+		return discovery, errs[0]
+	}
+
+	if resp.StatusCode != 200 || discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return discovery, errors.New("OIDC_PROVIDER_UNAVAILABLE")
+	}
+
+	//Issuer of ID tokens must be the configured one
+	if strings.TrimSuffix(discovery.Issuer, "/") != strings.TrimSuffix(provider.Issuer, "/") {
+		return discovery, errors.New("OIDC_INVALID_ISSUER")
+	}
+
+	provider.discovery = &discovery
+
+	return discovery, nil
+}
+
+//AuthURL returns authorization request URL of authorization code flow with PKCE, hint is optional email of the account
+func (provider *Provider) AuthURL(redirectURI string, state string, nonce string, challenge string, hint string) (string, error) {
+	discovery, err := provider.Discover()
+	if err != nil {
+		return "", err
+	}
+
+	scope := provider.Scope
+	if scope == "" {
+		scope = DEFAULT_SCOPE
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", provider.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", scope)
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", challenge)
+	query.Set("code_challenge_method", "S256")
+	if provider.ResponseMode != "" {
+		query.Set("response_mode", provider.ResponseMode)
+	}
+	if hint != "" {
+		query.Set("login_hint", hint)
+	}
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+//Exchange exchanges authorization code for ID token
+func (provider *Provider) Exchange(code string, verifier string, redirectURI string) (string, error) {
+	discovery, err := provider.Discover()
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := provider.clientSecret()
+	if err != nil {
+		return "", err
+	}
+
+	form := map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          code,
+		"redirect_uri":  redirectURI,
+		"client_id":     provider.ClientID,
+		"code_verifier": verifier,
+	}
+	if secret != "" {
+		form["client_secret"] = secret
+	}
+
+	var result struct {
+		IDToken string `json:"id_token"`
+		Error   string `json:"error"`
+	}
+
+	_, _, errs := gorequest.New().Post(discovery.TokenEndpoint).
+		Timeout(TIMEOUT).
+		Type("form").
+		Send(form).
+		EndStruct(&result)
+	if len(errs) > 0 {
+		return "", errs[0]
+	}
+
+	if result.IDToken == "" {
+		return "", errors.New("OIDC_CODE_EXCHANGE_FAILED")
+	}
+
+	return result.IDToken, nil
+}
+
+//Verify verifies signature and claims of ID token issued for the nonce
+func (provider *Provider) Verify(idToken string, nonce string) (Claims, error) {
+	var result Claims
+
+	discovery, err := provider.Discover()
+	if err != nil {
+		return result, err
+	}
+
+	//Time claims are validated below with allowed clock skew
+	parser := jwt.Parser{SkipClaimsValidation: true}
+
+	token, err := parser.Parse(idToken, provider.verificationKey)
+	if err != nil {
+		return result, errors.New("OIDC_INVALID_TOKEN")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return result, errors.New("OIDC_INVALID_TOKEN")
+	}
+
+	now := time.Now().Unix()
+
+	//Google issues tokens with and without scheme of issuer
+	issuer, _ := claims["iss"].(string)
+	if provider.Issuer == ISSUER_GOOGLE && issuer == "accounts.google.com" {
+		issuer = ISSUER_GOOGLE
+	}
+
+	if issuer != discovery.Issuer || !claims.VerifyExpiresAt(now-CLOCK_SKEW, true) || !claims.VerifyIssuedAt(now+CLOCK_SKEW, true) || !audience(claims["aud"], provider.ClientID) {
+		return result, errors.New("OIDC_INVALID_TOKEN")
+	}
+
+	if value, _ := claims["nonce"].(string); value == "" || value != nonce {
+		return result, errors.New("OIDC_INVALID_TOKEN")
+	}
+
+	result.Subject, _ = claims["sub"].(string)
+	if result.Subject == "" {
+		return result, errors.New("OIDC_INVALID_TOKEN")
+	}
+
+	result.Email, _ = claims["email"].(string)
+	result.FirstName, _ = claims["given_name"].(string)
+	result.LastName, _ = claims["family_name"].(string)
+
+	//Apple sends email_verified as string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+
+	return result, nil
+}
+
+//verificationKey returns provider key of kid, algorithm must match the key type
+func (provider *Provider) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, err := provider.key(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+	case *ecdsa.PublicKey:
+		if token.Method.Alg() != jwt.SigningMethodES256.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+	default:
+		if token.Method.Alg() != jwk.ALG_EDDSA {
+			return nil, errors.New("unexpected signing method")
+		}
+	}
+
+	return key, nil
+}
+
+//key returns provider signing key of kid from its JWKS
+func (provider *Provider) key(kid string) (crypto.PublicKey, error) {
+	discovery, err := provider.Discover()
+	if err != nil {
+		return nil, err
+	}
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	key, ok := provider.keys[kid]
+	age := time.Since(provider.keysAt)
+
+	if (ok && age < KEYS_TTL) || (!ok && age < KEYS_RETRY) {
+		if !ok {
+			return nil, errors.New("unknown signing key")
+		}
+		return key, nil
+	}
+
+	var set jwk.Set
+
+	resp, _, errs := gorequest.New().Get(discovery.JWKSURI).
+		Timeout(TIMEOUT).
+		EndStruct(&set)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("OIDC_PROVIDER_UNAVAILABLE")
+	}
+
+	keys := map[string]crypto.PublicKey{}
+
+	for _, item := range set.Keys {
+		if item.Use != "" && item.Use != "sig" {
+			continue
+		}
+
+		public, err := item.PublicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[item.KID] = public
+	}
+
+	provider.keys = keys
+	provider.keysAt = time.Now()
+
+	key, ok = keys[kid]
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+
+	return key, nil
+}
+
+//audience checks aud claim (string or array) contains client ID
+func audience(aud interface{}, clientID string) bool {
+	switch value := aud.(type) {
+	case string:
+		return value == clientID
+	case []interface{}:
+		for _, item := range value {
+			if item == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/spf13/viper"
+)
+
+//newIssuer starts mock issuer on a local server and registers it as mock provider, the server is closed by returned func
+func newIssuer(t *testing.T) (*Mock, func()) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	server := httptest.NewServer(router)
+
+	mock, err := NewMock(server.URL + "/oidc/mock")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.Routes(router.Group("/oidc/mock"))
+
+	viper.Set("public_url", server.URL+"/")
+
+	Register(PROVIDER_MOCK, &Provider{Issuer: mock.Issuer, ClientID: MOCK_CLIENT_ID})
+
+	return mock, server.Close
+}
+
+//newRedis replaces redis with in-memory stub of SET, GET and DEL, the pool is closed by returned func
+func newRedis(t *testing.T) func() {
+	var mu sync.Mutex
+	values := map[string]string{}
+
+	stub := func(args []string) interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch strings.ToUpper(args[0]) {
+		case "SET":
+			values[args[1]] = args[2]
+			return "OK"
+		case "GET":
+			value, ok := values[args[1]]
+			if !ok {
+				return nil
+			}
+			return value
+		case "DEL":
+			_, ok := values[args[1]]
+			delete(values, args[1])
+			if ok {
+				return int64(1)
+			}
+			return int64(0)
+		}
+		return "OK"
+	}
+
+	pool, err := radix.NewPool("tcp", "stub", 1, radix.PoolPipelineWindow(0, 0), radix.PoolConnFunc(func(network, addr string) (radix.Conn, error) {
+		return radix.Stub(network, addr, stub), nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redis.SetRedis(pool)
+
+	return func() { pool.Close() }
+}
+
+//authorize follows authorization request to the mock issuer and returns query of its redirect to callback
+func authorize(t *testing.T, location string) url.Values {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	resp, err := client.Get(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status %d", resp.StatusCode)
+	}
+
+	redirect, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(redirect.String(), RedirectURI(PROVIDER_MOCK)) {
+		t.Fatalf("redirected to %s", redirect)
+	}
+
+	return redirect.Query()
+}
+
+func challenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	_, stop := newIssuer(t)
+	defer stop()
+	defer newRedis(t)()
+
+	location, err := Begin(PROVIDER_MOCK, "Member@Example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := authorize(t, location)
+
+	claims, err := Finish(PROVIDER_MOCK, query.Get("state"), query.Get("code"))
+	if err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	if claims.Email != "member@example.com" || !claims.EmailVerified || claims.Subject != "mock|member@example.com" {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	//State can be used once
+	if _, err := Finish(PROVIDER_MOCK, query.Get("state"), query.Get("code")); err == nil || err.Error() != "OIDC_INVALID_STATE" {
+		t.Fatalf("reused state: got %v, want OIDC_INVALID_STATE", err)
+	}
+}
+
+func TestStateMismatch(t *testing.T) {
+	_, stop := newIssuer(t)
+	defer stop()
+	defer newRedis(t)()
+
+	location, err := Begin(PROVIDER_MOCK, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := authorize(t, location)
+
+	if _, err := Finish(PROVIDER_MOCK, "unknown", query.Get("code")); err == nil || err.Error() != "OIDC_INVALID_STATE" {
+		t.Fatalf("got %v, want OIDC_INVALID_STATE", err)
+	}
+
+	//State of another provider
+	Register(PROVIDER_OIDC, &Provider{Issuer: "http://127.0.0.1:0", ClientID: "other"})
+	defer delete(providers, PROVIDER_OIDC)
+
+	if _, err := Finish(PROVIDER_OIDC, query.Get("state"), query.Get("code")); err == nil || err.Error() != "OIDC_INVALID_STATE" {
+		t.Fatalf("got %v, want OIDC_INVALID_STATE", err)
+	}
+}
+
+func TestPKCE(t *testing.T) {
+	_, stop := newIssuer(t)
+	defer stop()
+
+	provider, err := GetProvider(PROVIDER_MOCK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	location, err := provider.AuthURL(RedirectURI(PROVIDER_MOCK), "state", "nonce", challenge("verifier"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := authorize(t, location)
+
+	if _, err := provider.Exchange(query.Get("code"), "another verifier", RedirectURI(PROVIDER_MOCK)); err == nil || err.Error() != "OIDC_CODE_EXCHANGE_FAILED" {
+		t.Fatalf("wrong verifier: got %v, want OIDC_CODE_EXCHANGE_FAILED", err)
+	}
+
+	//Code is used once, even by the failed exchange
+	if _, err := provider.Exchange(query.Get("code"), "verifier", RedirectURI(PROVIDER_MOCK)); err == nil {
+		t.Fatal("code exchanged twice")
+	}
+
+	query = authorize(t, location)
+
+	idToken, err := provider.Exchange(query.Get("code"), "verifier", RedirectURI(PROVIDER_MOCK))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := provider.Verify(idToken, "nonce"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNonceMismatch(t *testing.T) {
+	_, stop := newIssuer(t)
+	defer stop()
+
+	provider, err := GetProvider(PROVIDER_MOCK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	location, err := provider.AuthURL(RedirectURI(PROVIDER_MOCK), "state", "nonce", challenge("verifier"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := authorize(t, location)
+
+	idToken, err := provider.Exchange(query.Get("code"), "verifier", RedirectURI(PROVIDER_MOCK))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := provider.Verify(idToken, "another nonce"); err == nil || err.Error() != "OIDC_INVALID_TOKEN" {
+		t.Fatalf("got %v, want OIDC_INVALID_TOKEN", err)
+	}
+}
+
+func TestWrongAudience(t *testing.T) {
+	mock, stop := newIssuer(t)
+	defer stop()
+
+	provider := &Provider{Issuer: mock.Issuer, ClientID: "another client"}
+
+	location, err := provider.AuthURL(RedirectURI(PROVIDER_MOCK), "state", "nonce", challenge("verifier"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Mock issuer accepts its own client only
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	resp, err := client.Get(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", resp.StatusCode)
+	}
+
+	//Token of mock client isn't accepted by another client
+	mockProvider, _ := GetProvider(PROVIDER_MOCK)
+
+	location, err = mockProvider.AuthURL(RedirectURI(PROVIDER_MOCK), "state", "nonce", challenge("verifier"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := authorize(t, location)
+
+	idToken, err := mockProvider.Exchange(query.Get("code"), "verifier", RedirectURI(PROVIDER_MOCK))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := provider.Verify(idToken, "nonce"); err == nil || err.Error() != "OIDC_INVALID_TOKEN" {
+		t.Fatalf("got %v, want OIDC_INVALID_TOKEN", err)
+	}
+}
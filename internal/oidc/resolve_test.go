@@ -0,0 +1,288 @@
+package oidc
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ianidi/exchange-server/graph/methods/constants"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/jmoiron/sqlx"
+)
+
+//store - in-memory tables of the queries Resolve runs, served by fake database driver
+type store struct {
+	mu         sync.Mutex
+	members    []storeMember
+	identities []storeIdentity
+	onboarding []int64
+	passwords  map[int64]bool //Onboarding.Password of existing members
+}
+
+type storeMember struct {
+	MemberID     int64
+	Email        string
+	Status       string
+	PasswordHash string
+}
+
+type storeIdentity struct {
+	OIDCIdentityID int64
+	MemberID       int64
+	Provider       string
+	Subject        string
+	Email          string
+}
+
+var current *store
+
+func init() {
+	sql.Register("oidctest", storeDriver{})
+}
+
+//newStore replaces database with empty store
+func newStore(t *testing.T) *store {
+	current = &store{passwords: map[int64]bool{}}
+
+	conn, err := sql.Open("oidctest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db.DB = sqlx.NewDb(conn, "pgx")
+
+	return current
+}
+
+func (s *store) query(query string, args []driver.Value) ([]string, [][]driver.Value, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "SELECT * FROM OIDCIdentity WHERE Provider=$1 AND Subject=$2"):
+		for _, item := range s.identities {
+			if item.Provider == args[0] && item.Subject == args[1] {
+				return []string{"oidcidentityid", "memberid", "provider", "subject", "email"}, [][]driver.Value{{item.OIDCIdentityID, item.MemberID, item.Provider, item.Subject, item.Email}}, nil
+			}
+		}
+		return []string{"oidcidentityid"}, nil, nil
+	case strings.HasPrefix(query, "SELECT * FROM Member WHERE Email=$1"):
+		for _, item := range s.members {
+			if item.Email == args[0] {
+				return []string{"memberid", "email", "status", "passwordhash"}, [][]driver.Value{{item.MemberID, item.Email, item.Status, item.PasswordHash}}, nil
+			}
+		}
+		return []string{"memberid"}, nil, nil
+	case strings.HasPrefix(query, "SELECT Status FROM Member WHERE MemberID=$1"):
+		for _, item := range s.members {
+			if item.MemberID == args[0] {
+				return []string{"status"}, [][]driver.Value{{item.Status}}, nil
+			}
+		}
+		return []string{"status"}, nil, nil
+	case strings.HasPrefix(query, "INSERT INTO Member "):
+		member := storeMember{MemberID: int64(len(s.members) + 1), Email: args[0].(string), Status: args[5].(string)}
+		s.members = append(s.members, member)
+		return []string{"memberid"}, [][]driver.Value{{member.MemberID}}, nil
+	}
+
+	return nil, nil, errors.New("unexpected query: " + query)
+}
+
+func (s *store) exec(query string, args []driver.Value) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "INSERT INTO Onboarding "):
+		s.onboarding = append(s.onboarding, args[0].(int64))
+	case strings.HasPrefix(query, "INSERT INTO OIDCIdentity "):
+		s.identities = append(s.identities, storeIdentity{
+			OIDCIdentityID: int64(len(s.identities) + 1),
+			MemberID:       args[0].(int64),
+			Provider:       args[1].(string),
+			Subject:        args[2].(string),
+			Email:          args[3].(string),
+		})
+	case strings.HasPrefix(query, "UPDATE Member SET Status=$1, PasswordHash=$2 WHERE MemberID=$3"):
+		for i := range s.members {
+			if s.members[i].MemberID == args[2] {
+				s.members[i].Status = args[0].(string)
+				s.members[i].PasswordHash = args[1].(string)
+			}
+		}
+	case strings.HasPrefix(query, "UPDATE Onboarding SET Email=$1, Password=$2 WHERE MemberID=$3"):
+		s.passwords[args[2].(int64)] = args[1].(bool)
+	case strings.HasPrefix(query, "UPDATE OIDCIdentity SET LastUsed"):
+	default:
+		return errors.New("unexpected query: " + query)
+	}
+
+	return nil
+}
+
+type storeDriver struct{}
+
+func (storeDriver) Open(string) (driver.Conn, error) { return storeConn{}, nil }
+
+type storeConn struct{}
+
+func (storeConn) Prepare(query string) (driver.Stmt, error) { return storeStmt{query}, nil }
+func (storeConn) Close() error                              { return nil }
+func (storeConn) Begin() (driver.Tx, error)                 { return storeTx{}, nil }
+
+type storeTx struct{}
+
+func (storeTx) Commit() error   { return nil }
+func (storeTx) Rollback() error { return nil }
+
+type storeStmt struct {
+	query string
+}
+
+func (stmt storeStmt) Close() error  { return nil }
+func (stmt storeStmt) NumInput() int { return -1 }
+
+func (stmt storeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if err := current.exec(stmt.query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (stmt storeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	columns, rows, err := current.query(stmt.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &storeRows{columns: columns, rows: rows}, nil
+}
+
+type storeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (rows *storeRows) Columns() []string { return rows.columns }
+func (rows *storeRows) Close() error      { return nil }
+
+func (rows *storeRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+
+	return nil
+}
+
+func TestResolveCreatesMember(t *testing.T) {
+	s := newStore(t)
+
+	claims := Claims{Subject: "mock|new@example.com", Email: "New@Example.com", EmailVerified: true, FirstName: "New", LastName: "Member"}
+
+	MemberID, err := Resolve(PROVIDER_MOCK, claims, "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.members) != 1 || s.members[0].MemberID != MemberID || s.members[0].Email != "new@example.com" || s.members[0].Status != constants.STATUS_ACTIVE {
+		t.Fatalf("unexpected members %+v", s.members)
+	}
+
+	if len(s.onboarding) != 1 || s.onboarding[0] != MemberID {
+		t.Fatalf("onboarding record of member %d not created", MemberID)
+	}
+
+	if len(s.identities) != 1 || s.identities[0].MemberID != MemberID || s.identities[0].Subject != claims.Subject {
+		t.Fatalf("unexpected identities %+v", s.identities)
+	}
+
+	//Next sign in finds the identity
+	again, err := Resolve(PROVIDER_MOCK, claims, "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if again != MemberID || len(s.members) != 1 || len(s.identities) != 1 {
+		t.Fatalf("second sign in created records, member %d", again)
+	}
+}
+
+func TestResolveLinksVerifiedEmail(t *testing.T) {
+	s := newStore(t)
+	s.members = []storeMember{{MemberID: 7, Email: "member@example.com", Status: constants.STATUS_NOACTIVE}}
+
+	MemberID, err := Resolve(PROVIDER_MOCK, Claims{Subject: "mock|member@example.com", Email: "member@example.com", EmailVerified: true}, "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if MemberID != 7 || len(s.members) != 1 {
+		t.Fatalf("linked to member %d, members %+v", MemberID, s.members)
+	}
+
+	//Email is confirmed by the provider
+	if s.members[0].Status != constants.STATUS_ACTIVE {
+		t.Fatalf("status %s, want %s", s.members[0].Status, constants.STATUS_ACTIVE)
+	}
+
+	if len(s.identities) != 1 || s.identities[0].MemberID != 7 || len(s.onboarding) != 0 {
+		t.Fatalf("unexpected identities %+v, onboarding %v", s.identities, s.onboarding)
+	}
+}
+
+func TestResolveDiscardsUnconfirmedPassword(t *testing.T) {
+	s := newStore(t)
+
+	//Anybody could register the email before its owner and choose the password
+	s.members = []storeMember{
+		{MemberID: 7, Email: "member@example.com", Status: constants.STATUS_NOACTIVE, PasswordHash: "unconfirmed"},
+		{MemberID: 8, Email: "active@example.com", Status: constants.STATUS_ACTIVE, PasswordHash: "confirmed"},
+	}
+	s.passwords[7] = true
+	s.passwords[8] = true
+
+	if _, err := Resolve(PROVIDER_MOCK, Claims{Subject: "mock|member@example.com", Email: "member@example.com", EmailVerified: true}, "127.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.members[0].PasswordHash != "" || s.passwords[7] {
+		t.Fatal("password of unconfirmed member kept after linking")
+	}
+
+	if _, err := Resolve(PROVIDER_MOCK, Claims{Subject: "mock|active@example.com", Email: "active@example.com", EmailVerified: true}, "127.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.members[1].PasswordHash != "confirmed" || !s.passwords[8] {
+		t.Fatal("password of active member discarded")
+	}
+}
+
+func TestResolveUnverifiedEmail(t *testing.T) {
+	s := newStore(t)
+	s.members = []storeMember{{MemberID: 7, Email: "member@example.com", Status: constants.STATUS_ACTIVE}}
+
+	if _, err := Resolve(PROVIDER_MOCK, Claims{Subject: "mock|member@example.com", Email: "member@example.com"}, "127.0.0.1"); err == nil || err.Error() != "OIDC_EMAIL_NOT_VERIFIED" {
+		t.Fatalf("got %v, want OIDC_EMAIL_NOT_VERIFIED", err)
+	}
+
+	if len(s.identities) != 0 {
+		t.Fatal("identity linked by unverified email")
+	}
+}
+
+func TestResolveDisabledMember(t *testing.T) {
+	s := newStore(t)
+	s.members = []storeMember{{MemberID: 7, Email: "member@example.com", Status: constants.STATUS_DISABLED}}
+
+	if _, err := Resolve(PROVIDER_MOCK, Claims{Subject: "mock|member@example.com", Email: "member@example.com", EmailVerified: true}, "127.0.0.1"); err == nil || err.Error() != "ACCOUNT_DISABLED" {
+		t.Fatalf("got %v, want ACCOUNT_DISABLED", err)
+	}
+}
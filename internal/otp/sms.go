@@ -14,18 +14,13 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/ianidi/exchange-server/internal/config"
 	"github.com/mrjones/oauth"
 	"github.com/parnurzeal/gorequest"
 	"github.com/spf13/cast"
-	"github.com/spf13/viper"
 )
 
-const (
-	APIGateway       = "https://gatewayapi.com/rest"
-	GatewayAPIToken  = "qYixes_ETXuffKRGag_o5MnbtAIJF7WKw_osIyoJwpxzHrjmaYklBc19M8RwFIy3"
-	GatewayAPIKey    = "OCCpiLF6z8RNe598pEZqD-jk"
-	GatewayAPISecret = "BP!mpAXzCsz7qbGGIudvvCDmqI.j95-hPsxzGl3i"
-)
+const APIGateway = "https://gatewayapi.com/rest"
 
 func printBody(resp gorequest.Response, body string, errs []error) {
 	// fmt.Println(resp.Status)
@@ -33,8 +28,13 @@ func printBody(resp gorequest.Response, body string, errs []error) {
 }
 
 func SendSMS(phoneNumber string, message string) error {
+	gateway := config.Get().GatewayAPI
+	if gateway.Key == "" || gateway.Secret == "" {
+		return errors.New("SMS_SERVICE_UNAVAILABLE")
+	}
+
 	// Authentication
-	consumer := oauth.NewConsumer(GatewayAPIKey, GatewayAPISecret, oauth.ServiceProvider{})
+	consumer := oauth.NewConsumer(gateway.Key, gateway.Secret, oauth.ServiceProvider{})
 	client, err := consumer.MakeHttpClient(&oauth.AccessToken{})
 	if err != nil {
 		return err
@@ -81,12 +81,17 @@ func SendSMS(phoneNumber string, message string) error {
 // https://gatewayapi.com/docs/rest.html#sending-sms-es
 // https://mholt.github.io/json-to-go/
 func SendSMS2(phoneNumber string, message string) error {
+	token := config.Get().GatewayAPI.Token
+	if token == "" {
+		return errors.New("SMS_SERVICE_UNAVAILABLE")
+	}
+
 	request := gorequest.New()
 
 	var res GatewayAPIRes
 	//printBody
 
-	request.Get(fmt.Sprintf(`%s/mtsms?token=%s&message=%s&recipients.0.msisdn=%s`, APIGateway, GatewayAPIToken, message, phoneNumber)).
+	request.Get(fmt.Sprintf(`%s/mtsms?token=%s&message=%s&recipients.0.msisdn=%s`, APIGateway, token, message, phoneNumber)).
 		Retry(3, 2*time.Second, http.StatusBadRequest, http.StatusInternalServerError).
 		Set("Accept", "application/json").
 		Set("Content-Type", "application/json").
@@ -103,9 +108,11 @@ func SendSMS2(phoneNumber string, message string) error {
 
 func SendSMS_amazon(phoneNumber string, message string) error {
 	// Create Session and assign AccessKeyID and SecretAccessKey
+	settings := config.Get().SNS
+
 	sess, err := session.NewSession(&aws.Config{
-		Region:      aws.String(viper.GetString("aws_region_sns")),
-		Credentials: credentials.NewStaticCredentials(viper.GetString("aws_key_sns"), viper.GetString("aws_secret_sns"), ""),
+		Region:      aws.String(settings.Region),
+		Credentials: credentials.NewStaticCredentials(settings.Key, settings.Secret, ""),
 	},
 	)
 
@@ -0,0 +1,224 @@
+package outbox
+
+import (
+	ejson "encoding/json"
+	"sort"
+	"time"
+
+	"github.com/ianidi/exchange-server/graph/model"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/jmoiron/sqlx"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+const (
+	CHANNEL_INFO = "info" //Websocket subscriptions channel
+
+	//Events numbered and published per relay pass
+	BATCH = 500
+)
+
+//Event is a message published to a redis channel by the relay once the transaction that recorded it commits
+type Event struct {
+	Channel  string      //Redis channel
+	MemberID int64       //Recipient member, 0 - everyone
+	Message  interface{} //Message object, published as JSON with sequence number in Seq
+}
+
+//Stream - events numbered by one counter: events of a member or events of everyone (MemberID 0) of a channel
+type Stream struct {
+	Channel  string
+	MemberID int64
+}
+
+//Info wraps websocket message for the info channel
+func Info(ms model.Info) Event {
+	return Event{
+		Channel:  CHANNEL_INFO,
+		MemberID: int64(ms.MemberID),
+		Message:  ms,
+	}
+}
+
+//Record saves event within the transaction of the state change it describes
+func (event Event) Record(tx *sqlx.Tx) {
+	payload, err := json.Marshal(event.Message)
+	if err != nil {
+		payload = []byte("{}")
+	}
+
+	tx.MustExec("INSERT INTO Outbox (Channel, MemberID, Payload, Timestamp) VALUES ($1, $2, $3, $4)", event.Channel, event.MemberID, string(payload), time.Now().Unix())
+}
+
+//Save records event in its own transaction
+func (event Event) Save() {
+	db := db.GetDB()
+
+	tx := db.MustBegin()
+	event.Record(tx)
+	tx.Commit()
+}
+
+//Relay numbers new events and publishes numbered ones to redis, returns number of published events.
+//Events are numbered before publishing, so an event published again after a failure keeps its sequence number and clients can drop the duplicate.
+//Each stream has its own sequence, a subscriber sees every number of its streams and detects a gap of any missed event.
+func Relay() (int, error) {
+	db := db.GetDB()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	var unnumbered []models.Outbox
+
+	if err := tx.Select(&unnumbered, "SELECT * FROM Outbox WHERE Sequence=$1 ORDER BY OutboxID ASC LIMIT $2 FOR UPDATE", 0, BATCH); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	count := map[Stream]int64{}
+
+	var streams []Stream
+
+	for _, eventRow := range unnumbered {
+		stream := Stream{Channel: eventRow.Channel, MemberID: eventRow.MemberID}
+
+		if count[stream] == 0 {
+			streams = append(streams, stream)
+		}
+
+		count[stream]++
+	}
+
+	//Counter rows are locked in the same order by every relay
+	sort.Slice(streams, func(i, j int) bool {
+		if streams[i].Channel != streams[j].Channel {
+			return streams[i].Channel < streams[j].Channel
+		}
+		return streams[i].MemberID < streams[j].MemberID
+	})
+
+	next := map[Stream]int64{}
+
+	for _, stream := range streams {
+		var last int64
+
+		//Counter row keeps sequence gapless, unlike a Postgres sequence
+		if err := tx.Get(&last, "INSERT INTO OutboxSequence (Channel, MemberID, Value) VALUES ($1, $2, $3) ON CONFLICT (Channel, MemberID) DO UPDATE SET Value=OutboxSequence.Value+EXCLUDED.Value RETURNING Value", stream.Channel, stream.MemberID, count[stream]); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		next[stream] = last - count[stream] + 1
+	}
+
+	for _, eventRow := range unnumbered {
+		stream := Stream{Channel: eventRow.Channel, MemberID: eventRow.MemberID}
+
+		if _, err := tx.Exec("UPDATE Outbox SET Sequence=$1 WHERE OutboxID=$2", next[stream], eventRow.OutboxID); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		next[stream]++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	var event []models.Outbox
+
+	//Events of a stream are published in order of their numbers
+	if err := db.Select(&event, "SELECT * FROM Outbox WHERE Sequence>$1 AND Published=$2 ORDER BY Sequence ASC, OutboxID ASC LIMIT $3", 0, 0, BATCH); err != nil {
+		return 0, err
+	}
+
+	for i, eventRow := range event {
+		message, err := WithSequence(eventRow.Payload, eventRow.Sequence)
+		if err != nil {
+			return i, err
+		}
+
+		ch := redis.Channel{
+			Name:    eventRow.Channel,
+			Message: message,
+		}
+
+		//Stop on failure to keep events in order, the rest is published by the next pass
+		if err := ch.PubToChannel(); err != nil {
+			return i, err
+		}
+
+		if _, err := db.Exec("UPDATE Outbox SET Published=$1 WHERE OutboxID=$2", time.Now().Unix(), eventRow.OutboxID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(event), nil
+}
+
+//WithSequence adds sequence number to JSON message as Seq
+func WithSequence(payload string, sequence int64) (string, error) {
+	var fields map[string]ejson.RawMessage
+
+	if err := ejson.Unmarshal([]byte(payload), &fields); err != nil {
+		return "", err
+	}
+
+	seq, err := ejson.Marshal(sequence)
+	if err != nil {
+		return "", err
+	}
+
+	fields["Seq"] = seq
+
+	message, err := ejson.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	return string(message), nil
+}
+
+//Visible reports whether info channel event is delivered to the member: events of the member and events of everyone
+func Visible(info *model.Info, MemberID int64) bool {
+	return info.MemberID == 0 || int64(info.MemberID) == MemberID
+}
+
+//QueryInfoSince returns info channel events visible to the member after sequence numbers of the member stream (Seq) and the shared stream (SharedSeq), used by clients to fill gaps.
+//Events are ordered by sequence number, so a limited result has no gaps in either stream.
+func QueryInfoSince(MemberID int64, Seq int64, SharedSeq int64, limit int) ([]*model.Info, error) {
+	db := db.GetDB()
+
+	info := []*model.Info{}
+
+	var event []models.Outbox
+
+	if err := db.Select(&event, "SELECT * FROM Outbox WHERE Channel=$1 AND ((MemberID=$2 AND Sequence>$3) OR (MemberID=$4 AND Sequence>$5)) ORDER BY Sequence ASC, OutboxID ASC LIMIT $6", CHANNEL_INFO, MemberID, Seq, 0, SharedSeq, limit); err != nil {
+		return info, err
+	}
+
+	for _, eventRow := range event {
+		var infoMsg model.Info
+
+		if err := json.Unmarshal([]byte(eventRow.Payload), &infoMsg); err != nil {
+			continue
+		}
+
+		infoMsg.Seq = int(eventRow.Sequence)
+
+		if !Visible(&infoMsg, MemberID) {
+			continue
+		}
+
+		info = append(info, &infoMsg)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,34 @@
+package outbox
+
+import (
+	"testing"
+
+	"github.com/ianidi/exchange-server/graph/model"
+)
+
+func TestVisible(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		info    model.Info
+		visible bool
+	}{
+		{"own event", model.Info{MemberID: 7, Event: "deposit"}, true},
+		{"event of everyone", model.Info{Event: "rate"}, true},
+		{"event of another member", model.Info{MemberID: 8, Event: "trade"}, false},
+	} {
+		if visible := Visible(&test.info, 7); visible != test.visible {
+			t.Errorf("%s: got %v, want %v", test.name, visible, test.visible)
+		}
+	}
+}
+
+func TestWithSequence(t *testing.T) {
+	message, err := WithSequence(`{"Event":"rate","Seq":0}`, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message != `{"Event":"rate","Seq":42}` {
+		t.Fatalf("unexpected message %s", message)
+	}
+}
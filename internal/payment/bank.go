@@ -0,0 +1,57 @@
+package payment
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+//BankTransfer - deposit by bank transfer to the platform bank account. Bank statement import reports received transfers to the webhook
+type BankTransfer struct {
+	Secret string //Webhook signing secret
+}
+
+func (bank BankTransfer) Create(deposit models.Deposit) (Instructions, string, error) {
+	db := db.GetDB()
+
+	var bankDetails models.BankDetails
+
+	if err := db.Get(&bankDetails, "SELECT BankDetails.* FROM BankDetails INNER JOIN Settings ON Settings.DepositBankDetailsID=BankDetails.BankDetailsID WHERE Settings.SettingsID=$1", 1); err != nil {
+		if err == sql.ErrNoRows {
+			return Instructions{}, "", errors.New("NO_BANK_DETAILS_RECORD")
+		}
+		return Instructions{}, "", err
+	}
+
+	return Instructions{
+		Reference:   deposit.Reference,
+		BankDetails: &bankDetails,
+		Text:        fmt.Sprintf("Transfer %s %s to %s (IBAN %s, SWIFT %s). Payment reference: %s", deposit.Amount.Decimal.String(), deposit.Currency, bankDetails.BankName, bankDetails.BankIBAN, bankDetails.BankSWIFT, deposit.Reference),
+	}, "", nil
+}
+
+func (bank BankTransfer) Parse(header http.Header, body []byte) (Event, error) {
+	return parseEvent(bank.Secret, header, body)
+}
+
+//Parse webhook in platform event format (bank statement import and mock processor)
+func parseEvent(secret string, header http.Header, body []byte) (Event, error) {
+	var event Event
+
+	if err := Verify(secret, header, body); err != nil {
+		return event, err
+	}
+
+	if err := json.Unmarshal(body, &event); err != nil {
+		return event, errors.New("INVALID_PAYLOAD")
+	}
+
+	return event, nil
+}
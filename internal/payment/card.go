@@ -0,0 +1,95 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/parnurzeal/gorequest"
+	"github.com/shopspring/decimal"
+)
+
+//CardProcessor - card payment on the processor hosted checkout page
+type CardProcessor struct {
+	URL        string //Processor API URL
+	Key        string //Processor API key
+	Secret     string //Webhook signing secret
+	ReturnURL  string //Page member returns to after checkout
+	WebhookURL string //Webhook endpoint of the platform
+}
+
+//Processor payment object
+type cardPayment struct {
+	ID            string `json:"id"`
+	Reference     string `json:"reference"`
+	Status        string `json:"status"`
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+	CheckoutURL   string `json:"checkout_url"`
+	FailureReason string `json:"failure_reason"`
+}
+
+func (card CardProcessor) Create(deposit models.Deposit) (Instructions, string, error) {
+	var payment cardPayment
+
+	resp, _, errs := gorequest.New().Post(card.URL+"/payments").
+		Timeout(30*time.Second).
+		Set("Authorization", "Bearer "+card.Key).
+		Set("Idempotency-Key", deposit.Reference).
+		Send(map[string]interface{}{
+			"amount":      deposit.Amount.Decimal.String(),
+			"currency":    deposit.Currency,
+			"reference":   deposit.Reference,
+			"return_url":  card.ReturnURL,
+			"webhook_url": card.WebhookURL,
+		}).
+		EndStruct(&payment)
+	if len(errs) > 0 {
+		return Instructions{}, "", errs[0]
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return Instructions{}, "", errors.New("PROVIDER_ERROR")
+	}
+
+	return Instructions{
+		Reference: deposit.Reference,
+		URL:       payment.CheckoutURL,
+	}, payment.ID, nil
+}
+
+func (card CardProcessor) Parse(header http.Header, body []byte) (Event, error) {
+	var payment cardPayment
+
+	if err := Verify(card.Secret, header, body); err != nil {
+		return Event{}, err
+	}
+
+	if err := json.Unmarshal(body, &payment); err != nil {
+		return Event{}, errors.New("INVALID_PAYLOAD")
+	}
+
+	amount, err := decimal.NewFromString(payment.Amount)
+	if err != nil {
+		return Event{}, errors.New("INVALID_PAYLOAD")
+	}
+
+	event := Event{
+		TransactionID: payment.ID,
+		Reference:     payment.Reference,
+		Amount:        amount,
+		Currency:      payment.Currency,
+		Reason:        payment.FailureReason,
+	}
+
+	//Intermediate processor statuses are ignored
+	switch payment.Status {
+	case "succeeded":
+		event.Status = STATUS_CONFIRMED
+	case "failed", "canceled", "expired":
+		event.Status = STATUS_FAILED
+	}
+
+	return event, nil
+}
@@ -0,0 +1,53 @@
+package payment
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+//Mock - local payment processor for development and tests, deposits are completed by Complete
+type Mock struct {
+	Secret string //Webhook signing secret
+}
+
+func (mock Mock) Create(deposit models.Deposit) (Instructions, string, error) {
+	return Instructions{
+		Reference: deposit.Reference,
+		Text:      "Mock payment, complete it with operator deposit mock endpoint",
+	}, "", nil
+}
+
+func (mock Mock) Parse(header http.Header, body []byte) (Event, error) {
+	return parseEvent(mock.Secret, header, body)
+}
+
+//Complete sends signed webhook event for deposit as the processor would
+func (mock Mock) Complete(deposit models.Deposit, status string, amount decimal.Decimal) error {
+	body, err := json.Marshal(Event{
+		TransactionID: fmt.Sprintf("mock-%d", deposit.DepositID),
+		Reference:     deposit.Reference,
+		Status:        status,
+		Amount:        amount,
+		Currency:      deposit.Currency,
+		Reason:        "Mock payment " + status,
+	})
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	timestamp := fmt.Sprint(time.Now().Unix())
+	header.Set(HEADER_TIMESTAMP, timestamp)
+	header.Set(HEADER_SIGNATURE, Sign(mock.Secret, timestamp, body))
+
+	event, err := mock.Parse(header, body)
+	if err != nil {
+		return err
+	}
+
+	return Handle(PROVIDER_MOCK, event, body)
+}
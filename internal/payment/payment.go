@@ -0,0 +1,344 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ianidi/exchange-server/graph/model"
+	"github.com/ianidi/exchange-server/internal/account"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/ledger"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/outbox"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+//Deposit statuses
+const (
+	STATUS_PENDING   = "pending"   //Waiting for payment
+	STATUS_CONFIRMED = "confirmed" //Provider received the payment
+	STATUS_CREDITED  = "credited"  //Member balance credited
+	STATUS_FAILED    = "failed"    //Payment failed or declined
+)
+
+//Providers
+const (
+	PROVIDER_BANK   = "bank"
+	PROVIDER_CARD   = "card"
+	PROVIDER_MOCK   = "mock"
+	PROVIDER_MANUAL = "manual" //Deposits created before payment providers
+)
+
+const (
+	//Webhook signature headers
+	HEADER_SIGNATURE = "X-Signature"
+	HEADER_TIMESTAMP = "X-Timestamp"
+
+	//Webhooks signed earlier are rejected
+	SIGNATURE_TOLERANCE = 5 * time.Minute
+)
+
+//Provider is a payment method adapter
+type Provider interface {
+	//Create registers deposit with the provider, returns payment instructions for the member and provider transaction ID if it is known upfront
+	Create(deposit models.Deposit) (Instructions, string, error)
+
+	//Parse verifies webhook signature and returns the event
+	Parse(header http.Header, body []byte) (Event, error)
+}
+
+//Instructions tell member how to pay the deposit
+type Instructions struct {
+	Reference   string              //Payment reference member has to quote
+	URL         string              //Checkout page
+	BankDetails *models.BankDetails //Bank account to transfer to
+	Text        string
+}
+
+//Event - deposit status change reported by provider
+type Event struct {
+	TransactionID string //Provider transaction ID
+	Reference     string //Deposit reference
+	Status        string //confirmed, failed (empty - event is ignored)
+	Amount        decimal.Decimal
+	Currency      string
+	Reason        string //Failure reason
+}
+
+var providers = map[string]Provider{}
+
+//Register adds payment provider
+func Register(name string, provider Provider) {
+	providers[name] = provider
+}
+
+//GetProvider returns registered payment provider
+func GetProvider(name string) (Provider, error) {
+	provider, ok := providers[name]
+	if !ok {
+		return nil, errors.New("INVALID_PROVIDER")
+	}
+
+	return provider, nil
+}
+
+//Sign returns webhook signature of body sent at timestamp
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+//Verify checks webhook signature and its age
+func Verify(secret string, header http.Header, body []byte) error {
+	if secret == "" {
+		return errors.New("WEBHOOK_NOT_CONFIGURED")
+	}
+
+	timestamp := header.Get(HEADER_TIMESTAMP)
+
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("INVALID_SIGNATURE")
+	}
+
+	age := time.Since(time.Unix(sent, 0))
+	if age > SIGNATURE_TOLERANCE || age < -SIGNATURE_TOLERANCE {
+		return errors.New("SIGNATURE_EXPIRED")
+	}
+
+	if !hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(strings.ToLower(header.Get(HEADER_SIGNATURE)))) {
+		return errors.New("INVALID_SIGNATURE")
+	}
+
+	return nil
+}
+
+//NewReference generates deposit payment reference
+func NewReference() (string, error) {
+	b := make([]byte, 6)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "D" + strings.ToUpper(hex.EncodeToString(b)), nil
+}
+
+//Start creates member deposit with the provider
+func Start(MemberID int64, providerName string, amount decimal.Decimal) (models.Deposit, Instructions, error) {
+	db := db.GetDB()
+
+	var deposit models.Deposit
+	var instructions Instructions
+
+	if !amount.IsPositive() {
+		return deposit, instructions, errors.New("INVALID_AMOUNT")
+	}
+
+	provider, err := GetProvider(providerName)
+	if err != nil {
+		return deposit, instructions, err
+	}
+
+	//Deposit is made in member account currency
+	currency, err := account.QueryCurrency(MemberID)
+	if err != nil {
+		return deposit, instructions, err
+	}
+
+	reference, err := NewReference()
+	if err != nil {
+		return deposit, instructions, err
+	}
+
+	now := time.Now().Unix()
+
+	if err := db.Get(&deposit, "INSERT INTO Deposit (MemberID, Amount, Status, Provider, Reference, Currency, Created, Updated) VALUES ($1, $2, $3, $4, $5, $6, $7, $7) RETURNING *", MemberID, amount, STATUS_PENDING, providerName, reference, currency, now); err != nil {
+		return deposit, instructions, err
+	}
+
+	instructions, TransactionID, err := provider.Create(deposit)
+	if err != nil {
+		tx := db.MustBegin()
+		tx.MustExec("UPDATE Deposit SET Status=$1, Reason=$2, Updated=$3 WHERE DepositID=$4", STATUS_FAILED, err.Error(), time.Now().Unix(), deposit.DepositID)
+		tx.Commit()
+		return deposit, instructions, err
+	}
+
+	if TransactionID != "" {
+		tx := db.MustBegin()
+		tx.MustExec("UPDATE Deposit SET TransactionID=$1 WHERE DepositID=$2", TransactionID, deposit.DepositID)
+		tx.Commit()
+
+		deposit.TransactionID = TransactionID
+	}
+
+	return deposit, instructions, nil
+}
+
+//Handle applies webhook event to its deposit. Repeated events of a transaction are ignored, so are events of a deposit the operator already settled the same way
+func Handle(providerName string, event Event, payload []byte) error {
+	db := db.GetDB()
+
+	if event.Status == "" {
+		return nil
+	}
+
+	if event.Status != STATUS_CONFIRMED && event.Status != STATUS_FAILED {
+		return errors.New("INVALID_STATUS")
+	}
+
+	if event.TransactionID == "" {
+		return errors.New("INVALID_TRANSACTION")
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	var PaymentEventID int64
+
+	if err := tx.Get(&PaymentEventID, "INSERT INTO PaymentEvent (Provider, TransactionID, Status, Payload, Timestamp) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (Provider, TransactionID, Status) DO NOTHING RETURNING PaymentEventID", providerName, event.TransactionID, event.Status, string(payload), time.Now().Unix()); err != nil {
+		tx.Rollback()
+		//Event was processed already
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	var deposit models.Deposit
+
+	if err := tx.Get(&deposit, "SELECT * FROM Deposit WHERE Provider=$1 AND ((Reference<>'' AND Reference=$2) OR (TransactionID<>'' AND TransactionID=$3)) FOR UPDATE", providerName, event.Reference, event.TransactionID); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return errors.New("NO_DEPOSIT_RECORD")
+		}
+		return err
+	}
+
+	tx.MustExec("UPDATE PaymentEvent SET DepositID=$1 WHERE PaymentEventID=$2", deposit.DepositID, PaymentEventID)
+
+	if deposit.TransactionID == "" {
+		tx.MustExec("UPDATE Deposit SET TransactionID=$1 WHERE DepositID=$2", event.TransactionID, deposit.DepositID)
+	}
+
+	if event.Status == STATUS_FAILED {
+		if settled(deposit, event.Status) {
+			return tx.Commit()
+		}
+
+		if err := Fail(tx, deposit, event.Reason); err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}
+
+	if !event.Amount.Equal(deposit.Amount.Decimal) || !strings.EqualFold(event.Currency, deposit.Currency) {
+		tx.Rollback()
+		return errors.New("AMOUNT_MISMATCH")
+	}
+
+	if settled(deposit, event.Status) {
+		return tx.Commit()
+	}
+
+	if err := Confirm(tx, deposit); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+//settled checks if deposit already reached the status of the event, e.g. operator credited it before provider webhook arrived
+func settled(deposit models.Deposit, status string) bool {
+	switch status {
+	case STATUS_CONFIRMED:
+		return deposit.Status == STATUS_CONFIRMED || deposit.Status == STATUS_CREDITED
+	case STATUS_FAILED:
+		return deposit.Status == STATUS_FAILED
+	}
+
+	return false
+}
+
+//Confirm marks deposit as paid and credits member balance unless the amount requires operator approval
+func Confirm(tx *sqlx.Tx, deposit models.Deposit) error {
+	if deposit.Status != STATUS_PENDING {
+		return errors.New("DEPOSIT_NOT_PENDING")
+	}
+
+	var limit decimal.Decimal
+
+	if err := tx.Get(&limit, "SELECT DepositAutoCreditLimit FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		return err
+	}
+
+	tx.MustExec("UPDATE Deposit SET Status=$1, Updated=$2 WHERE DepositID=$3", STATUS_CONFIRMED, time.Now().Unix(), deposit.DepositID)
+	deposit.Status = STATUS_CONFIRMED
+
+	if deposit.Amount.Decimal.GreaterThan(limit) {
+		notify(tx, deposit)
+		return nil
+	}
+
+	return Credit(tx, deposit)
+}
+
+//Credit adds deposit to member balance
+func Credit(tx *sqlx.Tx, deposit models.Deposit) error {
+	if deposit.Status != STATUS_PENDING && deposit.Status != STATUS_CONFIRMED {
+		return errors.New("DEPOSIT_NOT_CREDITABLE")
+	}
+
+	if err := ledger.Transfer(ledger.ENTRY_DEPOSIT, "deposit", deposit.DepositID, ledger.Transit(deposit.Currency), ledger.Member(deposit.MemberID, deposit.Currency), deposit.Amount.Decimal).Post(tx); err != nil {
+		return err
+	}
+
+	tx.MustExec("UPDATE Deposit SET Status=$1, Updated=$2 WHERE DepositID=$3", STATUS_CREDITED, time.Now().Unix(), deposit.DepositID)
+	tx.MustExec("INSERT INTO History (MemberID, Type, Status, Currency, Profit, ProfitAbs) VALUES ($1, $2, $3, $4, $5, $6)", deposit.MemberID, "balance", "deposit", deposit.Currency, deposit.Amount.Decimal, deposit.Amount.Decimal.Abs())
+
+	deposit.Status = STATUS_CREDITED
+	notify(tx, deposit)
+
+	return nil
+}
+
+//Fail marks deposit as failed
+func Fail(tx *sqlx.Tx, deposit models.Deposit, reason string) error {
+	if deposit.Status != STATUS_PENDING && deposit.Status != STATUS_CONFIRMED {
+		return errors.New("DEPOSIT_NOT_PENDING")
+	}
+
+	tx.MustExec("UPDATE Deposit SET Status=$1, Reason=$2, Updated=$3 WHERE DepositID=$4", STATUS_FAILED, reason, time.Now().Unix(), deposit.DepositID)
+
+	deposit.Status = STATUS_FAILED
+	notify(tx, deposit)
+
+	return nil
+}
+
+//Notify member about deposit status via info channel
+func notify(tx *sqlx.Tx, deposit models.Deposit) {
+	outbox.Info(model.Info{
+		MemberID: int(deposit.MemberID),
+		Event:    "deposit",
+		ID:       int(deposit.DepositID),
+		Value:    deposit.Status,
+	}).Record(tx)
+}
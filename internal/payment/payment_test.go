@@ -0,0 +1,314 @@
+package payment
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+const testSecret = "secret"
+
+//state - in-memory tables of the queries Handle runs
+type state struct {
+	events   map[string]bool //Provider, TransactionID and Status of processed events
+	deposits []storeDeposit
+	credits  int //Ledger entries posted
+}
+
+type storeDeposit struct {
+	DepositID     int64
+	MemberID      int64
+	Amount        string
+	Status        string
+	Reference     string
+	TransactionID string
+	Currency      string
+}
+
+func (s state) copy() state {
+	events := map[string]bool{}
+	for key := range s.events {
+		events[key] = true
+	}
+
+	return state{events: events, deposits: append([]storeDeposit{}, s.deposits...), credits: s.credits}
+}
+
+//store serves the state to fake database driver, rolled back transaction restores it
+type store struct {
+	mu sync.Mutex
+	state
+}
+
+var current *store
+
+func init() {
+	sql.Register("paymenttest", storeDriver{})
+}
+
+//newStore replaces database with store of the deposit, credited automatically up to 1000
+func newStore(t *testing.T, deposit storeDeposit) *store {
+	current = &store{state: state{events: map[string]bool{}, deposits: []storeDeposit{deposit}}}
+
+	conn, err := sql.Open("paymenttest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db.DB = sqlx.NewDb(conn, "pgx")
+
+	return current
+}
+
+func (s *store) query(query string, args []driver.Value) ([]string, [][]driver.Value, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "INSERT INTO PaymentEvent "):
+		key := fmt.Sprint(args[0], args[1], args[2])
+		if s.events[key] {
+			return []string{"paymenteventid"}, nil, nil
+		}
+		s.events[key] = true
+		return []string{"paymenteventid"}, [][]driver.Value{{int64(len(s.events))}}, nil
+	case strings.HasPrefix(query, "SELECT * FROM Deposit "):
+		for _, item := range s.deposits {
+			if (item.Reference != "" && item.Reference == args[1]) || (item.TransactionID != "" && item.TransactionID == args[2]) {
+				return []string{"depositid", "memberid", "amount", "status", "provider", "reference", "transactionid", "currency", "reason", "created", "updated"}, [][]driver.Value{{item.DepositID, item.MemberID, item.Amount, item.Status, PROVIDER_MOCK, item.Reference, item.TransactionID, item.Currency, "", int64(0), int64(0)}}, nil
+			}
+		}
+		return []string{"depositid"}, nil, nil
+	case strings.HasPrefix(query, "SELECT DepositAutoCreditLimit "):
+		return []string{"depositautocreditlimit"}, [][]driver.Value{{"1000"}}, nil
+	case strings.HasPrefix(query, "INSERT INTO LedgerEntry "):
+		s.credits++
+		return []string{"ledgerentryid"}, [][]driver.Value{{int64(s.credits)}}, nil
+	case strings.HasPrefix(query, "UPDATE LedgerAccount "):
+		return []string{"ledgeraccountid", "type", "memberid", "currency", "balance", "updated"}, [][]driver.Value{{int64(1), args[2], args[3], args[4], "0", int64(0)}}, nil
+	case strings.HasPrefix(query, "SELECT CurrencyID "):
+		return []string{"currencyid"}, [][]driver.Value{{int64(1)}}, nil
+	}
+
+	return nil, nil, errors.New("unexpected query: " + query)
+}
+
+func (s *store) exec(query string, args []driver.Value) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update := func(DepositID driver.Value, apply func(deposit *storeDeposit)) {
+		for i := range s.deposits {
+			if s.deposits[i].DepositID == DepositID {
+				apply(&s.deposits[i])
+			}
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(query, "UPDATE Deposit SET Status=$1, Updated=$2 WHERE DepositID=$3"):
+		update(args[2], func(deposit *storeDeposit) { deposit.Status = args[0].(string) })
+	case strings.HasPrefix(query, "UPDATE Deposit SET Status=$1, Reason=$2, Updated=$3 WHERE DepositID=$4"):
+		update(args[3], func(deposit *storeDeposit) { deposit.Status = args[0].(string) })
+	case strings.HasPrefix(query, "UPDATE Deposit SET TransactionID=$1 WHERE DepositID=$2"):
+		update(args[1], func(deposit *storeDeposit) { deposit.TransactionID = args[0].(string) })
+	case strings.HasPrefix(query, "UPDATE PaymentEvent "),
+		strings.HasPrefix(query, "INSERT INTO LedgerAccount "),
+		strings.HasPrefix(query, "INSERT INTO LedgerPosting "),
+		strings.HasPrefix(query, "UPDATE Balance "),
+		strings.HasPrefix(query, "UPDATE Member "),
+		strings.HasPrefix(query, "INSERT INTO History "),
+		strings.HasPrefix(query, "INSERT INTO Outbox "):
+	default:
+		return errors.New("unexpected query: " + query)
+	}
+
+	return nil
+}
+
+type storeDriver struct{}
+
+func (storeDriver) Open(string) (driver.Conn, error) { return storeConn{}, nil }
+
+type storeConn struct{}
+
+func (storeConn) Prepare(query string) (driver.Stmt, error) { return storeStmt{query}, nil }
+func (storeConn) Close() error                              { return nil }
+
+func (storeConn) Begin() (driver.Tx, error) {
+	current.mu.Lock()
+	defer current.mu.Unlock()
+
+	return &storeTx{snapshot: current.state.copy()}, nil
+}
+
+type storeTx struct {
+	snapshot state
+}
+
+func (tx *storeTx) Commit() error { return nil }
+
+func (tx *storeTx) Rollback() error {
+	current.mu.Lock()
+	defer current.mu.Unlock()
+
+	current.state = tx.snapshot
+
+	return nil
+}
+
+type storeStmt struct {
+	query string
+}
+
+func (stmt storeStmt) Close() error  { return nil }
+func (stmt storeStmt) NumInput() int { return -1 }
+
+func (stmt storeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if err := current.exec(stmt.query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (stmt storeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	columns, rows, err := current.query(stmt.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &storeRows{columns: columns, rows: rows}, nil
+}
+
+type storeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (rows *storeRows) Columns() []string { return rows.columns }
+func (rows *storeRows) Close() error      { return nil }
+
+func (rows *storeRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+
+	return nil
+}
+
+//signed returns webhook headers of body signed with secret at time
+func signed(secret string, at time.Time, body []byte) http.Header {
+	header := http.Header{}
+	timestamp := fmt.Sprint(at.Unix())
+	header.Set(HEADER_TIMESTAMP, timestamp)
+	header.Set(HEADER_SIGNATURE, Sign(secret, timestamp, body))
+
+	return header
+}
+
+func TestParseSignature(t *testing.T) {
+	mock := Mock{Secret: testSecret}
+	body := []byte(`{"TransactionID":"mock-1","Reference":"D1","Status":"confirmed","Amount":"100","Currency":"USD"}`)
+
+	event, err := mock.Parse(signed(testSecret, time.Now(), body), body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event.TransactionID != "mock-1" || event.Status != STATUS_CONFIRMED || !event.Amount.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("unexpected event %+v", event)
+	}
+
+	missing := http.Header{}
+	missing.Set(HEADER_SIGNATURE, Sign(testSecret, "", body))
+
+	for _, test := range []struct {
+		name   string
+		mock   Mock
+		header http.Header
+		body   []byte
+		err    string
+	}{
+		{"wrong secret", mock, signed("other", time.Now(), body), body, "INVALID_SIGNATURE"},
+		{"changed body", mock, signed(testSecret, time.Now(), body), []byte(`{"TransactionID":"mock-1","Status":"confirmed","Amount":"1000"}`), "INVALID_SIGNATURE"},
+		{"no timestamp", mock, missing, body, "INVALID_SIGNATURE"},
+		{"expired", mock, signed(testSecret, time.Now().Add(-SIGNATURE_TOLERANCE-time.Minute), body), body, "SIGNATURE_EXPIRED"},
+		{"from the future", mock, signed(testSecret, time.Now().Add(SIGNATURE_TOLERANCE+time.Minute), body), body, "SIGNATURE_EXPIRED"},
+		{"no secret", Mock{}, signed("", time.Now(), body), body, "WEBHOOK_NOT_CONFIGURED"},
+	} {
+		if _, err := test.mock.Parse(test.header, test.body); err == nil || err.Error() != test.err {
+			t.Errorf("%s: got %v, want %s", test.name, err, test.err)
+		}
+	}
+}
+
+func TestHandleRepeatedTransaction(t *testing.T) {
+	s := newStore(t, storeDeposit{DepositID: 1, MemberID: 7, Amount: "100", Status: STATUS_PENDING, Reference: "D1", Currency: "USD"})
+	mock := Mock{Secret: testSecret}
+	deposit := models.Deposit{DepositID: 1, Reference: "D1", Currency: "USD"}
+
+	for i := 0; i < 2; i++ {
+		if err := mock.Complete(deposit, STATUS_CONFIRMED, decimal.NewFromInt(100)); err != nil {
+			t.Fatalf("event %d: %v", i, err)
+		}
+	}
+
+	if s.deposits[0].Status != STATUS_CREDITED || s.deposits[0].TransactionID != "mock-1" {
+		t.Fatalf("unexpected deposit %+v", s.deposits[0])
+	}
+
+	if s.credits != 1 {
+		t.Fatalf("deposit credited %d times", s.credits)
+	}
+}
+
+func TestHandleSettledByOperator(t *testing.T) {
+	s := newStore(t, storeDeposit{DepositID: 1, MemberID: 7, Amount: "100", Status: STATUS_CREDITED, Reference: "D1", Currency: "USD"})
+	mock := Mock{Secret: testSecret}
+	deposit := models.Deposit{DepositID: 1, Reference: "D1", Currency: "USD"}
+
+	//Provider confirms deposit the operator credited manually
+	if err := mock.Complete(deposit, STATUS_CONFIRMED, decimal.NewFromInt(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.deposits[0].Status != STATUS_CREDITED || s.credits != 0 {
+		t.Fatalf("deposit %+v credited %d times", s.deposits[0], s.credits)
+	}
+
+	//Failure of a credited deposit needs the operator
+	if err := mock.Complete(deposit, STATUS_FAILED, decimal.NewFromInt(100)); err == nil || err.Error() != "DEPOSIT_NOT_PENDING" {
+		t.Fatalf("got %v, want DEPOSIT_NOT_PENDING", err)
+	}
+
+	if s.deposits[0].Status != STATUS_CREDITED {
+		t.Fatalf("status %s, want %s", s.deposits[0].Status, STATUS_CREDITED)
+	}
+}
+
+func TestHandleAmountMismatch(t *testing.T) {
+	s := newStore(t, storeDeposit{DepositID: 1, MemberID: 7, Amount: "100", Status: STATUS_PENDING, Reference: "D1", Currency: "USD"})
+	mock := Mock{Secret: testSecret}
+
+	if err := mock.Complete(models.Deposit{DepositID: 1, Reference: "D1", Currency: "USD"}, STATUS_CONFIRMED, decimal.NewFromInt(90)); err == nil || err.Error() != "AMOUNT_MISMATCH" {
+		t.Fatalf("got %v, want AMOUNT_MISMATCH", err)
+	}
+
+	if s.deposits[0].Status != STATUS_PENDING || s.credits != 0 {
+		t.Fatalf("deposit %+v credited %d times", s.deposits[0], s.credits)
+	}
+}
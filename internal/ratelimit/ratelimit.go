@@ -0,0 +1,174 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ianidi/exchange-server/internal/jwt"
+	"github.com/ianidi/exchange-server/internal/metrics"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/spf13/viper"
+)
+
+const KEY = "ratelimit:" //ratelimit:<group>:ip:<IP>, ratelimit:<group>:member:<MemberID> - token bucket
+
+var rejected = metrics.NewCounter("ratelimit_rejected_total", "Requests rejected by rate limit.", "group")
+
+//Limit - token bucket: Burst requests at once, refilled at Burst per Period
+type Limit struct {
+	Burst  int64
+	Period time.Duration
+}
+
+//Result - state of the bucket after request
+type Result struct {
+	Allowed    bool
+	Remaining  int64
+	RetryAfter time.Duration //Time until the next request is allowed
+}
+
+//bucket refills tokens since the last request and takes one, the key expires once the bucket is full again
+//KEYS[1] - bucket, ARGV[1] - tokens per millisecond, ARGV[2] - burst, ARGV[3] - now (ms)
+var bucket = radix.NewEvalScript(1, `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate)
+end
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate))
+return {allowed, math.floor(tokens), wait}
+`)
+
+//ParseLimit parses limit of settings, e.g. 60/1m - 60 requests at once, one more each second
+func ParseLimit(value string) (Limit, error) {
+	var limit Limit
+
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	if len(parts) != 2 {
+		return limit, errors.New("INVALID_RATE_LIMIT")
+	}
+
+	burst, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || burst <= 0 {
+		return limit, errors.New("INVALID_RATE_LIMIT")
+	}
+
+	period, err := time.ParseDuration(parts[1])
+	if err != nil || period <= 0 {
+		return limit, errors.New("INVALID_RATE_LIMIT")
+	}
+
+	limit.Burst = burst
+	limit.Period = period
+
+	return limit, nil
+}
+
+//GetLimit returns limit of route group from settings (ratelimit_<group>), empty value disables the limit
+func GetLimit(group string) (Limit, bool) {
+	value := viper.GetString("ratelimit_" + group)
+	if value == "" {
+		return Limit{}, false
+	}
+
+	limit, err := ParseLimit(value)
+	if err != nil {
+		log.Println("ratelimit", group, err)
+		return Limit{}, false
+	}
+
+	return limit, true
+}
+
+//Allow takes a token from the bucket of key
+func Allow(key string, limit Limit) (Result, error) {
+	var result Result
+
+	rate := float64(limit.Burst) / float64(limit.Period.Milliseconds())
+
+	var reply []int64
+
+	if err := redis.GetRedis().Do(bucket.Cmd(&reply, KEY+key, strconv.FormatFloat(rate, 'f', -1, 64), strconv.FormatInt(limit.Burst, 10), strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10))); err != nil {
+		return result, err
+	}
+
+	if len(reply) != 3 {
+		return result, errors.New("INVALID_RATE_LIMIT_REPLY")
+	}
+
+	result.Allowed = reply[0] == 1
+	result.Remaining = reply[1]
+	result.RetryAfter = time.Duration(reply[2]) * time.Millisecond
+
+	return result, nil
+}
+
+//Middleware limits requests of route group per IP address and, once member is known, per member as well.
+//Requests are allowed if Redis is unavailable, so the limiter can't take the API down
+func Middleware(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, ok := GetLimit(group)
+		if !ok {
+			return
+		}
+
+		keys := []string{group + ":ip:" + c.ClientIP()}
+		if MemberID, ok := c.Get(jwt.MemberKey); ok {
+			keys = append(keys, fmt.Sprintf("%s:member:%v", group, MemberID))
+		}
+
+		//Request is allowed if every bucket has a token
+		result := Result{Allowed: true, Remaining: limit.Burst}
+
+		for _, key := range keys {
+			bucket, err := Allow(key, limit)
+			if err != nil {
+				log.Println("ratelimit", group, err)
+				return
+			}
+
+			if bucket.Remaining < result.Remaining {
+				result.Remaining = bucket.Remaining
+			}
+
+			if !bucket.Allowed {
+				result.Allowed = false
+				result.RetryAfter = bucket.RetryAfter
+				break
+			}
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.FormatInt(limit.Burst, 10))
+		c.Header("X-RateLimit-Remaining", strconv.FormatInt(result.Remaining, 10))
+
+		if !result.Allowed {
+			rejected.Inc(group)
+
+			c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(result.RetryAfter.Seconds())), 10))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"status": false,
+				"error":  "RATE_LIMITED",
+			})
+			return
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package rbac
+
+//Permissions of back office (operator API) and CRM (GraphQL Manager* fields)
+const (
+	MEMBERS_READ   = "members.read"
+	MEMBERS_CREATE = "members.create"
+	MEMBERS_UPDATE = "members.update"
+	MEMBERS_DELETE = "members.delete"
+
+	ASSETS_READ   = "assets.read"
+	ASSETS_UPDATE = "assets.update"
+
+	TRADES_READ = "trades.read"
+
+	WITHDRAWALS_READ    = "withdrawals.read"
+	WITHDRAWALS_APPROVE = "withdrawals.approve"
+
+	DEPOSITS_READ    = "deposits.read"
+	DEPOSITS_APPROVE = "deposits.approve"
+
+	TRANSFERS_READ = "transfers.read"
+
+	DOCUMENTS_READ   = "documents.read"
+	DOCUMENTS_CREATE = "documents.create"
+
+	SETTINGS_READ   = "settings.read"
+	SETTINGS_UPDATE = "settings.update"
+
+	SPREAD_READ   = "spread.read"
+	SPREAD_UPDATE = "spread.update"
+
+	FEEDS_READ   = "feeds.read"
+	FEEDS_UPDATE = "feeds.update"
+
+	TASKS_READ   = "tasks.read"
+	TASKS_UPDATE = "tasks.update"
+
+	JOBS_READ   = "jobs.read"
+	JOBS_UPDATE = "jobs.update"
+
+	LEDGER_READ = "ledger.read"
+
+	NEWS_READ   = "news.read"
+	NEWS_UPDATE = "news.update"
+
+	AUDIT_READ = "audit.read"
+
+	SECURITY_READ   = "security.read"
+	SECURITY_UPDATE = "security.update"
+
+	ROLES_READ   = "roles.read"
+	ROLES_UPDATE = "roles.update"
+
+	LEADS_READ   = "leads.read"
+	LEADS_UPDATE = "leads.update"
+	LEADS_ASSIGN = "leads.assign"
+
+	OFFERS_READ   = "offers.read"
+	OFFERS_UPDATE = "offers.update"
+
+	MANAGERS_READ   = "managers.read"
+	MANAGERS_UPDATE = "managers.update"
+)
+
+//Default roles created by migration
+const (
+	ROLE_OPERATOR  = "operator"
+	ROLE_MANAGER   = "manager"
+	ROLE_CRM_ADMIN = "admin" //Member.ManagerRole admin, manager role with managers.update
+)
+
+//Permission - description of permission for role editor
+type Permission struct {
+	Permission  string
+	Description string
+}
+
+//Permissions - all known permissions
+var Permissions = []Permission{
+	{MEMBERS_READ, "View members"},
+	{MEMBERS_CREATE, "Create members"},
+	{MEMBERS_UPDATE, "Update members, groups and transfer permissions"},
+	{MEMBERS_DELETE, "Delete members"},
+	{ASSETS_READ, "View assets and ticks"},
+	{ASSETS_UPDATE, "Update, halt and override assets"},
+	{TRADES_READ, "View trades"},
+	{WITHDRAWALS_READ, "View withdrawals"},
+	{WITHDRAWALS_APPROVE, "Approve and reject withdrawals"},
+	{DEPOSITS_READ, "View deposits"},
+	{DEPOSITS_APPROVE, "Approve and reject deposits"},
+	{TRANSFERS_READ, "View balance transfers"},
+	{DOCUMENTS_READ, "View member documents"},
+	{DOCUMENTS_CREATE, "Generate statements"},
+	{SETTINGS_READ, "View settings and retention policy"},
+	{SETTINGS_UPDATE, "Update settings, limits and retention policy"},
+	{SPREAD_READ, "View spread sessions and member groups"},
+	{SPREAD_UPDATE, "Update spread sessions and member groups"},
+	{FEEDS_READ, "View feed health"},
+	{FEEDS_UPDATE, "Update feed markets and alerts"},
+	{TASKS_READ, "View background tasks"},
+	{TASKS_UPDATE, "Retry background tasks"},
+	{JOBS_READ, "View scheduled jobs"},
+	{JOBS_UPDATE, "Update and trigger scheduled jobs"},
+	{LEDGER_READ, "View ledger and reconciliation"},
+	{NEWS_READ, "View news"},
+	{NEWS_UPDATE, "Publish news"},
+	{AUDIT_READ, "View audit log"},
+	{SECURITY_READ, "View security events and account lockouts"},
+	{SECURITY_UPDATE, "Unlock accounts"},
+	{ROLES_READ, "View roles"},
+	{ROLES_UPDATE, "Manage roles and assign them to members"},
+	{LEADS_READ, "View leads, comments, checklists, appointments and campaigns"},
+	{LEADS_UPDATE, "Update leads, comments, checklists, appointments and campaigns"},
+	{LEADS_ASSIGN, "Assign leads to managers"},
+	{OFFERS_READ, "View offers, investments, deals, contracts and invoices"},
+	{OFFERS_UPDATE, "Update offers, investments, deals, contracts and invoices"},
+	{MANAGERS_READ, "View managers"},
+	{MANAGERS_UPDATE, "Assign and remove managers"},
+}
+
+//Valid checks that permission is known
+func Valid(permission string) bool {
+	for _, row := range Permissions {
+		if row.Permission == permission {
+			return true
+		}
+	}
+	return false
+}
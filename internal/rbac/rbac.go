@@ -0,0 +1,176 @@
+package rbac
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+//ROLE_ADMIN - Member.Role of admins, they have every permission without roles
+const ROLE_ADMIN = 3
+
+//Role - role with its permissions
+type Role struct {
+	models.Role
+	Permissions []string
+}
+
+//QueryPermissions returns permissions of member combined from assigned roles
+func QueryPermissions(MemberID int64) ([]string, error) {
+	db := db.GetDB()
+
+	var Role int64
+
+	if err := db.Get(&Role, "SELECT Role FROM Member WHERE MemberID=$1", MemberID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("NO_MEMBER_RECORD")
+		}
+		return nil, err
+	}
+
+	permissions := []string{}
+
+	if Role == ROLE_ADMIN {
+		for _, row := range Permissions {
+			permissions = append(permissions, row.Permission)
+		}
+		return permissions, nil
+	}
+
+	if err := db.Select(&permissions, "SELECT DISTINCT RolePermission.Permission FROM MemberRole JOIN RolePermission ON RolePermission.RoleID=MemberRole.RoleID WHERE MemberRole.MemberID=$1 ORDER BY RolePermission.Permission ASC", MemberID); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+//Check returns ACCESS_DENIED if member doesn't have the permission
+func Check(MemberID int64, permission string) error {
+	permissions, err := QueryPermissions(MemberID)
+	if err != nil {
+		return err
+	}
+
+	if !Covers(permissions, []string{permission}) {
+		return errors.New("ACCESS_DENIED")
+	}
+
+	return nil
+}
+
+//Covers checks that granted permissions include all required ones
+func Covers(granted []string, required []string) bool {
+	for _, permission := range required {
+		found := false
+		for _, row := range granted {
+			if row == permission {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+//QueryRoles returns all roles with permissions
+func QueryRoles() ([]Role, error) {
+	db := db.GetDB()
+
+	var roles []models.Role
+
+	if err := db.Select(&roles, "SELECT * FROM Role ORDER BY RoleID ASC"); err != nil {
+		return nil, err
+	}
+
+	var permissions []models.RolePermission
+
+	if err := db.Select(&permissions, "SELECT * FROM RolePermission ORDER BY Permission ASC"); err != nil {
+		return nil, err
+	}
+
+	result := []Role{}
+
+	for _, role := range roles {
+		row := Role{Role: role, Permissions: []string{}}
+
+		for _, permission := range permissions {
+			if permission.RoleID == role.RoleID {
+				row.Permissions = append(row.Permissions, permission.Permission)
+			}
+		}
+
+		result = append(result, row)
+	}
+
+	return result, nil
+}
+
+//QueryRole returns role with permissions
+func QueryRole(RoleID int64) (Role, error) {
+	db := db.GetDB()
+
+	var role Role
+
+	if err := db.Get(&role.Role, "SELECT * FROM Role WHERE RoleID=$1", RoleID); err != nil {
+		if err == sql.ErrNoRows {
+			return role, errors.New("NO_ROLE_RECORD")
+		}
+		return role, err
+	}
+
+	role.Permissions = []string{}
+
+	if err := db.Select(&role.Permissions, "SELECT Permission FROM RolePermission WHERE RoleID=$1 ORDER BY Permission ASC", RoleID); err != nil {
+		return role, err
+	}
+
+	return role, nil
+}
+
+//QueryMemberRoles returns roles assigned to member
+func QueryMemberRoles(MemberID int64) ([]models.Role, error) {
+	db := db.GetDB()
+
+	roles := []models.Role{}
+
+	if err := db.Select(&roles, "SELECT Role.* FROM Role JOIN MemberRole ON MemberRole.RoleID=Role.RoleID WHERE MemberRole.MemberID=$1 ORDER BY Role.RoleID ASC", MemberID); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+//SetPermissions replaces permissions of role
+func SetPermissions(tx *sqlx.Tx, RoleID int64, permissions []string) {
+	tx.MustExec("DELETE FROM RolePermission WHERE RoleID=$1", RoleID)
+
+	for _, permission := range permissions {
+		tx.MustExec("INSERT INTO RolePermission (RoleID, Permission) VALUES ($1, $2) ON CONFLICT DO NOTHING", RoleID, permission)
+	}
+}
+
+//SetMemberRoles replaces roles assigned to member
+func SetMemberRoles(tx *sqlx.Tx, MemberID int64, RoleIDs []int64) {
+	tx.MustExec("DELETE FROM MemberRole WHERE MemberID=$1", MemberID)
+
+	for _, RoleID := range RoleIDs {
+		tx.MustExec("INSERT INTO MemberRole (MemberID, RoleID, Created) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", MemberID, RoleID, time.Now().Unix())
+	}
+}
+
+//Assign adds role to member by title, used to keep default roles in sync with Member.Role and Member.ManagerRole
+func Assign(tx *sqlx.Tx, MemberID int64, Title string) {
+	tx.MustExec("INSERT INTO MemberRole (MemberID, RoleID, Created) SELECT $1, RoleID, $2 FROM Role WHERE Title=$3 ON CONFLICT DO NOTHING", MemberID, time.Now().Unix(), Title)
+}
+
+//Unassign removes role of member by title
+func Unassign(tx *sqlx.Tx, MemberID int64, Title string) {
+	tx.MustExec("DELETE FROM MemberRole WHERE MemberID=$1 AND RoleID IN (SELECT RoleID FROM Role WHERE Title=$2)", MemberID, Title)
+}
@@ -1,11 +1,11 @@
 package redis
 
 import (
-	"fmt"
+	"log"
 	"time"
 
+	"github.com/ianidi/exchange-server/internal/config"
 	"github.com/mediocregopher/radix/v3"
-	"github.com/spf13/viper"
 )
 
 var redisClient *radix.Pool
@@ -15,6 +15,11 @@ func GetRedis() *radix.Pool {
 	return redisClient
 }
 
+//SetRedis replaces connection pool, tests use pool of radix.Stub connections
+func SetRedis(pool *radix.Pool) {
+	redisClient = pool
+}
+
 func InitRedis() {
 	// init redis connection pool
 	initPool()
@@ -26,33 +31,32 @@ func InitRedis() {
 func initSubscription() {
 	var err error
 
-	customConnFunc := radix.PersistentPubSubConnFunc(func(network, addr string) (radix.Conn, error) {
-		return radix.Dial(network, addr,
-			radix.DialTimeout(10*time.Second),
-			radix.DialAuthPass(viper.GetString("redis_password")),
-		)
-	})
+	customConnFunc := radix.PersistentPubSubConnFunc(dial)
 
-	psclient, err = radix.PersistentPubSubWithOpts("tcp", viper.GetString("redis_host"), customConnFunc)
+	psclient, err = radix.PersistentPubSubWithOpts("tcp", config.Get().Redis.Host, customConnFunc)
 	if err != nil {
-		fmt.Println("radix subscription error", err)
+		log.Println("radix subscription error", err)
 	}
 }
 
 func initPool() {
 	var err error
 
-	DefaultConnFunc := func(network, addr string) (radix.Conn, error) {
-		return radix.Dial(network, addr,
-			radix.DialTimeout(10*time.Second),
-			radix.DialAuthPass(viper.GetString("redis_password")),
-		)
+	redisClient, err = radix.NewPool("tcp", config.Get().Redis.Host, 10, radix.PoolConnFunc(dial))
+	if err != nil {
+		log.Println("radix error", err)
 	}
+}
 
-	redisClient, err = radix.NewPool("tcp", viper.GetString("redis_host"), 10, radix.PoolConnFunc(DefaultConnFunc))
-	if err != nil {
-		fmt.Println("radix error", err)
+//dial connects with password if it is set
+func dial(network, addr string) (radix.Conn, error) {
+	opts := []radix.DialOpt{radix.DialTimeout(10 * time.Second)}
+
+	if password := config.Get().Redis.Password; password != "" {
+		opts = append(opts, radix.DialAuthPass(password))
 	}
+
+	return radix.Dial(network, addr, opts...)
 }
 
 type Channel struct {
@@ -9,19 +9,16 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/ianidi/exchange-server/internal/config"
 	"github.com/minio/minio-go"
-	"github.com/spf13/viper"
 )
 
 func Upload_minio(filename string, file []byte) error {
-	endpoint := viper.GetString("s3_endpoint")
-	key := viper.GetString("s3_key")
-	secret := viper.GetString("s3_secret")
+	settings := config.Get().S3
 	useSSL := true
-	bucketName := "invest"
 
 	// Initialize minio client object.
-	minioClient, err := minio.New(endpoint, key, secret, useSSL)
+	minioClient, err := minio.New(settings.Endpoint, settings.Key, settings.Secret, useSSL)
 	if err != nil {
 		return err
 	}
@@ -34,7 +31,7 @@ func Upload_minio(filename string, file []byte) error {
 		return err
 	}
 
-	_, err = minioClient.FPutObject(bucketName, filename, filePath, minio.PutObjectOptions{})
+	_, err = minioClient.FPutObject(settings.Bucket, filename, filePath, minio.PutObjectOptions{})
 
 	os.Remove("/var/server/static/temp_" + filename)
 
@@ -46,15 +43,17 @@ func Upload_minio(filename string, file []byte) error {
 }
 
 func Upload(filename string, file []byte) (string, error) {
+	settings := config.Get().S3
+
 	sess := session.Must(session.NewSession(&aws.Config{
 		Region:      aws.String("ru-msk"),
-		Credentials: credentials.NewStaticCredentials(viper.GetString("s3_key"), viper.GetString("s3_secret"), ""),
-		Endpoint:    aws.String(viper.GetString("s3_endpoint")),
+		Credentials: credentials.NewStaticCredentials(settings.Key, settings.Secret, ""),
+		Endpoint:    aws.String(settings.Endpoint),
 	}))
 	svc := s3.New(sess)
 
 	params := &s3.PutObjectInput{
-		Bucket: aws.String(viper.GetString("s3_bucket")),
+		Bucket: aws.String(settings.Bucket),
 		Key:    aws.String(filename),
 		Body:   bytes.NewReader(file),
 	}
@@ -68,5 +67,5 @@ func Upload(filename string, file []byte) (string, error) {
 		return "", err
 	}
 
-	return viper.GetString("s3_cdn_url") + filename, nil
+	return settings.CDNURL + filename, nil
 }
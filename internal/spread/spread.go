@@ -0,0 +1,195 @@
+package spread
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	shopspring "github.com/jackc/pgtype/ext/shopspring-numeric"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cast"
+)
+
+const (
+	//Default period of tick history used to calculate realised volatility (seconds)
+	DEFAULT_VOLATILITY_WINDOW = 3600
+	//Min amount of ticks required to calculate realised volatility
+	MIN_VOLATILITY_TICKS = 3
+)
+
+//Policy determines effective asset spread at given time
+type Policy struct {
+	Asset     models.Asset //Asset information
+	Timestamp int64        //UNIX timestamp
+}
+
+//Spread is the effective asset spread
+type Spread struct {
+	Buy        decimal.Decimal //Effective buy spread (% or pips for Forex)
+	Sell       decimal.Decimal //Effective sell spread (% or pips for Forex)
+	Volatility decimal.Decimal //Realised volatility (%)
+	Multiplier decimal.Decimal //Total multiplier applied to asset base spread
+}
+
+//Determine effective spread: base asset spread widened by realised volatility and session schedule
+func (policy Policy) Determine() (Spread, error) {
+	var spread Spread
+	var err error
+
+	spread.Volatility, err = policy.QueryVolatility()
+	if err != nil {
+		return spread, err
+	}
+
+	session, err := policy.QuerySessionMultiplier()
+	if err != nil {
+		return spread, err
+	}
+
+	//Multiplier = (1 + VolatilityFactor * volatility) * session multiplier
+	spread.Multiplier = decimal.NewFromInt(1).Add(policy.Asset.VolatilityFactor.Decimal.Mul(spread.Volatility)).Mul(session)
+
+	//Limit spread widening
+	if !policy.Asset.MaxSpreadMultiplier.Decimal.IsZero() && spread.Multiplier.GreaterThan(policy.Asset.MaxSpreadMultiplier.Decimal) {
+		spread.Multiplier = policy.Asset.MaxSpreadMultiplier.Decimal
+	}
+
+	spread.Buy = policy.Asset.BuySpread.Decimal.Mul(spread.Multiplier)
+	spread.Sell = policy.Asset.SellSpread.Decimal.Mul(spread.Multiplier)
+
+	return spread, nil
+}
+
+//Realised volatility (%) of asset rate: root mean square of tick to tick returns within volatility window
+func (policy Policy) QueryVolatility() (decimal.Decimal, error) {
+	db := db.GetDB()
+
+	volatility := decimal.Zero
+
+	//Volatility doesn't affect spread of this asset
+	if policy.Asset.VolatilityFactor.Decimal.IsZero() {
+		return volatility, nil
+	}
+
+	window := policy.Asset.VolatilityWindow
+	if window == 0 {
+		window = DEFAULT_VOLATILITY_WINDOW
+	}
+
+	var rates []shopspring.Numeric
+
+	if err := db.Select(&rates, "SELECT Rate FROM Tick WHERE AssetID=$1 AND Timestamp>$2 AND Timestamp<=$3 ORDER BY Timestamp ASC", policy.Asset.AssetID, policy.Timestamp-window, policy.Timestamp); err != nil {
+		return volatility, err
+	}
+
+	if len(rates) < MIN_VOLATILITY_TICKS {
+		return volatility, nil
+	}
+
+	var sum float64
+	var count float64
+
+	for i := 1; i < len(rates); i++ {
+		previous, _ := rates[i-1].Decimal.Float64()
+		current, _ := rates[i].Decimal.Float64()
+
+		if previous == 0 {
+			continue
+		}
+
+		change := current/previous - 1
+		sum += change * change
+		count++
+	}
+
+	if count == 0 {
+		return volatility, nil
+	}
+
+	return decimal.NewFromFloat(math.Sqrt(sum/count) * 100), nil
+}
+
+//Session multiplier of asset spread at policy time. The widest active session wins
+func (policy Policy) QuerySessionMultiplier() (decimal.Decimal, error) {
+	db := db.GetDB()
+
+	multiplier := decimal.NewFromInt(1)
+
+	var sessions []models.SpreadSession
+
+	if err := db.Select(&sessions, "SELECT * FROM SpreadSession WHERE Active=$1 AND (AssetID=$2 OR AssetID=0) AND (MarketID=$3 OR MarketID=0)", true, policy.Asset.AssetID, policy.Asset.MarketID); err != nil {
+		return multiplier, err
+	}
+
+	//Session schedules are set in UTC, the server runs in local time zone
+	now := time.Unix(policy.Timestamp, 0).UTC()
+
+	for _, session := range sessions {
+		if Contains(session, now) && session.Multiplier.Decimal.GreaterThan(multiplier) {
+			multiplier = session.Multiplier.Decimal
+		}
+	}
+
+	return multiplier, nil
+}
+
+//Contains checks that the session schedule (UTC) includes given time, now is expected in UTC
+func Contains(session models.SpreadSession, now time.Time) bool {
+
+	//Session is limited to specific weekdays (0 - sunday, comma separated)
+	if session.Weekdays != "" {
+		found := false
+		for _, day := range strings.Split(session.Weekdays, ",") {
+			if cast.ToInt(strings.TrimSpace(day)) == int(now.Weekday()) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	minute := now.Hour()*60 + now.Minute()
+
+	//Session passes midnight (e.g. rollover 23:55 - 00:05)
+	if session.StartMinute > session.EndMinute {
+		return minute >= session.StartMinute || minute < session.EndMinute
+	}
+
+	return minute >= session.StartMinute && minute < session.EndMinute
+}
+
+//Apply spread to asset rate and return buy / sell rates
+func Apply(MarketID int64, rate decimal.Decimal, buySpread decimal.Decimal, sellSpread decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+
+	var rateBuy decimal.Decimal
+	var rateSell decimal.Decimal
+
+	//Forex spread has different formula (counted in pips e.g. 0.0005)
+	if MarketID == 3 {
+		rateBuy = rate.Add(buySpread)
+		rateSell = rate.Sub(sellSpread)
+	} else {
+		rateBuy = rate.Add(rate.Mul(buySpread.Div(decimal.NewFromInt(100))))
+		rateSell = rate.Sub(rate.Mul(sellSpread.Div(decimal.NewFromInt(100))))
+	}
+
+	return rateBuy, rateSell
+}
+
+//Markup widens buy / sell rates by member group markup (% of effective spread)
+func Markup(rate decimal.Decimal, rateBuy decimal.Decimal, rateSell decimal.Decimal, markup decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+
+	if markup.IsZero() {
+		return rateBuy, rateSell
+	}
+
+	multiplier := decimal.NewFromInt(1).Add(markup.Div(decimal.NewFromInt(100)))
+
+	rateBuy = rate.Add(rateBuy.Sub(rate).Mul(multiplier))
+	rateSell = rate.Sub(rate.Sub(rateSell).Mul(multiplier))
+
+	return rateBuy, rateSell
+}
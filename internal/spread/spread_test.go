@@ -0,0 +1,43 @@
+package spread
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+func TestContains(t *testing.T) {
+	//Monday to friday 13:30 - 20:00 UTC
+	session := models.SpreadSession{Weekdays: "1,2,3,4,5", StartMinute: 810, EndMinute: 1200}
+
+	//Friday 20:00 - 24:00 UTC
+	friday := models.SpreadSession{Weekdays: "5", StartMinute: 1200, EndMinute: 1440}
+
+	//Rollover 23:55 - 00:05 UTC
+	rollover := models.SpreadSession{StartMinute: 1435, EndMinute: 5}
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	for _, test := range []struct {
+		name     string
+		session  models.SpreadSession
+		now      time.Time
+		contains bool
+	}{
+		{"inside", session, time.Date(2020, 9, 1, 14, 0, 0, 0, time.UTC), true},
+		{"start", session, time.Date(2020, 9, 1, 13, 30, 0, 0, time.UTC), true},
+		{"end", session, time.Date(2020, 9, 1, 20, 0, 0, 0, time.UTC), false},
+		{"weekend", session, time.Date(2020, 9, 5, 14, 0, 0, 0, time.UTC), false},
+		{"rollover before midnight", rollover, time.Date(2020, 9, 1, 23, 58, 0, 0, time.UTC), true},
+		{"rollover after midnight", rollover, time.Date(2020, 9, 2, 0, 2, 0, 0, time.UTC), true},
+		{"rollover outside", rollover, time.Date(2020, 9, 2, 0, 5, 0, 0, time.UTC), false},
+		//Same instant as 14:00 UTC, local time of the server must be converted first
+		{"converted from local time", session, time.Date(2020, 9, 1, 17, 0, 0, 0, moscow).UTC(), true},
+		{"friday in UTC, saturday in local time", friday, time.Date(2020, 9, 5, 1, 0, 0, 0, moscow).UTC(), true},
+	} {
+		if contains := Contains(test.session, test.now); contains != test.contains {
+			t.Errorf("%s: got %v, want %v", test.name, contains, test.contains)
+		}
+	}
+}
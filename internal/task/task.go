@@ -0,0 +1,285 @@
+package task
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/jmoiron/sqlx"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+//Task status
+const (
+	STATUS_PENDING = "pending"
+	STATUS_RUNNING = "running"
+	STATUS_DONE    = "done"
+	STATUS_DEAD    = "dead" //Out of attempts, waits for operator retry
+)
+
+const (
+	MAX_ATTEMPTS  = 8
+	BACKOFF_BASE  = 10 * time.Second
+	BACKOFF_MAX   = time.Hour
+	LOCK_TIMEOUT  = 5 * time.Minute //Running task is handed to another worker if not finished by then (crashed worker)
+	POLL_INTERVAL = time.Second
+)
+
+//Task - typed task payload, stored as JSON
+type Task interface {
+	Type() string
+}
+
+//Handler performs a task from its JSON payload
+type Handler func(ctx context.Context, payload []byte) error
+
+//Redactor returns task payload without secrets (codes, links), it is shown to operators
+type Redactor func(payload []byte) (interface{}, error)
+
+var (
+	handlersMu sync.Mutex
+	handlers   = map[string]Handler{}
+	redactors  = map[string]Redactor{}
+
+	workerCtx, workerCancel = context.WithCancel(context.Background())
+	workerWG                sync.WaitGroup
+)
+
+//Register adds a handler for task type
+func Register(taskType string, handler Handler) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+
+	handlers[taskType] = handler
+}
+
+//RegisterRedactor adds a redactor for task type, payload of types without redactor isn't shown to operators
+func RegisterRedactor(taskType string, redactor Redactor) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+
+	redactors[taskType] = redactor
+}
+
+//Redact returns payload of the task without secrets, empty if its type has no redactor
+func Redact(task models.Task) (string, error) {
+	handlersMu.Lock()
+	redactor, ok := redactors[task.Type]
+	handlersMu.Unlock()
+
+	if !ok {
+		return "", nil
+	}
+
+	value, err := redactor([]byte(task.Payload))
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
+//Enqueue adds a task to the queue. Tasks with the same non-empty idempotency key are only queued once, the existing TaskID is returned.
+func Enqueue(task Task, key string) (int64, error) {
+	db := db.GetDB()
+
+	tx := db.MustBegin()
+	TaskID, err := EnqueueTx(tx, task, key)
+	if err != nil {
+		tx.Rollback()
+		return TaskID, err
+	}
+	tx.Commit()
+
+	return TaskID, nil
+}
+
+//EnqueueTx adds a task within a transaction, so it is only performed if the transaction commits
+func EnqueueTx(tx *sqlx.Tx, task Task, key string) (int64, error) {
+	var TaskID int64
+
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return TaskID, err
+	}
+
+	now := time.Now().Unix()
+
+	var IdempotencyKey sql.NullString
+	if key != "" {
+		IdempotencyKey = sql.NullString{String: key, Valid: true}
+	}
+
+	if err := tx.Get(&TaskID, "INSERT INTO Task (Type, Payload, Status, MaxAttempts, RunAt, IdempotencyKey, Timestamp, Updated) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (IdempotencyKey) DO NOTHING RETURNING TaskID", task.Type(), string(payload), STATUS_PENDING, MAX_ATTEMPTS, now, IdempotencyKey, now, now); err != nil {
+		if err != sql.ErrNoRows {
+			return TaskID, err
+		}
+
+		//Already queued with this key
+		if err := tx.Get(&TaskID, "SELECT TaskID FROM Task WHERE IdempotencyKey=$1", key); err != nil {
+			return TaskID, err
+		}
+	}
+
+	return TaskID, nil
+}
+
+//Retry queues a dead task again with a fresh set of attempts
+func Retry(TaskID int64) error {
+	db := db.GetDB()
+
+	res, err := db.Exec("UPDATE Task SET Status=$1, Attempts=$2, RunAt=$3, Updated=$3 WHERE TaskID=$4 AND Status=$5", STATUS_PENDING, 0, time.Now().Unix(), TaskID, STATUS_DEAD)
+	if err != nil {
+		return err
+	}
+
+	if count, _ := res.RowsAffected(); count == 0 {
+		return errors.New("TASK_NOT_DEAD")
+	}
+
+	return nil
+}
+
+//Start runs a pool of workers performing queued tasks
+func Start(workers int) {
+	for i := 0; i < workers; i++ {
+		workerWG.Add(1)
+		go func() {
+			defer workerWG.Done()
+			work(workerCtx)
+		}()
+	}
+}
+
+//Shutdown stops workers and waits for tasks in progress until ctx expires
+func Shutdown(ctx context.Context) error {
+	workerCancel()
+
+	done := make(chan struct{})
+	go func() {
+		workerWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func work(ctx context.Context) {
+	for {
+		task, ok, err := claim()
+		if err != nil {
+			log.Println("Task claim error", err)
+		}
+
+		if ok {
+			perform(ctx, task)
+			continue
+		}
+
+		//Queue is empty, wait for new tasks
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(POLL_INTERVAL):
+		}
+	}
+}
+
+//claim locks the next due task, tasks locked by crashed workers are claimed again after LOCK_TIMEOUT
+func claim() (models.Task, bool, error) {
+	db := db.GetDB()
+
+	var task models.Task
+
+	now := time.Now().Unix()
+
+	if err := db.Get(&task, "UPDATE Task SET Status=$1, Attempts=Attempts+1, LockedUntil=$2, Updated=$3 WHERE TaskID=(SELECT TaskID FROM Task WHERE (Status=$4 AND RunAt<=$3) OR (Status=$1 AND LockedUntil<$3) ORDER BY RunAt ASC LIMIT 1 FOR UPDATE SKIP LOCKED) RETURNING *", STATUS_RUNNING, now+int64(LOCK_TIMEOUT.Seconds()), now, STATUS_PENDING); err != nil {
+		if err == sql.ErrNoRows {
+			return task, false, nil
+		}
+		return task, false, err
+	}
+
+	return task, true, nil
+}
+
+//perform runs task handler and reschedules failed task with exponential backoff
+func perform(ctx context.Context, task models.Task) {
+	db := db.GetDB()
+
+	err := run(ctx, task)
+
+	now := time.Now()
+
+	if err == nil {
+		if _, err := db.Exec("UPDATE Task SET Status=$1, LastError=$2, Updated=$3 WHERE TaskID=$4", STATUS_DONE, "", now.Unix(), task.TaskID); err != nil {
+			log.Println("Task update error", task.TaskID, err)
+		}
+		return
+	}
+
+	log.Println("Task error", task.TaskID, task.Type, task.Attempts, err)
+
+	status := STATUS_PENDING
+	if task.Attempts >= task.MaxAttempts {
+		status = STATUS_DEAD
+	}
+
+	if _, err := db.Exec("UPDATE Task SET Status=$1, RunAt=$2, LastError=$3, Updated=$4 WHERE TaskID=$5", status, now.Add(Backoff(task.Attempts)).Unix(), err.Error(), now.Unix(), task.TaskID); err != nil {
+		log.Println("Task update error", task.TaskID, err)
+	}
+}
+
+//run calls task handler, panic is recovered and returned as error
+func run(ctx context.Context, task models.Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("PANIC: %v", r)
+			log.Println("Task panic", task.TaskID, r, string(debug.Stack()))
+		}
+	}()
+
+	handlersMu.Lock()
+	handler, ok := handlers[task.Type]
+	handlersMu.Unlock()
+
+	if !ok {
+		return errors.New("TASK_TYPE_NOT_REGISTERED")
+	}
+
+	return handler(ctx, []byte(task.Payload))
+}
+
+//Backoff delay before next attempt: BACKOFF_BASE * 2^(attempt-1) with jitter, at most BACKOFF_MAX
+func Backoff(attempt int) time.Duration {
+	delay := BACKOFF_MAX
+	if attempt < 20 {
+		delay = BACKOFF_BASE << uint(attempt-1)
+	}
+	if delay > BACKOFF_MAX || delay <= 0 {
+		delay = BACKOFF_MAX
+	}
+
+	//Up to 20% jitter so failed tasks don't retry all at once
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
@@ -0,0 +1,88 @@
+package task
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/ianidi/exchange-server/internal/mail"
+	"github.com/ianidi/exchange-server/internal/otp"
+)
+
+//Task types. Uploads and contract PDF of member requests stay inline since the response needs the file, documents are rendered by document.Render task
+const (
+	TYPE_EMAIL = "email"
+	TYPE_SMS   = "sms"
+)
+
+//Email sent with mail.SendMail
+type Email struct {
+	Email       string
+	Subject     string
+	Title       string
+	Content     string
+	Button      string
+	Link        string
+	Description string
+}
+
+//SMS sent with otp.SendSMS
+type SMS struct {
+	Phone   string
+	Message string
+}
+
+func (Email) Type() string {
+	return TYPE_EMAIL
+}
+
+func (SMS) Type() string {
+	return TYPE_SMS
+}
+
+//maskDigits hides one-time codes in message text
+func maskDigits(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return '*'
+		}
+		return r
+	}, text)
+}
+
+func init() {
+	Register(TYPE_EMAIL, func(ctx context.Context, payload []byte) error {
+		var task Email
+		if err := json.Unmarshal(payload, &task); err != nil {
+			return err
+		}
+		return mail.SendMail(task.Email, task.Subject, task.Title, task.Content, task.Button, task.Link, task.Description)
+	})
+
+	//Links activate accounts and reset passwords, content may include them
+	RegisterRedactor(TYPE_EMAIL, func(payload []byte) (interface{}, error) {
+		var task Email
+		if err := json.Unmarshal(payload, &task); err != nil {
+			return nil, err
+		}
+		task.Content, task.Link = "", ""
+		return task, nil
+	})
+
+	Register(TYPE_SMS, func(ctx context.Context, payload []byte) error {
+		var task SMS
+		if err := json.Unmarshal(payload, &task); err != nil {
+			return err
+		}
+		return otp.SendSMS(task.Phone, task.Message)
+	})
+
+	RegisterRedactor(TYPE_SMS, func(payload []byte) (interface{}, error) {
+		var task SMS
+		if err := json.Unmarshal(payload, &task); err != nil {
+			return nil, err
+		}
+		task.Message = maskDigits(task.Message)
+		return task, nil
+	})
+}
@@ -0,0 +1,49 @@
+package task
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ianidi/exchange-server/internal/models"
+)
+
+func redact(t *testing.T, value Task) string {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redacted, err := Redact(models.Task{Type: value.Type(), Payload: string(payload)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return redacted
+}
+
+func TestRedactEmail(t *testing.T) {
+	payload := redact(t, Email{Email: "member@example.com", Subject: "Reset password", Content: "Reset link https://example.com/reset?code=secret", Link: "https://example.com/reset?code=secret"})
+
+	if strings.Contains(payload, "secret") {
+		t.Fatalf("link in %s", payload)
+	}
+
+	if !strings.Contains(payload, "member@example.com") || !strings.Contains(payload, "Reset password") {
+		t.Fatalf("recipient and subject missing in %s", payload)
+	}
+}
+
+func TestRedactSMS(t *testing.T) {
+	payload := redact(t, SMS{Phone: "+79990000000", Message: "Your code is 123456"})
+
+	if strings.Contains(payload, "123456") || !strings.Contains(payload, "Your code is ******") {
+		t.Fatalf("code in %s", payload)
+	}
+}
+
+func TestRedactUnknownType(t *testing.T) {
+	payload, err := Redact(models.Task{Type: "unknown", Payload: `{"Secret":"value"}`})
+	if err != nil || payload != "" {
+		t.Fatalf("got %q, %v, want empty payload", payload, err)
+	}
+}
@@ -4,9 +4,14 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/ianidi/exchange-server/internal/account"
 	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/fx"
+	"github.com/ianidi/exchange-server/internal/ledger"
 	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/spread"
 	shopspring "github.com/jackc/pgtype/ext/shopspring-numeric"
+	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 )
 
@@ -75,23 +80,68 @@ func (order Order) QueryAsset(AssetID int64) (models.Asset, error) {
 		return asset, errors.New("TRADE_ASSET_NOT_TRADABLE")
 	}
 
+	//Trading is halted by operator
+	if asset.Halted {
+		return asset, errors.New("TRADE_ASSET_HALTED")
+	}
+
 	return asset, nil
 }
 
+//Determine asset buy / sell rates with spread effective at order creation time applied
+func (order Order) DetermineSpreadRate(rate decimal.Decimal) (decimal.Decimal, decimal.Decimal, error) {
+
+	policy := spread.Policy{
+		Asset:     order.Asset,
+		Timestamp: order.Timestamp,
+	}
+
+	effective, err := policy.Determine()
+	if err != nil {
+		return rate, rate, err
+	}
+
+	rateBuy, rateSell := spread.Apply(order.Asset.MarketID, rate, effective.Buy, effective.Sell)
+
+	return rateBuy, rateSell, nil
+}
+
+//Determine asset buy / sell rates for member with member group markup applied
+func (order Order) DetermineMemberRate() (decimal.Decimal, decimal.Decimal, error) {
+	db := db.GetDB()
+
+	var markup shopspring.Numeric
+
+	if err := db.Get(&markup, "SELECT MemberGroup.Markup FROM Member INNER JOIN MemberGroup ON MemberGroup.MemberGroupID=Member.MemberGroupID WHERE Member.MemberID=$1", order.MemberID); err != nil {
+		if err != sql.ErrNoRows {
+			return order.Asset.RateBuy.Decimal, order.Asset.RateSell.Decimal, err
+		}
+	}
+
+	rateBuy, rateSell := spread.Markup(order.Asset.Rate.Decimal, order.Asset.RateBuy.Decimal, order.Asset.RateSell.Decimal, markup.Decimal)
+
+	return rateBuy, rateSell, nil
+}
+
 //Query current asset rate
-func (order Order) DetermineMarketRate() decimal.Decimal {
+func (order Order) DetermineMarketRate() (decimal.Decimal, error) {
 
 	var rate decimal.Decimal
 
+	rateBuy, rateSell, err := order.DetermineMemberRate()
+	if err != nil {
+		return rate, err
+	}
+
 	if order.Action == ACTION_BUY {
-		rate = order.Asset.RateBuy.Decimal
+		rate = rateBuy
 	}
 
 	if order.Action == ACTION_SELL {
-		rate = order.Asset.RateSell.Decimal
+		rate = rateSell
 	}
 
-	return rate
+	return rate, nil
 }
 
 //Query asset balance in member wallet
@@ -142,22 +192,29 @@ func (order Order) DetermineRateEntry() decimal.Decimal {
 	return rate
 }
 
-//Query current member balance
+//Query current member balance in account currency
 func (order Order) QueryCurrentBalance() (decimal.Decimal, error) {
-	db := db.GetDB()
+	return account.QueryBalance(order.MemberID, order.AccountCurrency)
+}
 
-	var balance shopspring.Numeric
+//Determine member account currency the order is settled in
+func (order Order) DetermineAccountCurrency() (string, error) {
+	return account.QueryCurrency(order.MemberID)
+}
 
-	err := db.Get(&balance, "SELECT "+order.Asset.Currency+" FROM Member WHERE MemberID=$1", order.MemberID)
+//Determine currency of order margin. Forex lot margin is counted in base currency, other assets in quote currency
+func (order Order) DetermineMarginCurrency() string {
 
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return balance.Decimal, errors.New("INVALID_MEMBER")
-		}
-		return balance.Decimal, err
+	if order.Asset.MarketID == 3 {
+		return order.Asset.BaseCurrency
 	}
 
-	return balance.Decimal, nil
+	return order.Asset.Currency
+}
+
+//Determine order margin in member account currency
+func (order Order) DetermineMargin() decimal.Decimal {
+	return order.Total.Decimal.Mul(order.MarginRate.Decimal)
 }
 
 //Query current member
@@ -334,9 +391,22 @@ func (order Order) Close() error {
 func (order Order) Open() (int64, error) {
 	db := db.GetDB()
 
+	//Order margin in member account currency
+	margin := order.DetermineMargin()
+
+	var RecordID int64
+
 	tx := db.MustBegin()
-	tx.MustExec("UPDATE Member SET "+order.Asset.Currency+"="+order.Asset.Currency+"-$1 WHERE MemberID=$2", order.Total.Decimal, order.MemberID)
-	tx.MustExec("INSERT INTO Trade (MemberID, AssetID, Type, Action, MemberRate, MarketRate, RateEntry, Qty, TotalReal, Total, BalanceEntry, StopLoss, TakeProfit,  OnePip, PipsRateEntry, Leverage, Status, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)", order.MemberID, order.Asset.AssetID, order.Type, order.Action, order.MemberRate.Decimal, order.Asset.Rate.Decimal, order.RateEntry.Decimal, order.Qty.Decimal, order.TotalReal.Decimal, order.Total.Decimal, order.BalanceEntry.Decimal, order.StopLoss.Decimal, order.TakeProfit.Decimal, order.OnePip.Decimal, order.PipsRateEntry.Decimal, order.Leverage.Decimal, order.Status, order.Timestamp)
+	if err := tx.Get(&RecordID, "INSERT INTO Trade (MemberID, AssetID, Type, Action, MemberRate, MarketRate, RateEntry, Qty, TotalReal, Total, BalanceEntry, StopLoss, TakeProfit,  OnePip, PipsRateEntry, Leverage, Status, Timestamp, AccountCurrency, MarginRate) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20) RETURNING TradeID", order.MemberID, order.Asset.AssetID, order.Type, order.Action, order.MemberRate.Decimal, order.Asset.Rate.Decimal, order.RateEntry.Decimal, order.Qty.Decimal, order.TotalReal.Decimal, order.Total.Decimal, order.BalanceEntry.Decimal, order.StopLoss.Decimal, order.TakeProfit.Decimal, order.OnePip.Decimal, order.PipsRateEntry.Decimal, order.Leverage.Decimal, order.Status, order.Timestamp, order.AccountCurrency, order.MarginRate.Decimal); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	//Hold order margin
+	if err := ledger.Transfer(ledger.ENTRY_TRADE_OPEN, "trade", RecordID, ledger.Member(order.MemberID, order.AccountCurrency), ledger.Margin(order.MemberID, order.AccountCurrency), margin).Post(tx); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
 
 	//Add asset to wallet balance if the buy order was opened instantly
 	if order.Action == ACTION_BUY && order.Status == STATUS_OPEN {
@@ -344,14 +414,8 @@ func (order Order) Open() (int64, error) {
 	}
 	tx.Commit()
 
-	var RecordID int64
-
-	if err := db.Get(&RecordID, "SELECT TradeID FROM Trade WHERE MemberID=$1 AND AssetID=$2 AND Type=$3 AND Action=$4 AND Status=$5 AND Timestamp=$6 ORDER BY TradeID DESC", order.MemberID, order.Asset.AssetID, order.Type, order.Action, order.Status, order.Timestamp); err != nil {
-		return RecordID, err
-	}
-
 	tx = db.MustBegin()
-	tx.MustExec("INSERT INTO History (MemberID, AssetID, TradeID, Type, Action, Status, Currency, Qty, Rate, Leverage, Profit, ProfitAbs, ProfitNegative, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)", order.MemberID, order.Asset.AssetID, RecordID, order.Type, order.Action, order.Status, order.Asset.Currency, order.Qty.Decimal, order.RateEntry.Decimal, order.Leverage.Decimal, order.Total.Decimal.Mul(decimal.NewFromInt(-1)), order.Total.Decimal.Abs(), true, order.Timestamp)
+	tx.MustExec("INSERT INTO History (MemberID, AssetID, TradeID, Type, Action, Status, Currency, Qty, Rate, Leverage, Profit, ProfitAbs, ProfitNegative, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)", order.MemberID, order.Asset.AssetID, RecordID, order.Type, order.Action, order.Status, order.AccountCurrency, order.Qty.Decimal, order.RateEntry.Decimal, order.Leverage.Decimal, margin.Neg(), margin.Abs(), true, order.Timestamp)
 	tx.Commit()
 
 	return RecordID, nil
@@ -366,10 +430,15 @@ func (order Order) CalculateProfit() (Order, error) {
 		return order, nil
 	}
 
+	rateBuy, rateSell, err := order.DetermineMemberRate()
+	if err != nil {
+		return order, err
+	}
+
 	if order.Action == ACTION_BUY {
-		order.RateClosed = order.Asset.RateSell
+		order.RateClosed.Decimal = rateSell
 	} else {
-		order.RateClosed = order.Asset.RateBuy
+		order.RateClosed.Decimal = rateBuy
 	}
 
 	//Calculate final order TotalReal
@@ -425,6 +494,12 @@ func (order Order) CalculateProfit() (Order, error) {
 
 	}
 
+	//Profit is counted in asset quote currency, convert it into member account currency
+	order.Profit.Decimal, err = fx.Convert(order.Profit.Decimal, order.Asset.Currency, order.AccountCurrency)
+	if err != nil {
+		return order, err
+	}
+
 	//Get member account balance
 	order.BalanceClosed.Decimal, err = order.QueryCurrentBalance()
 	if err != nil {
@@ -465,12 +540,25 @@ func (order Order) CloseOrder() error {
 	db := db.GetDB()
 
 	//Return money used to purchase the order and add profit to it
-	Profit := order.Total.Decimal.Add(order.Profit.Decimal)
+	Profit := order.DetermineMargin().Add(order.Profit.Decimal)
 
 	tx := db.MustBegin()
-	tx.MustExec("UPDATE Member SET "+order.Asset.Currency+"="+order.Asset.Currency+"+$1 WHERE MemberID=$2", Profit, order.MemberID)
+	//Release margin, profit is paid by (loss goes to) the house
+	if err := (ledger.Entry{
+		Type:     ledger.ENTRY_TRADE_CLOSE,
+		Entity:   "trade",
+		EntityID: order.TradeID,
+		Postings: []ledger.Posting{
+			{Account: ledger.Margin(order.MemberID, order.AccountCurrency), Amount: order.DetermineMargin().Neg()},
+			{Account: ledger.Member(order.MemberID, order.AccountCurrency), Amount: Profit},
+			{Account: ledger.House(order.AccountCurrency), Amount: order.Profit.Decimal.Neg()},
+		},
+	}).Post(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
 	tx.MustExec("UPDATE Trade SET Status=$1, BalanceClosed=$2, RateClosed=$3, ClosedBySystem=$4, DateClosed=current_timestamp WHERE TradeID=$5", STATUS_CLOSED, order.BalanceClosed.Decimal, order.RateClosed.Decimal, order.ClosedBySystem, order.TradeID)
-	tx.MustExec("INSERT INTO History (MemberID, AssetID, TradeID, Type, Action, Status, Currency, Qty, Rate, Leverage, Profit, ProfitAbs, ProfitNegative, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)", order.MemberID, order.Asset.AssetID, order.TradeID, order.Type, order.Action, STATUS_CLOSED, order.Asset.Currency, order.Qty.Decimal, order.RateClosed.Decimal, order.Leverage.Decimal, Profit, Profit.Abs(), Profit.IsNegative(), order.Timestamp)
+	tx.MustExec("INSERT INTO History (MemberID, AssetID, TradeID, Type, Action, Status, Currency, Qty, Rate, Leverage, Profit, ProfitAbs, ProfitNegative, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)", order.MemberID, order.Asset.AssetID, order.TradeID, order.Type, order.Action, STATUS_CLOSED, order.AccountCurrency, order.Qty.Decimal, order.RateClosed.Decimal, order.Leverage.Decimal, Profit, Profit.Abs(), Profit.IsNegative(), order.Timestamp)
 
 	//Deduct member asset balance is case of long order
 	if order.Action == ACTION_BUY {
@@ -485,12 +573,8 @@ func (order Order) CloseOrder() error {
 //Determine forex profit
 func (order Order) DetermineForexProfit() (decimal.Decimal, error) {
 
-	// How much money to pay user per pips (pip value)
-	if order.Asset.BaseCurrency == CURRENCY_USD {
-		order.PipValue.Decimal = decimal.NewFromInt(10).Mul(order.Qty.Decimal)
-	} else {
-		order.PipValue.Decimal = order.OnePip.Decimal.Div(order.RateClosed.Decimal).Mul(order.TotalReal.Decimal)
-	}
+	//How much money to pay user per pips (pip value in quote currency, converted into account currency by CalculateProfit)
+	order.PipValue.Decimal = order.OnePip.Decimal.Mul(order.TotalReal.Decimal)
 
 	//How much pips Forex lot rate had at order closure time
 	order.PipsRateClosed.Decimal = order.RateClosed.Decimal.Div(order.OnePip.Decimal)
@@ -507,22 +591,37 @@ func (order Order) DetermineForexProfit() (decimal.Decimal, error) {
 //Cancel pending limit order
 func (order Order) CancelPending() error {
 	db := db.GetDB()
+
+	tx := db.MustBegin()
+	if err := order.cancelPending(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+//cancelPending cancels pending limit order within transaction
+func (order Order) cancelPending(tx *sqlx.Tx) error {
 	var err error
 
-	//Get member account balance
-	order.BalanceClosed.Decimal, err = order.QueryCurrentBalance()
+	//Get member account balance, locked until the transaction ends
+	order.BalanceClosed.Decimal, err = account.QueryBalanceForUpdate(tx, order.MemberID, order.AccountCurrency)
 	if err != nil {
 		return err
 	}
 
+	//Order margin in member account currency
+	margin := order.DetermineMargin()
+
 	//Member receives money on order closure, add them to member account balance
-	order.BalanceClosed.Decimal = order.BalanceClosed.Decimal.Add(order.Total.Decimal)
+	order.BalanceClosed.Decimal = order.BalanceClosed.Decimal.Add(margin)
 
-	tx := db.MustBegin()
 	tx.MustExec("UPDATE Trade SET Status=$1, BalanceClosed=$2, Profit=$3, ProfitAbs=$4, DateClosed=current_timestamp WHERE TradeID=$5", STATUS_CANCELLED, order.BalanceClosed.Decimal, 0, 0, order.TradeID)
-	tx.MustExec("UPDATE Member SET "+order.Asset.Currency+"="+order.Asset.Currency+"+$1 WHERE MemberID=$2", order.Total.Decimal, order.MemberID)
-	tx.MustExec("INSERT INTO History (MemberID, AssetID, TradeID, Type, Action, Status, Currency, Qty, Rate, Leverage, Profit, ProfitAbs, ProfitNegative, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)", order.MemberID, order.Asset.AssetID, order.TradeID, order.Type, order.Action, STATUS_CANCELLED, order.Asset.Currency, order.Qty.Decimal, order.RateClosed.Decimal, order.Leverage.Decimal, order.Total.Decimal, order.Total.Decimal.Abs(), false, order.Timestamp)
-	tx.Commit()
+	if err := ledger.Transfer(ledger.ENTRY_TRADE_CANCEL, "trade", order.TradeID, ledger.Margin(order.MemberID, order.AccountCurrency), ledger.Member(order.MemberID, order.AccountCurrency), margin).Post(tx); err != nil {
+		return err
+	}
+	tx.MustExec("INSERT INTO History (MemberID, AssetID, TradeID, Type, Action, Status, Currency, Qty, Rate, Leverage, Profit, ProfitAbs, ProfitNegative, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)", order.MemberID, order.Asset.AssetID, order.TradeID, order.Type, order.Action, STATUS_CANCELLED, order.AccountCurrency, order.Qty.Decimal, order.RateClosed.Decimal, order.Leverage.Decimal, margin, margin.Abs(), false, order.Timestamp)
 
 	return nil
 }
@@ -623,3 +722,98 @@ func (order Order) DetermineMaxAllowedSLTP() (decimal.Decimal, decimal.Decimal,
 
 	return stopLoss, takeProfit, nil
 }
+
+//Cancel order executed during a bad tick window and return member balance to the state before the order.
+//Changes are made within the caller's transaction, so the operator action is recorded with them
+func (order Order) CancelBadTick(tx *sqlx.Tx) error {
+	var err error
+
+	if order.Status == STATUS_CANCELLED {
+		return errors.New("TRADE_ALREADY_CANCELLED")
+	}
+
+	//Pending order didn't affect member balance except of reserved total
+	if order.Status == STATUS_PENDING {
+		return order.cancelPending(tx)
+	}
+
+	//Open order: return money used to purchase the order
+	refund := order.DetermineMargin()
+	source := ledger.Margin(order.MemberID, order.AccountCurrency)
+
+	//Closed order: member already received total with profit, take the profit back
+	if order.Status == STATUS_CLOSED {
+		refund = order.Profit.Decimal.Neg()
+		source = ledger.House(order.AccountCurrency)
+	}
+
+	//Get member account balance, locked until the transaction ends
+	order.BalanceClosed.Decimal, err = account.QueryBalanceForUpdate(tx, order.MemberID, order.AccountCurrency)
+	if err != nil {
+		return err
+	}
+
+	order.BalanceClosed.Decimal = order.BalanceClosed.Decimal.Add(refund)
+
+	tx.MustExec("UPDATE Trade SET Status=$1, BalanceClosed=$2, Profit=$3, ProfitAbs=$4, ProfitNegative=$5, Gain=$6, DateClosed=current_timestamp WHERE TradeID=$7", STATUS_CANCELLED, order.BalanceClosed.Decimal, 0, 0, false, 0, order.TradeID)
+	if err := ledger.Transfer(ledger.ENTRY_TRADE_CANCEL, "trade", order.TradeID, source, ledger.Member(order.MemberID, order.AccountCurrency), refund).Post(tx); err != nil {
+		return err
+	}
+	tx.MustExec("INSERT INTO History (MemberID, AssetID, TradeID, Type, Action, Status, Currency, Qty, Rate, Leverage, Profit, ProfitAbs, ProfitNegative, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)", order.MemberID, order.Asset.AssetID, order.TradeID, order.Type, order.Action, STATUS_CANCELLED, order.AccountCurrency, order.Qty.Decimal, order.RateEntry.Decimal, order.Leverage.Decimal, refund, refund.Abs(), refund.IsNegative(), order.Timestamp)
+
+	//Deduct member asset balance in case of open long order
+	if order.Status == STATUS_OPEN && order.Action == ACTION_BUY {
+		tx.MustExec("UPDATE Wallet SET Balance=Balance-$1 WHERE MemberID=$2 AND AssetID=$3", order.Qty.Decimal, order.MemberID, order.Asset.AssetID)
+	}
+
+	return nil
+}
+
+//Reprice open market order executed during a bad tick window using corrected asset rate within the caller's transaction.
+//Profit of the returned order is recalculated with CalculateProfit after the transaction is committed
+func (order Order) Reprice(tx *sqlx.Tx, rate decimal.Decimal) (Order, error) {
+	var err error
+
+	//Limit orders are executed at member defined rate, closed orders can only be cancelled
+	if order.Type != ORDER_MARKET || order.Status != STATUS_OPEN {
+		return order, errors.New("TRADE_NOT_REPRICEABLE")
+	}
+
+	rateBuy, rateSell, err := order.DetermineSpreadRate(rate)
+	if err != nil {
+		return order, err
+	}
+
+	order.MarketRate.Decimal = rate
+
+	if order.Action == ACTION_BUY {
+		order.RateEntry.Decimal = rateBuy
+	} else {
+		order.RateEntry.Decimal = rateSell
+	}
+
+	previousMargin := order.DetermineMargin()
+
+	order.TotalReal.Decimal, err = order.DetermineTotalReal()
+	if err != nil {
+		return order, err
+	}
+
+	order.Total.Decimal = order.TotalReal.Decimal.Div(order.Leverage.Decimal)
+
+	//How much pips forex asset rate had at corrected rate
+	if order.Asset.MarketID == 3 {
+		order.PipsRateEntry.Decimal = order.RateEntry.Decimal.Div(order.OnePip.Decimal)
+	}
+
+	//Member balance is adjusted by the difference of order cost
+	difference := previousMargin.Sub(order.DetermineMargin())
+
+	tx.MustExec("UPDATE Trade SET MarketRate=$1, RateEntry=$2, TotalReal=$3, Total=$4, PipsRateEntry=$5 WHERE TradeID=$6", order.MarketRate.Decimal, order.RateEntry.Decimal, order.TotalReal.Decimal, order.Total.Decimal, order.PipsRateEntry.Decimal, order.TradeID)
+	if err := ledger.Transfer(ledger.ENTRY_TRADE_REPRICE, "trade", order.TradeID, ledger.Margin(order.MemberID, order.AccountCurrency), ledger.Member(order.MemberID, order.AccountCurrency), difference).Post(tx); err != nil {
+		return order, err
+	}
+	tx.MustExec("UPDATE History SET Rate=$1, Profit=$2, ProfitAbs=$3 WHERE TradeID=$4 AND Status=$5", order.RateEntry.Decimal, order.DetermineMargin().Neg(), order.DetermineMargin().Abs(), order.TradeID, STATUS_OPEN)
+
+	return order, nil
+}
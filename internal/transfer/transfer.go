@@ -0,0 +1,255 @@
+package transfer
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ianidi/exchange-server/graph/model"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/fx"
+	"github.com/ianidi/exchange-server/internal/ledger"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/outbox"
+	"github.com/ianidi/exchange-server/internal/withdrawal"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+//Transfer types
+const (
+	TYPE_CONVERSION = "conversion" //Between member own currencies
+	TYPE_TRANSFER   = "transfer"   //To another member
+)
+
+//Convert exchanges amount of member currency into another currency at live forex rate. Conversion fee is charged in source currency
+func Convert(MemberID int64, currency string, to string, amount decimal.Decimal) (models.Transfer, error) {
+	db := db.GetDB()
+
+	var transfer models.Transfer
+
+	if !amount.IsPositive() {
+		return transfer, errors.New("INVALID_AMOUNT")
+	}
+
+	if currency == to {
+		return transfer, errors.New("CONVERSION_SAME_CURRENCY")
+	}
+
+	if err := checkCurrency(to); err != nil {
+		return transfer, err
+	}
+
+	rate, err := fx.Rate(currency, to)
+	if err != nil {
+		return transfer, err
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return transfer, err
+	}
+
+	if _, err := lockMember(tx, MemberID); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	if err := withdrawal.CheckBalance(tx, MemberID, currency, amount); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	var percent decimal.Decimal
+
+	if err := tx.Get(&percent, "SELECT ConversionFee FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	fee := amount.Mul(percent).Div(decimal.NewFromInt(100))
+	converted := amount.Sub(fee).Mul(rate)
+
+	if err := tx.Get(&transfer, "INSERT INTO Transfer (Type, MemberID, RecipientID, Currency, Amount, RecipientCurrency, RecipientAmount, Rate, Fee, Timestamp) VALUES ($1, $2, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING *", TYPE_CONVERSION, MemberID, currency, amount, to, converted, rate, fee, time.Now().Unix()); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	//House buys source currency and sells target currency, ledger entries are balanced per currency
+	entries := []ledger.Entry{
+		{
+			Type:     ledger.ENTRY_CONVERSION,
+			Entity:   "transfer",
+			EntityID: transfer.TransferID,
+			Postings: []ledger.Posting{
+				{Account: ledger.Member(MemberID, currency), Amount: amount.Neg()},
+				{Account: ledger.Fees(currency), Amount: fee},
+				{Account: ledger.House(currency), Amount: amount.Sub(fee)},
+			},
+		},
+		ledger.Transfer(ledger.ENTRY_CONVERSION, "transfer", transfer.TransferID, ledger.House(to), ledger.Member(MemberID, to), converted),
+	}
+
+	for _, entry := range entries {
+		if err := entry.Post(tx); err != nil {
+			tx.Rollback()
+			return transfer, err
+		}
+	}
+
+	if err := record(tx, transfer); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	return transfer, tx.Commit()
+}
+
+//Send transfers amount to another member in the same currency. Both members need transfers allowed by operator, the amount counts towards sender withdrawal limits
+func Send(MemberID int64, RecipientID int64, currency string, amount decimal.Decimal) (models.Transfer, error) {
+	db := db.GetDB()
+
+	var transfer models.Transfer
+
+	if !amount.IsPositive() {
+		return transfer, errors.New("INVALID_AMOUNT")
+	}
+
+	if MemberID == RecipientID {
+		return transfer, errors.New("TRANSFER_SAME_MEMBER")
+	}
+
+	if err := checkCurrency(currency); err != nil {
+		return transfer, err
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return transfer, err
+	}
+
+	//Members are locked in the same order by concurrent transfers
+	first, second := MemberID, RecipientID
+	if first > second {
+		first, second = second, first
+	}
+
+	members := map[int64]models.Member{}
+
+	for _, ID := range []int64{first, second} {
+		member, err := lockMember(tx, ID)
+		if err != nil {
+			tx.Rollback()
+			return transfer, err
+		}
+		members[ID] = member
+	}
+
+	if !members[MemberID].TransferSendAllowed {
+		tx.Rollback()
+		return transfer, errors.New("TRANSFER_SEND_NOT_ALLOWED")
+	}
+
+	//Same error as of an unknown recipient, so members can't be enumerated
+	if !members[RecipientID].TransferReceiveAllowed {
+		tx.Rollback()
+		return transfer, errors.New("TRANSFER_RECIPIENT_INVALID")
+	}
+
+	if err := withdrawal.CheckBalance(tx, MemberID, currency, amount); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	if err := withdrawal.CheckLimits(tx, MemberID, members[MemberID].MemberGroupID, currency, amount); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	if err := tx.Get(&transfer, "INSERT INTO Transfer (Type, MemberID, RecipientID, Currency, Amount, RecipientCurrency, RecipientAmount, Timestamp) VALUES ($1, $2, $3, $4, $5, $4, $5, $6) RETURNING *", TYPE_TRANSFER, MemberID, RecipientID, currency, amount, time.Now().Unix()); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	if err := ledger.Transfer(ledger.ENTRY_TRANSFER, "transfer", transfer.TransferID, ledger.Member(MemberID, currency), ledger.Member(RecipientID, currency), amount).Post(tx); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	if err := record(tx, transfer); err != nil {
+		tx.Rollback()
+		return transfer, err
+	}
+
+	return transfer, tx.Commit()
+}
+
+//Lock member row for the rest of the transaction
+func lockMember(tx *sqlx.Tx, MemberID int64) (models.Member, error) {
+	var member models.Member
+
+	if err := tx.Get(&member, "SELECT * FROM Member WHERE MemberID=$1 FOR UPDATE", MemberID); err != nil {
+		if err == sql.ErrNoRows {
+			return member, errors.New("NO_MEMBER_RECORD")
+		}
+		return member, err
+	}
+
+	return member, nil
+}
+
+//Check that currency code exists
+func checkCurrency(code string) error {
+	db := db.GetDB()
+
+	var count int
+
+	if err := db.Get(&count, "SELECT count(*) FROM Currency WHERE Code=$1", code); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return errors.New("INVALID_CURRENCY")
+	}
+
+	return nil
+}
+
+//Record balance transactions and history of both sides and notify members
+func record(tx *sqlx.Tx, transfer models.Transfer) error {
+	sides := []struct {
+		MemberID int64
+		Currency string
+		Amount   decimal.Decimal
+	}{
+		{transfer.MemberID, transfer.Currency, transfer.Amount.Decimal.Neg()},
+		{transfer.RecipientID, transfer.RecipientCurrency, transfer.RecipientAmount.Decimal},
+	}
+
+	for _, side := range sides {
+		var CurrencyID int64
+
+		if err := tx.Get(&CurrencyID, "SELECT CurrencyID FROM Currency WHERE Code=$1", side.Currency); err != nil {
+			return err
+		}
+
+		tx.MustExec("INSERT INTO TX (MemberID, Amount, AmountNegative, CurrencyID, Status, DateComplete, TimestampCreated, TimestampComplete, Type, TransferID) VALUES ($1, $2, $3, $4, $5, now(), $6, $6, $7, $8)", side.MemberID, side.Amount, side.Amount.IsNegative(), CurrencyID, "complete", transfer.Timestamp, transfer.Type, transfer.TransferID)
+		tx.MustExec("INSERT INTO History (MemberID, Type, Status, Currency, Profit, ProfitAbs, ProfitNegative, Timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", side.MemberID, "balance", transfer.Type, side.Currency, side.Amount, side.Amount.Abs(), side.Amount.IsNegative(), transfer.Timestamp)
+	}
+
+	for _, MemberID := range []int64{transfer.MemberID, transfer.RecipientID} {
+		outbox.Info(model.Info{
+			MemberID: int(MemberID),
+			Event:    transfer.Type,
+			ID:       int(transfer.TransferID),
+			Value:    transfer.RecipientAmount.Decimal.String(),
+		}).Record(tx)
+
+		//Conversion has one member
+		if transfer.RecipientID == transfer.MemberID {
+			break
+		}
+	}
+
+	return nil
+}
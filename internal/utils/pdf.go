@@ -4,15 +4,18 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
-	"os"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/spf13/viper"
 	"github.com/thecodingmachine/gotenberg-go-client/v7"
 )
 
 func GeneratePDF(content string) ([]byte, error) {
+	return RenderPDF("<html><head><style>body {padding: 40px;}</style></head><body><h1>Contract</h1>" + content + "</body></html>")
+}
+
+//RenderPDF converts complete HTML document to PDF with Gotenberg (pdf_host)
+func RenderPDF(html string) ([]byte, error) {
 	var err error
 
 	httpClient := &http.Client{
@@ -20,7 +23,7 @@ func GeneratePDF(content string) ([]byte, error) {
 	}
 	client := &gotenberg.Client{Hostname: viper.GetString("pdf_host"), HTTPClient: httpClient}
 
-	index, err := gotenberg.NewDocumentFromString("index.html", "<html><head><style>body {padding: 40px;}</style></head><body><h1>Contract</h1>"+content+"</body></html>")
+	index, err := gotenberg.NewDocumentFromString("index.html", html)
 	if err != nil {
 		return nil, errors.New("PDF_GENERATION_ERROR")
 	}
@@ -38,21 +41,20 @@ func GeneratePDF(content string) ([]byte, error) {
 	req.Margins(gotenberg.NoMargins)
 	req.Scale(0.75)
 
-	filepath := "/var/server/static/" + uuid.New().String() + ".pdf"
-
-	err = client.Store(req, filepath)
+	//PDF is read from the response, no temporary file is written
+	resp, err := client.Post(req)
 	if err != nil {
-		return nil, errors.New("PDF_WRITE_ERROR")
+		return nil, errors.New("PDF_GENERATION_ERROR")
 	}
+	defer resp.Body.Close()
 
-	data, err := ioutil.ReadFile(filepath)
-	if err != nil {
-		return nil, errors.New("PDF_READ_ERROR")
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("PDF_GENERATION_ERROR")
 	}
 
-	err = os.Remove(filepath)
+	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.New("PDF_REMOVE_ERROR")
+		return nil, errors.New("PDF_READ_ERROR")
 	}
 
 	return data, nil
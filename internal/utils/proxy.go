@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//TrustedProxies sets client address of requests sent by trusted proxies (IP addresses or CIDR ranges) to the last
+//X-Forwarded-For address not added by them. Other requests keep the remote address, so clients can't choose the address
+//rate limits and lockouts are counted by. Engine.ForwardedByClientIP must be disabled
+func TrustedProxies(proxies []string) (gin.HandlerFunc, error) {
+	networks := []*net.IPNet{}
+
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, errors.New("trusted_proxies: invalid address " + proxy)
+			}
+
+			if ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, errors.New("trusted_proxies: invalid address " + proxy)
+		}
+
+		networks = append(networks, network)
+	}
+
+	trusted := func(ip net.IP) bool {
+		for _, network := range networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(c *gin.Context) {
+		host, _, err := net.SplitHostPort(strings.TrimSpace(c.Request.RemoteAddr))
+		if err != nil || !trusted(net.ParseIP(host)) {
+			return
+		}
+
+		//Proxies append the address they received the request from, addresses on the left are sent by the client
+		addresses := strings.Split(c.GetHeader("X-Forwarded-For"), ",")
+
+		for i := len(addresses) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(addresses[i]))
+			if ip == nil {
+				return
+			}
+
+			c.Request.RemoteAddr = net.JoinHostPort(ip.String(), "0")
+
+			if !trusted(ip) {
+				return
+			}
+		}
+	}, nil
+}
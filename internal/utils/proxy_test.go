@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func clientIP(t *testing.T, proxies []string, remoteAddr string, forwardedFor string) string {
+	gin.SetMode(gin.TestMode)
+
+	middleware, err := TrustedProxies(proxies)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.ForwardedByClientIP = false
+	router.Use(middleware)
+
+	var ip string
+	router.GET("/", func(c *gin.Context) {
+		ip = c.ClientIP()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	return ip
+}
+
+func TestTrustedProxies(t *testing.T) {
+	proxies := []string{"10.0.0.0/8", "192.168.1.1"}
+
+	for _, test := range []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		ip           string
+	}{
+		{"direct client", "203.0.113.7:5000", "", "203.0.113.7"},
+		{"spoofed by client", "203.0.113.7:5000", "198.51.100.1", "203.0.113.7"},
+		{"single proxy", "192.168.1.1:5000", "203.0.113.7", "203.0.113.7"},
+		{"address prepended by client", "192.168.1.1:5000", "198.51.100.1, 203.0.113.7", "203.0.113.7"},
+		{"proxy chain", "10.0.0.2:5000", "203.0.113.7, 10.0.0.1", "203.0.113.7"},
+		{"invalid entry", "10.0.0.2:5000", "garbage, 10.0.0.1", "10.0.0.1"},
+		{"proxy without header", "10.0.0.2:5000", "", "10.0.0.2"},
+	} {
+		if ip := clientIP(t, proxies, test.remoteAddr, test.forwardedFor); ip != test.ip {
+			t.Errorf("%s: client IP %s, want %s", test.name, ip, test.ip)
+		}
+	}
+}
+
+func TestTrustedProxiesInvalid(t *testing.T) {
+	for _, proxy := range []string{"localhost", "10.0.0.0/33"} {
+		if _, err := TrustedProxies([]string{proxy}); err == nil {
+			t.Errorf("%s accepted", proxy)
+		}
+	}
+}
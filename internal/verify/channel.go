@@ -0,0 +1,117 @@
+package verify
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ianidi/exchange-server/internal/mfa"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/task"
+	"github.com/jmoiron/sqlx"
+)
+
+//Channels of verification codes (Verify.Method)
+const (
+	CHANNEL_EMAIL = "email"
+	CHANNEL_PHONE = "phone" //SMS
+	CHANNEL_TOTP  = "totp"  //Authenticator app of member with MFA enabled
+)
+
+//Channel delivers codes generated by the service
+type Channel interface {
+	//Deliver sends code within transaction of the stored request
+	Deliver(tx *sqlx.Tx, delivery Delivery, message Message) error
+}
+
+//Checker - channel with codes of its own, they are checked by the channel instead of stored codes
+type Checker interface {
+	Check(MemberID int64, code string) error
+}
+
+//Delivery - code of stored request
+type Delivery struct {
+	Request
+	Code      string
+	EmailHash string
+}
+
+var channels = map[string]Channel{
+	CHANNEL_EMAIL: Email{},
+	CHANNEL_PHONE: SMS{},
+	CHANNEL_TOTP:  TOTP{},
+}
+
+//Register adds or replaces channel
+func Register(name string, channel Channel) {
+	channels[name] = channel
+}
+
+//GetChannel returns registered channel
+func GetChannel(name string) (Channel, error) {
+	channel, ok := channels[name]
+	if !ok {
+		return nil, errors.New("INVALID_METHOD")
+	}
+
+	return channel, nil
+}
+
+//Email - code or confirmation link sent by email with task queue
+type Email struct{}
+
+//Deliver queues email of the code
+func (Email) Deliver(tx *sqlx.Tx, delivery Delivery, message Message) error {
+	if delivery.Email == "" {
+		return errors.New("INVALID_EMAIL")
+	}
+
+	var settings models.Settings
+
+	if err := tx.Get(&settings, "SELECT * FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		return err
+	}
+
+	link := settings.PlatformURL
+	if delivery.Link != "" {
+		link += delivery.Link + delivery.EmailHash + "/" + delivery.Code
+	}
+
+	_, err := task.EnqueueTx(tx, task.Email{
+		Email:       delivery.Email,
+		Subject:     message.Subject,
+		Title:       "Dear customer,",
+		Content:     strings.Replace(message.Content, "{code}", delivery.Code, -1),
+		Button:      message.Button,
+		Link:        link,
+		Description: message.Description,
+	}, "")
+
+	return err
+}
+
+//SMS - code sent by SMS with task queue
+type SMS struct{}
+
+//Deliver queues SMS of the code
+func (SMS) Deliver(tx *sqlx.Tx, delivery Delivery, message Message) error {
+	if delivery.Phone == "" {
+		return errors.New("PHONE_REQUIRED")
+	}
+
+	_, err := task.EnqueueTx(tx, task.SMS{Phone: delivery.Phone, Message: strings.Replace(message.SMS, "{code}", delivery.Code, -1)}, "")
+
+	return err
+}
+
+//TOTP - TOTP or recovery code of internal/mfa
+type TOTP struct{}
+
+//Deliver - authenticator app codes aren't sent
+func (TOTP) Deliver(tx *sqlx.Tx, delivery Delivery, message Message) error {
+	return errors.New("INVALID_METHOD")
+}
+
+//Check verifies TOTP or recovery code of member
+func (TOTP) Check(MemberID int64, code string) error {
+	return mfa.Verify(MemberID, code)
+}
@@ -0,0 +1,379 @@
+package verify
+
+import (
+	"crypto/md5"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/sethvargo/go-password/password"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//Purposes of verification requests (Verify.Action)
+const (
+	PURPOSE_SIGNUP        = "signup"
+	PURPOSE_SIGNIN        = "signin" //Sign in with SMS code
+	PURPOSE_RESET         = "reset"
+	PURPOSE_EMAIL_CHANGE  = "email_change"
+	PURPOSE_PHONE_CHANGE  = "phone_change"
+	PURPOSE_SIGN_CONTRACT = "sign_contract"
+	PURPOSE_WITHDRAWAL    = "withdrawal"
+	PURPOSE_PASSWORD      = "password" //Password change
+	PURPOSE_PASSKEY       = "passkey"  //Passkey registration
+)
+
+//Statuses of verification requests
+const (
+	STATUS_PENDING   = "pending"
+	STATUS_CANCELLED = "cancelled"
+	STATUS_FAIL      = "fail"
+	STATUS_SUCCESS   = "success"
+)
+
+const (
+	CODE_LENGTH = 6
+	CODE_COST   = 12 //bcrypt cost of stored codes
+)
+
+//Policy - channels, expiry, attempts and resend cooldown of purpose codes
+type Policy struct {
+	Channels []string      //Channels of the purpose, the first one is default
+	TTL      time.Duration //Code is accepted within TTL after it was sent
+	Attempts int           //Wrong codes before the request fails
+	Cooldown time.Duration //A new code can be requested after Cooldown
+	Message  Message
+}
+
+//Message - text of code sent to member, {code} is replaced with the code
+type Message struct {
+	Subject     string
+	Content     string
+	Button      string
+	Description string
+	SMS         string
+}
+
+var policies = map[string]Policy{
+	PURPOSE_SIGNUP: {
+		Channels: []string{CHANNEL_EMAIL},
+		TTL:      24 * time.Hour,
+		Attempts: 3,
+		Cooldown: time.Minute,
+		Message: Message{
+			Subject:     "Account activation",
+			Content:     "Please activate your account by clicking on the button below.",
+			Button:      "Activate account",
+			Description: "Thank you for joining our platform.",
+		},
+	},
+	PURPOSE_SIGNIN: {
+		Channels: []string{CHANNEL_PHONE},
+		TTL:      10 * time.Minute,
+		Attempts: 3,
+		Cooldown: time.Minute,
+		Message:  Message{SMS: "Your code is {code}"},
+	},
+	PURPOSE_RESET: {
+		Channels: []string{CHANNEL_EMAIL},
+		TTL:      time.Hour,
+		Attempts: 3,
+		Cooldown: time.Minute,
+		Message: Message{
+			Subject:     "Password reset",
+			Content:     "Please reset your password by clicking on the button below.",
+			Button:      "Reset password",
+			Description: "If you have received a password reset email without requesting one, we recommend that you take steps to secure your account.",
+		},
+	},
+	PURPOSE_EMAIL_CHANGE: {
+		Channels: []string{CHANNEL_EMAIL, CHANNEL_TOTP},
+		TTL:      time.Hour,
+		Attempts: 3,
+		Cooldown: time.Minute,
+		Message: Message{
+			Subject:     "Email change",
+			Content:     "Please use code {code} to confirm your new email address.",
+			Button:      "Go to platform",
+			Description: "If you haven't requested the change, we recommend that you change your password.",
+		},
+	},
+	PURPOSE_PHONE_CHANGE: {
+		Channels: []string{CHANNEL_PHONE, CHANNEL_TOTP},
+		TTL:      10 * time.Minute,
+		Attempts: 3,
+		Cooldown: time.Minute,
+		Message:  Message{SMS: "Your code to confirm the phone number is {code}"},
+	},
+	PURPOSE_SIGN_CONTRACT: {
+		Channels: []string{CHANNEL_PHONE},
+		TTL:      time.Hour,
+		Attempts: 3,
+		Cooldown: time.Minute,
+		Message:  Message{SMS: "Your code to sign the contract is {code}"},
+	},
+	PURPOSE_WITHDRAWAL: {
+		Channels: []string{CHANNEL_TOTP},
+		Attempts: 3,
+	},
+	PURPOSE_PASSWORD: {
+		Channels: []string{CHANNEL_TOTP},
+		Attempts: 3,
+	},
+	PURPOSE_PASSKEY: {
+		Channels: []string{CHANNEL_TOTP},
+		Attempts: 3,
+	},
+}
+
+//Request - code to send
+type Request struct {
+	Purpose  string
+	Channel  string
+	MemberID int64
+	Email    string //Recipient of email channel
+	Phone    string //Recipient of phone channel
+	IP       string
+	Link     string //Path of email confirmation link from PlatformURL, email hash and code are appended. Code is sent without link if empty
+}
+
+//GetPolicy returns policy of the purpose, the channel must be one of its channels
+func GetPolicy(purpose string, channel string) (Policy, error) {
+	policy, ok := policies[purpose]
+	if !ok {
+		return policy, errors.New("INVALID_ACTION")
+	}
+
+	for _, name := range policy.Channels {
+		if name == channel {
+			return policy, nil
+		}
+	}
+
+	return policy, errors.New("INVALID_METHOD")
+}
+
+//Send cancels pending request of the member, stores a new code and delivers it with the channel in one transaction.
+//Returns seconds until the next code can be requested, with CODE_TIMEOUT error within the cooldown
+func Send(request Request) (int64, error) {
+	db := db.GetDB()
+
+	policy, err := GetPolicy(request.Purpose, request.Channel)
+	if err != nil {
+		return 0, err
+	}
+
+	channel, err := GetChannel(request.Channel)
+	if err != nil {
+		return 0, err
+	}
+
+	//Authenticator app codes aren't sent
+	if _, ok := channel.(Checker); ok {
+		return 0, errors.New("INVALID_METHOD")
+	}
+
+	timeout, err := Cooldown(request.Purpose, request.Channel, request.MemberID)
+	if err != nil {
+		return 0, err
+	}
+
+	if timeout > 0 {
+		return timeout, errors.New("CODE_TIMEOUT")
+	}
+
+	code, err := password.Generate(CODE_LENGTH, CODE_LENGTH, 0, true, false)
+	if err != nil {
+		return 0, err
+	}
+
+	CodeHash, err := bcrypt.GenerateFromPassword([]byte(code), CODE_COST)
+	if err != nil {
+		return 0, err
+	}
+
+	delivery := Delivery{Request: request, Code: code}
+	if request.Email != "" {
+		delivery.EmailHash = hashEmail(request.Email)
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	tx.MustExec("UPDATE Verify SET Status=$1 WHERE MemberID=$2 AND Action=$3 AND Method=$4 AND Status=$5", STATUS_CANCELLED, request.MemberID, request.Purpose, request.Channel, STATUS_PENDING)
+	tx.MustExec("INSERT INTO Verify (MemberID, Action, Method, CodeHash, Email, EmailHash, Phone, Status, IP, Created) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)", request.MemberID, request.Purpose, request.Channel, string(CodeHash), request.Email, delivery.EmailHash, request.Phone, STATUS_PENDING, request.IP, time.Now().Unix())
+
+	if err := channel.Deliver(tx, delivery, policy.Message); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int64(policy.Cooldown.Seconds()), nil
+}
+
+//Cooldown returns seconds until a new code of the purpose can be sent to the member
+func Cooldown(purpose string, channel string, MemberID int64) (int64, error) {
+	db := db.GetDB()
+
+	policy, err := GetPolicy(purpose, channel)
+	if err != nil {
+		return 0, err
+	}
+
+	var Created int64
+
+	if err := db.Get(&Created, "SELECT COALESCE(MAX(Created), 0) FROM Verify WHERE MemberID=$1 AND Action=$2 AND Method=$3", MemberID, purpose, channel); err != nil {
+		return 0, err
+	}
+
+	timeout := Created + int64(policy.Cooldown.Seconds()) - time.Now().Unix()
+	if timeout < 0 {
+		return 0, nil
+	}
+
+	return timeout, nil
+}
+
+//Check returns pending request of the member with the code. Authenticator app codes are checked by the channel, request has MemberID only
+func Check(purpose string, channel string, MemberID int64, code string) (models.Verify, error) {
+	db := db.GetDB()
+
+	var verify models.Verify
+
+	policy, err := GetPolicy(purpose, channel)
+	if err != nil {
+		return verify, err
+	}
+
+	value, err := GetChannel(channel)
+	if err != nil {
+		return verify, err
+	}
+
+	if checker, ok := value.(Checker); ok {
+		verify.MemberID = MemberID
+		return verify, checker.Check(MemberID, code)
+	}
+
+	if err := db.Get(&verify, "SELECT * FROM Verify WHERE MemberID=$1 AND Action=$2 AND Method=$3 AND Status=$4 ORDER BY Created DESC LIMIT 1", MemberID, purpose, channel, STATUS_PENDING); err != nil {
+		if err == sql.ErrNoRows {
+			return verify, errors.New("NO_VERIFY_RECORD")
+		}
+		return verify, err
+	}
+
+	if expired(policy, verify) {
+		return verify, errors.New("EXPIRED_REQUEST")
+	}
+
+	return verify, compare(policy, verify, code)
+}
+
+//CheckLink returns pending request of email confirmation link with the code
+func CheckLink(purpose string, hash string, code string) (models.Verify, error) {
+	db := db.GetDB()
+
+	var verify models.Verify
+
+	policy, err := GetPolicy(purpose, CHANNEL_EMAIL)
+	if err != nil {
+		return verify, err
+	}
+
+	if err := db.Get(&verify, "SELECT * FROM Verify WHERE EmailHash=$1 AND Action=$2 AND Method=$3 AND Status=$4 ORDER BY Created DESC LIMIT 1", hash, purpose, CHANNEL_EMAIL, STATUS_PENDING); err != nil {
+		if err == sql.ErrNoRows {
+			return verify, errors.New("INVALID_LINK")
+		}
+		return verify, err
+	}
+
+	if expired(policy, verify) {
+		return verify, errors.New("EXPIRED_LINK")
+	}
+
+	return verify, compare(policy, verify, code)
+}
+
+//Complete marks checked request as used within transaction of the verified action.
+//ALREADY_USED is returned if concurrent request completed it first, the caller must roll back the transaction
+func Complete(tx *sqlx.Tx, VerifyID int64) error {
+	result, err := tx.Exec("UPDATE Verify SET Status=$1 WHERE VerifyID=$2 AND Status=$3", STATUS_SUCCESS, VerifyID, STATUS_PENDING)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("ALREADY_USED")
+	}
+
+	return nil
+}
+
+//StepUp checks authenticator app code before sensitive action of member with MFA enabled, other members pass
+func StepUp(purpose string, member models.Member, code string) error {
+	if _, err := GetPolicy(purpose, CHANNEL_TOTP); err != nil {
+		return err
+	}
+
+	if !member.MFAEnabled {
+		return nil
+	}
+
+	if code == "" {
+		return errors.New("MFA_CODE_REQUIRED")
+	}
+
+	_, err := Check(purpose, CHANNEL_TOTP, member.MemberID, code)
+
+	return err
+}
+
+func expired(policy Policy, verify models.Verify) bool {
+	return time.Unix(verify.Created, 0).Add(policy.TTL).Before(time.Now())
+}
+
+//compare checks code of the request, the request fails after policy attempts
+func compare(policy Policy, verify models.Verify, code string) error {
+	db := db.GetDB()
+
+	if err := bcrypt.CompareHashAndPassword([]byte(verify.CodeHash), []byte(code)); err == nil {
+		return nil
+	}
+
+	var Attempts int
+
+	if err := db.Get(&Attempts, "UPDATE Verify SET Attempts=Attempts+1 WHERE VerifyID=$1 RETURNING Attempts", verify.VerifyID); err != nil {
+		return err
+	}
+
+	if Attempts >= policy.Attempts {
+		if _, err := db.Exec("UPDATE Verify SET Status=$1 WHERE VerifyID=$2 AND Status=$3", STATUS_FAIL, verify.VerifyID, STATUS_PENDING); err != nil {
+			return err
+		}
+
+		return errors.New("TOO_MANY_ATTEMPTS")
+	}
+
+	return errors.New("INVALID_CODE")
+}
+
+//hashEmail returns md5 hash of email, it identifies request of confirmation link
+func hashEmail(email string) string {
+	hash := md5.Sum([]byte(email))
+	return hex.EncodeToString(hash[:])
+}
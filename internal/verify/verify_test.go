@@ -0,0 +1,317 @@
+package verify
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testCode = "123456"
+
+//store - in-memory Verify table served by fake database driver
+type store struct {
+	mu     sync.Mutex
+	verify []models.Verify
+}
+
+var current *store
+
+func init() {
+	sql.Register("verifytest", storeDriver{})
+}
+
+//newStore replaces database with store of the requests
+func newStore(t *testing.T, verify ...models.Verify) *store {
+	current = &store{verify: verify}
+
+	conn, err := sql.Open("verifytest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db.DB = sqlx.NewDb(conn, "pgx")
+
+	return current
+}
+
+//pending returns pending request of member sent ago with hash of testCode
+func pending(t *testing.T, purpose string, channel string, ago time.Duration) models.Verify {
+	CodeHash, err := bcrypt.GenerateFromPassword([]byte(testCode), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return models.Verify{VerifyID: 1, MemberID: 7, CodeHash: string(CodeHash), Method: channel, Action: purpose, Status: STATUS_PENDING, Created: time.Now().Add(-ago).Unix()}
+}
+
+func verifyRow(item models.Verify) []driver.Value {
+	return []driver.Value{item.VerifyID, item.MemberID, item.CodeHash, item.Method, item.Action, item.Email, item.EmailHash, item.Phone, item.Status, int64(item.Attempts), item.IP, item.Created}
+}
+
+var verifyColumns = []string{"verifyid", "memberid", "codehash", "method", "action", "email", "emailhash", "phone", "status", "attempts", "ip", "created"}
+
+func (s *store) query(query string, args []driver.Value) ([]string, [][]driver.Value, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "SELECT COALESCE(MAX(Created), 0) FROM Verify WHERE MemberID=$1 AND Action=$2 AND Method=$3"):
+		var Created int64
+		for _, item := range s.verify {
+			if item.MemberID == args[0] && item.Action == args[1] && item.Method == args[2] && item.Created > Created {
+				Created = item.Created
+			}
+		}
+		return []string{"created"}, [][]driver.Value{{Created}}, nil
+	case strings.HasPrefix(query, "SELECT * FROM Verify WHERE MemberID=$1 AND Action=$2 AND Method=$3 AND Status=$4 ORDER BY Created DESC LIMIT 1"):
+		for i := len(s.verify) - 1; i >= 0; i-- {
+			item := s.verify[i]
+			if item.MemberID == args[0] && item.Action == args[1] && item.Method == args[2] && item.Status == args[3] {
+				return verifyColumns, [][]driver.Value{verifyRow(item)}, nil
+			}
+		}
+		return verifyColumns, nil, nil
+	case strings.HasPrefix(query, "UPDATE Verify SET Attempts=Attempts+1 WHERE VerifyID=$1 RETURNING Attempts"):
+		for i := range s.verify {
+			if s.verify[i].VerifyID == args[0] {
+				s.verify[i].Attempts++
+				return []string{"attempts"}, [][]driver.Value{{int64(s.verify[i].Attempts)}}, nil
+			}
+		}
+		return []string{"attempts"}, nil, nil
+	}
+
+	return nil, nil, errors.New("unexpected query: " + query)
+}
+
+func (s *store) exec(query string, args []driver.Value) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rows int64
+
+	switch {
+	case strings.HasPrefix(query, "UPDATE Verify SET Status=$1 WHERE VerifyID=$2 AND Status=$3"):
+		for i := range s.verify {
+			if s.verify[i].VerifyID == args[1] && s.verify[i].Status == args[2] {
+				s.verify[i].Status = args[0].(string)
+				rows++
+			}
+		}
+	default:
+		return 0, errors.New("unexpected query: " + query)
+	}
+
+	return rows, nil
+}
+
+type storeDriver struct{}
+
+func (storeDriver) Open(string) (driver.Conn, error) { return storeConn{}, nil }
+
+type storeConn struct{}
+
+func (storeConn) Prepare(query string) (driver.Stmt, error) { return storeStmt{query}, nil }
+func (storeConn) Close() error                              { return nil }
+func (storeConn) Begin() (driver.Tx, error)                 { return storeTx{}, nil }
+
+type storeTx struct{}
+
+func (storeTx) Commit() error   { return nil }
+func (storeTx) Rollback() error { return nil }
+
+type storeStmt struct {
+	query string
+}
+
+func (stmt storeStmt) Close() error  { return nil }
+func (stmt storeStmt) NumInput() int { return -1 }
+
+func (stmt storeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	rows, err := current.exec(stmt.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(rows), nil
+}
+
+func (stmt storeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	columns, rows, err := current.query(stmt.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &storeRows{columns: columns, rows: rows}, nil
+}
+
+type storeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (rows *storeRows) Columns() []string { return rows.columns }
+func (rows *storeRows) Close() error      { return nil }
+
+func (rows *storeRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+
+	return nil
+}
+
+//totp - authenticator app channel accepting testCode
+type totp struct{}
+
+func (totp) Deliver(tx *sqlx.Tx, delivery Delivery, message Message) error {
+	return errors.New("INVALID_METHOD")
+}
+
+func (totp) Check(MemberID int64, code string) error {
+	if code != testCode {
+		return errors.New("INVALID_CODE")
+	}
+	return nil
+}
+
+func TestCheckAttempts(t *testing.T) {
+	s := newStore(t, pending(t, PURPOSE_SIGNIN, CHANNEL_PHONE, time.Minute))
+
+	//Request fails after policy attempts
+	for i := 1; i <= policies[PURPOSE_SIGNIN].Attempts; i++ {
+		want := "INVALID_CODE"
+		if i == policies[PURPOSE_SIGNIN].Attempts {
+			want = "TOO_MANY_ATTEMPTS"
+		}
+
+		if _, err := Check(PURPOSE_SIGNIN, CHANNEL_PHONE, 7, "000000"); err == nil || err.Error() != want {
+			t.Fatalf("attempt %d: got %v, want %s", i, err, want)
+		}
+	}
+
+	if s.verify[0].Status != STATUS_FAIL {
+		t.Fatalf("status %s, want %s", s.verify[0].Status, STATUS_FAIL)
+	}
+
+	//Correct code doesn't revive the failed request
+	if _, err := Check(PURPOSE_SIGNIN, CHANNEL_PHONE, 7, testCode); err == nil || err.Error() != "NO_VERIFY_RECORD" {
+		t.Fatalf("got %v, want NO_VERIFY_RECORD", err)
+	}
+}
+
+func TestCheckComplete(t *testing.T) {
+	s := newStore(t, pending(t, PURPOSE_SIGNIN, CHANNEL_PHONE, time.Minute))
+
+	verify, err := Check(PURPOSE_SIGNIN, CHANNEL_PHONE, 7, testCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []string{"", "ALREADY_USED"} {
+		tx := db.GetDB().MustBegin()
+		err := Complete(tx, verify.VerifyID)
+		tx.Commit()
+
+		if (want == "" && err != nil) || (want != "" && (err == nil || err.Error() != want)) {
+			t.Fatalf("complete %d: got %v, want %q", i, err, want)
+		}
+	}
+
+	if s.verify[0].Status != STATUS_SUCCESS {
+		t.Fatalf("status %s, want %s", s.verify[0].Status, STATUS_SUCCESS)
+	}
+}
+
+func TestCheckExpiry(t *testing.T) {
+	TTL := policies[PURPOSE_SIGNIN].TTL
+
+	newStore(t, pending(t, PURPOSE_SIGNIN, CHANNEL_PHONE, TTL+time.Minute))
+
+	if _, err := Check(PURPOSE_SIGNIN, CHANNEL_PHONE, 7, testCode); err == nil || err.Error() != "EXPIRED_REQUEST" {
+		t.Fatalf("got %v, want EXPIRED_REQUEST", err)
+	}
+
+	newStore(t, pending(t, PURPOSE_SIGNIN, CHANNEL_PHONE, TTL-time.Minute))
+
+	if _, err := Check(PURPOSE_SIGNIN, CHANNEL_PHONE, 7, testCode); err != nil {
+		t.Fatalf("code within TTL: %v", err)
+	}
+}
+
+func TestCooldown(t *testing.T) {
+	cooldown := policies[PURPOSE_SIGNIN].Cooldown
+
+	newStore(t)
+
+	if timeout, err := Cooldown(PURPOSE_SIGNIN, CHANNEL_PHONE, 7); err != nil || timeout != 0 {
+		t.Fatalf("first code: timeout %d, error %v", timeout, err)
+	}
+
+	newStore(t, pending(t, PURPOSE_SIGNIN, CHANNEL_PHONE, 20*time.Second))
+
+	timeout, err := Cooldown(PURPOSE_SIGNIN, CHANNEL_PHONE, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := int64((cooldown - 20*time.Second).Seconds()); timeout < want-1 || timeout > want {
+		t.Fatalf("timeout %d, want %d", timeout, want)
+	}
+
+	//A new code is refused within the cooldown
+	if _, err := Send(Request{Purpose: PURPOSE_SIGNIN, Channel: CHANNEL_PHONE, MemberID: 7, Phone: "+10000000000"}); err == nil || err.Error() != "CODE_TIMEOUT" {
+		t.Fatalf("got %v, want CODE_TIMEOUT", err)
+	}
+
+	newStore(t, pending(t, PURPOSE_SIGNIN, CHANNEL_PHONE, cooldown+time.Second))
+
+	if timeout, err := Cooldown(PURPOSE_SIGNIN, CHANNEL_PHONE, 7); err != nil || timeout != 0 {
+		t.Fatalf("after cooldown: timeout %d, error %v", timeout, err)
+	}
+}
+
+func TestStepUp(t *testing.T) {
+	Register(CHANNEL_TOTP, totp{})
+	defer Register(CHANNEL_TOTP, TOTP{})
+
+	enabled := models.Member{MemberID: 7, MFAEnabled: true}
+
+	for _, purpose := range []string{PURPOSE_WITHDRAWAL, PURPOSE_PASSWORD, PURPOSE_PASSKEY} {
+		for _, test := range []struct {
+			name   string
+			member models.Member
+			code   string
+			err    string
+		}{
+			{"MFA disabled", models.Member{MemberID: 7}, "", ""},
+			{"no code", enabled, "", "MFA_CODE_REQUIRED"},
+			{"wrong code", enabled, "000000", "INVALID_CODE"},
+			{"code", enabled, testCode, ""},
+		} {
+			err := StepUp(purpose, test.member, test.code)
+
+			if (test.err == "" && err != nil) || (test.err != "" && (err == nil || err.Error() != test.err)) {
+				t.Errorf("%s, %s: got %v, want %q", purpose, test.name, err, test.err)
+			}
+		}
+	}
+
+	//Purposes without authenticator app codes
+	for purpose, want := range map[string]string{PURPOSE_SIGNUP: "INVALID_METHOD", "unknown": "INVALID_ACTION"} {
+		if err := StepUp(purpose, enabled, testCode); err == nil || err.Error() != want {
+			t.Errorf("%s: got %v, want %s", purpose, err, want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/ugorji/go/codec"
+)
+
+//COSE key parameters (RFC 8152)
+const (
+	COSE_KTY = 1
+	COSE_ALG = 3
+	COSE_CRV = -1 //EC2, OKP
+	COSE_X   = -2 //EC2, OKP
+	COSE_Y   = -3 //EC2
+	COSE_N   = -1 //RSA
+	COSE_E   = -2 //RSA
+
+	KTY_OKP = 1
+	KTY_EC2 = 2
+	KTY_RSA = 3
+
+	CRV_P256    = 1
+	CRV_ED25519 = 6
+
+	ALG_ES256 = -7
+	ALG_EDDSA = -8
+	ALG_RS256 = -257
+)
+
+var cborHandle = &codec.CborHandle{}
+
+func init() {
+	cborHandle.SignedInteger = true
+}
+
+//PublicKey - credential public key parsed from COSE key
+type PublicKey struct {
+	Alg int64
+	Key crypto.PublicKey
+}
+
+//ParsePublicKey parses COSE key of ES256, EdDSA (Ed25519) or RS256 credential
+func ParsePublicKey(cose []byte) (PublicKey, error) {
+	var publicKey PublicKey
+
+	var key map[int64]interface{}
+
+	if err := codec.NewDecoderBytes(cose, cborHandle).Decode(&key); err != nil {
+		return publicKey, errors.New("WEBAUTHN_INVALID_PUBLIC_KEY")
+	}
+
+	publicKey.Alg = coseInt(key[COSE_ALG])
+
+	switch coseInt(key[COSE_KTY]) {
+	case KTY_EC2:
+		x, y := coseBytes(key[COSE_X]), coseBytes(key[COSE_Y])
+		if publicKey.Alg != ALG_ES256 || coseInt(key[COSE_CRV]) != CRV_P256 || len(x) != 32 || len(y) != 32 {
+			return publicKey, errors.New("WEBAUTHN_UNSUPPORTED_ALGORITHM")
+		}
+
+		ecKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !ecKey.Curve.IsOnCurve(ecKey.X, ecKey.Y) {
+			return publicKey, errors.New("WEBAUTHN_INVALID_PUBLIC_KEY")
+		}
+
+		publicKey.Key = ecKey
+	case KTY_OKP:
+		x := coseBytes(key[COSE_X])
+		if publicKey.Alg != ALG_EDDSA || coseInt(key[COSE_CRV]) != CRV_ED25519 || len(x) != ed25519.PublicKeySize {
+			return publicKey, errors.New("WEBAUTHN_UNSUPPORTED_ALGORITHM")
+		}
+
+		publicKey.Key = ed25519.PublicKey(x)
+	case KTY_RSA:
+		n, e := coseBytes(key[COSE_N]), coseBytes(key[COSE_E])
+		if publicKey.Alg != ALG_RS256 || len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return publicKey, errors.New("WEBAUTHN_UNSUPPORTED_ALGORITHM")
+		}
+
+		publicKey.Key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	default:
+		return publicKey, errors.New("WEBAUTHN_UNSUPPORTED_ALGORITHM")
+	}
+
+	return publicKey, nil
+}
+
+//Verify checks signature of data
+func (publicKey PublicKey) Verify(data []byte, signature []byte) error {
+	digest := sha256.Sum256(data)
+
+	switch key := publicKey.Key.(type) {
+	case *ecdsa.PublicKey:
+		//ES256 signature is ASN.1 DER encoded
+		var sig struct {
+			R, S *big.Int
+		}
+
+		if rest, err := asn1.Unmarshal(signature, &sig); err != nil || len(rest) > 0 {
+			return errors.New("WEBAUTHN_INVALID_SIGNATURE")
+		}
+
+		if !ecdsa.Verify(key, digest[:], sig.R, sig.S) {
+			return errors.New("WEBAUTHN_INVALID_SIGNATURE")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return errors.New("WEBAUTHN_INVALID_SIGNATURE")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("WEBAUTHN_INVALID_SIGNATURE")
+		}
+	default:
+		return errors.New("WEBAUTHN_UNSUPPORTED_ALGORITHM")
+	}
+
+	return nil
+}
+
+func coseInt(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	}
+	return 0
+}
+
+func coseBytes(value interface{}) []byte {
+	if v, ok := value.([]byte); ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+package webauthn
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/spf13/cast"
+)
+
+const (
+	CHALLENGE_SIZE = 32
+	CHALLENGE_KEY  = "webauthn:challenge:" //webauthn:challenge:<base64url challenge> - Challenge JSON
+)
+
+//Challenge - issued challenge, it can be used once within TIMEOUT
+type Challenge struct {
+	Ceremony         string
+	MemberID         int64 //0 - passwordless sign in with any member credential
+	UserVerification string
+}
+
+//NewChallenge issues random challenge of the ceremony
+func NewChallenge(challenge Challenge) ([]byte, error) {
+	value := make([]byte, CHALLENGE_SIZE)
+
+	if _, err := rand.Read(value); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := redis.GetRedis().Do(radix.FlatCmd(nil, "SET", CHALLENGE_KEY+EncodeBase64(value), string(data), "EX", int64(TIMEOUT.Seconds()))); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+//UseChallenge returns issued challenge of client data and deletes it, so a response can't be replayed
+func UseChallenge(value []byte, ceremony string) (Challenge, error) {
+	var challenge Challenge
+
+	pool := redis.GetRedis()
+
+	key := CHALLENGE_KEY + EncodeBase64(value)
+
+	var data string
+
+	mn := radix.MaybeNil{Rcv: &data}
+	if err := pool.Do(radix.Cmd(&mn, "GET", key)); err != nil {
+		return challenge, err
+	}
+
+	var deleted int64
+
+	if err := pool.Do(radix.Cmd(&deleted, "DEL", key)); err != nil {
+		return challenge, err
+	}
+
+	if mn.Nil || deleted == 0 {
+		return challenge, errors.New("WEBAUTHN_CHALLENGE_EXPIRED")
+	}
+
+	if err := json.Unmarshal([]byte(data), &challenge); err != nil {
+		return challenge, err
+	}
+
+	if challenge.Ceremony != ceremony {
+		return challenge, errors.New("WEBAUTHN_INVALID_CHALLENGE")
+	}
+
+	return challenge, nil
+}
+
+//RegistrationOptions creates options of passkey registration of member, registered authenticators are excluded
+func RegistrationOptions(member models.Member) (CreationOptions, error) {
+	config := GetConfig()
+
+	var options CreationOptions
+
+	credentials, err := QueryCredentials(member.MemberID)
+	if err != nil {
+		return options, err
+	}
+
+	challenge, err := NewChallenge(Challenge{Ceremony: CEREMONY_REGISTER, MemberID: member.MemberID, UserVerification: VERIFICATION_PREFERRED})
+	if err != nil {
+		return options, err
+	}
+
+	DisplayName := strings.TrimSpace(member.FirstName + " " + member.LastName)
+	if DisplayName == "" {
+		DisplayName = member.Email
+	}
+
+	options = CreationOptions{
+		Challenge: challenge,
+		RP:        RelyingParty{ID: config.RPID, Name: config.RPName},
+		User: User{
+			ID:          UserHandle(member.MemberID),
+			Name:        member.Email,
+			DisplayName: DisplayName,
+		},
+		PubKeyCredParams:   credentialParameters,
+		Timeout:            TIMEOUT.Milliseconds(),
+		ExcludeCredentials: descriptors(credentials),
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: VERIFICATION_PREFERRED,
+		},
+		Attestation: "none",
+	}
+
+	return options, nil
+}
+
+//FinishRegistration verifies registration response of member and stores the credential
+func FinishRegistration(MemberID int64, name string, credential Credential) (models.WebAuthnCredential, error) {
+	db := db.GetDB()
+
+	var result models.WebAuthnCredential
+
+	_, value, err := ParseClientData(credential.Response.ClientDataJSON)
+	if err != nil {
+		return result, err
+	}
+
+	challenge, err := UseChallenge(value, CEREMONY_REGISTER)
+	if err != nil {
+		return result, err
+	}
+
+	if challenge.MemberID != MemberID {
+		return result, errors.New("WEBAUTHN_INVALID_CHALLENGE")
+	}
+
+	registration, err := GetConfig().VerifyRegistration(value, credential, challenge.UserVerification == VERIFICATION_REQUIRED)
+	if err != nil {
+		return result, err
+	}
+
+	CredentialID := EncodeBase64(registration.CredentialID)
+
+	var count int
+
+	if err := db.Get(&count, "SELECT count(*) FROM WebAuthnCredential WHERE CredentialID=$1", CredentialID); err != nil {
+		return result, err
+	}
+
+	if count > 0 {
+		return result, errors.New("WEBAUTHN_CREDENTIAL_EXISTS")
+	}
+
+	if name == "" {
+		name = "Passkey"
+	}
+
+	if err := db.Get(&result, "INSERT INTO WebAuthnCredential (MemberID, CredentialID, PublicKey, SignCount, AAGUID, Name, Created) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *", MemberID, CredentialID, EncodeBase64(registration.PublicKey), registration.SignCount, hex.EncodeToString(registration.AAGUID), name, time.Now().Unix()); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+//LoginOptions creates options of passkey sign in. Member credentials are allowed if MemberID is known, otherwise authenticator offers discoverable credentials
+func LoginOptions(ceremony string, MemberID int64) (RequestOptions, error) {
+	config := GetConfig()
+
+	var options RequestOptions
+
+	var credentials []models.WebAuthnCredential
+
+	if MemberID != 0 {
+		var err error
+
+		credentials, err = QueryCredentials(MemberID)
+		if err != nil {
+			return options, err
+		}
+	}
+
+	//Passwordless sign in replaces password, so the authenticator verifies the user (PIN, biometrics)
+	UserVerification := VERIFICATION_PREFERRED
+	if ceremony == CEREMONY_LOGIN {
+		UserVerification = VERIFICATION_REQUIRED
+	}
+
+	challenge, err := NewChallenge(Challenge{Ceremony: ceremony, MemberID: MemberID, UserVerification: UserVerification})
+	if err != nil {
+		return options, err
+	}
+
+	options = RequestOptions{
+		Challenge:        challenge,
+		Timeout:          TIMEOUT.Milliseconds(),
+		RPID:             config.RPID,
+		AllowCredentials: descriptors(credentials),
+		UserVerification: UserVerification,
+	}
+
+	return options, nil
+}
+
+//FinishLogin verifies sign in response and returns the used credential. MemberID limits credentials to the member (second step), 0 - member of the challenge options or any member (passwordless)
+func FinishLogin(ceremony string, MemberID int64, credential Credential) (models.WebAuthnCredential, error) {
+	db := db.GetDB()
+
+	var result models.WebAuthnCredential
+
+	_, value, err := ParseClientData(credential.Response.ClientDataJSON)
+	if err != nil {
+		return result, err
+	}
+
+	challenge, err := UseChallenge(value, ceremony)
+	if err != nil {
+		return result, err
+	}
+
+	//Options of a known email allow credentials of the member only
+	if MemberID == 0 {
+		MemberID = challenge.MemberID
+	}
+
+	if challenge.MemberID != 0 && challenge.MemberID != MemberID {
+		return result, errors.New("WEBAUTHN_INVALID_CHALLENGE")
+	}
+
+	if err := db.Get(&result, "SELECT * FROM WebAuthnCredential WHERE CredentialID=$1", EncodeBase64(credential.RawID)); err != nil {
+		if err == sql.ErrNoRows {
+			return result, errors.New("WEBAUTHN_UNKNOWN_CREDENTIAL")
+		}
+		return result, err
+	}
+
+	if MemberID != 0 && result.MemberID != MemberID {
+		return result, errors.New("WEBAUTHN_UNKNOWN_CREDENTIAL")
+	}
+
+	//Discoverable credential returns user handle of registration
+	if len(credential.Response.UserHandle) > 0 && string(credential.Response.UserHandle) != string(UserHandle(result.MemberID)) {
+		return result, errors.New("WEBAUTHN_UNKNOWN_CREDENTIAL")
+	}
+
+	publicKey, err := DecodeBase64(result.PublicKey)
+	if err != nil {
+		return result, err
+	}
+
+	signCount, err := GetConfig().VerifyAssertion(value, credential, publicKey, uint32(result.SignCount), challenge.UserVerification == VERIFICATION_REQUIRED)
+	if err != nil {
+		return result, err
+	}
+
+	result.SignCount = int64(signCount)
+	result.LastUsed = time.Now().Unix()
+
+	tx := db.MustBegin()
+	tx.MustExec("UPDATE WebAuthnCredential SET SignCount=$1, LastUsed=$2 WHERE WebAuthnCredentialID=$3", result.SignCount, result.LastUsed, result.WebAuthnCredentialID)
+	tx.Commit()
+
+	return result, nil
+}
+
+//QueryCredentials returns passkeys of member
+func QueryCredentials(MemberID int64) ([]models.WebAuthnCredential, error) {
+	db := db.GetDB()
+
+	credentials := []models.WebAuthnCredential{}
+
+	if err := db.Select(&credentials, "SELECT * FROM WebAuthnCredential WHERE MemberID=$1 ORDER BY WebAuthnCredentialID ASC", MemberID); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+//Delete removes passkey of member
+func Delete(MemberID int64, WebAuthnCredentialID int64) error {
+	db := db.GetDB()
+
+	res := db.MustExec("DELETE FROM WebAuthnCredential WHERE WebAuthnCredentialID=$1 AND MemberID=$2", WebAuthnCredentialID, MemberID)
+
+	if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+		return errors.New("NO_PASSKEY_RECORD")
+	}
+
+	return nil
+}
+
+//UserHandle - WebAuthn user ID of member
+func UserHandle(MemberID int64) []byte {
+	return []byte(cast.ToString(MemberID))
+}
+
+func descriptors(credentials []models.WebAuthnCredential) []CredentialDescriptor {
+	result := []CredentialDescriptor{}
+
+	for _, credential := range credentials {
+		ID, err := DecodeBase64(credential.CredentialID)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, CredentialDescriptor{Type: "public-key", ID: ID})
+	}
+
+	return result
+}
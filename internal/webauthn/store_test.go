@@ -0,0 +1,293 @@
+package webauthn
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/mediocregopher/radix/v3"
+	"github.com/spf13/viper"
+)
+
+//store - in-memory WebAuthnCredential table served by fake database driver
+type store struct {
+	mu          sync.Mutex
+	credentials []models.WebAuthnCredential
+}
+
+var current *store
+
+func init() {
+	sql.Register("webauthntest", storeDriver{})
+}
+
+//newStore replaces database with empty store, redis with in-memory stub and settings with the test relying party
+func newStore(t *testing.T) (*store, func()) {
+	viper.Set("webauthn_rp_id", testConfig.RPID)
+	viper.Set("webauthn_rp_name", testConfig.RPName)
+	viper.Set("webauthn_origins", strings.Join(testConfig.Origins, ","))
+
+	current = &store{}
+
+	conn, err := sql.Open("webauthntest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db.DB = sqlx.NewDb(conn, "pgx")
+
+	var mu sync.Mutex
+	values := map[string]string{}
+
+	stub := func(args []string) interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch strings.ToUpper(args[0]) {
+		case "SET":
+			values[args[1]] = args[2]
+			return "OK"
+		case "GET":
+			value, ok := values[args[1]]
+			if !ok {
+				return nil
+			}
+			return value
+		case "DEL":
+			_, ok := values[args[1]]
+			delete(values, args[1])
+			if ok {
+				return int64(1)
+			}
+			return int64(0)
+		}
+		return "OK"
+	}
+
+	pool, err := radix.NewPool("tcp", "stub", 1, radix.PoolPipelineWindow(0, 0), radix.PoolConnFunc(func(network, addr string) (radix.Conn, error) {
+		return radix.Stub(network, addr, stub), nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redis.SetRedis(pool)
+
+	return current, func() { pool.Close() }
+}
+
+//add registers credential of the authenticator to member
+func (s *store) add(t *testing.T, MemberID int64, a *authenticator) {
+	registration := register(t, a)
+
+	s.credentials = append(s.credentials, models.WebAuthnCredential{
+		WebAuthnCredentialID: int64(len(s.credentials) + 1),
+		MemberID:             MemberID,
+		CredentialID:         EncodeBase64(registration.CredentialID),
+		PublicKey:            EncodeBase64(registration.PublicKey),
+		SignCount:            int64(registration.SignCount),
+	})
+}
+
+func credentialRow(item models.WebAuthnCredential) []driver.Value {
+	return []driver.Value{item.WebAuthnCredentialID, item.MemberID, item.CredentialID, item.PublicKey, item.SignCount, item.AAGUID, item.Name, item.Created, item.LastUsed}
+}
+
+var credentialColumns = []string{"webauthncredentialid", "memberid", "credentialid", "publickey", "signcount", "aaguid", "name", "created", "lastused"}
+
+func (s *store) query(query string, args []driver.Value) ([]string, [][]driver.Value, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows := [][]driver.Value{}
+
+	switch {
+	case strings.HasPrefix(query, "SELECT * FROM WebAuthnCredential WHERE CredentialID=$1"):
+		for _, item := range s.credentials {
+			if item.CredentialID == args[0] {
+				rows = append(rows, credentialRow(item))
+			}
+		}
+	case strings.HasPrefix(query, "SELECT * FROM WebAuthnCredential WHERE MemberID=$1"):
+		for _, item := range s.credentials {
+			if item.MemberID == args[0] {
+				rows = append(rows, credentialRow(item))
+			}
+		}
+	default:
+		return nil, nil, errors.New("unexpected query: " + query)
+	}
+
+	return credentialColumns, rows, nil
+}
+
+func (s *store) exec(query string, args []driver.Value) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "UPDATE WebAuthnCredential SET SignCount=$1, LastUsed=$2 WHERE WebAuthnCredentialID=$3"):
+		for i := range s.credentials {
+			if s.credentials[i].WebAuthnCredentialID == args[2] {
+				s.credentials[i].SignCount = args[0].(int64)
+				s.credentials[i].LastUsed = args[1].(int64)
+			}
+		}
+	default:
+		return errors.New("unexpected query: " + query)
+	}
+
+	return nil
+}
+
+type storeDriver struct{}
+
+func (storeDriver) Open(string) (driver.Conn, error) { return storeConn{}, nil }
+
+type storeConn struct{}
+
+func (storeConn) Prepare(query string) (driver.Stmt, error) { return storeStmt{query}, nil }
+func (storeConn) Close() error                              { return nil }
+func (storeConn) Begin() (driver.Tx, error)                 { return storeTx{}, nil }
+
+type storeTx struct{}
+
+func (storeTx) Commit() error   { return nil }
+func (storeTx) Rollback() error { return nil }
+
+type storeStmt struct {
+	query string
+}
+
+func (stmt storeStmt) Close() error  { return nil }
+func (stmt storeStmt) NumInput() int { return -1 }
+
+func (stmt storeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if err := current.exec(stmt.query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (stmt storeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	columns, rows, err := current.query(stmt.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &storeRows{columns: columns, rows: rows}, nil
+}
+
+type storeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (rows *storeRows) Columns() []string { return rows.columns }
+func (rows *storeRows) Close() error      { return nil }
+
+func (rows *storeRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+
+	return nil
+}
+
+func TestLoginOfKnownEmail(t *testing.T) {
+	s, done := newStore(t)
+	defer done()
+
+	member, other := newAuthenticator(t), newAuthenticator(t)
+	s.add(t, 7, member)
+	s.add(t, 8, other)
+
+	//Sign in of a known email, the handler passes no member to FinishLogin
+	options, err := LoginOptions(CEREMONY_LOGIN, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(options.AllowCredentials) != 1 || string(options.AllowCredentials[0].ID) != string(member.credentialID) {
+		t.Fatalf("unexpected allowed credentials %+v", options.AllowCredentials)
+	}
+
+	credential, err := FinishLogin(CEREMONY_LOGIN, 0, member.get(options.Challenge))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if credential.MemberID != 7 || s.credentials[0].SignCount != int64(member.signCount) {
+		t.Fatalf("unexpected credential %+v", credential)
+	}
+
+	//Options of the email don't sign in another member
+	options, err = LoginOptions(CEREMONY_LOGIN, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FinishLogin(CEREMONY_LOGIN, 0, other.get(options.Challenge)); err == nil || err.Error() != "WEBAUTHN_UNKNOWN_CREDENTIAL" {
+		t.Fatalf("got %v, want WEBAUTHN_UNKNOWN_CREDENTIAL", err)
+	}
+}
+
+func TestLoginDiscoverable(t *testing.T) {
+	s, done := newStore(t)
+	defer done()
+
+	a := newAuthenticator(t)
+	s.add(t, 7, a)
+
+	options, err := LoginOptions(CEREMONY_LOGIN, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(options.AllowCredentials) != 0 {
+		t.Fatalf("unexpected allowed credentials %+v", options.AllowCredentials)
+	}
+
+	response := a.get(options.Challenge)
+
+	credential, err := FinishLogin(CEREMONY_LOGIN, 0, response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if credential.MemberID != 7 {
+		t.Fatalf("signed in member %d, want 7", credential.MemberID)
+	}
+
+	//Challenge is used once
+	if _, err := FinishLogin(CEREMONY_LOGIN, 0, response); err == nil || err.Error() != "WEBAUTHN_CHALLENGE_EXPIRED" {
+		t.Fatalf("replayed: got %v, want WEBAUTHN_CHALLENGE_EXPIRED", err)
+	}
+}
+
+func TestLoginSecondStepOfAnotherMember(t *testing.T) {
+	s, done := newStore(t)
+	defer done()
+
+	a := newAuthenticator(t)
+	s.add(t, 7, a)
+
+	options, err := LoginOptions(CEREMONY_MFA, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := FinishLogin(CEREMONY_MFA, 8, a.get(options.Challenge)); err == nil || err.Error() != "WEBAUTHN_INVALID_CHALLENGE" {
+		t.Fatalf("got %v, want WEBAUTHN_INVALID_CHALLENGE", err)
+	}
+}
@@ -0,0 +1,231 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+
+	"github.com/ugorji/go/codec"
+)
+
+//Authenticator data flags
+const (
+	FLAG_UP = 0x01 //User present
+	FLAG_UV = 0x04 //User verified
+	FLAG_AT = 0x40 //Attested credential data included
+	FLAG_ED = 0x80 //Extension data included
+)
+
+//ClientData - collected client data signed by authenticator
+type ClientData struct {
+	Type      string `json:"type"` //webauthn.create, webauthn.get
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+//ParseClientData parses clientDataJSON and returns its challenge
+func ParseClientData(raw []byte) (ClientData, []byte, error) {
+	var clientData ClientData
+
+	if err := json.Unmarshal(raw, &clientData); err != nil {
+		return clientData, nil, errors.New("WEBAUTHN_INVALID_CLIENT_DATA")
+	}
+
+	challenge, err := DecodeBase64(clientData.Challenge)
+	if err != nil || len(challenge) == 0 {
+		return clientData, nil, errors.New("WEBAUTHN_INVALID_CLIENT_DATA")
+	}
+
+	return clientData, challenge, nil
+}
+
+//AuthenticatorData - parsed authenticator data
+type AuthenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte //Attested credential data (registration)
+	CredentialID []byte
+	PublicKey    []byte //COSE key
+}
+
+//ParseAuthenticatorData parses authenticator data with optional attested credential data
+func ParseAuthenticatorData(raw []byte) (AuthenticatorData, error) {
+	var data AuthenticatorData
+
+	if len(raw) < 37 {
+		return data, errors.New("WEBAUTHN_INVALID_AUTHENTICATOR_DATA")
+	}
+
+	data.RPIDHash = raw[:32]
+	data.Flags = raw[32]
+	data.SignCount = binary.BigEndian.Uint32(raw[33:37])
+
+	if data.Flags&FLAG_AT == 0 {
+		return data, nil
+	}
+
+	//aaguid (16), credential ID length (2), credential ID, COSE public key
+	rest := raw[37:]
+	if len(rest) < 18 {
+		return data, errors.New("WEBAUTHN_INVALID_AUTHENTICATOR_DATA")
+	}
+
+	data.AAGUID = rest[:16]
+	length := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+
+	if length == 0 || len(rest) < length {
+		return data, errors.New("WEBAUTHN_INVALID_AUTHENTICATOR_DATA")
+	}
+
+	data.CredentialID = rest[:length]
+	rest = rest[length:]
+
+	//COSE key length is known after decoding, extensions may follow
+	var key interface{}
+
+	decoder := codec.NewDecoderBytes(rest, cborHandle)
+	if err := decoder.Decode(&key); err != nil {
+		return data, errors.New("WEBAUTHN_INVALID_AUTHENTICATOR_DATA")
+	}
+
+	data.PublicKey = rest[:decoder.NumBytesRead()]
+
+	return data, nil
+}
+
+//Registration - verified new credential
+type Registration struct {
+	CredentialID []byte
+	PublicKey    []byte //COSE key
+	SignCount    uint32
+	AAGUID       []byte
+}
+
+//VerifyRegistration verifies attestation response of navigator.credentials.create() against issued challenge.
+//Attestation "none" is requested, so attestation statement is not verified: the key is trusted as registered by signed in member
+func (config Config) VerifyRegistration(challenge []byte, credential Credential, requireUV bool) (Registration, error) {
+	var registration Registration
+
+	if err := config.verifyClientData(credential.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return registration, err
+	}
+
+	var attestation struct {
+		Fmt      string `codec:"fmt"`
+		AuthData []byte `codec:"authData"`
+	}
+
+	if err := codec.NewDecoderBytes(credential.Response.AttestationObject, cborHandle).Decode(&attestation); err != nil {
+		return registration, errors.New("WEBAUTHN_INVALID_ATTESTATION")
+	}
+
+	data, err := ParseAuthenticatorData(attestation.AuthData)
+	if err != nil {
+		return registration, err
+	}
+
+	if err := config.verifyAuthenticatorData(data, requireUV); err != nil {
+		return registration, err
+	}
+
+	if data.Flags&FLAG_AT == 0 || len(data.CredentialID) == 0 {
+		return registration, errors.New("WEBAUTHN_INVALID_ATTESTATION")
+	}
+
+	if len(credential.RawID) > 0 && !bytes.Equal(credential.RawID, data.CredentialID) {
+		return registration, errors.New("WEBAUTHN_INVALID_ATTESTATION")
+	}
+
+	if _, err := ParsePublicKey(data.PublicKey); err != nil {
+		return registration, err
+	}
+
+	registration.CredentialID = data.CredentialID
+	registration.PublicKey = data.PublicKey
+	registration.SignCount = data.SignCount
+	registration.AAGUID = data.AAGUID
+
+	return registration, nil
+}
+
+//VerifyAssertion verifies assertion response of navigator.credentials.get() with stored credential public key and returns new signature counter
+func (config Config) VerifyAssertion(challenge []byte, credential Credential, publicKey []byte, signCount uint32, requireUV bool) (uint32, error) {
+	if err := config.verifyClientData(credential.Response.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+
+	data, err := ParseAuthenticatorData(credential.Response.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := config.verifyAuthenticatorData(data, requireUV); err != nil {
+		return 0, err
+	}
+
+	key, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	//Signature over authenticator data and client data hash
+	clientDataHash := sha256.Sum256(credential.Response.ClientDataJSON)
+	signed := append(append([]byte{}, credential.Response.AuthenticatorData...), clientDataHash[:]...)
+
+	if err := key.Verify(signed, credential.Response.Signature); err != nil {
+		return 0, err
+	}
+
+	//Counter that doesn't grow means a cloned authenticator, authenticators without counter always send 0
+	if (data.SignCount != 0 || signCount != 0) && data.SignCount <= signCount {
+		return 0, errors.New("WEBAUTHN_INVALID_SIGN_COUNT")
+	}
+
+	return data.SignCount, nil
+}
+
+func (config Config) verifyClientData(raw []byte, ceremony string, challenge []byte) error {
+	clientData, received, err := ParseClientData(raw)
+	if err != nil {
+		return err
+	}
+
+	if clientData.Type != ceremony {
+		return errors.New("WEBAUTHN_INVALID_CLIENT_DATA")
+	}
+
+	if subtle.ConstantTimeCompare(received, challenge) != 1 {
+		return errors.New("WEBAUTHN_INVALID_CHALLENGE")
+	}
+
+	for _, origin := range config.Origins {
+		if clientData.Origin == origin {
+			return nil
+		}
+	}
+
+	return errors.New("WEBAUTHN_INVALID_ORIGIN")
+}
+
+func (config Config) verifyAuthenticatorData(data AuthenticatorData, requireUV bool) error {
+	rpIDHash := sha256.Sum256([]byte(config.RPID))
+
+	if subtle.ConstantTimeCompare(data.RPIDHash, rpIDHash[:]) != 1 {
+		return errors.New("WEBAUTHN_INVALID_RP_ID")
+	}
+
+	if data.Flags&FLAG_UP == 0 {
+		return errors.New("WEBAUTHN_USER_NOT_PRESENT")
+	}
+
+	if requireUV && data.Flags&FLAG_UV == 0 {
+		return errors.New("WEBAUTHN_USER_NOT_VERIFIED")
+	}
+
+	return nil
+}
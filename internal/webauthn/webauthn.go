@@ -0,0 +1,159 @@
+package webauthn
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+//Ceremonies of a challenge
+const (
+	CEREMONY_REGISTER = "register" //Passkey registration of signed in member
+	CEREMONY_LOGIN    = "login"    //Passwordless sign in
+	CEREMONY_MFA      = "mfa"      //Passkey as the second sign in step
+)
+
+//User verification requirement (PIN, biometrics) of the authenticator
+const (
+	VERIFICATION_REQUIRED  = "required"
+	VERIFICATION_PREFERRED = "preferred"
+)
+
+const TIMEOUT = 5 * time.Minute
+
+//Config - relying party, the site passkeys are scoped to
+type Config struct {
+	RPID    string   //Domain of the site, e.g. example.com
+	RPName  string   //Name shown by authenticator
+	Origins []string //Allowed origins of client data, e.g. https://app.example.com
+}
+
+//GetConfig returns relying party from settings
+func GetConfig() Config {
+	config := Config{
+		RPID:   viper.GetString("webauthn_rp_id"),
+		RPName: viper.GetString("webauthn_rp_name"),
+	}
+
+	for _, origin := range strings.Split(viper.GetString("webauthn_origins"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			config.Origins = append(config.Origins, strings.TrimSuffix(origin, "/"))
+		}
+	}
+
+	return config
+}
+
+//Base64 - binary field encoded as base64url in JSON (WebAuthn JSON serialization)
+type Base64 []byte
+
+func (b Base64) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + base64.RawURLEncoding.EncodeToString(b) + `"`), nil
+}
+
+func (b *Base64) UnmarshalJSON(data []byte) error {
+	value := string(data)
+
+	if value == "null" {
+		*b = nil
+		return nil
+	}
+
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return errors.New("WEBAUTHN_INVALID_ENCODING")
+	}
+
+	decoded, err := DecodeBase64(value[1 : len(value)-1])
+	if err != nil {
+		return errors.New("WEBAUTHN_INVALID_ENCODING")
+	}
+
+	*b = decoded
+
+	return nil
+}
+
+//DecodeBase64 decodes base64url with or without padding
+func DecodeBase64(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+//EncodeBase64 encodes base64url without padding, credential IDs are stored encoded
+func EncodeBase64(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+type RelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type User struct {
+	ID          Base64 `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   Base64 `json:"id"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+//CreationOptions - options of navigator.credentials.create()
+type CreationOptions struct {
+	Challenge              Base64                 `json:"challenge"`
+	RP                     RelyingParty           `json:"rp"`
+	User                   User                   `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+//RequestOptions - options of navigator.credentials.get()
+type RequestOptions struct {
+	Challenge        Base64                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+//AuthenticatorResponse - attestation (registration) or assertion (sign in) response
+type AuthenticatorResponse struct {
+	ClientDataJSON    Base64 `json:"clientDataJSON"`
+	AttestationObject Base64 `json:"attestationObject"` //Registration
+	AuthenticatorData Base64 `json:"authenticatorData"` //Sign in
+	Signature         Base64 `json:"signature"`         //Sign in
+	UserHandle        Base64 `json:"userHandle"`        //Sign in with discoverable credential
+}
+
+//Credential - PublicKeyCredential returned by the browser
+type Credential struct {
+	ID       string                `json:"id"`
+	RawID    Base64                `json:"rawId"`
+	Type     string                `json:"type"`
+	Response AuthenticatorResponse `json:"response"`
+}
+
+//credentialParameters - supported algorithms in order of preference
+var credentialParameters = []CredentialParameter{
+	{Type: "public-key", Alg: ALG_ES256},
+	{Type: "public-key", Alg: ALG_EDDSA},
+	{Type: "public-key", Alg: ALG_RS256},
+}
@@ -0,0 +1,278 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	testRPID   = "example.com"
+	testOrigin = "https://app.example.com"
+)
+
+var testConfig = Config{RPID: testRPID, RPName: "Example", Origins: []string{testOrigin}}
+
+//authenticator - software authenticator with ES256 (P-256) credential
+type authenticator struct {
+	t            *testing.T
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	signCount    uint32
+	origin       string
+	rpID         string
+}
+
+func newAuthenticator(t *testing.T) *authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		t.Fatal(err)
+	}
+
+	return &authenticator{t: t, key: key, credentialID: credentialID, origin: testOrigin, rpID: testRPID}
+}
+
+func (a *authenticator) encode(value interface{}) []byte {
+	var out []byte
+
+	if err := codec.NewEncoderBytes(&out, cborHandle).Encode(value); err != nil {
+		a.t.Fatal(err)
+	}
+
+	return out
+}
+
+//coseKey returns COSE EC2 key of the credential
+func (a *authenticator) coseKey() []byte {
+	x, y := a.key.X.Bytes(), a.key.Y.Bytes()
+	x = append(make([]byte, 32-len(x)), x...)
+	y = append(make([]byte, 32-len(y)), y...)
+
+	return a.encode(map[int64]interface{}{
+		COSE_KTY: KTY_EC2,
+		COSE_ALG: ALG_ES256,
+		COSE_CRV: CRV_P256,
+		COSE_X:   x,
+		COSE_Y:   y,
+	})
+}
+
+func (a *authenticator) clientData(ceremony string, challenge []byte) []byte {
+	raw, err := json.Marshal(ClientData{Type: ceremony, Challenge: EncodeBase64(challenge), Origin: a.origin})
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	return raw
+}
+
+func (a *authenticator) authenticatorData(flags byte, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[33:37], a.signCount)
+
+	if attested {
+		length := make([]byte, 2)
+		binary.BigEndian.PutUint16(length, uint16(len(a.credentialID)))
+
+		data = append(data, make([]byte, 16)...)
+		data = append(data, length...)
+		data = append(data, a.credentialID...)
+		data = append(data, a.coseKey()...)
+	}
+
+	return data
+}
+
+//create returns attestation response of navigator.credentials.create() with "none" attestation
+func (a *authenticator) create(challenge []byte) Credential {
+	authData := a.authenticatorData(FLAG_UP|FLAG_UV|FLAG_AT, true)
+
+	return Credential{
+		ID:    EncodeBase64(a.credentialID),
+		RawID: a.credentialID,
+		Type:  "public-key",
+		Response: AuthenticatorResponse{
+			ClientDataJSON:    a.clientData("webauthn.create", challenge),
+			AttestationObject: a.encode(map[string]interface{}{"fmt": "none", "attStmt": map[string]interface{}{}, "authData": authData}),
+		},
+	}
+}
+
+//get returns assertion response of navigator.credentials.get(), the counter is incremented
+func (a *authenticator) get(challenge []byte) Credential {
+	a.signCount++
+
+	authData := a.authenticatorData(FLAG_UP|FLAG_UV, false)
+	clientData := a.clientData("webauthn.get", challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+
+	r, s, err := ecdsa.Sign(rand.Reader, a.key, digest[:])
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	signature, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	return Credential{
+		ID:    EncodeBase64(a.credentialID),
+		RawID: a.credentialID,
+		Type:  "public-key",
+		Response: AuthenticatorResponse{
+			ClientDataJSON:    clientData,
+			AuthenticatorData: authData,
+			Signature:         signature,
+		},
+	}
+}
+
+func newChallenge(t *testing.T) []byte {
+	challenge := make([]byte, 32)
+	if _, err := rand.Read(challenge); err != nil {
+		t.Fatal(err)
+	}
+	return challenge
+}
+
+//register runs registration ceremony of the authenticator
+func register(t *testing.T, a *authenticator) Registration {
+	challenge := newChallenge(t)
+
+	registration, err := testConfig.VerifyRegistration(challenge, a.create(challenge), true)
+	if err != nil {
+		t.Fatalf("registration: %v", err)
+	}
+
+	return registration
+}
+
+func TestRegistrationAndAssertion(t *testing.T) {
+	a := newAuthenticator(t)
+
+	registration := register(t, a)
+
+	if string(registration.CredentialID) != string(a.credentialID) {
+		t.Fatal("credential ID mismatch")
+	}
+
+	signCount := registration.SignCount
+
+	for i := 0; i < 2; i++ {
+		challenge := newChallenge(t)
+
+		count, err := testConfig.VerifyAssertion(challenge, a.get(challenge), registration.PublicKey, signCount, true)
+		if err != nil {
+			t.Fatalf("assertion %d: %v", i, err)
+		}
+
+		if count != a.signCount {
+			t.Fatalf("sign count %d, want %d", count, a.signCount)
+		}
+
+		signCount = count
+	}
+}
+
+func TestRegistrationWrongChallenge(t *testing.T) {
+	a := newAuthenticator(t)
+
+	if _, err := testConfig.VerifyRegistration(newChallenge(t), a.create(newChallenge(t)), true); err == nil || err.Error() != "WEBAUTHN_INVALID_CHALLENGE" {
+		t.Fatalf("got %v, want WEBAUTHN_INVALID_CHALLENGE", err)
+	}
+}
+
+func TestAssertionBadSignature(t *testing.T) {
+	a := newAuthenticator(t)
+	registration := register(t, a)
+
+	challenge := newChallenge(t)
+	credential := a.get(challenge)
+
+	//Signature of another key
+	other := newAuthenticator(t)
+	credential.Response.Signature = other.get(challenge).Response.Signature
+
+	if _, err := testConfig.VerifyAssertion(challenge, credential, registration.PublicKey, registration.SignCount, true); err == nil || err.Error() != "WEBAUTHN_INVALID_SIGNATURE" {
+		t.Fatalf("got %v, want WEBAUTHN_INVALID_SIGNATURE", err)
+	}
+
+	//Authenticator data changed after signing
+	credential = a.get(challenge)
+	credential.Response.AuthenticatorData[36]++
+
+	if _, err := testConfig.VerifyAssertion(challenge, credential, registration.PublicKey, registration.SignCount, true); err == nil || err.Error() != "WEBAUTHN_INVALID_SIGNATURE" {
+		t.Fatalf("got %v, want WEBAUTHN_INVALID_SIGNATURE", err)
+	}
+}
+
+func TestWrongOrigin(t *testing.T) {
+	a := newAuthenticator(t)
+	a.origin = "https://evil.example.net"
+
+	challenge := newChallenge(t)
+
+	if _, err := testConfig.VerifyRegistration(challenge, a.create(challenge), true); err == nil || err.Error() != "WEBAUTHN_INVALID_ORIGIN" {
+		t.Fatalf("registration: got %v, want WEBAUTHN_INVALID_ORIGIN", err)
+	}
+
+	a.origin = testOrigin
+	registration := register(t, a)
+
+	a.origin = "https://evil.example.net"
+
+	if _, err := testConfig.VerifyAssertion(challenge, a.get(challenge), registration.PublicKey, registration.SignCount, true); err == nil || err.Error() != "WEBAUTHN_INVALID_ORIGIN" {
+		t.Fatalf("assertion: got %v, want WEBAUTHN_INVALID_ORIGIN", err)
+	}
+}
+
+func TestWrongRPID(t *testing.T) {
+	a := newAuthenticator(t)
+	a.rpID = "evil.example.net"
+
+	challenge := newChallenge(t)
+
+	if _, err := testConfig.VerifyRegistration(challenge, a.create(challenge), true); err == nil || err.Error() != "WEBAUTHN_INVALID_RP_ID" {
+		t.Fatalf("got %v, want WEBAUTHN_INVALID_RP_ID", err)
+	}
+}
+
+func TestSignCountRegression(t *testing.T) {
+	a := newAuthenticator(t)
+	registration := register(t, a)
+
+	challenge := newChallenge(t)
+
+	signCount, err := testConfig.VerifyAssertion(challenge, a.get(challenge), registration.PublicKey, registration.SignCount, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Cloned authenticator sends a counter that isn't greater than the stored one
+	for _, count := range []uint32{signCount, signCount - 1} {
+		a.signCount = count - 1
+
+		if _, err := testConfig.VerifyAssertion(challenge, a.get(challenge), registration.PublicKey, signCount, true); err == nil || err.Error() != "WEBAUTHN_INVALID_SIGN_COUNT" {
+			t.Fatalf("counter %d: got %v, want WEBAUTHN_INVALID_SIGN_COUNT", count, err)
+		}
+	}
+}
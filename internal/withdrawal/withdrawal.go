@@ -0,0 +1,322 @@
+package withdrawal
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ianidi/exchange-server/graph/model"
+	"github.com/ianidi/exchange-server/internal/account"
+	"github.com/ianidi/exchange-server/internal/db"
+	"github.com/ianidi/exchange-server/internal/ledger"
+	"github.com/ianidi/exchange-server/internal/models"
+	"github.com/ianidi/exchange-server/internal/outbox"
+	"github.com/ianidi/exchange-server/internal/task"
+	"github.com/ianidi/exchange-server/internal/trade"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+//Withdrawal statuses
+const (
+	STATUS_PENDING  = "pending"  //Requested by member, amount is held
+	STATUS_APPROVED = "approved" //Approved by first operator, waits for second approval
+	STATUS_COMPLETE = "complete" //Paid out
+	STATUS_DECLINED = "declined" //Declined by operator, hold is released
+)
+
+//Limits of member withdrawals and transfers to other members, zero - unlimited
+type Limits struct {
+	Daily   decimal.Decimal
+	Monthly decimal.Decimal
+}
+
+//Request creates member withdrawal and holds the amount on member hold account
+func Request(MemberID int64, amount decimal.Decimal) (models.Withdrawal, error) {
+	db := db.GetDB()
+
+	var withdrawal models.Withdrawal
+
+	if !amount.IsPositive() {
+		return withdrawal, errors.New("INVALID_AMOUNT")
+	}
+
+	//Withdrawal is paid in member account currency
+	currency, err := account.QueryCurrency(MemberID)
+	if err != nil {
+		return withdrawal, err
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return withdrawal, err
+	}
+
+	//Concurrent requests of the member are checked one after another
+	var MemberGroupID int64
+
+	if err := tx.Get(&MemberGroupID, "SELECT MemberGroupID FROM Member WHERE MemberID=$1 FOR UPDATE", MemberID); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return withdrawal, errors.New("NO_MEMBER_RECORD")
+		}
+		return withdrawal, err
+	}
+
+	if err := CheckBalance(tx, MemberID, currency, amount); err != nil {
+		tx.Rollback()
+		return withdrawal, err
+	}
+
+	if err := CheckLimits(tx, MemberID, MemberGroupID, currency, amount); err != nil {
+		tx.Rollback()
+		return withdrawal, err
+	}
+
+	now := time.Now().Unix()
+
+	if err := tx.Get(&withdrawal, "INSERT INTO Withdrawal (MemberID, Amount, Status, Currency, Held, Created, Updated) VALUES ($1, $2, $3, $4, $5, $6, $6) RETURNING *", MemberID, amount, STATUS_PENDING, currency, true, now); err != nil {
+		tx.Rollback()
+		return withdrawal, err
+	}
+
+	if err := ledger.Transfer(ledger.ENTRY_WITHDRAWAL_HOLD, "withdrawal", withdrawal.WithdrawalID, ledger.Member(MemberID, currency), ledger.Hold(MemberID, currency), amount).Post(tx); err != nil {
+		tx.Rollback()
+		return withdrawal, err
+	}
+
+	if err := notify(tx, withdrawal); err != nil {
+		tx.Rollback()
+		return withdrawal, err
+	}
+
+	return withdrawal, tx.Commit()
+}
+
+//CheckBalance makes sure member has the cash and open positions keep enough free equity after amount leaves member balance
+func CheckBalance(tx *sqlx.Tx, MemberID int64, currency string, amount decimal.Decimal) error {
+	var balance decimal.Decimal
+
+	if err := tx.Get(&balance, "SELECT COALESCE(SUM(Balance.Amount), 0) FROM Balance INNER JOIN Currency ON Currency.CurrencyID=Balance.CurrencyID WHERE Balance.MemberID=$1 AND Currency.Code=$2", MemberID, currency); err != nil {
+		return err
+	}
+
+	if amount.GreaterThan(balance) {
+		return errors.New("WITHDRAW_INSUFFICIENT")
+	}
+
+	var positions struct {
+		Margin decimal.Decimal //Margin used by open positions
+		Profit decimal.Decimal //Unrealized profit and loss of open positions
+	}
+
+	if err := tx.Get(&positions, "SELECT COALESCE(SUM(Total*MarginRate), 0) AS Margin, COALESCE(SUM(Profit), 0) AS Profit FROM Trade WHERE MemberID=$1 AND AccountCurrency=$2 AND Status=$3", MemberID, currency, trade.STATUS_OPEN); err != nil {
+		return err
+	}
+
+	if positions.Margin.IsZero() {
+		return nil
+	}
+
+	//Margin is already moved off member balance, so free equity is the cash left after withdrawal reduced by unrealized losses. Unrealized profit can't be withdrawn
+	free := balance.Sub(amount).Add(decimal.Min(positions.Profit, decimal.Zero))
+
+	if positions.Margin.GreaterThan(free) {
+		return errors.New("WITHDRAW_MARGIN_IN_USE")
+	}
+
+	return nil
+}
+
+//QueryLimits returns withdrawal limits of member group, falling back to default limits from Settings
+func QueryLimits(tx *sqlx.Tx, MemberGroupID int64) (Limits, error) {
+	var limits Limits
+	var settings models.Settings
+
+	if err := tx.Get(&settings, "SELECT * FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		return limits, err
+	}
+
+	limits.Daily = settings.WithdrawDailyLimit.Decimal
+	limits.Monthly = settings.WithdrawMonthlyLimit.Decimal
+
+	if MemberGroupID == 0 {
+		return limits, nil
+	}
+
+	var group models.MemberGroup
+
+	if err := tx.Get(&group, "SELECT * FROM MemberGroup WHERE MemberGroupID=$1", MemberGroupID); err != nil {
+		if err == sql.ErrNoRows {
+			return limits, nil
+		}
+		return limits, err
+	}
+
+	if group.WithdrawDailyLimit.Decimal.IsPositive() {
+		limits.Daily = group.WithdrawDailyLimit.Decimal
+	}
+
+	if group.WithdrawMonthlyLimit.Decimal.IsPositive() {
+		limits.Monthly = group.WithdrawMonthlyLimit.Decimal
+	}
+
+	return limits, nil
+}
+
+//CheckLimits compares amount leaving member balance in the currency since start of the day and month (UTC) with member limits.
+//Withdrawals and transfers to other members count, declined withdrawals and conversions between own currencies don't
+func CheckLimits(tx *sqlx.Tx, MemberID int64, MemberGroupID int64, currency string, amount decimal.Decimal) error {
+	limits, err := QueryLimits(tx, MemberGroupID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Unix()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	var withdrawn struct {
+		Daily   decimal.Decimal
+		Monthly decimal.Decimal
+	}
+
+	if err := tx.Get(&withdrawn, "SELECT COALESCE(SUM(Amount) FILTER (WHERE Created>=$1), 0) AS Daily, COALESCE(SUM(Amount), 0) AS Monthly FROM (SELECT Amount, Created FROM Withdrawal WHERE MemberID=$2 AND Currency=$3 AND Status<>$4 AND Created>=$5 UNION ALL SELECT Amount, Timestamp AS Created FROM Transfer WHERE MemberID=$2 AND RecipientID<>$2 AND Currency=$3 AND Timestamp>=$5) AS Outgoing", day, MemberID, currency, STATUS_DECLINED, month); err != nil {
+		return err
+	}
+
+	if limits.Daily.IsPositive() && withdrawn.Daily.Add(amount).GreaterThan(limits.Daily) {
+		return errors.New("WITHDRAW_DAILY_LIMIT")
+	}
+
+	if limits.Monthly.IsPositive() && withdrawn.Monthly.Add(amount).GreaterThan(limits.Monthly) {
+		return errors.New("WITHDRAW_MONTHLY_LIMIT")
+	}
+
+	return nil
+}
+
+//Approve approves withdrawal by operator. Withdrawals above approval threshold are completed by second approval of another operator
+func Approve(tx *sqlx.Tx, withdrawal models.Withdrawal, OperatorID int64) (models.Withdrawal, error) {
+	if withdrawal.Status != STATUS_PENDING && withdrawal.Status != STATUS_APPROVED {
+		return withdrawal, errors.New("WITHDRAWAL_NOT_PENDING")
+	}
+
+	var threshold decimal.Decimal
+
+	if err := tx.Get(&threshold, "SELECT WithdrawApprovalThreshold FROM Settings WHERE SettingsID=$1", 1); err != nil {
+		return withdrawal, err
+	}
+
+	now := time.Now().Unix()
+
+	if withdrawal.Amount.Decimal.GreaterThan(threshold) {
+		if withdrawal.Status == STATUS_PENDING {
+			tx.MustExec("UPDATE Withdrawal SET Status=$1, ApprovedBy=$2, Updated=$3 WHERE WithdrawalID=$4", STATUS_APPROVED, OperatorID, now, withdrawal.WithdrawalID)
+
+			withdrawal.Status = STATUS_APPROVED
+			withdrawal.ApprovedBy = OperatorID
+			withdrawal.Updated = now
+
+			return withdrawal, notify(tx, withdrawal)
+		}
+
+		if withdrawal.ApprovedBy == OperatorID {
+			return withdrawal, errors.New("WITHDRAWAL_SECOND_APPROVER_REQUIRED")
+		}
+	}
+
+	//Held amount is paid out from hold account, withdrawals requested before holds - from member balance
+	from := ledger.Member(withdrawal.MemberID, withdrawal.Currency)
+	if withdrawal.Held {
+		from = ledger.Hold(withdrawal.MemberID, withdrawal.Currency)
+	}
+
+	if err := ledger.Transfer(ledger.ENTRY_WITHDRAWAL, "withdrawal", withdrawal.WithdrawalID, from, ledger.Transit(withdrawal.Currency), withdrawal.Amount.Decimal).Post(tx); err != nil {
+		return withdrawal, err
+	}
+
+	tx.MustExec("UPDATE Withdrawal SET Status=$1, CompletedBy=$2, Updated=$3 WHERE WithdrawalID=$4", STATUS_COMPLETE, OperatorID, now, withdrawal.WithdrawalID)
+	tx.MustExec("INSERT INTO History (MemberID, Type, Status, Currency, Profit, ProfitAbs, ProfitNegative) VALUES ($1, $2, $3, $4, $5, $6, $7)", withdrawal.MemberID, "balance", "withdrawal", withdrawal.Currency, withdrawal.Amount.Decimal, withdrawal.Amount.Decimal.Abs(), true)
+
+	withdrawal.Status = STATUS_COMPLETE
+	withdrawal.CompletedBy = OperatorID
+	withdrawal.Updated = now
+
+	return withdrawal, notify(tx, withdrawal)
+}
+
+//Decline declines withdrawal and releases held amount back to member balance
+func Decline(tx *sqlx.Tx, withdrawal models.Withdrawal, OperatorID int64, reason string) (models.Withdrawal, error) {
+	if withdrawal.Status != STATUS_PENDING && withdrawal.Status != STATUS_APPROVED {
+		return withdrawal, errors.New("WITHDRAWAL_NOT_PENDING")
+	}
+
+	if withdrawal.Held {
+		if err := ledger.Transfer(ledger.ENTRY_WITHDRAWAL_RELEASE, "withdrawal", withdrawal.WithdrawalID, ledger.Hold(withdrawal.MemberID, withdrawal.Currency), ledger.Member(withdrawal.MemberID, withdrawal.Currency), withdrawal.Amount.Decimal).Post(tx); err != nil {
+			return withdrawal, err
+		}
+	}
+
+	now := time.Now().Unix()
+
+	tx.MustExec("UPDATE Withdrawal SET Status=$1, CompletedBy=$2, Reason=$3, Updated=$4 WHERE WithdrawalID=$5", STATUS_DECLINED, OperatorID, reason, now, withdrawal.WithdrawalID)
+
+	withdrawal.Status = STATUS_DECLINED
+	withdrawal.CompletedBy = OperatorID
+	withdrawal.Reason = reason
+	withdrawal.Updated = now
+
+	return withdrawal, notify(tx, withdrawal)
+}
+
+//Subject and text of member email per withdrawal status
+var emails = map[string][2]string{
+	STATUS_PENDING:  {"Withdrawal request received", "We have received your withdrawal request of %s %s. The amount is reserved on your account until the request is processed."},
+	STATUS_APPROVED: {"Withdrawal request approved", "Your withdrawal request of %s %s has been approved and waits for final confirmation."},
+	STATUS_COMPLETE: {"Withdrawal completed", "Your withdrawal of %s %s has been completed."},
+	STATUS_DECLINED: {"Withdrawal request declined", "Your withdrawal request of %s %s has been declined and the amount is returned to your balance."},
+}
+
+//Notify member about withdrawal status via info channel and email
+func notify(tx *sqlx.Tx, withdrawal models.Withdrawal) error {
+	outbox.Info(model.Info{
+		MemberID: int(withdrawal.MemberID),
+		Event:    "withdrawal",
+		ID:       int(withdrawal.WithdrawalID),
+		Value:    withdrawal.Status,
+	}).Record(tx)
+
+	text, ok := emails[withdrawal.Status]
+	if !ok {
+		return nil
+	}
+
+	var member struct {
+		Email       string
+		PlatformURL string
+	}
+
+	if err := tx.Get(&member, "SELECT Member.Email, Settings.PlatformURL FROM Member INNER JOIN Settings ON Settings.SettingsID=$1 WHERE Member.MemberID=$2", 1, withdrawal.MemberID); err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf(text[1], withdrawal.Amount.Decimal.StringFixed(2), withdrawal.Currency)
+	if withdrawal.Status == STATUS_DECLINED && withdrawal.Reason != "" {
+		content += " Reason: " + withdrawal.Reason
+	}
+
+	//One email per status change, the task is created with the change itself
+	_, err := task.EnqueueTx(tx, task.Email{
+		Email:       member.Email,
+		Subject:     text[0],
+		Title:       "Dear customer,",
+		Content:     content,
+		Button:      "View balance",
+		Link:        member.PlatformURL,
+		Description: "Thank you for using our platform.",
+	}, fmt.Sprintf("withdrawal:%d:%s", withdrawal.WithdrawalID, withdrawal.Status))
+
+	return err
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS Audit;
+
+ALTER TABLE Asset DROP COLUMN IF EXISTS ManualRateUntil;
+ALTER TABLE Asset DROP COLUMN IF EXISTS ManualRate;
+ALTER TABLE Asset DROP COLUMN IF EXISTS HaltReason;
+ALTER TABLE Asset DROP COLUMN IF EXISTS Halted;
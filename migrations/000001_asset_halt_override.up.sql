@@ -0,0 +1,18 @@
+ALTER TABLE Asset ADD COLUMN Halted boolean NOT NULL DEFAULT false;
+ALTER TABLE Asset ADD COLUMN HaltReason varchar NOT NULL DEFAULT '';
+ALTER TABLE Asset ADD COLUMN ManualRate numeric NOT NULL DEFAULT 0;
+ALTER TABLE Asset ADD COLUMN ManualRateUntil bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE Audit (
+  AuditID bigserial PRIMARY KEY,
+  MemberID bigint NOT NULL,
+  Action varchar NOT NULL,
+  Entity varchar NOT NULL,
+  EntityID bigint NOT NULL DEFAULT 0,
+  Reason text NOT NULL DEFAULT '',
+  Data text NOT NULL DEFAULT '',
+  Timestamp bigint NOT NULL
+);
+
+CREATE INDEX audit_entity_idx ON Audit (Entity, EntityID);
+CREATE INDEX audit_timestamp_idx ON Audit (Timestamp);
@@ -0,0 +1,9 @@
+ALTER TABLE Member DROP COLUMN IF EXISTS MemberGroupID;
+
+DROP TABLE IF EXISTS MemberGroup;
+DROP TABLE IF EXISTS SpreadSession;
+DROP TABLE IF EXISTS Tick;
+
+ALTER TABLE Asset DROP COLUMN IF EXISTS MaxSpreadMultiplier;
+ALTER TABLE Asset DROP COLUMN IF EXISTS VolatilityFactor;
+ALTER TABLE Asset DROP COLUMN IF EXISTS VolatilityWindow;
@@ -0,0 +1,38 @@
+ALTER TABLE Asset ADD COLUMN VolatilityWindow bigint NOT NULL DEFAULT 3600;
+ALTER TABLE Asset ADD COLUMN VolatilityFactor numeric NOT NULL DEFAULT 0;
+ALTER TABLE Asset ADD COLUMN MaxSpreadMultiplier numeric NOT NULL DEFAULT 0;
+
+CREATE TABLE Tick (
+  TickID bigserial PRIMARY KEY,
+  AssetID bigint NOT NULL,
+  Rate numeric NOT NULL,
+  RateBuy numeric NOT NULL,
+  RateSell numeric NOT NULL,
+  BuySpread numeric NOT NULL,
+  SellSpread numeric NOT NULL,
+  Volatility numeric NOT NULL DEFAULT 0,
+  Multiplier numeric NOT NULL DEFAULT 1,
+  Timestamp bigint NOT NULL
+);
+
+CREATE INDEX tick_asset_timestamp_idx ON Tick (AssetID, Timestamp);
+
+CREATE TABLE SpreadSession (
+  SpreadSessionID bigserial PRIMARY KEY,
+  Title varchar NOT NULL DEFAULT '',
+  MarketID bigint NOT NULL DEFAULT 0,
+  AssetID bigint NOT NULL DEFAULT 0,
+  Weekdays varchar NOT NULL DEFAULT '',
+  StartMinute int NOT NULL,
+  EndMinute int NOT NULL,
+  Multiplier numeric NOT NULL DEFAULT 1,
+  Active boolean NOT NULL DEFAULT true
+);
+
+CREATE TABLE MemberGroup (
+  MemberGroupID bigserial PRIMARY KEY,
+  Title varchar NOT NULL,
+  Markup numeric NOT NULL DEFAULT 0
+);
+
+ALTER TABLE Member ADD COLUMN MemberGroupID bigint NOT NULL DEFAULT 0;
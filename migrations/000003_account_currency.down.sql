@@ -0,0 +1,7 @@
+ALTER TABLE Trade DROP COLUMN IF EXISTS MarginRate;
+ALTER TABLE Trade DROP COLUMN IF EXISTS AccountCurrency;
+
+DROP INDEX IF EXISTS balance_member_currency_idx;
+DROP INDEX IF EXISTS currency_code_idx;
+
+ALTER TABLE Currency DROP COLUMN IF EXISTS Code;
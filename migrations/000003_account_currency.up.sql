@@ -0,0 +1,24 @@
+ALTER TABLE Currency ADD COLUMN Code varchar NOT NULL DEFAULT '';
+
+UPDATE Currency SET Code=upper(Title) WHERE upper(Title) IN ('USD', 'EUR');
+
+INSERT INTO Currency (Title, Symbol, Code) SELECT 'USD', '$', 'USD' WHERE NOT EXISTS (SELECT 1 FROM Currency WHERE Code='USD');
+INSERT INTO Currency (Title, Symbol, Code) SELECT 'EUR', '€', 'EUR' WHERE NOT EXISTS (SELECT 1 FROM Currency WHERE Code='EUR');
+
+CREATE UNIQUE INDEX currency_code_idx ON Currency (Code) WHERE Code<>'';
+
+-- Seed per-currency balances from legacy Member columns
+INSERT INTO Balance (MemberID, CurrencyID, Amount, AmountNegative)
+  SELECT Member.MemberID, Currency.CurrencyID, Member.USD, Member.USD<0 FROM Member, Currency
+  WHERE Currency.Code='USD' AND NOT EXISTS (SELECT 1 FROM Balance WHERE Balance.MemberID=Member.MemberID AND Balance.CurrencyID=Currency.CurrencyID);
+INSERT INTO Balance (MemberID, CurrencyID, Amount, AmountNegative)
+  SELECT Member.MemberID, Currency.CurrencyID, Member.EUR, Member.EUR<0 FROM Member, Currency
+  WHERE Currency.Code='EUR' AND NOT EXISTS (SELECT 1 FROM Balance WHERE Balance.MemberID=Member.MemberID AND Balance.CurrencyID=Currency.CurrencyID);
+
+CREATE INDEX balance_member_currency_idx ON Balance (MemberID, CurrencyID);
+
+ALTER TABLE Trade ADD COLUMN AccountCurrency varchar NOT NULL DEFAULT '';
+ALTER TABLE Trade ADD COLUMN MarginRate numeric NOT NULL DEFAULT 1;
+
+-- Existing trades were settled in the asset currency column
+UPDATE Trade SET AccountCurrency=Asset.Currency FROM Asset WHERE Asset.AssetID=Trade.AssetID;
@@ -0,0 +1,49 @@
+DROP TABLE IF EXISTS RetentionPolicy;
+
+DROP INDEX IF EXISTS trade_asset_status_idx;
+
+-- History
+ALTER TABLE History RENAME TO history_partitioned;
+ALTER SEQUENCE history_historyid_seq OWNED BY NONE;
+
+CREATE TABLE History (
+  HistoryID bigint NOT NULL DEFAULT nextval('history_historyid_seq') PRIMARY KEY,
+  MemberID bigint,
+  Type varchar,
+  AssetID bigint DEFAULT 0,
+  Currency varchar DEFAULT '',
+  Address varchar DEFAULT '',
+  Created timestamptz DEFAULT now(),
+  Qty numeric DEFAULT 0,
+  Rate numeric DEFAULT 0,
+  TradeID bigint DEFAULT 0,
+  Action varchar DEFAULT '',
+  Timestamp bigint DEFAULT 0,
+  Leverage numeric DEFAULT 0,
+  Profit numeric DEFAULT 0,
+  ProfitNegative boolean DEFAULT false,
+  ProfitAbs numeric DEFAULT 0,
+  Status varchar DEFAULT ''
+);
+
+INSERT INTO History SELECT * FROM history_partitioned;
+DROP TABLE history_partitioned;
+ALTER SEQUENCE history_historyid_seq OWNED BY History.HistoryID;
+
+-- Rate
+ALTER TABLE Rate RENAME TO rate_partitioned;
+ALTER SEQUENCE rate_rateid_seq OWNED BY NONE;
+
+CREATE TABLE Rate (
+  RateID integer NOT NULL DEFAULT nextval('rate_rateid_seq') PRIMARY KEY,
+  AssetID integer NOT NULL DEFAULT 0,
+  Rate numeric DEFAULT 0,
+  Timestamp bigint DEFAULT 0,
+  Datetime timestamptz DEFAULT now()
+);
+
+INSERT INTO Rate SELECT * FROM rate_partitioned;
+DROP TABLE rate_partitioned;
+ALTER SEQUENCE rate_rateid_seq OWNED BY Rate.RateID;
+
+DROP FUNCTION IF EXISTS create_month_partition(text, timestamptz, boolean);
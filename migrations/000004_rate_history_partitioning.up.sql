@@ -0,0 +1,117 @@
+-- Creates a monthly range partition of parent table containing given time.
+-- epoch: partition key is a UNIX timestamp (bigint), otherwise timestamptz
+CREATE OR REPLACE FUNCTION create_month_partition(parent text, ts timestamptz, epoch boolean) RETURNS void AS $$
+DECLARE
+  start_at timestamptz := date_trunc('month', ts);
+  end_at timestamptz := date_trunc('month', ts) + interval '1 month';
+  partition_name text := lower(parent) || '_' || to_char(date_trunc('month', ts), 'YYYYMM');
+BEGIN
+  IF to_regclass(partition_name) IS NOT NULL THEN
+    RETURN;
+  END IF;
+
+  IF epoch THEN
+    EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%s) TO (%s)', partition_name, lower(parent), extract(epoch FROM start_at)::bigint, extract(epoch FROM end_at)::bigint);
+  ELSE
+    EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)', partition_name, lower(parent), start_at, end_at);
+  END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Rate, partitioned by Timestamp
+ALTER TABLE Rate RENAME TO rate_unpartitioned;
+ALTER SEQUENCE rate_rateid_seq OWNED BY NONE;
+
+CREATE TABLE Rate (
+  RateID bigint NOT NULL DEFAULT nextval('rate_rateid_seq'),
+  AssetID integer NOT NULL DEFAULT 0,
+  Rate numeric DEFAULT 0,
+  Timestamp bigint NOT NULL DEFAULT 0,
+  Datetime timestamptz DEFAULT now(),
+  PRIMARY KEY (RateID, Timestamp)
+) PARTITION BY RANGE (Timestamp);
+
+CREATE TABLE rate_default PARTITION OF Rate DEFAULT;
+
+DO $$
+DECLARE
+  month_at timestamptz;
+BEGIN
+  month_at := coalesce((SELECT to_timestamp(min(Timestamp)) FROM rate_unpartitioned WHERE Timestamp>0), now());
+  WHILE month_at < now() + interval '3 months' LOOP
+    PERFORM create_month_partition('rate', month_at, true);
+    month_at := month_at + interval '1 month';
+  END LOOP;
+END $$;
+
+INSERT INTO Rate (RateID, AssetID, Rate, Timestamp, Datetime) SELECT RateID, AssetID, Rate, coalesce(Timestamp, 0), Datetime FROM rate_unpartitioned;
+DROP TABLE rate_unpartitioned;
+ALTER SEQUENCE rate_rateid_seq OWNED BY Rate.RateID;
+
+-- Per tick "record rate every 3 hours" check, 24h ago lookup and performance chart
+CREATE INDEX rate_asset_timestamp_idx ON Rate (AssetID, Timestamp);
+
+-- History, partitioned by Created (Timestamp is not set for balance records)
+ALTER TABLE History RENAME TO history_unpartitioned;
+ALTER SEQUENCE history_historyid_seq OWNED BY NONE;
+
+CREATE TABLE History (
+  HistoryID bigint NOT NULL DEFAULT nextval('history_historyid_seq'),
+  MemberID bigint,
+  Type varchar,
+  AssetID bigint DEFAULT 0,
+  Currency varchar DEFAULT '',
+  Address varchar DEFAULT '',
+  Created timestamptz NOT NULL DEFAULT now(),
+  Qty numeric DEFAULT 0,
+  Rate numeric DEFAULT 0,
+  TradeID bigint DEFAULT 0,
+  Action varchar DEFAULT '',
+  Timestamp bigint DEFAULT 0,
+  Leverage numeric DEFAULT 0,
+  Profit numeric DEFAULT 0,
+  ProfitNegative boolean DEFAULT false,
+  ProfitAbs numeric DEFAULT 0,
+  Status varchar DEFAULT '',
+  PRIMARY KEY (HistoryID, Created)
+) PARTITION BY RANGE (Created);
+
+CREATE TABLE history_default PARTITION OF History DEFAULT;
+
+DO $$
+DECLARE
+  month_at timestamptz;
+BEGIN
+  month_at := coalesce((SELECT min(Created) FROM history_unpartitioned), now());
+  WHILE month_at < now() + interval '3 months' LOOP
+    PERFORM create_month_partition('history', month_at, false);
+    month_at := month_at + interval '1 month';
+  END LOOP;
+END $$;
+
+INSERT INTO History (HistoryID, MemberID, Type, AssetID, Currency, Address, Created, Qty, Rate, TradeID, Action, Timestamp, Leverage, Profit, ProfitNegative, ProfitAbs, Status)
+  SELECT HistoryID, MemberID, Type, AssetID, Currency, Address, coalesce(Created, now()), Qty, Rate, TradeID, Action, Timestamp, Leverage, Profit, ProfitNegative, ProfitAbs, Status FROM history_unpartitioned;
+DROP TABLE history_unpartitioned;
+ALTER SEQUENCE history_historyid_seq OWNED BY History.HistoryID;
+
+CREATE INDEX history_member_created_idx ON History (MemberID, Created);
+CREATE INDEX history_trade_idx ON History (TradeID);
+
+-- Per tick order updates and pending limit orders lookup
+CREATE INDEX IF NOT EXISTS trade_asset_status_idx ON Trade (AssetID, Status);
+
+-- Retention policies, applied by RetentionJob
+CREATE TABLE RetentionPolicy (
+  RetentionPolicyID bigserial PRIMARY KEY,
+  TableName varchar NOT NULL UNIQUE,
+  RawPeriod bigint NOT NULL DEFAULT 0,
+  DownsampleInterval bigint NOT NULL DEFAULT 0,
+  MaxPeriod bigint NOT NULL DEFAULT 0,
+  Processed bigint NOT NULL DEFAULT 0,
+  Active boolean NOT NULL DEFAULT true
+);
+
+-- Rate: full resolution for 30 days, one rate per day afterwards, kept forever
+INSERT INTO RetentionPolicy (TableName, RawPeriod, DownsampleInterval, MaxPeriod) VALUES ('Rate', 2592000, 86400, 0);
+-- Tick: full resolution for 1 day, one tick per minute afterwards, kept for 30 days
+INSERT INTO RetentionPolicy (TableName, RawPeriod, DownsampleInterval, MaxPeriod) VALUES ('Tick', 86400, 60, 2592000);
@@ -0,0 +1,7 @@
+ALTER TABLE Settings DROP COLUMN IF EXISTS StaleAlertWebhook;
+ALTER TABLE Settings DROP COLUMN IF EXISTS StaleAlertEmail;
+
+ALTER TABLE Market DROP COLUMN IF EXISTS StaleIntervals;
+ALTER TABLE Market DROP COLUMN IF EXISTS EndMinute;
+ALTER TABLE Market DROP COLUMN IF EXISTS StartMinute;
+ALTER TABLE Market DROP COLUMN IF EXISTS Weekdays;
@@ -0,0 +1,12 @@
+ALTER TABLE Market ADD COLUMN Weekdays varchar NOT NULL DEFAULT '';
+ALTER TABLE Market ADD COLUMN StartMinute int NOT NULL DEFAULT 0;
+ALTER TABLE Market ADD COLUMN EndMinute int NOT NULL DEFAULT 0;
+ALTER TABLE Market ADD COLUMN StaleIntervals int NOT NULL DEFAULT 3;
+
+-- Trading sessions (UTC): crypto trades 24/7,
+-- forex, commodities and indices on weekdays, stocks during US session
+UPDATE Market SET Weekdays='1,2,3,4,5' WHERE MarketID IN (3, 5, 7);
+UPDATE Market SET Weekdays='1,2,3,4,5', StartMinute=810, EndMinute=1200 WHERE MarketID IN (2, 4, 6);
+
+ALTER TABLE Settings ADD COLUMN StaleAlertEmail varchar NOT NULL DEFAULT '';
+ALTER TABLE Settings ADD COLUMN StaleAlertWebhook varchar NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DELETE FROM RetentionPolicy WHERE TableName='JobRun';
+
+DROP TABLE IF EXISTS JobRun;
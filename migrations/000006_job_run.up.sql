@@ -0,0 +1,15 @@
+CREATE TABLE JobRun (
+  JobRunID bigserial PRIMARY KEY,
+  Name varchar NOT NULL,
+  Instance varchar NOT NULL DEFAULT '',
+  Timestamp bigint NOT NULL,
+  Finished bigint NOT NULL DEFAULT 0,
+  Duration bigint NOT NULL DEFAULT 0,
+  Status varchar NOT NULL DEFAULT 'running',
+  Error varchar NOT NULL DEFAULT ''
+);
+
+CREATE INDEX jobrun_name_timestamp_idx ON JobRun (Name, Timestamp);
+
+-- JobRun: kept for 7 days
+INSERT INTO RetentionPolicy (TableName, RawPeriod, DownsampleInterval, MaxPeriod) VALUES ('JobRun', 0, 0, 604800);
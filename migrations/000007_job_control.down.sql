@@ -0,0 +1,4 @@
+ALTER TABLE Job DROP COLUMN IF EXISTS LastDuration;
+ALTER TABLE Job DROP COLUMN IF EXISTS LastError;
+ALTER TABLE Job DROP COLUMN IF EXISTS LastStatus;
+ALTER TABLE Job DROP COLUMN IF EXISTS LastRun;
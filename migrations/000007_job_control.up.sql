@@ -0,0 +1,4 @@
+ALTER TABLE Job ADD COLUMN LastRun bigint NOT NULL DEFAULT 0;
+ALTER TABLE Job ADD COLUMN LastStatus varchar NOT NULL DEFAULT '';
+ALTER TABLE Job ADD COLUMN LastError varchar NOT NULL DEFAULT '';
+ALTER TABLE Job ADD COLUMN LastDuration bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DELETE FROM RetentionPolicy WHERE TableName='Task';
+
+DROP TABLE IF EXISTS Task;
@@ -0,0 +1,19 @@
+CREATE TABLE Task (
+  TaskID bigserial PRIMARY KEY,
+  Type varchar NOT NULL,
+  Payload text NOT NULL DEFAULT '',
+  Status varchar NOT NULL DEFAULT 'pending',
+  Attempts int NOT NULL DEFAULT 0,
+  MaxAttempts int NOT NULL DEFAULT 8,
+  RunAt bigint NOT NULL,
+  LockedUntil bigint NOT NULL DEFAULT 0,
+  LastError varchar NOT NULL DEFAULT '',
+  IdempotencyKey varchar UNIQUE,
+  Timestamp bigint NOT NULL,
+  Updated bigint NOT NULL
+);
+
+CREATE INDEX task_status_runat_idx ON Task (Status, RunAt);
+
+-- Task: kept for 30 days
+INSERT INTO RetentionPolicy (TableName, RawPeriod, DownsampleInterval, MaxPeriod) VALUES ('Task', 0, 0, 2592000);
@@ -0,0 +1,4 @@
+DELETE FROM RetentionPolicy WHERE TableName='Outbox';
+
+DROP TABLE IF EXISTS OutboxSequence;
+DROP TABLE IF EXISTS Outbox;
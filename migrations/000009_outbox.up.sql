@@ -0,0 +1,23 @@
+CREATE TABLE Outbox (
+  OutboxID bigserial PRIMARY KEY,
+  Channel varchar NOT NULL,
+  MemberID bigint NOT NULL DEFAULT 0,
+  Payload text NOT NULL,
+  Sequence bigint NOT NULL DEFAULT 0,
+  Published bigint NOT NULL DEFAULT 0,
+  Timestamp bigint NOT NULL
+);
+
+CREATE INDEX outbox_unnumbered_idx ON Outbox (OutboxID) WHERE Sequence=0;
+CREATE INDEX outbox_unpublished_idx ON Outbox (Sequence) WHERE Sequence>0 AND Published=0;
+CREATE INDEX outbox_channel_sequence_idx ON Outbox (Channel, Sequence);
+
+CREATE TABLE OutboxSequence (
+  OutboxSequenceID int PRIMARY KEY,
+  Value bigint NOT NULL DEFAULT 0
+);
+
+INSERT INTO OutboxSequence (OutboxSequenceID, Value) VALUES (1, 0);
+
+-- Outbox: kept for 3 days
+INSERT INTO RetentionPolicy (TableName, RawPeriod, DownsampleInterval, MaxPeriod) VALUES ('Outbox', 0, 0, 259200);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS LedgerPosting;
+DROP TABLE IF EXISTS LedgerEntry;
+DROP TABLE IF EXISTS LedgerAccount;
+
+DROP FUNCTION IF EXISTS ledger_balanced();
+DROP FUNCTION IF EXISTS ledger_immutable();
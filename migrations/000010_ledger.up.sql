@@ -0,0 +1,72 @@
+-- Account types: member (cash), margin (held by open and pending orders), house (P&L), fees, transit (deposits and withdrawals with payment providers), opening (balances before the ledger)
+CREATE TABLE LedgerAccount (
+  LedgerAccountID bigserial PRIMARY KEY,
+  Type varchar NOT NULL,
+  MemberID bigint NOT NULL DEFAULT 0,
+  Currency varchar NOT NULL,
+  Balance numeric NOT NULL DEFAULT 0,
+  Updated bigint NOT NULL DEFAULT 0,
+  UNIQUE (Type, MemberID, Currency)
+);
+
+CREATE TABLE LedgerEntry (
+  LedgerEntryID bigserial PRIMARY KEY,
+  Type varchar NOT NULL,
+  Entity varchar NOT NULL DEFAULT '',
+  EntityID bigint NOT NULL DEFAULT 0,
+  Currency varchar NOT NULL,
+  Description varchar NOT NULL DEFAULT '',
+  Timestamp bigint NOT NULL
+);
+
+CREATE INDEX ledger_entry_entity_idx ON LedgerEntry (Entity, EntityID);
+
+CREATE TABLE LedgerPosting (
+  LedgerPostingID bigserial PRIMARY KEY,
+  LedgerEntryID bigint NOT NULL REFERENCES LedgerEntry (LedgerEntryID),
+  LedgerAccountID bigint NOT NULL REFERENCES LedgerAccount (LedgerAccountID),
+  Amount numeric NOT NULL,
+  BalanceAfter numeric NOT NULL
+);
+
+CREATE INDEX ledger_posting_entry_idx ON LedgerPosting (LedgerEntryID);
+CREATE INDEX ledger_posting_account_idx ON LedgerPosting (LedgerAccountID, LedgerPostingID);
+
+-- Journal is append-only
+CREATE FUNCTION ledger_immutable() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'LEDGER_IMMUTABLE';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_entry_immutable BEFORE UPDATE OR DELETE ON LedgerEntry FOR EACH ROW EXECUTE PROCEDURE ledger_immutable();
+CREATE TRIGGER ledger_posting_immutable BEFORE UPDATE OR DELETE ON LedgerPosting FOR EACH ROW EXECUTE PROCEDURE ledger_immutable();
+
+-- Postings of an entry must add up to zero when the transaction commits
+CREATE FUNCTION ledger_balanced() RETURNS trigger AS $$
+BEGIN
+  IF (SELECT COALESCE(SUM(Amount), 0) FROM LedgerPosting WHERE LedgerEntryID=NEW.LedgerEntryID) <> 0 THEN
+    RAISE EXCEPTION 'LEDGER_UNBALANCED: %', NEW.LedgerEntryID;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER ledger_posting_balanced AFTER INSERT ON LedgerPosting DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE PROCEDURE ledger_balanced();
+
+-- Opening balances: member cash from Balance, margin from open and pending orders
+INSERT INTO LedgerAccount (Type, MemberID, Currency, Balance)
+  SELECT 'member', Balance.MemberID, Currency.Code, SUM(Balance.Amount) FROM Balance INNER JOIN Currency ON Currency.CurrencyID=Balance.CurrencyID
+  WHERE Currency.Code<>'' GROUP BY Balance.MemberID, Currency.Code;
+INSERT INTO LedgerAccount (Type, MemberID, Currency, Balance)
+  SELECT 'margin', MemberID, AccountCurrency, SUM(Total*MarginRate) FROM Trade
+  WHERE Status IN ('open', 'pending') AND AccountCurrency<>'' GROUP BY MemberID, AccountCurrency;
+INSERT INTO LedgerAccount (Type, MemberID, Currency, Balance)
+  SELECT 'opening', 0, Currency, -SUM(Balance) FROM LedgerAccount GROUP BY Currency;
+
+INSERT INTO LedgerEntry (Type, Currency, Description, Timestamp)
+  SELECT 'opening', Currency, 'Opening balances', extract(epoch from now())::bigint FROM LedgerAccount WHERE Type='opening';
+INSERT INTO LedgerPosting (LedgerEntryID, LedgerAccountID, Amount, BalanceAfter)
+  SELECT LedgerEntry.LedgerEntryID, LedgerAccount.LedgerAccountID, LedgerAccount.Balance, LedgerAccount.Balance FROM LedgerAccount
+  INNER JOIN LedgerEntry ON LedgerEntry.Type='opening' AND LedgerEntry.Currency=LedgerAccount.Currency
+  WHERE LedgerAccount.Balance<>0;
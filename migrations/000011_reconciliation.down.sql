@@ -0,0 +1,5 @@
+DELETE FROM RetentionPolicy WHERE TableName='Reconciliation';
+
+ALTER TABLE Settings DROP COLUMN IF EXISTS ReconciliationEmail;
+
+DROP TABLE IF EXISTS Reconciliation;
@@ -0,0 +1,22 @@
+CREATE TABLE Reconciliation (
+  ReconciliationID bigserial PRIMARY KEY,
+  Run bigint NOT NULL,
+  Type varchar NOT NULL,
+  MemberID bigint NOT NULL DEFAULT 0,
+  Currency varchar NOT NULL DEFAULT '',
+  AssetID bigint NOT NULL DEFAULT 0,
+  Entity varchar NOT NULL DEFAULT '',
+  EntityID bigint NOT NULL DEFAULT 0,
+  Expected numeric NOT NULL DEFAULT 0,
+  Actual numeric NOT NULL DEFAULT 0,
+  Difference numeric NOT NULL DEFAULT 0,
+  Timestamp bigint NOT NULL
+);
+
+CREATE INDEX reconciliation_run_idx ON Reconciliation (Run, Type);
+CREATE INDEX reconciliation_member_idx ON Reconciliation (MemberID);
+
+ALTER TABLE Settings ADD COLUMN ReconciliationEmail varchar NOT NULL DEFAULT '';
+
+-- Reconciliation: kept for 90 days
+INSERT INTO RetentionPolicy (TableName, RawPeriod, DownsampleInterval, MaxPeriod) VALUES ('Reconciliation', 0, 0, 7776000);
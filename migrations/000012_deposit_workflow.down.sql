@@ -0,0 +1,19 @@
+ALTER TABLE Settings DROP COLUMN IF EXISTS DepositAutoCreditLimit;
+ALTER TABLE Settings DROP COLUMN IF EXISTS DepositBankDetailsID;
+
+DROP TABLE IF EXISTS PaymentEvent;
+
+DROP INDEX IF EXISTS deposit_transaction_idx;
+DROP INDEX IF EXISTS deposit_reference_idx;
+
+UPDATE Deposit SET Status='declined' WHERE Status='failed';
+UPDATE Deposit SET Status='complete' WHERE Status='credited';
+UPDATE Deposit SET Status='pending' WHERE Status='confirmed';
+
+ALTER TABLE Deposit DROP COLUMN IF EXISTS Updated;
+ALTER TABLE Deposit DROP COLUMN IF EXISTS Created;
+ALTER TABLE Deposit DROP COLUMN IF EXISTS Reason;
+ALTER TABLE Deposit DROP COLUMN IF EXISTS Currency;
+ALTER TABLE Deposit DROP COLUMN IF EXISTS TransactionID;
+ALTER TABLE Deposit DROP COLUMN IF EXISTS Reference;
+ALTER TABLE Deposit DROP COLUMN IF EXISTS Provider;
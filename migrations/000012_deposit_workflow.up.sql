@@ -0,0 +1,30 @@
+ALTER TABLE Deposit ADD COLUMN Provider varchar NOT NULL DEFAULT 'manual';
+ALTER TABLE Deposit ADD COLUMN Reference varchar NOT NULL DEFAULT '';
+ALTER TABLE Deposit ADD COLUMN TransactionID varchar NOT NULL DEFAULT '';
+ALTER TABLE Deposit ADD COLUMN Currency varchar NOT NULL DEFAULT '';
+ALTER TABLE Deposit ADD COLUMN Reason varchar NOT NULL DEFAULT '';
+ALTER TABLE Deposit ADD COLUMN Created bigint NOT NULL DEFAULT 0;
+ALTER TABLE Deposit ADD COLUMN Updated bigint NOT NULL DEFAULT 0;
+
+-- Deposits used to be approved by hand in USD: pending, complete or declined
+UPDATE Deposit SET Currency='USD' WHERE Currency='';
+UPDATE Deposit SET Status='credited' WHERE Status='complete';
+UPDATE Deposit SET Status='failed' WHERE Status='declined';
+
+CREATE UNIQUE INDEX deposit_reference_idx ON Deposit (Reference) WHERE Reference<>'';
+CREATE UNIQUE INDEX deposit_transaction_idx ON Deposit (Provider, TransactionID) WHERE TransactionID<>'';
+
+-- Webhook events, processed once per provider transaction and status
+CREATE TABLE PaymentEvent (
+  PaymentEventID bigserial PRIMARY KEY,
+  Provider varchar NOT NULL,
+  TransactionID varchar NOT NULL,
+  Status varchar NOT NULL,
+  DepositID bigint NOT NULL DEFAULT 0,
+  Payload text NOT NULL DEFAULT '',
+  Timestamp bigint NOT NULL,
+  UNIQUE (Provider, TransactionID, Status)
+);
+
+ALTER TABLE Settings ADD COLUMN DepositBankDetailsID bigint NOT NULL DEFAULT 0;
+ALTER TABLE Settings ADD COLUMN DepositAutoCreditLimit numeric NOT NULL DEFAULT 50000;
@@ -0,0 +1,18 @@
+ALTER TABLE Settings DROP COLUMN IF EXISTS WithdrawApprovalThreshold;
+ALTER TABLE Settings DROP COLUMN IF EXISTS WithdrawMonthlyLimit;
+ALTER TABLE Settings DROP COLUMN IF EXISTS WithdrawDailyLimit;
+
+ALTER TABLE MemberGroup DROP COLUMN IF EXISTS WithdrawMonthlyLimit;
+ALTER TABLE MemberGroup DROP COLUMN IF EXISTS WithdrawDailyLimit;
+
+DROP INDEX IF EXISTS withdrawal_member_idx;
+
+UPDATE Withdrawal SET Status='pending' WHERE Status='approved';
+
+ALTER TABLE Withdrawal DROP COLUMN IF EXISTS Updated;
+ALTER TABLE Withdrawal DROP COLUMN IF EXISTS Created;
+ALTER TABLE Withdrawal DROP COLUMN IF EXISTS Reason;
+ALTER TABLE Withdrawal DROP COLUMN IF EXISTS CompletedBy;
+ALTER TABLE Withdrawal DROP COLUMN IF EXISTS ApprovedBy;
+ALTER TABLE Withdrawal DROP COLUMN IF EXISTS Held;
+ALTER TABLE Withdrawal DROP COLUMN IF EXISTS Currency;
@@ -0,0 +1,21 @@
+ALTER TABLE Withdrawal ADD COLUMN Currency varchar NOT NULL DEFAULT '';
+ALTER TABLE Withdrawal ADD COLUMN Held boolean NOT NULL DEFAULT false;
+ALTER TABLE Withdrawal ADD COLUMN ApprovedBy bigint NOT NULL DEFAULT 0;
+ALTER TABLE Withdrawal ADD COLUMN CompletedBy bigint NOT NULL DEFAULT 0;
+ALTER TABLE Withdrawal ADD COLUMN Reason varchar NOT NULL DEFAULT '';
+ALTER TABLE Withdrawal ADD COLUMN Created bigint NOT NULL DEFAULT 0;
+ALTER TABLE Withdrawal ADD COLUMN Updated bigint NOT NULL DEFAULT 0;
+
+-- Withdrawals used to be requested in USD without a hold on member funds
+UPDATE Withdrawal SET Currency='USD' WHERE Currency='';
+
+CREATE INDEX withdrawal_member_idx ON Withdrawal (MemberID, Created);
+
+-- Withdrawal limits per member group, 0 - group uses default limits from Settings
+ALTER TABLE MemberGroup ADD COLUMN WithdrawDailyLimit numeric NOT NULL DEFAULT 0;
+ALTER TABLE MemberGroup ADD COLUMN WithdrawMonthlyLimit numeric NOT NULL DEFAULT 0;
+
+-- Default limits (0 - unlimited) and amount above which withdrawal is approved by two operators
+ALTER TABLE Settings ADD COLUMN WithdrawDailyLimit numeric NOT NULL DEFAULT 0;
+ALTER TABLE Settings ADD COLUMN WithdrawMonthlyLimit numeric NOT NULL DEFAULT 0;
+ALTER TABLE Settings ADD COLUMN WithdrawApprovalThreshold numeric NOT NULL DEFAULT 10000;
@@ -0,0 +1,9 @@
+ALTER TABLE TX DROP COLUMN IF EXISTS TransferID;
+ALTER TABLE TX DROP COLUMN IF EXISTS Type;
+
+DROP TABLE IF EXISTS Transfer;
+
+ALTER TABLE Settings DROP COLUMN IF EXISTS ConversionFee;
+
+ALTER TABLE Member DROP COLUMN IF EXISTS TransferReceiveAllowed;
+ALTER TABLE Member DROP COLUMN IF EXISTS TransferSendAllowed;
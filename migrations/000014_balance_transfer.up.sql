@@ -0,0 +1,28 @@
+-- Transfers to other members are allowed by operator per member
+ALTER TABLE Member ADD COLUMN TransferSendAllowed boolean NOT NULL DEFAULT false;
+ALTER TABLE Member ADD COLUMN TransferReceiveAllowed boolean NOT NULL DEFAULT false;
+
+-- Fee charged on conversion between member currencies, % of converted amount
+ALTER TABLE Settings ADD COLUMN ConversionFee numeric NOT NULL DEFAULT 0.5;
+
+-- Types: conversion (between member own currencies), transfer (to another member)
+CREATE TABLE Transfer (
+  TransferID bigserial PRIMARY KEY,
+  Type varchar NOT NULL,
+  MemberID bigint NOT NULL,
+  RecipientID bigint NOT NULL,
+  Currency varchar NOT NULL,
+  Amount numeric NOT NULL,
+  RecipientCurrency varchar NOT NULL,
+  RecipientAmount numeric NOT NULL,
+  Rate numeric NOT NULL DEFAULT 1,
+  Fee numeric NOT NULL DEFAULT 0,
+  Timestamp bigint NOT NULL
+);
+
+CREATE INDEX transfer_member_idx ON Transfer (MemberID);
+CREATE INDEX transfer_recipient_idx ON Transfer (RecipientID);
+
+-- Balance transactions list transfers of both sides
+ALTER TABLE TX ADD COLUMN Type varchar NOT NULL DEFAULT '';
+ALTER TABLE TX ADD COLUMN TransferID bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,19 @@
+-- Member documents rendered to PDF and stored in S3
+-- Types: statement (monthly account statement), confirmation (trade confirmation note)
+-- Period: statement month (2020-09) or confirmed trade status (open, closed)
+CREATE TABLE Document (
+  DocumentID bigserial PRIMARY KEY,
+  MemberID bigint NOT NULL,
+  Type varchar NOT NULL,
+  Period varchar NOT NULL DEFAULT '',
+  TradeID bigint NOT NULL DEFAULT 0,
+  Status varchar NOT NULL DEFAULT 'pending',
+  Filename varchar NOT NULL DEFAULT '',
+  URL varchar NOT NULL DEFAULT '',
+  Email boolean NOT NULL DEFAULT false,
+  Created bigint NOT NULL,
+  Updated bigint NOT NULL,
+  UNIQUE (MemberID, Type, Period, TradeID)
+);
+
+CREATE INDEX document_trade_idx ON Document (TradeID) WHERE TradeID<>0;
@@ -0,0 +1,7 @@
+ALTER TABLE Settings DROP COLUMN IF EXISTS MFARequiredOperator;
+
+DROP TABLE IF EXISTS MFARecovery;
+
+ALTER TABLE Member DROP COLUMN IF EXISTS MFAStep;
+ALTER TABLE Member DROP COLUMN IF EXISTS MFAEnabled;
+ALTER TABLE Member DROP COLUMN IF EXISTS MFASecret;
@@ -0,0 +1,19 @@
+-- TOTP (RFC 6238) two-factor authentication, secret is set on enrollment and enabled after the first valid code
+ALTER TABLE Member ADD COLUMN MFASecret varchar NOT NULL DEFAULT '';
+ALTER TABLE Member ADD COLUMN MFAEnabled boolean NOT NULL DEFAULT false;
+-- Last accepted TOTP time step, codes of the same or earlier step are rejected
+ALTER TABLE Member ADD COLUMN MFAStep bigint NOT NULL DEFAULT 0;
+
+-- One-time recovery codes, stored as sha256 hashes
+CREATE TABLE MFARecovery (
+  MFARecoveryID bigserial PRIMARY KEY,
+  MemberID bigint NOT NULL,
+  CodeHash varchar NOT NULL,
+  Used bigint NOT NULL DEFAULT 0,
+  Created bigint NOT NULL
+);
+
+CREATE INDEX mfarecovery_member_idx ON MFARecovery (MemberID);
+
+-- Require two-factor authentication of operators and admins (Role >= 2)
+ALTER TABLE Settings ADD COLUMN MFARequiredOperator boolean NOT NULL DEFAULT false;
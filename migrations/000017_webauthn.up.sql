@@ -0,0 +1,14 @@
+-- WebAuthn passkeys, a member can register several authenticators
+CREATE TABLE WebAuthnCredential (
+  WebAuthnCredentialID bigserial PRIMARY KEY,
+  MemberID bigint NOT NULL,
+  CredentialID varchar NOT NULL UNIQUE,
+  PublicKey varchar NOT NULL,
+  SignCount bigint NOT NULL DEFAULT 0,
+  AAGUID varchar NOT NULL DEFAULT '',
+  Name varchar NOT NULL DEFAULT '',
+  Created bigint NOT NULL,
+  LastUsed bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX webauthncredential_member_idx ON WebAuthnCredential (MemberID);
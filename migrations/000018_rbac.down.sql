@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS MemberRole;
+DROP TABLE IF EXISTS RolePermission;
+DROP TABLE IF EXISTS Role;
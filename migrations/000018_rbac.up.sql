@@ -0,0 +1,46 @@
+-- Roles group named permissions (e.g. trades.read, withdrawals.approve), permission names are defined in internal/rbac
+CREATE TABLE Role (
+  RoleID bigserial PRIMARY KEY,
+  Title varchar NOT NULL UNIQUE,
+  Description varchar NOT NULL DEFAULT '',
+  Created bigint NOT NULL
+);
+
+CREATE TABLE RolePermission (
+  RoleID bigint NOT NULL REFERENCES Role (RoleID) ON DELETE CASCADE,
+  Permission varchar NOT NULL,
+  PRIMARY KEY (RoleID, Permission)
+);
+
+-- Roles assigned to member, permissions of all roles are combined
+CREATE TABLE MemberRole (
+  MemberID bigint NOT NULL,
+  RoleID bigint NOT NULL REFERENCES Role (RoleID) ON DELETE CASCADE,
+  Created bigint NOT NULL,
+  PRIMARY KEY (MemberID, RoleID)
+);
+
+CREATE INDEX memberrole_role_idx ON MemberRole (RoleID);
+
+-- Default roles of the former numeric roles, admins (Member.Role 3) keep every permission without roles
+INSERT INTO Role (Title, Description, Created) VALUES
+  ('operator', 'Back office operator', extract(epoch FROM now())::bigint),
+  ('manager', 'CRM manager', extract(epoch FROM now())::bigint);
+
+INSERT INTO RolePermission (RoleID, Permission)
+SELECT RoleID, unnest(ARRAY[
+  'members.read', 'members.update', 'assets.read', 'trades.read', 'withdrawals.read', 'deposits.read',
+  'transfers.read', 'documents.read', 'documents.create', 'spread.read', 'feeds.read', 'tasks.read',
+  'jobs.read', 'ledger.read', 'news.read', 'news.update', 'audit.read', 'settings.read'
+]) FROM Role WHERE Title='operator';
+
+INSERT INTO RolePermission (RoleID, Permission)
+SELECT RoleID, unnest(ARRAY[
+  'members.read', 'leads.read', 'leads.update', 'leads.assign', 'offers.read', 'offers.update', 'managers.read'
+]) FROM Role WHERE Title='manager';
+
+INSERT INTO MemberRole (MemberID, RoleID, Created)
+SELECT Member.MemberID, Role.RoleID, extract(epoch FROM now())::bigint FROM Member, Role WHERE Member.Role=2 AND Role.Title='operator';
+
+INSERT INTO MemberRole (MemberID, RoleID, Created)
+SELECT Member.MemberID, Role.RoleID, extract(epoch FROM now())::bigint FROM Member, Role WHERE Member.ManagerRole IN ('manager', 'admin') AND Role.Title='manager';
@@ -0,0 +1,3 @@
+DELETE FROM RolePermission WHERE Permission IN ('security.read', 'security.update');
+
+DROP TABLE IF EXISTS SecurityEvent;
@@ -0,0 +1,18 @@
+-- Security events of sign in: failed sign in, account lockout and unlock
+-- Types: login.failed, account.locked, account.unlocked
+CREATE TABLE SecurityEvent (
+  SecurityEventID bigserial PRIMARY KEY,
+  MemberID bigint NOT NULL DEFAULT 0,
+  Email varchar NOT NULL DEFAULT '',
+  IP varchar NOT NULL DEFAULT '',
+  UserAgent varchar NOT NULL DEFAULT '',
+  Type varchar NOT NULL,
+  Data varchar NOT NULL DEFAULT '',
+  Created bigint NOT NULL
+);
+
+CREATE INDEX securityevent_member_idx ON SecurityEvent (MemberID);
+CREATE INDEX securityevent_ip_idx ON SecurityEvent (IP);
+
+INSERT INTO RolePermission (RoleID, Permission)
+SELECT RoleID, 'security.read' FROM Role WHERE Title='operator';
@@ -0,0 +1,13 @@
+-- Asymmetric token signing keys, published at /.well-known/jwks.json
+-- Activates - time the key starts signing tokens (keys are published ahead), Expires - time it is no longer accepted (0 - current key)
+-- PrivateKey - PKCS #8 key encrypted with jwt_secret of EncryptionKeyID (AES-GCM), PublicKey - PKIX key
+CREATE TABLE JWTKey (
+  KID varchar PRIMARY KEY,
+  Algorithm varchar NOT NULL,
+  PrivateKey varchar NOT NULL,
+  EncryptionKeyID varchar NOT NULL,
+  PublicKey varchar NOT NULL,
+  Created bigint NOT NULL,
+  Activates bigint NOT NULL,
+  Expires bigint NOT NULL DEFAULT 0
+);
@@ -0,0 +1,13 @@
+-- External OpenID Connect identities (Google, Apple, generic OIDC) linked to members
+CREATE TABLE OIDCIdentity (
+  OIDCIdentityID bigserial PRIMARY KEY,
+  MemberID bigint NOT NULL,
+  Provider varchar NOT NULL,
+  Subject varchar NOT NULL,
+  Email varchar NOT NULL DEFAULT '',
+  Created bigint NOT NULL,
+  LastUsed bigint NOT NULL DEFAULT 0,
+  UNIQUE (Provider, Subject)
+);
+
+CREATE INDEX oidcidentity_member_idx ON OIDCIdentity (MemberID);
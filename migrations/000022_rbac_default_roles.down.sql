@@ -0,0 +1,14 @@
+INSERT INTO MemberRole (MemberID, RoleID, Created)
+SELECT Member.MemberID, Role.RoleID, extract(epoch FROM now())::bigint FROM Member, Role WHERE Member.ManagerRole='admin' AND Role.Title='manager'
+ON CONFLICT DO NOTHING;
+
+DELETE FROM Role WHERE Title='admin';
+
+DELETE FROM MemberRole WHERE RoleID=(SELECT RoleID FROM Role WHERE Title='operator')
+  AND MemberID IN (SELECT MemberID FROM Member WHERE Role=3);
+
+DELETE FROM RolePermission WHERE RoleID=(SELECT RoleID FROM Role WHERE Title='operator') AND Permission IN (
+  'members.create', 'members.delete', 'assets.update', 'withdrawals.approve', 'deposits.approve', 'settings.update',
+  'spread.update', 'feeds.update', 'tasks.update', 'jobs.update', 'security.read', 'security.update', 'roles.read',
+  'roles.update'
+);
@@ -0,0 +1,34 @@
+-- Default roles keep abilities of the former numeric roles: operators (Member.Role 2 and 3) get every back office
+-- permission, CRM admins (Member.ManagerRole 'admin') also manage managers
+INSERT INTO RolePermission (RoleID, Permission)
+SELECT RoleID, unnest(ARRAY[
+  'members.read', 'members.create', 'members.update', 'members.delete', 'assets.read', 'assets.update', 'trades.read',
+  'withdrawals.read', 'withdrawals.approve', 'deposits.read', 'deposits.approve', 'transfers.read', 'documents.read',
+  'documents.create', 'settings.read', 'settings.update', 'spread.read', 'spread.update', 'feeds.read', 'feeds.update',
+  'tasks.read', 'tasks.update', 'jobs.read', 'jobs.update', 'ledger.read', 'news.read', 'news.update', 'audit.read',
+  'security.read', 'security.update', 'roles.read', 'roles.update'
+]) FROM Role WHERE Title='operator'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO MemberRole (MemberID, RoleID, Created)
+SELECT Member.MemberID, Role.RoleID, extract(epoch FROM now())::bigint FROM Member, Role WHERE Member.Role IN (2, 3) AND Role.Title='operator'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO Role (Title, Description, Created) VALUES
+  ('admin', 'CRM admin', extract(epoch FROM now())::bigint)
+ON CONFLICT (Title) DO NOTHING;
+
+INSERT INTO RolePermission (RoleID, Permission)
+SELECT RoleID, unnest(ARRAY[
+  'members.read', 'leads.read', 'leads.update', 'leads.assign', 'offers.read', 'offers.update', 'managers.read',
+  'managers.update'
+]) FROM Role WHERE Title='admin'
+ON CONFLICT DO NOTHING;
+
+-- CRM admins get admin role instead of manager role
+INSERT INTO MemberRole (MemberID, RoleID, Created)
+SELECT Member.MemberID, Role.RoleID, extract(epoch FROM now())::bigint FROM Member, Role WHERE Member.ManagerRole='admin' AND Role.Title='admin'
+ON CONFLICT DO NOTHING;
+
+DELETE FROM MemberRole WHERE RoleID=(SELECT RoleID FROM Role WHERE Title='manager')
+  AND MemberID IN (SELECT MemberID FROM Member WHERE ManagerRole='admin');
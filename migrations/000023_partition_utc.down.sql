@@ -0,0 +1,19 @@
+-- Creates a monthly range partition of parent table containing given time.
+-- epoch: partition key is a UNIX timestamp (bigint), otherwise timestamptz
+CREATE OR REPLACE FUNCTION create_month_partition(parent text, ts timestamptz, epoch boolean) RETURNS void AS $$
+DECLARE
+  start_at timestamptz := date_trunc('month', ts);
+  end_at timestamptz := date_trunc('month', ts) + interval '1 month';
+  partition_name text := lower(parent) || '_' || to_char(date_trunc('month', ts), 'YYYYMM');
+BEGIN
+  IF to_regclass(partition_name) IS NOT NULL THEN
+    RETURN;
+  END IF;
+
+  IF epoch THEN
+    EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%s) TO (%s)', partition_name, lower(parent), extract(epoch FROM start_at)::bigint, extract(epoch FROM end_at)::bigint);
+  ELSE
+    EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)', partition_name, lower(parent), start_at, end_at);
+  END IF;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,49 @@
+-- Creates a monthly range partition of parent table containing given time, months are in UTC.
+-- epoch: partition key is a UNIX timestamp (bigint), otherwise timestamptz.
+-- Rows of the month that already went to the DEFAULT partition would violate the new partition,
+-- the DEFAULT partition is detached, the rows are moved to the new partition and it is attached back.
+CREATE OR REPLACE FUNCTION create_month_partition(parent text, ts timestamptz, epoch boolean) RETURNS void AS $$
+DECLARE
+  month_at timestamp := date_trunc('month', ts AT TIME ZONE 'UTC');
+  start_at timestamptz := month_at AT TIME ZONE 'UTC';
+  end_at timestamptz := (month_at + interval '1 month') AT TIME ZONE 'UTC';
+  partition_name text := lower(parent) || '_' || to_char(month_at, 'YYYYMM');
+  default_name text := lower(parent) || '_default';
+  key_column text;
+  range_from text;
+  range_to text;
+  moved boolean := false;
+BEGIN
+  IF to_regclass(partition_name) IS NOT NULL THEN
+    RETURN;
+  END IF;
+
+  IF epoch THEN
+    range_from := extract(epoch FROM start_at)::bigint::text;
+    range_to := extract(epoch FROM end_at)::bigint::text;
+  ELSE
+    range_from := quote_literal(start_at);
+    range_to := quote_literal(end_at);
+  END IF;
+
+  SELECT attname INTO key_column FROM pg_partitioned_table
+    INNER JOIN pg_attribute ON pg_attribute.attrelid=pg_partitioned_table.partrelid AND pg_attribute.attnum=pg_partitioned_table.partattrs[0]
+    WHERE pg_partitioned_table.partrelid=lower(parent)::regclass;
+
+  IF to_regclass(default_name) IS NOT NULL THEN
+    EXECUTE format('SELECT EXISTS (SELECT 1 FROM %I WHERE %I>=%s AND %I<%s)', default_name, key_column, range_from, key_column, range_to) INTO moved;
+  END IF;
+
+  IF moved THEN
+    EXECUTE format('ALTER TABLE %I DETACH PARTITION %I', lower(parent), default_name);
+  END IF;
+
+  EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%s) TO (%s)', partition_name, lower(parent), range_from, range_to);
+
+  IF moved THEN
+    EXECUTE format('INSERT INTO %I SELECT * FROM %I WHERE %I>=%s AND %I<%s', partition_name, default_name, key_column, range_from, key_column, range_to);
+    EXECUTE format('DELETE FROM %I WHERE %I>=%s AND %I<%s', default_name, key_column, range_from, key_column, range_to);
+    EXECUTE format('ALTER TABLE %I ATTACH PARTITION %I DEFAULT', lower(parent), default_name);
+  END IF;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS outbox_stream_sequence_idx;
+CREATE INDEX outbox_channel_sequence_idx ON Outbox (Channel, Sequence);
+
+DROP TABLE IF EXISTS OutboxSequence;
+
+CREATE TABLE OutboxSequence (
+  OutboxSequenceID int PRIMARY KEY,
+  Value bigint NOT NULL DEFAULT 0
+);
+
+-- Events are renumbered by one counter in the order they were recorded
+UPDATE Outbox SET Sequence=numbered.Sequence FROM (
+  SELECT OutboxID, row_number() OVER (ORDER BY OutboxID ASC) AS Sequence FROM Outbox WHERE Sequence>0
+) AS numbered WHERE Outbox.OutboxID=numbered.OutboxID;
+
+INSERT INTO OutboxSequence (OutboxSequenceID, Value) SELECT 1, COALESCE(max(Sequence), 0) FROM Outbox;
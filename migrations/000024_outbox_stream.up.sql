@@ -0,0 +1,20 @@
+-- Events are numbered per stream: events of a member and events of everyone (MemberID=0) of a channel.
+-- Subscribers receive their own stream and the shared one, so a global counter left gaps between their events.
+DROP TABLE IF EXISTS OutboxSequence;
+
+CREATE TABLE OutboxSequence (
+  Channel varchar NOT NULL,
+  MemberID bigint NOT NULL,
+  Value bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY (Channel, MemberID)
+);
+
+-- Renumber recorded events within their streams
+UPDATE Outbox SET Sequence=numbered.Sequence FROM (
+  SELECT OutboxID, row_number() OVER (PARTITION BY Channel, MemberID ORDER BY Sequence ASC) AS Sequence FROM Outbox WHERE Sequence>0
+) AS numbered WHERE Outbox.OutboxID=numbered.OutboxID;
+
+INSERT INTO OutboxSequence (Channel, MemberID, Value) SELECT Channel, MemberID, max(Sequence) FROM Outbox WHERE Sequence>0 GROUP BY Channel, MemberID;
+
+DROP INDEX IF EXISTS outbox_channel_sequence_idx;
+CREATE INDEX outbox_stream_sequence_idx ON Outbox (Channel, MemberID, Sequence);
@@ -0,0 +1 @@
+UPDATE Market SET StartMinute=990, EndMinute=1380 WHERE MarketID IN (2, 4, 6) AND StartMinute=810 AND EndMinute=1200;
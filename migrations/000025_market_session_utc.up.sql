@@ -0,0 +1,3 @@
+-- Trading sessions are in UTC: stocks trade during US session 13:30 - 20:00 UTC.
+-- Sessions seeded in server time (Europe/Moscow) by earlier installs are moved, sessions changed by operator are kept
+UPDATE Market SET StartMinute=810, EndMinute=1200 WHERE MarketID IN (2, 4, 6) AND StartMinute=990 AND EndMinute=1380;